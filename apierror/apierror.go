@@ -0,0 +1,109 @@
+// Package apierror is the error taxonomy shared by the marketplace packages.
+// Every ResponseError implements Error and matches one of the category
+// errors with errors.Is, whatever marketplace it comes from:
+//
+//	_, err := client.Shop.GetShopInfo(shopID, token)
+//	if errors.Is(err, apierror.ErrTokenExpired) {
+//		// refresh the token and call again
+//	}
+//
+//	var apiErr apierror.Error
+//	if errors.As(err, &apiErr) {
+//		log.Printf("%s %s: request %s", apiErr.GetMarketplace(), apiErr.GetCode(), apiErr.GetRequestID())
+//	}
+package apierror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Marketplace names the marketplace an Error comes from.
+type Marketplace string
+
+const (
+	Shopee    Marketplace = "shopee"
+	Lazada    Marketplace = "lazada"
+	Tiktok    Marketplace = "tiktok"
+	Tokopedia Marketplace = "tokopedia"
+)
+
+// The categories of marketplace errors.
+var (
+	// ErrTokenExpired is an access token the marketplace does not accept
+	// anymore, refreshing it may help.
+	ErrTokenExpired = errors.New("access token expired or invalid")
+	// ErrUnauthorized is a call refused for its signature, app key or
+	// permissions.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited is a throttled call, it can be retried later.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrNotFound is a resource that does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidParam is a call with missing or invalid parameters.
+	ErrInvalidParam = errors.New("invalid parameter")
+	// ErrUnavailable is a marketplace failing or busy, it can be retried.
+	ErrUnavailable = errors.New("marketplace unavailable")
+)
+
+// Error is implemented by the ResponseError of every marketplace package.
+type Error interface {
+	error
+	// GetMarketplace returns the marketplace answering the call.
+	GetMarketplace() Marketplace
+	// GetStatus returns the HTTP status of the response, marketplaces often
+	// return errors with a 200.
+	GetStatus() int
+	// GetCode returns the error code of the marketplace, empty when it gave
+	// none.
+	GetCode() string
+	// GetRequestID returns the id of the call at the marketplace, to give
+	// their support.
+	GetRequestID() string
+	// Retryable tells whether the same call may succeed later.
+	Retryable() bool
+}
+
+// Codes maps the error codes of a marketplace to categories.
+type Codes map[string]error
+
+// statuses categorize the errors whose code is unknown.
+var statuses = map[int]error{
+	http.StatusBadRequest:          ErrInvalidParam,
+	http.StatusUnauthorized:        ErrTokenExpired,
+	http.StatusForbidden:           ErrUnauthorized,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusUnprocessableEntity: ErrInvalidParam,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusInternalServerError: ErrUnavailable,
+	http.StatusBadGateway:          ErrUnavailable,
+	http.StatusServiceUnavailable:  ErrUnavailable,
+	http.StatusGatewayTimeout:      ErrUnavailable,
+}
+
+// Category returns the category of an error by its code in codes, or by its
+// HTTP status when the code is unknown. It returns nil for an error in
+// neither.
+func (codes Codes) Category(status int, code string) error {
+	if err, ok := codes[code]; ok {
+		return err
+	}
+	return statuses[status]
+}
+
+// IsRetryable tells whether errors of category may go away on their own.
+func IsRetryable(category error) bool {
+	return category == ErrRateLimited || category == ErrUnavailable
+}
+
+// Match tells whether target is the category of an error with status and
+// code, the Is method of every ResponseError.
+func (codes Codes) Match(status int, code string, target error) bool {
+	return target != nil && target == codes.Category(status, code)
+}
+
+// Retryable tells whether an error with status and code may go away on its
+// own, the Retryable method of every ResponseError.
+func (codes Codes) Retryable(status int, code string) bool {
+	return IsRetryable(codes.Category(status, code))
+}
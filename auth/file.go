@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a TokenStore that keeps every token in one JSON file. The file
+// is rewritten on each change, so it suits a handful of shops on one host.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Get(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.Marketplace == marketplace && t.ShopID == shopID {
+			return t, nil
+		}
+	}
+	return nil, ErrTokenNotFound
+}
+
+func (s *FileStore) Save(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	saved := *token
+	replaced := false
+	for i, t := range tokens {
+		if keyOf(t) == keyOf(token) {
+			tokens[i] = &saved
+			replaced = true
+		}
+	}
+	if !replaced {
+		tokens = append(tokens, &saved)
+	}
+	return s.write(tokens)
+}
+
+func (s *FileStore) Delete(ctx context.Context, marketplace Marketplace, shopID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	kept := tokens[:0]
+	for _, t := range tokens {
+		if t.Marketplace != marketplace || t.ShopID != shopID {
+			kept = append(kept, t)
+		}
+	}
+	return s.write(kept)
+}
+
+func (s *FileStore) List(ctx context.Context) ([]*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+func (s *FileStore) load() ([]*Token, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*Token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("auth: decode %s: %w", s.path, err)
+	}
+	return tokens, nil
+}
+
+// write replaces the file through a rename so a crash never leaves half a file.
+func (s *FileStore) write(tokens []*Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
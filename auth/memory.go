@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is a TokenStore kept in memory, tokens are lost on restart.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	tokens map[tokenKey]Token
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[tokenKey]Token)}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tokens[tokenKey{marketplace: marketplace, shopID: shopID}]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &t, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[keyOf(token)] = *token
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, marketplace Marketplace, shopID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, tokenKey{marketplace: marketplace, shopID: shopID})
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tokens := make([]*Token, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		t := t
+		tokens = append(tokens, &t)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DB is the part of pgx used by PostgresStore, it is implemented by
+// *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// DefaultTable is the table used by PostgresStore when none is given.
+const DefaultTable = "marketplace_token"
+
+// PostgresStore is a TokenStore backed by a Postgres table, see Migrate for
+// its layout.
+type PostgresStore struct {
+	db    DB
+	table string
+}
+
+// NewPostgresStore returns a store using table, or DefaultTable when table is
+// empty.
+func NewPostgresStore(db DB, table string) *PostgresStore {
+	if table == "" {
+		table = DefaultTable
+	}
+	return &PostgresStore{db: db, table: pgx.Identifier{table}.Sanitize()}
+}
+
+// Migrate creates the token table if it does not exist yet.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	_, err := s.db.Exec(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	marketplace        text        NOT NULL,
+	shop_id            text        NOT NULL,
+	access_token       text        NOT NULL,
+	refresh_token      text        NOT NULL,
+	expires_at         timestamptz NOT NULL,
+	refresh_expires_at timestamptz,
+	updated_at         timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (marketplace, shop_id)
+)`, s.table))
+	return err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error) {
+	row := s.db.QueryRow(ctx, fmt.Sprintf(`SELECT marketplace, shop_id, access_token, refresh_token, expires_at, refresh_expires_at
+FROM %s WHERE marketplace = $1 AND shop_id = $2`, s.table), string(marketplace), shopID)
+
+	t, err := scanToken(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTokenNotFound
+	}
+	return t, err
+}
+
+func (s *PostgresStore) Save(ctx context.Context, token *Token) error {
+	var refreshExpiresAt *time.Time
+	if !token.RefreshExpiresAt.IsZero() {
+		refreshExpiresAt = &token.RefreshExpiresAt
+	}
+
+	_, err := s.db.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (marketplace, shop_id, access_token, refresh_token, expires_at, refresh_expires_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+ON CONFLICT (marketplace, shop_id) DO UPDATE SET
+	access_token = EXCLUDED.access_token,
+	refresh_token = EXCLUDED.refresh_token,
+	expires_at = EXCLUDED.expires_at,
+	refresh_expires_at = EXCLUDED.refresh_expires_at,
+	updated_at = now()`, s.table),
+		string(token.Marketplace), token.ShopID, token.AccessToken, token.RefreshToken, token.ExpiresAt, refreshExpiresAt)
+	return err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, marketplace Marketplace, shopID string) error {
+	_, err := s.db.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE marketplace = $1 AND shop_id = $2`, s.table), string(marketplace), shopID)
+	return err
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]*Token, error) {
+	rows, err := s.db.Query(ctx, fmt.Sprintf(`SELECT marketplace, shop_id, access_token, refresh_token, expires_at, refresh_expires_at
+FROM %s`, s.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*Token
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+func scanToken(row pgx.Row) (*Token, error) {
+	var (
+		t                Token
+		marketplace      string
+		refreshExpiresAt *time.Time
+	)
+	if err := row.Scan(&marketplace, &t.ShopID, &t.AccessToken, &t.RefreshToken, &t.ExpiresAt, &refreshExpiresAt); err != nil {
+		return nil, err
+	}
+	t.Marketplace = Marketplace(marketplace)
+	if refreshExpiresAt != nil {
+		t.RefreshExpiresAt = *refreshExpiresAt
+	}
+	return &t, nil
+}
@@ -0,0 +1,294 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+)
+
+// DefaultRefreshBefore is how long before expiry a token gets refreshed.
+const DefaultRefreshBefore = 10 * time.Minute
+
+// shopeeRefreshTokenTTL is the lifetime of a Shopee refresh token, the API
+// does not return it.
+const shopeeRefreshTokenTTL = 30 * 24 * time.Hour
+
+type refreshFunc func(ctx context.Context, t *Token) (*Token, error)
+
+// RefresherOption is used to configure a Refresher with options
+type RefresherOption func(r *Refresher)
+
+// WithShopee refreshes Shopee shop tokens with auth.RefreshAccessToken.
+func WithShopee(auth shopee.AuthService) RefresherOption {
+	return func(r *Refresher) {
+		r.refreshers[Shopee] = func(ctx context.Context, t *Token) (*Token, error) {
+			shopID, err := strconv.ParseUint(t.ShopID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("auth: invalid shopee shop id %q: %w", t.ShopID, err)
+			}
+
+			resp, err := auth.RefreshAccessTokenWithContext(ctx, shopID, 0, t.RefreshToken)
+			if err != nil {
+				return nil, err
+			}
+
+			now := time.Now()
+			return &Token{
+				Marketplace:      Shopee,
+				ShopID:           t.ShopID,
+				AccessToken:      resp.AccessToken,
+				RefreshToken:     resp.RefreshToken,
+				ExpiresAt:        now.Add(time.Duration(resp.ExpireIn) * time.Second),
+				RefreshExpiresAt: now.Add(shopeeRefreshTokenTTL),
+			}, nil
+		}
+	}
+}
+
+// WithLazada refreshes Lazada seller tokens with auth.RefreshToken.
+func WithLazada(auth *lazada.AuthService) RefresherOption {
+	return func(r *Refresher) {
+		r.refreshers[Lazada] = func(ctx context.Context, t *Token) (*Token, error) {
+			resp, err := auth.RefreshToken(ctx, t.RefreshToken)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Token{
+				Marketplace:      Lazada,
+				ShopID:           t.ShopID,
+				AccessToken:      resp.AccessToken,
+				RefreshToken:     resp.RefreshToken,
+				ExpiresAt:        resp.ExpiresAt(),
+				RefreshExpiresAt: resp.RefreshExpiresAt(),
+			}, nil
+		}
+	}
+}
+
+// WithTiktok refreshes TikTok shop tokens with auth.GetRefreshToken, appKey
+// and appSecret are the ones of the app the shop authorized.
+func WithTiktok(auth tiktok.AuthService, appKey, appSecret string) RefresherOption {
+	return func(r *Refresher) {
+		r.refreshers[Tiktok] = func(ctx context.Context, t *Token) (*Token, error) {
+			resp, err := auth.GetRefreshTokenWithContext(ctx, tiktok.GetRefreshTokenParams{
+				AppKey:       appKey,
+				AppSecret:    appSecret,
+				RefreshToken: t.RefreshToken,
+				GrantType:    "refresh_token",
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			now := time.Now()
+			return &Token{
+				Marketplace:      Tiktok,
+				ShopID:           t.ShopID,
+				AccessToken:      resp.Data.AccessToken,
+				RefreshToken:     resp.Data.RefreshToken,
+				ExpiresAt:        tiktokExpiry(now, resp.Data.AccessTokenExpireIn),
+				RefreshExpiresAt: tiktokExpiry(now, resp.Data.RefreshTokenExpireIn),
+			}, nil
+		}
+	}
+}
+
+// tiktokExpiry reads a TikTok expire_in value, which is a unix timestamp on
+// current API versions and a number of seconds on older ones.
+func tiktokExpiry(now time.Time, v int) time.Time {
+	if v > 1e9 {
+		return time.Unix(int64(v), 0)
+	}
+	return now.Add(time.Duration(v) * time.Second)
+}
+
+// WithRefreshBefore sets how long before expiry a token gets refreshed.
+func WithRefreshBefore(d time.Duration) RefresherOption {
+	return func(r *Refresher) {
+		r.refreshBefore = d
+	}
+}
+
+// WithErrorHandler sets a function called by Run for every token that failed
+// to refresh.
+func WithErrorHandler(fn func(t *Token, err error)) RefresherOption {
+	return func(r *Refresher) {
+		r.onError = fn
+	}
+}
+
+// Refresher hands out tokens from a TokenStore and refreshes them before they
+// expire. Refreshes of one shop are serialized, so a refresh token is never
+// used twice by the same Refresher. Processes sharing a store do not
+// coordinate, run the refresh loop in one of them only.
+type Refresher struct {
+	store         TokenStore
+	refreshers    map[Marketplace]refreshFunc
+	refreshBefore time.Duration
+	onError       func(t *Token, err error)
+
+	mu    sync.Mutex
+	locks map[tokenKey]*sync.Mutex
+}
+
+func NewRefresher(store TokenStore, opts ...RefresherOption) *Refresher {
+	r := &Refresher{
+		store:         store,
+		refreshers:    make(map[Marketplace]refreshFunc),
+		refreshBefore: DefaultRefreshBefore,
+		locks:         make(map[tokenKey]*sync.Mutex),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Token returns a usable token of a shop, refreshing it first when it
+// expires within the refresh window.
+func (r *Refresher) Token(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error) {
+	t, err := r.store.Get(ctx, marketplace, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.ExpiresWithin(r.refreshBefore) {
+		return t, nil
+	}
+
+	t, err = r.refresh(ctx, tokenKey{marketplace: marketplace, shopID: shopID}, false)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Refresh refreshes the token of a shop right away and saves it.
+func (r *Refresher) Refresh(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error) {
+	return r.refresh(ctx, tokenKey{marketplace: marketplace, shopID: shopID}, true)
+}
+
+// RefreshDue refreshes every stored token that expires within the refresh
+// window and returns the errors of the ones that failed.
+func (r *Refresher) RefreshDue(ctx context.Context) error {
+	tokens, err := r.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, t := range tokens {
+		if !t.ExpiresWithin(r.refreshBefore) {
+			continue
+		}
+		if _, ok := r.refreshers[t.Marketplace]; !ok {
+			continue
+		}
+		if _, err := r.refresh(ctx, keyOf(t), false); err != nil {
+			if r.onError != nil {
+				r.onError(t, err)
+			}
+			errs = append(errs, fmt.Errorf("%s shop %s: %w", t.Marketplace, t.ShopID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run calls RefreshDue every interval until ctx is done.
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_ = r.RefreshDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context, key tokenKey, force bool) (*Token, error) {
+	lock := r.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Another goroutine may have refreshed the token while we were waiting.
+	t, err := r.store.Get(ctx, key.marketplace, key.shopID)
+	if err != nil {
+		return nil, err
+	}
+	if !force && !t.ExpiresWithin(r.refreshBefore) {
+		return t, nil
+	}
+
+	refresh, ok := r.refreshers[key.marketplace]
+	if !ok {
+		if !force && t.Valid() {
+			return t, nil
+		}
+		return nil, fmt.Errorf("%w: no refresher configured for %s", ErrTokenExpired, key.marketplace)
+	}
+	if !t.RefreshExpiresAt.IsZero() && time.Now().After(t.RefreshExpiresAt) {
+		return nil, fmt.Errorf("%w: refresh token of %s shop %s expired", ErrTokenExpired, key.marketplace, key.shopID)
+	}
+
+	refreshed, err := refresh(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if refreshed.AccessToken == "" {
+		return nil, fmt.Errorf("auth: %s returned an empty access token for shop %s", key.marketplace, key.shopID)
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = t.RefreshToken
+	}
+	if err := r.store.Save(ctx, refreshed); err != nil {
+		return nil, err
+	}
+	return refreshed, nil
+}
+
+func (r *Refresher) lock(key tokenKey) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		r.locks[key] = l
+	}
+	return l
+}
+
+// Source returns the tokens of one marketplace through the TokenSource
+// interface of the marketplace clients, e.g.
+//
+//	shopee.NewClient(app, shopee.WithTokenSource(refresher.Source(auth.Shopee)))
+func (r *Refresher) Source(marketplace Marketplace) *Source {
+	return &Source{refresher: r, marketplace: marketplace}
+}
+
+// Source implements the TokenSource interfaces of the shopee, lazada and
+// tiktok packages.
+type Source struct {
+	refresher   *Refresher
+	marketplace Marketplace
+}
+
+func (s *Source) AccessToken(ctx context.Context, shopID string) (string, error) {
+	t, err := s.refresher.Token(ctx, s.marketplace, shopID)
+	if err != nil {
+		return "", err
+	}
+	return t.AccessToken, nil
+}
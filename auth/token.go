@@ -0,0 +1,72 @@
+// Package auth keeps marketplace access tokens in a TokenStore and refreshes
+// them before they expire, so callers can address a shop by its id instead of
+// carrying raw access tokens around.
+//
+// Shopee, Lazada and TikTok tokens belong to a shop (a seller on Lazada) and
+// are supported. Tokopedia tokens belong to the app and are not covered.
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Marketplace string
+
+const (
+	Shopee Marketplace = "shopee"
+	Lazada Marketplace = "lazada"
+	Tiktok Marketplace = "tiktok"
+)
+
+var (
+	// ErrTokenNotFound is returned by a TokenStore when it has no token for a shop.
+	ErrTokenNotFound = errors.New("auth: token not found")
+	// ErrTokenExpired is returned when a token expired and cannot be refreshed.
+	ErrTokenExpired = errors.New("auth: token expired")
+)
+
+// Token is the access token of one shop.
+//
+// ShopID is the shop id on Shopee, the seller id on Lazada and the shop cipher
+// on TikTok, the same value the marketplace clients pass to their TokenSource.
+type Token struct {
+	Marketplace      Marketplace `json:"marketplace"`
+	ShopID           string      `json:"shop_id"`
+	AccessToken      string      `json:"access_token"`
+	RefreshToken     string      `json:"refresh_token"`
+	ExpiresAt        time.Time   `json:"expires_at"`
+	RefreshExpiresAt time.Time   `json:"refresh_expires_at,omitempty"`
+}
+
+// Valid tells you if the access token can be used right now.
+func (t *Token) Valid() bool {
+	return t.AccessToken != "" && time.Now().Before(t.ExpiresAt)
+}
+
+// ExpiresWithin tells you if the access token expires in less than d.
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	return time.Now().Add(d).After(t.ExpiresAt)
+}
+
+// TokenStore persists tokens. Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Get returns the token of a shop or ErrTokenNotFound.
+	Get(ctx context.Context, marketplace Marketplace, shopID string) (*Token, error)
+	// Save inserts or replaces the token of a shop.
+	Save(ctx context.Context, token *Token) error
+	// Delete removes the token of a shop, it is not an error if there is none.
+	Delete(ctx context.Context, marketplace Marketplace, shopID string) error
+	// List returns every stored token.
+	List(ctx context.Context) ([]*Token, error)
+}
+
+type tokenKey struct {
+	marketplace Marketplace
+	shopID      string
+}
+
+func keyOf(t *Token) tokenKey {
+	return tokenKey{marketplace: t.Marketplace, shopID: t.ShopID}
+}
@@ -0,0 +1,243 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Option is used to configure a Recorder with options
+type Option func(r *Recorder)
+
+// WithSecrets redacts more query, form and JSON fields besides
+// DefaultSecrets.
+func WithSecrets(keys ...string) Option {
+	return func(r *Recorder) {
+		for _, k := range keys {
+			r.redact[k] = true
+		}
+	}
+}
+
+// WithIgnored leaves more query and form fields besides DefaultIgnored out
+// when matching requests.
+func WithIgnored(params ...string) Option {
+	return func(r *Recorder) {
+		for _, p := range params {
+			r.ignored[p] = true
+		}
+	}
+}
+
+// Recorder is an http.RoundTripper recording to or replaying from a cassette
+// file. In replay a request is answered by the first interaction not replayed
+// yet with the same method, path, query and form or JSON body, the host and
+// the DefaultIgnored fields left out. It is safe for concurrent use and can
+// be shared by clients.
+type Recorder struct {
+	path     string
+	mode     Mode
+	redact   redactor
+	ignored  map[string]bool
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// New returns a Recorder of the cassette at path. In replay the cassette is
+// loaded, in record it is written after every round trip.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		redact:   newRedactor(DefaultSecrets),
+		ignored:  map[string]bool{},
+		cassette: &Cassette{Interactions: []Interaction{}},
+	}
+	for _, p := range DefaultIgnored {
+		r.ignored[p] = true
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.replayed = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode of r.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Cassette returns a copy of the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// RoundTrip records through http.DefaultTransport, see Wrap for another one.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.Wrap(nil).RoundTrip(req)
+}
+
+// Wrap returns a transport recording the round trips of base,
+// http.DefaultTransport when nil. Base is not called in replay.
+func (r *Recorder) Wrap(base http.RoundTripper) http.RoundTripper {
+	return &transport{recorder: r, base: base}
+}
+
+type transport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.recorder.mode == ModeReplay {
+		return t.recorder.replay(req)
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return t.recorder.record(req, base)
+}
+
+func (r *Recorder) record(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	send := req.Clone(req.Context())
+	if body != nil {
+		send.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := base.RoundTrip(send)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: r.request(req, body),
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: resp.Header.Clone(),
+			Body:   string(r.redact.body(respBody, resp.Header.Get("Content-Type"))),
+		},
+	})
+	if err := r.cassette.Save(r.path); err != nil {
+		return nil, fmt.Errorf("cassette: save %s: %w", r.path, err)
+	}
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := r.key(r.request(req, body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.replayed[i] || r.key(in.Request) != key {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette: no recorded response for %s %s in %s", req.Method, req.URL.Path, r.path)
+}
+
+// request returns req as it is recorded, redacted.
+func (r *Recorder) request(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		URL:    r.redact.url(req.URL),
+		Header: r.redact.header(req.Header),
+		Body:   string(r.redact.body(body, req.Header.Get("Content-Type"))),
+	}
+}
+
+// key is what two requests matching each other have in common.
+func (r *Recorder) key(req Request) string {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return req.Method + " " + req.URL
+	}
+	return req.Method + " " + u.Path + "?" + r.withoutIgnored(u.Query()) + "\n" + r.bodyKey(req)
+}
+
+func (r *Recorder) bodyKey(req Request) string {
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case isForm(contentType):
+		form, err := url.ParseQuery(req.Body)
+		if err != nil {
+			return req.Body
+		}
+		return r.withoutIgnored(form)
+	case isJSON(contentType) || json.Valid([]byte(req.Body)):
+		// decoded and encoded again to sort the fields
+		v, err := decodeJSON([]byte(req.Body))
+		if err != nil {
+			return req.Body
+		}
+		return string(encodeJSON(v))
+	}
+	// multipart boundaries are random
+	if mediaType, _, _ := mime.ParseMediaType(contentType); strings.HasPrefix(mediaType, "multipart/") {
+		return ""
+	}
+	return req.Body
+}
+
+func (r *Recorder) withoutIgnored(v url.Values) string {
+	for k := range r.ignored {
+		v.Del(k)
+	}
+	return v.Encode()
+}
+
+// readBody reads and closes the body of req, as a transport does.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
@@ -0,0 +1,136 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the secrets in a cassette.
+const Redacted = "REDACTED"
+
+// DefaultSecrets are the query, form and JSON fields redacted in cassettes.
+var DefaultSecrets = []string{"access_token", "refresh_token", "sign", "partner_key", "app_secret"}
+
+// DefaultIgnored are the query and form fields left out when matching a
+// request in replay, they change with every call.
+var DefaultIgnored = []string{"timestamp", "sign"}
+
+// secretHeaders carry the access token of Tokopedia and TikTok Shop.
+var secretHeaders = []string{"Authorization", "X-Tts-Access-Token"}
+
+type redactor map[string]bool
+
+func newRedactor(keys []string) redactor {
+	r := redactor{}
+	for _, k := range keys {
+		r[k] = true
+	}
+	return r
+}
+
+func (r redactor) values(v url.Values) url.Values {
+	out := url.Values{}
+	for k, vs := range v {
+		if r[k] {
+			vs = []string{Redacted}
+		}
+		out[k] = vs
+	}
+	return out
+}
+
+func (r redactor) url(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = r.values(u.Query()).Encode()
+	return redacted.String()
+}
+
+func (r redactor) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for _, k := range secretHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, Redacted)
+		}
+	}
+	return out
+}
+
+// body redacts form and JSON bodies, others are kept as they are. A JSON
+// body without secrets is not encoded again.
+func (r redactor) body(b []byte, contentType string) []byte {
+	switch {
+	case len(b) == 0:
+		return b
+	case isForm(contentType):
+		form, err := url.ParseQuery(string(b))
+		if err != nil {
+			return b
+		}
+		return []byte(r.values(form).Encode())
+	case json.Valid(b):
+		v, err := decodeJSON(b)
+		if err != nil || !r.json(v) {
+			return b
+		}
+		return encodeJSON(v)
+	}
+	return b
+}
+
+// json redacts the secrets of v in place and tells whether it found any.
+func (r redactor) json(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if _, ok := field.(string); ok && r[k] {
+				v[k] = Redacted
+				found = true
+			} else if r.json(field) {
+				found = true
+			}
+		}
+	case []any:
+		for _, elem := range v {
+			if r.json(elem) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func isForm(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON keeps numbers as they are written, large IDs and amounts do not
+// fit a float64.
+func decodeJSON(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	return v, err
+}
+
+func encodeJSON(v any) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
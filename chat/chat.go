@@ -0,0 +1,148 @@
+// Package chat provides one conversation and message model on top of the
+// marketplace specific chat services, so a helpdesk can list conversations,
+// read messages, reply and mark conversations as read through a single API.
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Marketplace string
+
+const (
+	Shopee    Marketplace = "shopee"
+	Lazada    Marketplace = "lazada"
+	Tiktok    Marketplace = "tiktok"
+	Tokopedia Marketplace = "tokopedia"
+)
+
+type MessageType string
+
+const (
+	MessageText    MessageType = "text"
+	MessageImage   MessageType = "image"
+	MessageProduct MessageType = "product"
+	MessageOrder   MessageType = "order"
+	MessageSticker MessageType = "sticker"
+	MessageVideo   MessageType = "video"
+	MessageVoucher MessageType = "voucher"
+	MessageOther   MessageType = "other"
+)
+
+// Service is implemented by every marketplace adapter in this package.
+type Service interface {
+	Marketplace() Marketplace
+	ListConversations(ctx context.Context, params ListConversationsParams) (*ConversationPage, error)
+	ListMessages(ctx context.Context, params ListMessagesParams) (*MessagePage, error)
+	SendMessage(ctx context.Context, msg SendMessage) (*Message, error)
+	MarkAsRead(ctx context.Context, conversationID, lastMessageID string) error
+}
+
+type Conversation struct {
+	ID              string
+	Marketplace     Marketplace
+	BuyerID         string
+	BuyerName       string
+	BuyerAvatar     string
+	UnreadCount     int
+	LastMessageID   string
+	LastMessageType MessageType
+	LastMessageText string
+	LastMessageAt   time.Time
+
+	// Raw holds the marketplace struct the conversation was built from.
+	Raw any
+}
+
+type Message struct {
+	ID             string // empty for a message sent to Tokopedia
+	ConversationID string
+	Marketplace    Marketplace
+	Type           MessageType
+	SenderID       string
+	FromBuyer      bool
+	Text           string
+	ImageURL       string
+	ProductID      string
+	OrderID        string
+	CreatedAt      time.Time
+
+	// Raw holds the marketplace struct the message was built from.
+	Raw any
+}
+
+// SendMessage describes an outgoing message. Only the field matching Type is
+// used, e.g. Text for MessageText or ImageURL for MessageImage.
+type SendMessage struct {
+	ConversationID string
+	// ToID is the buyer id. Shopee needs it to send a message, when empty it is
+	// looked up from the conversation.
+	ToID string
+	Type MessageType
+
+	Text        string
+	ImageURL    string
+	ImageWidth  int
+	ImageHeight int
+	ProductID   string
+	OrderID     string
+}
+
+// ListConversationsParams is used to page through conversations. Cursor is the
+// NextCursor of the previous page, empty for the first page.
+type ListConversationsParams struct {
+	PageSize int
+	Cursor   string
+}
+
+type ConversationPage struct {
+	Conversations []Conversation
+	NextCursor    string
+	HasMore       bool
+}
+
+// ListMessagesParams is used to page through the messages of one conversation.
+// Cursor is the NextCursor of the previous page, empty for the first page.
+type ListMessagesParams struct {
+	ConversationID string
+	PageSize       int
+	Cursor         string
+}
+
+type MessagePage struct {
+	Messages   []Message
+	NextCursor string
+	HasMore    bool
+}
+
+// ErrUnsupported is matched by every UnsupportedError with errors.Is.
+var ErrUnsupported = errors.New("chat: unsupported")
+
+// UnsupportedError is returned when a marketplace has no API for a capability,
+// e.g. sending an order card on Tokopedia.
+type UnsupportedError struct {
+	Marketplace Marketplace
+	Capability  string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("chat: %s does not support %s", e.Marketplace, e.Capability)
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+func unsupported(m Marketplace, capability string) error {
+	return &UnsupportedError{Marketplace: m, Capability: capability}
+}
+
+func pageSizeOrDefault(size, def int) int {
+	if size <= 0 {
+		return def
+	}
+	return size
+}
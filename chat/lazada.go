@@ -0,0 +1,227 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+)
+
+type lazadaChat struct {
+	client *lazada.Client
+	token  string
+}
+
+// NewLazada returns a Service for the seller owning token.
+func NewLazada(client *lazada.Client, token string) Service {
+	return &lazadaChat{client: client, token: token}
+}
+
+func (l *lazadaChat) Marketplace() Marketplace {
+	return Lazada
+}
+
+// Lazada pages with a start time plus the last id of the previous page, both
+// are kept in the cursor as "<start time>:<last id>".
+func encodeLazadaCursor(startTime int64, lastID string) string {
+	return fmt.Sprintf("%d:%s", startTime, lastID)
+}
+
+func decodeLazadaCursor(cursor string) (int64, string, error) {
+	ts, lastID, _ := strings.Cut(cursor, ":")
+	startTime, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid lazada cursor %q: %w", cursor, err)
+	}
+	return startTime, lastID, nil
+}
+
+func (l *lazadaChat) ListConversations(ctx context.Context, params ListConversationsParams) (*ConversationPage, error) {
+	query := &lazada.SessionListQuery{
+		PageSize:  pageSizeOrDefault(params.PageSize, 20),
+		StartTime: time.Now().AddDate(0, -1, 0).UnixMilli(),
+	}
+	if params.Cursor != "" {
+		startTime, lastID, err := decodeLazadaCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query.StartTime = startTime
+		query.LastSessionID = lastID
+	}
+
+	resp, err := l.client.Chat.GetSessionList(ctx, l.token, query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := resp.SessionListResponseData
+	page := &ConversationPage{HasMore: data.HasMore}
+	if page.HasMore {
+		page.NextCursor = encodeLazadaCursor(data.NextStartTime, data.LastSessionID)
+	}
+	for _, s := range data.SessionList {
+		page.Conversations = append(page.Conversations, Conversation{
+			ID:              s.SessionID,
+			Marketplace:     Lazada,
+			BuyerID:         strconv.FormatInt(s.BuyerID, 10),
+			BuyerName:       s.Title,
+			BuyerAvatar:     s.HeadURL,
+			UnreadCount:     s.UnreadCount,
+			LastMessageID:   s.LastMessageID,
+			LastMessageText: s.Summary,
+			LastMessageAt:   time.UnixMilli(s.LastMessageTime),
+			Raw:             s,
+		})
+	}
+
+	return page, nil
+}
+
+func (l *lazadaChat) ListMessages(ctx context.Context, params ListMessagesParams) (*MessagePage, error) {
+	query := &lazada.MessageQueryParams{
+		SessionID: params.ConversationID,
+		PageSize:  pageSizeOrDefault(params.PageSize, 20),
+		StartTime: time.Now().UnixMilli(),
+	}
+	if params.Cursor != "" {
+		startTime, lastID, err := decodeLazadaCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query.StartTime = startTime
+		query.LastMessageID = lastID
+	}
+
+	resp, err := l.client.Chat.GetMessageList(ctx, l.token, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MessagePage{HasMore: resp.Data.HasMore}
+	if page.HasMore {
+		page.NextCursor = encodeLazadaCursor(int64(resp.Data.NextStartTime), resp.Data.LastMessageID)
+	}
+	for _, m := range resp.Data.MessageList {
+		page.Messages = append(page.Messages, lazadaMessage(m))
+	}
+
+	return page, nil
+}
+
+// lazadaContent is the JSON document carried in MessagesListData.Content.
+type lazadaContent struct {
+	Txt    string `json:"txt"`
+	ImgURL string `json:"imgUrl"`
+	// ids come as numbers or as strings
+	ItemID  json.Number `json:"itemId"`
+	OrderID json.Number `json:"orderId"`
+}
+
+func lazadaMessage(m lazada.MessagesListData) Message {
+	msg := Message{
+		ID:             m.MessageID,
+		ConversationID: m.SessionID,
+		Marketplace:    Lazada,
+		Type:           lazadaMessageType(m.TemplateID),
+		SenderID:       m.FromAccountID,
+		FromBuyer:      m.FromAccountType == 1,
+		CreatedAt:      time.UnixMilli(int64(m.SendTime)),
+		Raw:            m,
+	}
+
+	var content lazadaContent
+	if err := json.Unmarshal([]byte(m.Content), &content); err == nil {
+		msg.Text = content.Txt
+		msg.ImageURL = content.ImgURL
+		msg.ProductID = content.ItemID.String()
+		msg.OrderID = content.OrderID.String()
+	} else {
+		msg.Text = m.Content
+	}
+
+	return msg
+}
+
+func (l *lazadaChat) SendMessage(ctx context.Context, msg SendMessage) (*Message, error) {
+	params := &lazada.SendMessageParams{SessionID: msg.ConversationID}
+	switch msg.Type {
+	case MessageText:
+		params.TemplateID = lazada.NormalTextMessage
+		params.Txt = msg.Text
+	case MessageImage:
+		params.TemplateID = lazada.PictureMessage
+		params.ImgUrl = msg.ImageURL
+		if msg.ImageWidth > 0 && msg.ImageHeight > 0 {
+			params.Width = strconv.Itoa(msg.ImageWidth)
+			params.Height = strconv.Itoa(msg.ImageHeight)
+		}
+	case MessageProduct:
+		params.TemplateID = lazada.ItemMessage
+		params.ItemId = msg.ProductID
+	case MessageOrder:
+		params.TemplateID = lazada.OrderMessage
+		params.OrderId = msg.OrderID
+	default:
+		return nil, unsupported(Lazada, "sending "+string(msg.Type)+" messages")
+	}
+
+	resp, err := l.client.Chat.SendMessage(ctx, l.token, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:             resp.Data.MessageID,
+		ConversationID: msg.ConversationID,
+		Marketplace:    Lazada,
+		Type:           msg.Type,
+		Text:           msg.Text,
+		ImageURL:       msg.ImageURL,
+		ProductID:      msg.ProductID,
+		OrderID:        msg.OrderID,
+		CreatedAt:      time.UnixMilli(resp.Data.CurrentTime),
+		Raw:            resp.Data,
+	}, nil
+}
+
+func (l *lazadaChat) MarkAsRead(ctx context.Context, conversationID, lastMessageID string) error {
+	if lastMessageID == "" {
+		detail, err := l.client.Chat.GetSessionDetail(ctx, l.token, conversationID)
+		if err != nil {
+			return err
+		}
+		lastMessageID = detail.LastMessageID
+	}
+
+	_, err := l.client.Chat.ReadSession(ctx, l.token, lazada.ReadSessionParams{
+		SessionID:         conversationID,
+		LastReadMessageID: lastMessageID,
+	})
+	return err
+}
+
+func lazadaMessageType(templateID int) MessageType {
+	switch templateID {
+	case lazada.NormalTextMessage:
+		return MessageText
+	case lazada.PictureMessage:
+		return MessageImage
+	case lazada.EmojiMessage:
+		return MessageSticker
+	case lazada.ItemMessage:
+		return MessageProduct
+	case lazada.OrderMessage:
+		return MessageOrder
+	case lazada.VoucherMessage:
+		return MessageVoucher
+	case lazada.VideoMessage:
+		return MessageVideo
+	default:
+		return MessageOther
+	}
+}
@@ -0,0 +1,226 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+)
+
+type shopeeChat struct {
+	client *shopee.ShopeeClient
+	shopID uint64
+	token  string
+}
+
+// NewShopee returns a Service for one Shopee shop.
+func NewShopee(client *shopee.ShopeeClient, shopID uint64, token string) Service {
+	return &shopeeChat{client: client, shopID: shopID, token: token}
+}
+
+func (s *shopeeChat) Marketplace() Marketplace {
+	return Shopee
+}
+
+func (s *shopeeChat) ListConversations(ctx context.Context, params ListConversationsParams) (*ConversationPage, error) {
+	req := shopee.GetConversationParamsRequest{
+		Direction: "latest",
+		Type:      "all",
+		PageSize:  pageSizeOrDefault(params.PageSize, 25),
+	}
+	if params.Cursor != "" {
+		next, err := strconv.ParseInt(params.Cursor, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.NextTimeNano = next
+	}
+
+	resp, err := s.client.Chat.GetConversationListWithContext(ctx, s.shopID, s.token, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ConversationPage{HasMore: resp.Response.PageResult.More}
+	if page.HasMore {
+		page.NextCursor = resp.Response.PageResult.NextCursor.NextMessageTimeNano
+	}
+	for _, c := range resp.Response.ConversationsList {
+		page.Conversations = append(page.Conversations, Conversation{
+			ID:              c.ConversationID,
+			Marketplace:     Shopee,
+			BuyerID:         strconv.Itoa(c.ToID),
+			BuyerName:       c.ToName,
+			BuyerAvatar:     c.ToAvatar,
+			UnreadCount:     c.UnreadCount,
+			LastMessageID:   c.LatestMessageID,
+			LastMessageType: shopeeMessageType(c.LatestMessageType),
+			LastMessageText: c.LatestMessageContent.Text,
+			LastMessageAt:   time.Unix(0, c.LastMessageTimestamp),
+			Raw:             c,
+		})
+	}
+
+	return page, nil
+}
+
+func (s *shopeeChat) ListMessages(ctx context.Context, params ListMessagesParams) (*MessagePage, error) {
+	conversationID, err := strconv.ParseInt(params.ConversationID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Chat.GetMessageWithContext(ctx, s.shopID, s.token, shopee.GetMessageParamsRequest{
+		Offset:         params.Cursor,
+		PageSize:       pageSizeOrDefault(params.PageSize, 25),
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MessagePage{}
+	next := resp.Response.PageResult.NextOffset
+	if len(resp.Response.MessagesList) > 0 && next != "" && next != "0" {
+		page.NextCursor = next
+		page.HasMore = true
+	}
+	for _, m := range resp.Response.MessagesList {
+		page.Messages = append(page.Messages, s.message(m))
+	}
+
+	return page, nil
+}
+
+func (s *shopeeChat) message(m shopee.Messages) Message {
+	msg := Message{
+		ID:             m.MessageID,
+		ConversationID: m.ConversationID,
+		Marketplace:    Shopee,
+		Type:           shopeeMessageType(m.MessageType),
+		SenderID:       strconv.FormatInt(m.FromID, 10),
+		FromBuyer:      uint64(m.FromShopID) != s.shopID,
+		Text:           m.Content.Text,
+		ImageURL:       m.Content.Url,
+		CreatedAt:      time.Unix(m.CreatedTimeStamp, 0),
+		Raw:            m,
+	}
+	if m.Content.ImageURL != "" {
+		msg.ImageURL = m.Content.ImageURL
+	}
+	if m.Content.ItemID != 0 {
+		msg.ProductID = strconv.FormatInt(m.Content.ItemID, 10)
+	}
+	switch {
+	case m.Content.OrderSN != "":
+		msg.OrderID = m.Content.OrderSN
+	case m.Content.SourceContent.OrderSN != "":
+		msg.OrderID = m.Content.SourceContent.OrderSN
+	case m.Content.OrderID != 0:
+		msg.OrderID = strconv.FormatInt(m.Content.OrderID, 10)
+	}
+	return msg
+}
+
+func (s *shopeeChat) SendMessage(ctx context.Context, msg SendMessage) (*Message, error) {
+	req := shopee.SendMessageRequest{}
+	switch msg.Type {
+	case MessageText:
+		req.MessageType = "text"
+		req.Content.Text = msg.Text
+	case MessageImage:
+		req.MessageType = "image"
+		req.Content.ImageURL = msg.ImageURL
+	case MessageProduct:
+		req.MessageType = "item"
+		req.Content.ItemID = json.Number(msg.ProductID)
+	case MessageOrder:
+		req.MessageType = "order"
+		req.Content.OrderSN = msg.OrderID
+	default:
+		return nil, unsupported(Shopee, "sending "+string(msg.Type)+" messages")
+	}
+
+	toID := msg.ToID
+	if toID == "" {
+		conv, err := s.conversation(ctx, msg.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		toID = strconv.Itoa(conv.ToID)
+	}
+	req.ToID = json.Number(toID)
+
+	resp, err := s.client.Chat.SendMessageWithContext(ctx, s.shopID, s.token, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:             resp.Response.MessageID,
+		ConversationID: strconv.FormatInt(resp.Response.ConversationID, 10),
+		Marketplace:    Shopee,
+		Type:           shopeeMessageType(resp.Response.MessageType),
+		SenderID:       strconv.FormatUint(s.shopID, 10),
+		Text:           resp.Response.Content.Text,
+		ImageURL:       msg.ImageURL,
+		ProductID:      msg.ProductID,
+		OrderID:        msg.OrderID,
+		CreatedAt:      time.Unix(int64(resp.Response.CreatedTimestamp), 0),
+		Raw:            resp.Response,
+	}, nil
+}
+
+func (s *shopeeChat) MarkAsRead(ctx context.Context, conversationID, lastMessageID string) error {
+	if lastMessageID == "" {
+		conv, err := s.conversation(ctx, conversationID)
+		if err != nil {
+			return err
+		}
+		lastMessageID = conv.LatestMessageID
+	}
+
+	_, err := s.client.Chat.ReadConversationWithContext(ctx, s.shopID, s.token, shopee.ReadMessageRequest{
+		ConversationID:    json.Number(conversationID),
+		LastReadMessageID: lastMessageID,
+	})
+	return err
+}
+
+func (s *shopeeChat) conversation(ctx context.Context, conversationID string) (*shopee.Conversation, error) {
+	id, err := strconv.ParseInt(conversationID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Chat.GetOneConversationWithContext(ctx, s.shopID, s.token, shopee.GetMessageParamsRequest{
+		ConversationID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Response, nil
+}
+
+func shopeeMessageType(t string) MessageType {
+	switch t {
+	case "text":
+		return MessageText
+	case "image":
+		return MessageImage
+	case "item", "product":
+		return MessageProduct
+	case "order":
+		return MessageOrder
+	case "sticker":
+		return MessageSticker
+	case "video":
+		return MessageVideo
+	case "voucher":
+		return MessageVoucher
+	default:
+		return MessageOther
+	}
+}
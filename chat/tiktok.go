@@ -0,0 +1,218 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+)
+
+type tiktokChat struct {
+	client      *tiktok.TiktokClient
+	shopCipher  string
+	accessToken string
+}
+
+// NewTiktok returns a Service for one TikTok shop. The shop is passed to every
+// call with tiktok.WithShop, so the Services of several shops can share one
+// client.
+func NewTiktok(client *tiktok.TiktokClient, shopCipher, accessToken string) Service {
+	return &tiktokChat{client: client, shopCipher: shopCipher, accessToken: accessToken}
+}
+
+func (t *tiktokChat) Marketplace() Marketplace {
+	return Tiktok
+}
+
+// withShop returns a copy of ctx carrying the shop of t.
+func (t *tiktokChat) withShop(ctx context.Context) context.Context {
+	return tiktok.WithShop(ctx, tiktok.CommonParamRequest{
+		AccessToken: t.accessToken,
+		ShopCipher:  t.shopCipher,
+	})
+}
+
+func (t *tiktokChat) ListConversations(ctx context.Context, params ListConversationsParams) (*ConversationPage, error) {
+	resp, err := t.client.Chat.GetConversationsWithContext(t.withShop(ctx), tiktok.GetConversationsParam{
+		PageToken: params.Cursor,
+		PageSize:  pageSizeOrDefault(params.PageSize, 20),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ConversationPage{}
+	if resp.Data == nil {
+		return page, nil
+	}
+	page.NextCursor = resp.Data.NextPageToken
+	page.HasMore = page.NextCursor != ""
+
+	for _, c := range resp.Data.Conversations {
+		conv := Conversation{
+			ID:          c.ID,
+			Marketplace: Tiktok,
+			UnreadCount: c.UnreadCount,
+			Raw:         c,
+		}
+		for _, p := range c.Participants {
+			if p.Role == "BUYER" {
+				conv.BuyerID = p.UserID
+				conv.BuyerName = p.Nickname
+				conv.BuyerAvatar = p.Avatar
+			}
+		}
+		if c.LatestMessage != nil {
+			last := tiktokMessage(c.ID, tiktok.MessagesConversation(*c.LatestMessage))
+			conv.LastMessageID = last.ID
+			conv.LastMessageType = last.Type
+			conv.LastMessageText = last.Text
+			conv.LastMessageAt = last.CreatedAt
+		}
+		page.Conversations = append(page.Conversations, conv)
+	}
+
+	return page, nil
+}
+
+func (t *tiktokChat) ListMessages(ctx context.Context, params ListMessagesParams) (*MessagePage, error) {
+	resp, err := t.client.Chat.GetConversationMessagesWithContext(t.withShop(ctx), params.ConversationID, tiktok.GetConversationMessagesParam{
+		PageToken: params.Cursor,
+		PageSize:  pageSizeOrDefault(params.PageSize, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MessagePage{}
+	if resp.Data == nil {
+		return page, nil
+	}
+	page.NextCursor = resp.Data.NextPageToken
+	page.HasMore = page.NextCursor != ""
+
+	for _, m := range resp.Data.Messages {
+		page.Messages = append(page.Messages, tiktokMessage(params.ConversationID, m))
+	}
+
+	return page, nil
+}
+
+// tiktokContent is the JSON document carried in MessagesConversation.Content.
+type tiktokContent struct {
+	Content   string `json:"content,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	ProductID string `json:"product_id,omitempty"`
+	OrderID   string `json:"order_id,omitempty"`
+}
+
+func tiktokMessage(conversationID string, m tiktok.MessagesConversation) Message {
+	msg := Message{
+		ID:             m.ID,
+		ConversationID: conversationID,
+		Marketplace:    Tiktok,
+		Type:           tiktokMessageType(m.Type),
+		CreatedAt:      time.Unix(int64(m.CreateTime), 0),
+		Raw:            m,
+	}
+	if m.Sender != nil {
+		msg.SenderID = m.Sender.ImUserID
+		msg.FromBuyer = m.Sender.Role == "BUYER"
+	}
+
+	var content tiktokContent
+	if err := json.Unmarshal([]byte(m.Content), &content); err == nil {
+		msg.Text = content.Content
+		msg.ImageURL = content.URL
+		msg.ProductID = content.ProductID
+		msg.OrderID = content.OrderID
+	} else {
+		msg.Text = m.Content
+	}
+
+	return msg
+}
+
+func (t *tiktokChat) SendMessage(ctx context.Context, msg SendMessage) (*Message, error) {
+	var (
+		typ     string
+		content tiktokContent
+	)
+	switch msg.Type {
+	case MessageText:
+		typ = tiktok.TypeMessageText
+		content.Content = msg.Text
+	case MessageImage:
+		typ = tiktok.TypeMessageImage
+		content.URL = msg.ImageURL
+		content.Width = msg.ImageWidth
+		content.Height = msg.ImageHeight
+	case MessageProduct:
+		typ = tiktok.TypeMessageProduct
+		content.ProductID = msg.ProductID
+	case MessageOrder:
+		typ = tiktok.TypeMessageOrder
+		content.OrderID = msg.OrderID
+	default:
+		return nil, unsupported(Tiktok, "sending "+string(msg.Type)+" messages")
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("encode tiktok message content: %w", err)
+	}
+
+	resp, err := t.client.Chat.SendMessageToConversationIDWithContext(t.withShop(ctx), msg.ConversationID, tiktok.SendMessageToConversationIDReq{
+		TypeMessage: typ,
+		Content:     string(body),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sent := &Message{
+		ConversationID: msg.ConversationID,
+		Marketplace:    Tiktok,
+		Type:           msg.Type,
+		Text:           msg.Text,
+		ImageURL:       msg.ImageURL,
+		ProductID:      msg.ProductID,
+		OrderID:        msg.OrderID,
+		CreatedAt:      time.Now(),
+		Raw:            resp.Data,
+	}
+	if resp.Data != nil {
+		sent.ID = resp.Data.MessageID
+	}
+	return sent, nil
+}
+
+func (t *tiktokChat) MarkAsRead(ctx context.Context, conversationID, _ string) error {
+	_, err := t.client.Chat.ReadMessageConversationIDWithContext(t.withShop(ctx), conversationID)
+	return err
+}
+
+func tiktokMessageType(t string) MessageType {
+	switch t {
+	case tiktok.TypeMessageText:
+		return MessageText
+	case tiktok.TypeMessageImage:
+		return MessageImage
+	case tiktok.TypeMessageProduct:
+		return MessageProduct
+	case tiktok.TypeMessageOrder:
+		return MessageOrder
+	case tiktok.TypeMessageVideo:
+		return MessageVideo
+	case tiktok.TypeMessageEmoticons:
+		return MessageSticker
+	case tiktok.TypeMessageCoupon:
+		return MessageVoucher
+	default:
+		return MessageOther
+	}
+}
@@ -0,0 +1,168 @@
+package chat
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+)
+
+type tokopediaChat struct {
+	client *tokopedia.TokopediaClient
+	shopID int
+	token  string
+}
+
+// NewTokopedia returns a Service for one Tokopedia shop. The client must be
+// created with the FsID of the app.
+func NewTokopedia(client *tokopedia.TokopediaClient, shopID int, token string) Service {
+	return &tokopediaChat{client: client, shopID: shopID, token: token}
+}
+
+func (t *tokopediaChat) Marketplace() Marketplace {
+	return Tokopedia
+}
+
+// Tokopedia pages by page number, the cursor is the next page number.
+func tokopediaPage(cursor string) (int, error) {
+	if cursor == "" {
+		return 1, nil
+	}
+	return strconv.Atoi(cursor)
+}
+
+func (t *tokopediaChat) ListConversations(ctx context.Context, params ListConversationsParams) (*ConversationPage, error) {
+	pageNum, err := tokopediaPage(params.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	perPage := pageSizeOrDefault(params.PageSize, 20)
+
+	resp, err := t.client.Chat.GetMessagesListWithContext(ctx, t.token, tokopedia.GetMessagesParams{
+		Page:    pageNum,
+		PerPage: perPage,
+		ShopID:  t.shopID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ConversationPage{HasMore: len(resp.Data) == perPage}
+	if page.HasMore {
+		page.NextCursor = strconv.Itoa(pageNum + 1)
+	}
+	for _, m := range resp.Data {
+		page.Conversations = append(page.Conversations, Conversation{
+			ID:              strconv.Itoa(m.MsgID),
+			Marketplace:     Tokopedia,
+			BuyerID:         strconv.Itoa(m.Attributes.Contact.ID),
+			BuyerName:       m.Attributes.Contact.Attributes.Name,
+			BuyerAvatar:     m.Attributes.Contact.Attributes.Thumbnail,
+			UnreadCount:     m.Attributes.Unreads,
+			LastMessageType: MessageText,
+			LastMessageText: m.Attributes.LastReplyMsg,
+			LastMessageAt:   time.UnixMilli(m.Attributes.LastReplyTime),
+			Raw:             m,
+		})
+	}
+
+	return page, nil
+}
+
+func (t *tokopediaChat) ListMessages(ctx context.Context, params ListMessagesParams) (*MessagePage, error) {
+	msgID, err := strconv.Atoi(params.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	pageNum, err := tokopediaPage(params.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	perPage := pageSizeOrDefault(params.PageSize, 20)
+
+	resp, err := t.client.Chat.GetReplyListWithContext(ctx, t.token, tokopedia.GetReplyListParams{
+		ShopID:  t.shopID,
+		MsgID:   msgID,
+		Page:    pageNum,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MessagePage{HasMore: len(resp.Data) == perPage}
+	if page.HasMore {
+		page.NextCursor = strconv.Itoa(pageNum + 1)
+	}
+	for _, r := range resp.Data {
+		page.Messages = append(page.Messages, tokopediaMessage(r))
+	}
+
+	return page, nil
+}
+
+func tokopediaMessage(r tokopedia.ReplyData) Message {
+	msg := Message{
+		ID:             strconv.Itoa(r.ReplyID),
+		ConversationID: strconv.Itoa(r.MsgID),
+		Marketplace:    Tokopedia,
+		Type:           MessageText,
+		SenderID:       strconv.Itoa(r.SenderID),
+		FromBuyer:      r.IsOpposite,
+		Text:           r.Msg,
+		CreatedAt:      time.UnixMilli(r.ReplyTime),
+		Raw:            r,
+	}
+
+	attrs := r.Attachment.Attributes
+	switch {
+	case attrs.ProductID != 0:
+		msg.Type = MessageProduct
+		msg.ProductID = strconv.Itoa(attrs.ProductID)
+	case attrs.ImageURL != "":
+		msg.Type = MessageImage
+		msg.ImageURL = attrs.ImageURL
+	case r.AttachmentID != 0:
+		msg.Type = MessageOther
+	}
+
+	return msg
+}
+
+// SendMessage sends a text message. The returned Message has no ID, Tokopedia
+// answers a reply with its conversation but not with an id of the reply.
+func (t *tokopediaChat) SendMessage(ctx context.Context, msg SendMessage) (*Message, error) {
+	if msg.Type != MessageText {
+		return nil, unsupported(Tokopedia, "sending "+string(msg.Type)+" messages")
+	}
+
+	msgID, err := strconv.Atoi(msg.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.Chat.SendMessageWithContext(ctx, t.token, msgID, tokopedia.SendMessageBody{
+		Message: msg.Text,
+		MsgID:   msgID,
+		ShopID:  t.shopID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ConversationID: msg.ConversationID,
+		Marketplace:    Tokopedia,
+		Type:           MessageText,
+		SenderID:       strconv.Itoa(resp.Data.SenderID),
+		Text:           resp.Data.Msg,
+		CreatedAt:      time.UnixMilli(resp.Data.ReplyTime),
+		Raw:            resp.Data,
+	}, nil
+}
+
+// MarkAsRead is not available, Tokopedia has no API to mark a chat as read.
+func (t *tokopediaChat) MarkAsRead(ctx context.Context, _, _ string) error {
+	return unsupported(Tokopedia, "marking conversations as read")
+}
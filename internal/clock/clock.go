@@ -0,0 +1,22 @@
+// Package clock holds the context aware sleep shared by the retries of the
+// clients, the middleware and the uploaders.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep waits for d, it returns early with the context error once ctx is
+// done.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
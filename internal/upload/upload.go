@@ -0,0 +1,212 @@
+// Package upload holds what the resumable video uploaders of the marketplace
+// packages share: the stores of their upload states, the pool uploading parts
+// at once and the retry of a part.
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+)
+
+// Store keeps the state of uploads by key. Load returns nil and no error when
+// there is no upload for key.
+type Store[S any] interface {
+	Load(ctx context.Context, key string) (*S, error)
+	Save(ctx context.Context, key string, state *S) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore keeps upload states in memory, it resumes uploads within the
+// process only. States are kept as JSON, so a saved state is not changed by
+// the uploader going on with it.
+type MemoryStore[S any] struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+func NewMemoryStore[S any]() *MemoryStore[S] {
+	return &MemoryStore[S]{states: make(map[string][]byte)}
+}
+
+func (s *MemoryStore[S]) Load(ctx context.Context, key string) (*S, error) {
+	s.mu.Lock()
+	data, ok := s.states[key]
+	s.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	state := new(S)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *MemoryStore[S]) Save(ctx context.Context, key string, state *S) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[key] = data
+	return nil
+}
+
+func (s *MemoryStore[S]) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, key)
+	return nil
+}
+
+// FileStore keeps each upload state in a JSON file of dir, named after the
+// key, so uploads resume after a restart.
+type FileStore[S any] struct {
+	dir string
+}
+
+func NewFileStore[S any](dir string) *FileStore[S] {
+	return &FileStore[S]{dir: dir}
+}
+
+func (s *FileStore[S]) Load(ctx context.Context, key string) (*S, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(S)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", s.path(key), err)
+	}
+	return state, nil
+}
+
+// Save replaces the file through a rename so a crash never leaves half a file.
+func (s *FileStore[S]) Save(ctx context.Context, key string, state *S) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(s.path(key))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+func (s *FileStore[S]) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStore[S]) path(key string) string {
+	return filepath.Join(s.dir, filepath.Base(key)+".json")
+}
+
+// Parallel calls fn for each part of parts, at most concurrency at once. It
+// returns the first error and cancels the calls left.
+func Parallel(ctx context.Context, concurrency int, parts []int, fn func(ctx context.Context, n int) error) error {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	work := make(chan int)
+	errs := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(max(concurrency, 1), len(parts)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range work {
+				if err := fn(ctx, n); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+send:
+	for _, n := range parts {
+		select {
+		case work <- n:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+	}
+	// the parent context ended before a part failed
+	return ctx.Err()
+}
+
+// Retry calls fn until it succeeds, at most retries times more, waiting
+// backoff before the first retry and twice as long before each next one. An
+// apierror.Error that is not Retryable, such as an expired token, is returned
+// at once.
+func Retry(ctx context.Context, retries int, backoff time.Duration, fn func() error) error {
+	wait := backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries || ctx.Err() != nil {
+			return err
+		}
+		var apiErr apierror.Error
+		if errors.As(err, &apiErr) && !apiErr.Retryable() {
+			return err
+		}
+
+		if err := clock.Sleep(ctx, wait); err != nil {
+			return err
+		}
+		wait *= 2
+	}
+}
@@ -97,7 +97,7 @@ func (a *AuthService) GetAccessToken(ctx context.Context, code string) (*Token,
 	}
 
 	var buf bytes.Buffer
-	_, err = a.client.Do(ctx, req, &buf)
+	_, err = a.client.Do(withoutSeller(ctx), req, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (a *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*T
 	}
 
 	var buf bytes.Buffer
-	_, err = a.client.Do(ctx, req, &buf)
+	_, err = a.client.Do(withoutSeller(ctx), req, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"os"
 	"time"
 )
@@ -92,6 +93,44 @@ func (m *ChatService) GetSessionList(ctx context.Context, token string, opts *Se
 	return resp, nil
 }
 
+// Sessions iterates over the sessions returned by GetSessionList, following
+// next_start_time and last_session_id from page to page. A failed page is
+// yielded as an error and ends the iteration.
+func (m *ChatService) Sessions(ctx context.Context, token string, opts *SessionListQuery) iter.Seq2[SessionListData, error] {
+	query := SessionListQuery{PageSize: 20, StartTime: epochTimeOneMonthAgo()}
+	if opts != nil {
+		query = *opts
+	}
+
+	return func(yield func(SessionListData, error) bool) {
+		// a copy, so ranging again starts over
+		query := query
+		for {
+			resp, err := m.GetSessionList(ctx, token, &query)
+			if err != nil {
+				yield(SessionListData{}, err)
+				return
+			}
+
+			page := resp.SessionListResponseData
+			for _, s := range page.SessionList {
+				if !yield(s, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || len(page.SessionList) == 0 {
+				return
+			}
+			if page.NextStartTime == query.StartTime && page.LastSessionID == query.LastSessionID {
+				return
+			}
+			query.StartTime = page.NextStartTime
+			query.LastSessionID = page.LastSessionID
+		}
+	}
+}
+
 // A session detail object returned from the open platform
 type GetSessionDetailResponse struct {
 	BaseResponse
@@ -187,6 +226,38 @@ func (m *ChatService) GetMessageList(ctx context.Context, token string, opts *Me
 	return res, nil
 }
 
+// Messages iterates over the messages returned by GetMessageList, following
+// next_start_time and last_message_id from page to page. A failed page is
+// yielded as an error and ends the iteration.
+func (m *ChatService) Messages(ctx context.Context, token string, opts MessageQueryParams) iter.Seq2[MessagesListData, error] {
+	return func(yield func(MessagesListData, error) bool) {
+		opts := opts
+		for {
+			resp, err := m.GetMessageList(ctx, token, &opts)
+			if err != nil {
+				yield(MessagesListData{}, err)
+				return
+			}
+
+			page := resp.Data
+			for _, msg := range page.MessageList {
+				if !yield(msg, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || len(page.MessageList) == 0 {
+				return
+			}
+			if int64(page.NextStartTime) == opts.StartTime && page.LastMessageID == opts.LastMessageID {
+				return
+			}
+			opts.StartTime = int64(page.NextStartTime)
+			opts.LastMessageID = page.LastMessageID
+		}
+	}
+}
+
 // MessageRecallParams is a struct that holds parameters for the MessageRecall function.
 type MessageRecallParams struct {
 	SessionID string `url:"session_id"`
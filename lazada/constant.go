@@ -20,6 +20,14 @@ var ApiNames = map[string]string{
 	"SendMessage":            "/im/message/send",
 	"GetMultipleOrdersItems": "/orders/items/get",
 	"GetOrders":              "/orders/get",
+	"GetOrder":               "/order/get",
+	"GetOrderItems":          "/order/items/get",
+	"SetStatusToPacked":      "/order/pack",
+	"SetStatusToRTS":         "/order/rts",
+	"CancelValidate":         "/order/cancel/validate",
+	"ReverseCancelCreate":    "/order/reverse/cancel/create",
+	"GetDocument":            "/order/document/get",
+	"GetShipmentProviders":   "/shipment/providers/get",
 	"GetVideo":               "/media/video/get",
 	"InitCreateVideo":        "/media/video/block/create",
 	"UploadVideoBlock":       "/media/video/block/upload",
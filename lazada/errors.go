@@ -1,6 +1,10 @@
 package lazada
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+)
 
 // Error response is used to return as much data as possible to the calling application to help with dealing with any API issues.
 type ResponseError struct {
@@ -9,6 +13,9 @@ type ResponseError struct {
 	Message   string          `json:"message"`
 	RequestID string          `json:"request_id"`
 	Detail    []*ErrorDetails `json:"detail,omitempty"`
+
+	// Status is the HTTP status, Lazada answers most errors with a 200.
+	Status int `json:"-"`
 }
 
 type ErrorDetails struct {
@@ -34,3 +41,59 @@ func (e ResponseError) Error() string {
 	jsonErr, _ := json.Marshal(err)
 	return string(jsonErr)
 }
+
+// Codes categorizes the error codes of Lazada, see apierror.
+var Codes = apierror.Codes{
+	"IllegalAccessToken":     apierror.ErrTokenExpired,
+	"IllegalRefreshToken":    apierror.ErrTokenExpired,
+	"InvalidApiKey":          apierror.ErrUnauthorized,
+	"IncompleteSignature":    apierror.ErrUnauthorized,
+	"InvalidSignatureMethod": apierror.ErrUnauthorized,
+	"InvalidTimestamp":       apierror.ErrUnauthorized,
+	"MissingParameter":       apierror.ErrInvalidParam,
+	"InvalidParameter":       apierror.ErrInvalidParam,
+	"ApiCallLimit":           apierror.ErrRateLimited,
+	"ServiceTimeout":         apierror.ErrUnavailable,
+	"InternalError":          apierror.ErrUnavailable,
+}
+
+var _ apierror.Error = ResponseError{}
+
+// GetMarketplace returns apierror.Lazada
+func (e ResponseError) GetMarketplace() apierror.Marketplace {
+	return apierror.Lazada
+}
+
+// GetStatus returns http response status
+func (e ResponseError) GetStatus() int {
+	return e.Status
+}
+
+// GetCode returns the error code
+func (e ResponseError) GetCode() string {
+	return e.Code
+}
+
+// GetRequestID returns the request id of the call
+func (e ResponseError) GetRequestID() string {
+	return e.RequestID
+}
+
+// Retryable tells whether the call may succeed later
+func (e ResponseError) Retryable() bool {
+	return Codes.Retryable(e.Status, e.Code)
+}
+
+// As lets errors.As match e with a *ResponseError target too.
+func (e ResponseError) As(target any) bool {
+	if p, ok := target.(**ResponseError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches the apierror category of e.
+func (e ResponseError) Is(target error) bool {
+	return Codes.Match(e.Status, e.Code, target)
+}
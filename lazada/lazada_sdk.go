@@ -19,6 +19,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 	"github.com/apsyadira-jubelio/go-marketplace-sdk/utils"
 	"github.com/google/go-querystring/query"
 	"github.com/pkg/errors"
@@ -37,6 +39,15 @@ type Client struct {
 
 	accessToken string
 
+	// looks up seller tokens when a call has none, see WithTokenSource
+	tokenSource TokenSource
+
+	// wraps the transport once options are applied, see WithMiddleware
+	middleware []middleware.Option
+
+	// traces and measures calls, see WithTelemetry
+	telemetry []telemetry.Option
+
 	// The auth service used for making API calls related to authorization or OAuth
 	Auth    *AuthService
 	Chat    *ChatService
@@ -84,7 +95,7 @@ type LazadaResponse struct {
 }
 
 // NewClient takes in the application key, secret, and Lazada region and returns a client.
-func NewClient(appKey, secret string, region Region) *Client {
+func NewClient(appKey, secret string, region Region, opts ...Option) *Client {
 	baseURL, _ := url.Parse(endpoints[region])
 
 	c := &Client{
@@ -101,6 +112,18 @@ func NewClient(appKey, secret string, region Region) *Client {
 	c.Product = (*ProductService)(&c.common)
 	c.Order = (*OrderService)(&c.common)
 	c.Media = (*MediaService)(&c.common)
+
+	// apply any options
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.telemetry != nil {
+		c.Client.Transport = telemetry.New(c.Client.Transport, telemetryMarketplace, c.telemetry...)
+	}
+	if c.middleware != nil {
+		c.Client.Transport = middleware.New(c.Client.Transport, c.middleware...)
+	}
+
 	return c
 }
 
@@ -138,7 +161,8 @@ func addOptions(s string, opt interface{}) (string, error) {
 }
 
 // NewRequest returns an http request conforming to the open platform
-// Any body supplied will be encoded to XML.
+// Any body supplied will be encoded to XML and sent as the payload form field,
+// Do signs it together with the query.
 // token is used per-request; if empty, falls back to the client token (deprecated).
 func (c *Client) NewRequest(token, method, urlStr string, body interface{}) (*http.Request, error) {
 	if !strings.HasPrefix(urlStr, "https") {
@@ -165,18 +189,6 @@ func (c *Client) NewRequest(token, method, urlStr string, body interface{}) (*ht
 
 		reqParams := url.Values{}
 		reqParams.Set("payload", buf.String())
-		reqParams.Set("sign_method", "sha256")
-		reqParams.Set("timestamp", fmt.Sprintf("%d", time.Now().Unix()*1000))
-		reqParams.Set("app_key", c.appKey)
-
-		if token == "" {
-			token = c.accessToken
-		}
-		if token != "" {
-			reqParams.Set("access_token", token)
-		}
-		sig := c.Signature(strings.TrimPrefix(u.Path, "/rest"), reqParams)
-		reqParams.Set("sign", sig)
 
 		req, err = http.NewRequest(method, u.String(), strings.NewReader(reqParams.Encode()))
 		if err != nil {
@@ -197,33 +209,50 @@ func (c *Client) NewRequest(token, method, urlStr string, body interface{}) (*ht
 	return req, nil
 }
 
-// Do runs a http.Request adding in the various required query parameters if they weren't set by the body already.
+// Do runs a http.Request adding in the various required query parameters.
+// The signature covers the query and, for a form body, the form fields.
 // It will marshal the data returned into the provided interface.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*LazadaResponse, error) {
-	var q url.Values
 	// token carried on request header (set by NewRequest), fall back to client token
-	token := req.Header.Get("X-Access-Token")
+	token, err := c.resolveToken(ctx, req.Header.Get("X-Access-Token"))
 	req.Header.Del("X-Access-Token")
-	if token == "" {
-		token = c.accessToken
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("sign_method", "sha256")
+	q.Set("timestamp", fmt.Sprintf("%d", time.Now().Unix()*1000))
+	q.Set("app_key", c.appKey)
+	if token != "" {
+		q.Set("access_token", token)
 	}
 
-	if req.Body == nil {
-		q = req.URL.Query()
-		q.Set("sign_method", "sha256")
-		q.Set("timestamp", fmt.Sprintf("%d", time.Now().Unix()*1000))
-		q.Set("app_key", c.appKey)
-		if token != "" {
-			q.Set("access_token", token)
+	signed := url.Values{}
+	for k, vs := range q {
+		signed[k] = vs
+	}
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "cant read body")
 		}
+		req.Body = io.NopCloser(bytes.NewReader(b))
 
-		sig := c.Signature(strings.TrimPrefix(req.URL.Path, "/rest"), q)
-		q.Set("sign", sig)
+		form, err := url.ParseQuery(string(b))
+		if err != nil {
+			return nil, errors.Wrap(err, "cant parse body")
+		}
+		for k, vs := range form {
+			signed[k] = append(signed[k], vs...)
+		}
 	}
 
+	q.Set("sign", c.Signature(strings.TrimPrefix(req.URL.Path, "/rest"), signed))
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.Client.Do(req.WithContext(ctx))
 	c.logResponse(resp)
 
 	if err != nil {
@@ -255,6 +284,8 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Laz
 }
 
 // CheckResponse makes sure we didn't receive an error from the platform and if we did it returns the error properly.
+// The error is a ResponseError value for any status, match it with errors.As
+// on a ResponseError or a *ResponseError.
 func CheckResponse(r *http.Response) (*LazadaResponse, error) {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		lazResp := &LazadaResponse{}
@@ -272,13 +303,13 @@ func CheckResponse(r *http.Response) (*LazadaResponse, error) {
 			r.Body = io.NopCloser(bytes.NewBuffer(data))
 			return lazResp, nil
 		default:
-			var errResp ResponseError
+			errResp := ResponseError{Status: r.StatusCode}
 			json.Unmarshal(data, &errResp)
 			return nil, errResp
 		}
 	}
 
-	errResp := &ResponseError{}
+	errResp := ResponseError{Status: r.StatusCode}
 	data, err := io.ReadAll(r.Body)
 	if err == nil && data != nil {
 		json.Unmarshal(data, &errResp)
@@ -127,8 +127,13 @@ type UploadVideoBlockResponse struct {
 }
 
 func (m *MediaService) UploadVideoBlockRaw(ctx context.Context, token, filename string, param *UploadVideoBlockRequest) (*UploadVideoBlockResponse, error) {
+	token, err := m.client.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
 	ts := fmt.Sprintf("%d", time.Now().Unix()*1000)
-	baseURL := "https://api.lazada.co.id/rest/media/video/block/upload"
+	baseURL := m.client.BaseURL.String() + "rest/media/video/block/upload"
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
@@ -198,8 +203,7 @@ func (m *MediaService) UploadVideoBlockRaw(ctx context.Context, token, filename
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := m.client.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -245,6 +249,11 @@ type CompleteCreateVideoResponse struct {
 
 // CompleteCreateVideoRaw calls /media/video/block/commit to finalize an upload.
 func (m *MediaService) CompleteCreateVideoRaw(ctx context.Context, token string, req *CompleteCreateVideoRequest, parts []VideoPart) (*CompleteCreateVideoResponse, error) {
+	token, err := m.client.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
 	ts := fmt.Sprintf("%d", time.Now().Unix()*1000)
 	baseURL := m.client.BaseURL.String() + "rest/media/video/block/commit"
 	u, err := url.Parse(baseURL)
@@ -303,8 +312,7 @@ func (m *MediaService) CompleteCreateVideoRaw(ctx context.Context, token string,
 		return nil, err
 	}
 
-	client := http.Client{}
-	resp, err := client.Do(httpReq)
+	resp, err := m.client.Client.Do(httpReq)
 	if err != nil {
 		return nil, err
 	}
@@ -338,48 +346,19 @@ type UploadVideoResponse struct {
 // 2. CompleteCreateVideo to commit and get video_id
 //
 // title is the video title (required), coverUrl is the cover image URL (required).
+// Blocks are uploaded and retried as a VideoUploader with the default options
+// does, use one to resume uploads or report progress.
 func (m *MediaService) UploadVideo(ctx context.Context, token, filename, title, coverUrl, uploadID string, fileData []byte) (*UploadVideoResponse, error) {
-	// Step 1: Validate uploadID
 	if uploadID == "" {
 		return nil, fmt.Errorf("init create video returned empty upload_id")
 	}
 
-	// Step 2: Split and upload blocks, collect eTags
-	blocks := SplitFileToBlocks(fileData, MaxBlockSizeBytes)
-	parts := make([]VideoPart, 0, len(blocks))
-
-	for i, block := range blocks {
-		blockResp, err := m.UploadVideoBlockRaw(ctx, token, filename, &UploadVideoBlockRequest{
-			UploadId:   uploadID,
-			BlockNo:    i,
-			BlockCount: len(blocks),
-			File:       block,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("upload block %d/%d: %w", i+1, len(blocks), err)
-		}
-
-		parts = append(parts, VideoPart{
-			PartNumber: i + 1,
-			ETag:       blockResp.ETag,
-		})
-	}
-
-	// Step 3: Commit
-	commitResp, err := m.CompleteCreateVideoRaw(ctx, token, &CompleteCreateVideoRequest{
+	return m.NewVideoUploader().Upload(ctx, token, bytes.NewReader(fileData), int64(len(fileData)), &VideoUploadRequest{
 		UploadID: uploadID,
+		FileName: filename,
 		Title:    title,
 		CoverURL: coverUrl,
-	}, parts)
-
-	if err != nil {
-		return nil, fmt.Errorf("complete create video: %w", err)
-	}
-
-	return &UploadVideoResponse{
-		UploadID: uploadID,
-		VideoID:  commitResp.VideoID,
-	}, nil
+	})
 }
 
 type ThumbnailOptions struct {
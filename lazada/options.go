@@ -0,0 +1,134 @@
+package lazada
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
+)
+
+// Option is used to configure client with options
+type Option func(c *Client)
+
+// TokenSource returns the access token of a seller.
+type TokenSource interface {
+	AccessToken(ctx context.Context, sellerID string) (string, error)
+}
+
+// WithTokenSource makes service calls given an empty token fetch the token of
+// the seller set on their context with WithSeller.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = src
+	}
+}
+
+// WithMiddleware routes requests through a middleware.Transport, retrying
+// Lazada call limit errors and rate limiting by app and seller. Only calls
+// with a seller set by WithSeller count against a seller bucket, the others
+// only against the app one. It wraps the transport set by the other options
+// whatever their order.
+func WithMiddleware(opts ...middleware.Option) Option {
+	return func(c *Client) {
+		c.middleware = append([]middleware.Option{
+			middleware.WithRetryable(Retryable),
+			middleware.WithShopKey(sellerKeyOf),
+		}, opts...)
+	}
+}
+
+// WithTelemetry traces and measures every call with OpenTelemetry, see telemetry.
+func WithTelemetry(opts ...telemetry.Option) Option {
+	return func(c *Client) {
+		c.telemetry = append([]telemetry.Option{}, opts...)
+	}
+}
+
+// Retryable is the middleware.Retryable of Lazada, it also retries the call
+// limit and timeout errors Lazada returns with a 200 status.
+func Retryable(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err == nil && resp.StatusCode == http.StatusOK {
+		var body struct {
+			Code string `json:"code"`
+		}
+		if json.Unmarshal(middleware.PeekBody(resp), &body) == nil {
+			switch body.Code {
+			case "ApiCallLimit", "ServiceTimeout":
+				return true, 0
+			}
+		}
+	}
+	return middleware.DefaultRetryable(req, resp, err)
+}
+
+// sellerKeyOf picks the rate limit bucket of a request by its seller. The
+// access token is not used, it changes on every refresh.
+func sellerKeyOf(req *http.Request) string {
+	sellerID, _ := SellerFromContext(req.Context())
+	return sellerID
+}
+
+type sellerKey struct{}
+
+// WithSeller returns a copy of ctx carrying the seller a call is made for, see
+// WithTokenSource.
+func WithSeller(ctx context.Context, sellerID string) context.Context {
+	return context.WithValue(ctx, sellerKey{}, sellerID)
+}
+
+// withoutSeller hides the seller of ctx, for calls made without a seller token
+// such as the token exchange itself.
+func withoutSeller(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sellerKey{}, "")
+}
+
+// SellerFromContext returns the seller set with WithSeller.
+func SellerFromContext(ctx context.Context) (string, bool) {
+	sellerID, ok := ctx.Value(sellerKey{}).(string)
+	return sellerID, ok && sellerID != ""
+}
+
+// resolveToken returns token, or when it is empty the token of the seller of
+// ctx from the token source, or the client token when there is no seller.
+func (c *Client) resolveToken(ctx context.Context, token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+
+	sellerID, ok := SellerFromContext(ctx)
+	if !ok || c.tokenSource == nil {
+		return c.accessToken, nil
+	}
+	token, err := c.tokenSource.AccessToken(ctx, sellerID)
+	if err != nil {
+		return "", fmt.Errorf("lazada: access token of seller %s: %w", sellerID, err)
+	}
+	return token, nil
+}
+
+// telemetryMarketplace describes the calls of Lazada to the telemetry
+// transport. Only sellers set by WithSeller are told, the access token is
+// not.
+var telemetryMarketplace = telemetry.Marketplace{
+	Name: string(apierror.Lazada),
+	ShopKey: func(req *http.Request) string {
+		sellerID, _ := SellerFromContext(req.Context())
+		return sellerID
+	},
+	Describe: func(resp *http.Response) (string, string) {
+		var body struct {
+			Code      string `json:"code"`
+			RequestID string `json:"request_id"`
+		}
+		json.Unmarshal(middleware.PeekBody(resp), &body)
+		if body.Code == "0" {
+			body.Code = ""
+		}
+		return body.Code, body.RequestID
+	},
+}
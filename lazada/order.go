@@ -2,7 +2,12 @@ package lazada
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"iter"
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
 )
 
 // The Order Service deals with any methods under the "Order" category of the open platform
@@ -26,6 +31,8 @@ type DataGetMultipleOrderItem struct {
 type PickUpStoreInfo struct {
 }
 
+// OrderItems amounts are in Currency, but SupplyPrice which is in
+// SupplyPriceCurrency.
 type OrderItems struct {
 	BuyerID                     int64           `json:"buyer_id"`
 	CancelReturnInitiator       string          `json:"cancel_return_initiator"`
@@ -40,7 +47,7 @@ type OrderItems struct {
 	IsDigital                   int             `json:"is_digital"`
 	IsFbl                       int             `json:"is_fbl"`
 	IsReroute                   int             `json:"is_reroute"`
-	ItemPrice                   int             `json:"item_price"`
+	ItemPrice                   money.Money     `json:"item_price"`
 	Mp3Order                    bool            `json:"mp3_order"`
 	Name                        string          `json:"name"`
 	OrderFlag                   string          `json:"order_flag"`
@@ -48,7 +55,7 @@ type OrderItems struct {
 	OrderItemID                 int64           `json:"order_item_id"`
 	OrderType                   string          `json:"order_type"`
 	PackageID                   string          `json:"package_id"`
-	PaidPrice                   int             `json:"paid_price"`
+	PaidPrice                   money.Money     `json:"paid_price"`
 	Personalization             string          `json:"personalization"`
 	PickUpStoreInfo             PickUpStoreInfo `json:"pick_up_store_info"`
 	PriorityFulfillmentTag      string          `json:"priority_fulfillment_tag"`
@@ -62,12 +69,12 @@ type OrderItems struct {
 	ReturnStatus                string          `json:"return_status"`
 	SemiManaged                 bool            `json:"semi_managed"`
 	ShipmentProvider            string          `json:"shipment_provider"`
-	ShippingAmount              int             `json:"shipping_amount"`
-	ShippingFeeDiscountPlatform int             `json:"shipping_fee_discount_platform"`
-	ShippingFeeDiscountSeller   int             `json:"shipping_fee_discount_seller"`
-	ShippingFeeOriginal         int             `json:"shipping_fee_original"`
+	ShippingAmount              money.Money     `json:"shipping_amount"`
+	ShippingFeeDiscountPlatform money.Money     `json:"shipping_fee_discount_platform"`
+	ShippingFeeDiscountSeller   money.Money     `json:"shipping_fee_discount_seller"`
+	ShippingFeeOriginal         money.Money     `json:"shipping_fee_original"`
 	ShippingProviderType        string          `json:"shipping_provider_type"`
-	ShippingServiceCost         int             `json:"shipping_service_cost"`
+	ShippingServiceCost         money.Money     `json:"shipping_service_cost"`
 	ShippingType                string          `json:"shipping_type"`
 	ShopID                      string          `json:"shop_id"`
 	ShopSku                     string          `json:"shop_sku"`
@@ -76,25 +83,39 @@ type OrderItems struct {
 	SLATimeStamp                string          `json:"sla_time_stamp"`
 	StagePayStatus              string          `json:"stage_pay_status"`
 	Status                      string          `json:"status"`
-	SupplyPrice                 int             `json:"supply_price"`
+	SupplyPrice                 money.Money     `json:"supply_price"`
 	SupplyPriceCurrency         string          `json:"supply_price_currency"`
-	TaxAmount                   int             `json:"tax_amount"`
+	TaxAmount                   money.Money     `json:"tax_amount"`
 	TrackingCode                string          `json:"tracking_code"`
 	TrackingCodePre             string          `json:"tracking_code_pre"`
 	UpdatedAt                   string          `json:"updated_at"`
 	Variation                   string          `json:"variation"`
-	VoucherAmount               int             `json:"voucher_amount"`
+	VoucherAmount               money.Money     `json:"voucher_amount"`
 	VoucherCode                 string          `json:"voucher_code"`
 	VoucherCodePlatform         string          `json:"voucher_code_platform"`
 	VoucherCodeSeller           string          `json:"voucher_code_seller"`
-	VoucherPlatform             int             `json:"voucher_platform"`
-	VoucherPlatformLpi          int             `json:"voucher_platform_lpi"`
-	VoucherSeller               int             `json:"voucher_seller"`
-	VoucherSellerLpi            int             `json:"voucher_seller_lpi"`
-	WalletCredits               int             `json:"wallet_credits"`
+	VoucherPlatform             money.Money     `json:"voucher_platform"`
+	VoucherPlatformLpi          money.Money     `json:"voucher_platform_lpi"`
+	VoucherSeller               money.Money     `json:"voucher_seller"`
+	VoucherSellerLpi            money.Money     `json:"voucher_seller_lpi"`
+	WalletCredits               money.Money     `json:"wallet_credits"`
 	WarehouseCode               string          `json:"warehouse_code"`
 }
 
+func (o *OrderItems) UnmarshalJSON(b []byte) error {
+	type orderItems OrderItems
+	if err := json.Unmarshal(b, (*orderItems)(o)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(o.Currency, &o.ItemPrice, &o.PaidPrice, &o.ShippingAmount,
+		&o.ShippingFeeDiscountPlatform, &o.ShippingFeeDiscountSeller, &o.ShippingFeeOriginal,
+		&o.ShippingServiceCost, &o.TaxAmount, &o.VoucherAmount, &o.VoucherPlatform,
+		&o.VoucherPlatformLpi, &o.VoucherSeller, &o.VoucherSellerLpi, &o.WalletCredits)
+	money.FillCurrency(o.SupplyPriceCurrency, &o.SupplyPrice)
+	return nil
+}
+
 // GetMultipleOrdersItems is a method on the OrderService struct. Use this API to get detailed information of the specified orders.
 // The function returns a pointer to an GetMultipleOrdersItemsResponse struct containing the server's response, and an error, if there is one.
 func (o *OrderService) GetMultipleOrdersItems(ctx context.Context, token string, opts *GetMultipleOrdersItemsParam) (res *GetMultipleOrdersItemsResponse, err error) {
@@ -141,6 +162,8 @@ type DataGetOrders struct {
 	Orders     []Orders `json:"orders"`
 }
 
+// Orders amounts carry no currency, Lazada only sends it with the items of
+// the order, see OrderItems.
 type Orders struct {
 	AddressBilling              AddressBilling  `json:"address_billing"`
 	AddressShipping             AddressShipping `json:"address_shipping"`
@@ -158,20 +181,20 @@ type Orders struct {
 	OrderID                     int64           `json:"order_id"`
 	OrderNumber                 int64           `json:"order_number"`
 	PaymentMethod               string          `json:"payment_method"`
-	Price                       string          `json:"price"`
+	Price                       money.Money     `json:"price"`
 	PromisedShippingTimes       string          `json:"promised_shipping_times"`
 	Remarks                     string          `json:"remarks"`
-	ShippingFee                 int             `json:"shipping_fee"`
-	ShippingFeeDiscountPlatform int             `json:"shipping_fee_discount_platform"`
-	ShippingFeeDiscountSeller   int             `json:"shipping_fee_discount_seller"`
-	ShippingFeeOriginal         int             `json:"shipping_fee_original"`
+	ShippingFee                 money.Money     `json:"shipping_fee"`
+	ShippingFeeDiscountPlatform money.Money     `json:"shipping_fee_discount_platform"`
+	ShippingFeeDiscountSeller   money.Money     `json:"shipping_fee_discount_seller"`
+	ShippingFeeOriginal         money.Money     `json:"shipping_fee_original"`
 	Statuses                    []string        `json:"statuses"`
 	TaxCode                     string          `json:"tax_code"`
 	UpdatedAt                   string          `json:"updated_at"`
-	Voucher                     int             `json:"voucher"`
+	Voucher                     money.Money     `json:"voucher"`
 	VoucherCode                 string          `json:"voucher_code"`
-	VoucherPlatform             int             `json:"voucher_platform"`
-	VoucherSeller               int             `json:"voucher_seller"`
+	VoucherPlatform             money.Money     `json:"voucher_platform"`
+	VoucherSeller               money.Money     `json:"voucher_seller"`
 	WarehouseCode               string          `json:"warehouse_code"`
 }
 
@@ -232,3 +255,375 @@ func (o *OrderService) GetOrders(ctx context.Context, token string, opts *GetOrd
 
 	return res, nil
 }
+
+// All iterates over the orders returned by GetOrders, moving offset by limit
+// until a short page or countTotal is reached. A limit of 100 is used when
+// opts has none. A failed page is yielded as an error and ends the iteration.
+func (o *OrderService) All(ctx context.Context, token string, opts GetOrdersParam) iter.Seq2[Orders, error] {
+	return func(yield func(Orders, error) bool) {
+		opts := opts
+		offset, limit, err := offsetLimit(opts.Offset, opts.Limit, 100)
+		if err != nil {
+			yield(Orders{}, err)
+			return
+		}
+		opts.Limit = strconv.Itoa(limit)
+
+		for {
+			opts.Offset = strconv.Itoa(offset)
+			resp, err := o.GetOrders(ctx, token, &opts)
+			if err != nil {
+				yield(Orders{}, err)
+				return
+			}
+
+			for _, order := range resp.Data.Orders {
+				if !yield(order, nil) {
+					return
+				}
+			}
+
+			offset += len(resp.Data.Orders)
+			if len(resp.Data.Orders) < limit || (resp.Data.CountTotal > 0 && offset >= resp.Data.CountTotal) {
+				return
+			}
+		}
+	}
+}
+
+type GetOrderParam struct {
+	OrderID int64 `url:"order_id"`
+}
+
+type GetOrderResponse struct {
+	BaseResponse
+	Data Orders `json:"data"`
+}
+
+// GetOrder is a method on the OrderService struct. Use this API to get a single order.
+func (o *OrderService) GetOrder(ctx context.Context, token string, opts *GetOrderParam) (res *GetOrderResponse, err error) {
+	u, err := addOptions(ApiNames["GetOrder"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+type GetOrderItemsResponse struct {
+	BaseResponse
+	Data []OrderItems `json:"data"`
+}
+
+// GetOrderItems is a method on the OrderService struct. Use this API to get the items of a single order.
+func (o *OrderService) GetOrderItems(ctx context.Context, token string, opts *GetOrderParam) (res *GetOrderItemsResponse, err error) {
+	u, err := addOptions(ApiNames["GetOrderItems"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+// PackParam packs order items with a shipment provider of
+// GetShipmentProviders, DeliveryType is dropship, pickup or send_to_warehouse.
+type PackParam struct {
+	OrderItemIDs     IDList `url:"order_item_ids"`
+	DeliveryType     string `url:"delivery_type"`
+	ShippingProvider string `url:"shipping_provider,omitempty"`
+}
+
+type PackResponse struct {
+	BaseResponse
+	Data struct {
+		OrderItems []PackedOrderItem `json:"order_items"`
+	} `json:"data"`
+}
+
+type PackedOrderItem struct {
+	OrderItemID         int64  `json:"order_item_id"`
+	PurchaseOrderID     string `json:"purchase_order_id"`
+	PurchaseOrderNumber string `json:"purchase_order_number"`
+	PackageID           string `json:"package_id"`
+	ShipmentProvider    string `json:"shipment_provider"`
+	TrackingNumber      string `json:"tracking_number"`
+}
+
+// Pack is a method on the OrderService struct. Use this API to mark order items as packed, the response carries the tracking number.
+func (o *OrderService) Pack(ctx context.Context, token string, opts *PackParam) (res *PackResponse, err error) {
+	u, err := addOptions(ApiNames["SetStatusToPacked"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+type ReadyToShipParam struct {
+	OrderItemIDs     IDList `url:"order_item_ids"`
+	DeliveryType     string `url:"delivery_type"`
+	ShipmentProvider string `url:"shipment_provider,omitempty"`
+	TrackingNumber   string `url:"tracking_number,omitempty"`
+}
+
+type ReadyToShipResponse struct {
+	BaseResponse
+	Data struct {
+		OrderItems []PackedOrderItem `json:"order_items"`
+	} `json:"data"`
+}
+
+// ReadyToShip is a method on the OrderService struct. Use this API to mark packed order items as ready to ship.
+func (o *OrderService) ReadyToShip(ctx context.Context, token string, opts *ReadyToShipParam) (res *ReadyToShipResponse, err error) {
+	u, err := addOptions(ApiNames["SetStatusToRTS"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+type CancelValidateParam struct {
+	OrderID         int64  `url:"order_id"`
+	OrderItemIDList IDList `url:"order_item_id_list"`
+}
+
+type CancelValidateResponse struct {
+	BaseResponse
+	Data struct {
+		TipContent    string         `json:"tip_content"`
+		TipType       string         `json:"tip_type"`
+		ReasonOptions []CancelReason `json:"reason_options"`
+	} `json:"data"`
+}
+
+type CancelReason struct {
+	ReasonID   int64  `json:"reason_id"`
+	ReasonName string `json:"reason_name"`
+}
+
+// CancelValidate is a method on the OrderService struct. Use this API to check order items can be cancelled, it lists the reasons CancelOrder accepts.
+func (o *OrderService) CancelValidate(ctx context.Context, token string, opts *CancelValidateParam) (res *CancelValidateResponse, err error) {
+	u, err := addOptions(ApiNames["CancelValidate"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+type CancelOrderParam struct {
+	OrderID         int64  `url:"order_id"`
+	OrderItemIDList IDList `url:"order_item_id_list"`
+	ReasonID        int64  `url:"reason_id"`
+	ReasonDetail    string `url:"reason_detail,omitempty"`
+}
+
+type CancelOrderResponse BaseResponse
+
+// CancelOrder is a method on the OrderService struct. Use this API to cancel order items with a reason of CancelValidate.
+func (o *OrderService) CancelOrder(ctx context.Context, token string, opts *CancelOrderParam) (res *CancelOrderResponse, err error) {
+	u, err := addOptions(ApiNames["ReverseCancelCreate"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+// Document types of GetDocument
+const (
+	DocumentInvoice         = "invoice"
+	DocumentShippingLabel   = "shippingLabel"
+	DocumentCarrierManifest = "carrierManifest"
+)
+
+type GetDocumentParam struct {
+	DocType      string `url:"doc_type"`
+	OrderItemIDs IDList `url:"order_item_ids"`
+}
+
+type GetDocumentResponse struct {
+	BaseResponse
+	Data struct {
+		Document Document `json:"document"`
+	} `json:"data"`
+}
+
+type Document struct {
+	DocumentType string `json:"document_type"`
+	MimeType     string `json:"mime_type"`
+	File         string `json:"file"` // base64 encoded
+}
+
+// Bytes decodes the file of the document, e.g. the PDF of a shipping label.
+func (d Document) Bytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(d.File)
+}
+
+// GetDocument is a method on the OrderService struct. Use this API to get the invoice, shipping label or carrier manifest of order items.
+func (o *OrderService) GetDocument(ctx context.Context, token string, opts *GetDocumentParam) (res *GetDocumentResponse, err error) {
+	u, err := addOptions(ApiNames["GetDocument"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := o.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
+
+type GetShipmentProvidersResponse struct {
+	BaseResponse
+	Data struct {
+		ShipmentProviders []ShipmentProvider `json:"shipment_providers"`
+	} `json:"data"`
+}
+
+type ShipmentProvider struct {
+	Name                        string `json:"name"`
+	Cod                         int    `json:"cod"`
+	IsDefault                   int    `json:"is_default"`
+	APIIntegration              int    `json:"api_integration"`
+	TrackingCodeExample         string `json:"tracking_code_example"`
+	TrackingCodeValidationRegex string `json:"tracking_code_validation_regex"`
+}
+
+// GetShipmentProviders is a method on the OrderService struct. Use this API to list the shipment providers Pack accepts.
+func (o *OrderService) GetShipmentProviders(ctx context.Context, token string) (res *GetShipmentProvidersResponse, err error) {
+	req, err := o.client.NewRequest(token, "GET", ApiNames["GetShipmentProviders"], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal([]byte(jsonData), &res)
+
+	return res, nil
+}
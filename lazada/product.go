@@ -3,6 +3,11 @@ package lazada
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"iter"
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
 )
 
 // The Product Service deals with any methods under the "Instant Messaging" category of the open platform
@@ -42,25 +47,28 @@ type Products struct {
 	Status          string         `json:"status"`
 }
 
+// Skus prices carry no currency, it is the one of the country of the seller.
 type Skus struct {
-	Status          string   `json:"Status"`
-	Quantity        int      `json:"quantity"`
-	ProductWeight   int      `json:"product_weight"`
-	Images          []string `json:"Images"`
-	SellerSku       string   `json:"SellerSku"`
-	ShopSku         string   `json:"ShopSku"`
-	URL             string   `json:"Url"`
-	PackageWidth    string   `json:"package_width"`
-	SpecialToTime   string   `json:"special_to_time"`
-	SpecialFromTime string   `json:"special_from_time"`
-	PackageHeight   string   `json:"package_height"`
-	SpecialPrice    float64  `json:"special_price"`
-	Price           float64  `json:"price"`
-	PackageLength   string   `json:"package_length"`
-	PackageWeight   string   `json:"package_weight"`
-	Available       int      `json:"Available"`
-	SkuID           int      `json:"SkuId"`
-	SpecialToDate   string   `json:"special_to_date"`
+	Status          string      `json:"Status"`
+	Quantity        int         `json:"quantity"`
+	ProductWeight   int         `json:"product_weight"`
+	Images          []string    `json:"Images"`
+	SellerSku       string      `json:"SellerSku"`
+	ShopSku         string      `json:"ShopSku"`
+	URL             string      `json:"Url"`
+	PackageWidth    string      `json:"package_width"`
+	SpecialToTime   string      `json:"special_to_time"`
+	SpecialFromTime string      `json:"special_from_time"`
+	PackageHeight   string      `json:"package_height"`
+	SpecialPrice    money.Money `json:"special_price"`
+	Price           money.Money `json:"price"`
+	PackageLength   string      `json:"package_length"`
+	PackageWeight   string      `json:"package_weight"`
+	Available       int         `json:"Available"`
+	SkuID           int         `json:"SkuId"`
+	SpecialToDate   string      `json:"special_to_date"`
+	// SaleProp holds the sale properties of the sku, e.g. color_family
+	SaleProp StringMap `json:"saleProp,omitempty"`
 }
 
 type RejectReason struct {
@@ -115,3 +123,400 @@ func (p *ProductService) GetProducts(ctx context.Context, token string, opts *Ge
 
 	return res, nil
 }
+
+// All iterates over the products returned by GetProducts, moving offset by
+// limit until a short page or total_products is reached. A limit of 50 is
+// used when opts has none. A failed page is yielded as an error and ends the
+// iteration.
+func (p *ProductService) All(ctx context.Context, token string, opts GetProductsParams) iter.Seq2[Products, error] {
+	return func(yield func(Products, error) bool) {
+		opts := opts
+		offset, limit, err := offsetLimit(opts.Offset, opts.Limit, 50)
+		if err != nil {
+			yield(Products{}, err)
+			return
+		}
+		opts.Limit = strconv.Itoa(limit)
+
+		for {
+			opts.Offset = strconv.Itoa(offset)
+			resp, err := p.GetProducts(ctx, token, &opts)
+			if err != nil {
+				yield(Products{}, err)
+				return
+			}
+
+			for _, product := range resp.Data.Products {
+				if !yield(product, nil) {
+					return
+				}
+			}
+
+			offset += len(resp.Data.Products)
+			if len(resp.Data.Products) < limit || (resp.Data.TotalProducts > 0 && offset >= resp.Data.TotalProducts) {
+				return
+			}
+		}
+	}
+}
+
+// ProductRequest is the XML payload of CreateProduct and UpdateProduct, build
+// it from a product with NewProductRequest.
+type ProductRequest struct {
+	XMLName xml.Name       `xml:"Request"`
+	Product ProductPayload `xml:"Product"`
+}
+
+type ProductPayload struct {
+	ItemID          int            `xml:"ItemId,omitempty"`
+	PrimaryCategory string         `xml:"PrimaryCategory,omitempty"`
+	Images          *PayloadImages `xml:"Images,omitempty"`
+	// Attributes holds the product attributes of the category, see
+	// GetCategoryAttributes
+	Attributes StringMap    `xml:"Attributes,omitempty"`
+	Skus       []SkuPayload `xml:"Skus>Sku"`
+}
+
+type SkuPayload struct {
+	SkuID           int            `xml:"SkuId,omitempty"`
+	SellerSku       string         `xml:"SellerSku"`
+	Status          string         `xml:"Status,omitempty"`
+	Quantity        *int           `xml:"quantity,omitempty"` // nil leaves the stock unchanged on update
+	Price           float64        `xml:"price,omitempty"`
+	SpecialPrice    float64        `xml:"special_price,omitempty"`
+	SpecialFromDate string         `xml:"special_from_date,omitempty"`
+	SpecialToDate   string         `xml:"special_to_date,omitempty"`
+	PackageWeight   string         `xml:"package_weight,omitempty"`
+	PackageLength   string         `xml:"package_length,omitempty"`
+	PackageWidth    string         `xml:"package_width,omitempty"`
+	PackageHeight   string         `xml:"package_height,omitempty"`
+	Images          *PayloadImages `xml:"Images,omitempty"`
+	// SaleProp holds the sale properties of the sku, e.g. color_family
+	SaleProp StringMap `xml:"saleProp,omitempty"`
+}
+
+// PayloadImages lists image URLs hosted by Lazada, see MigrateImage.
+type PayloadImages struct {
+	Image []string `xml:"Image"`
+}
+
+// NewPayloadImages returns nil for no urls, which leaves the images of an
+// update unchanged.
+func NewPayloadImages(urls []string) *PayloadImages {
+	if len(urls) == 0 {
+		return nil
+	}
+	return &PayloadImages{Image: urls}
+}
+
+// NewProductRequest builds the payload that writes p back, for a read, modify,
+// write round trip from GetProducts. Category specific attributes are not part
+// of Products and can be added to the result. Sku quantities are left nil so
+// the update keeps the stock sold in the meantime, set them to write it.
+func NewProductRequest(p Products) *ProductRequest {
+	attrs := StringMap{}
+	for key, value := range map[string]string{
+		"name":              p.Attributes.Name,
+		"short_description": p.Attributes.ShortDescription,
+		"description":       p.Attributes.Description,
+		"brand":             p.Attributes.Brand,
+		"warranty_type":     p.Attributes.WarrantyType,
+		"name_engravement":  p.Attributes.NameEngravement,
+		"gift_wrapping":     p.Attributes.GiftWrapping,
+		"preorder":          p.Attributes.Preorder,
+	} {
+		if value != "" {
+			attrs[key] = value
+		}
+	}
+	if p.Attributes.PreorderDays > 0 {
+		attrs["preorder_days"] = strconv.Itoa(p.Attributes.PreorderDays)
+	}
+
+	req := &ProductRequest{
+		Product: ProductPayload{
+			ItemID:          p.ItemID,
+			PrimaryCategory: p.PrimaryCategory,
+			Images:          NewPayloadImages(p.Images),
+			Attributes:      attrs,
+		},
+	}
+
+	for _, sku := range p.Skus {
+		req.Product.Skus = append(req.Product.Skus, SkuPayload{
+			SkuID:           sku.SkuID,
+			SellerSku:       sku.SellerSku,
+			Status:          sku.Status,
+			Price:           sku.Price.Amount.Float64(),
+			SpecialPrice:    sku.SpecialPrice.Amount.Float64(),
+			SpecialFromDate: sku.SpecialFromTime,
+			SpecialToDate:   sku.SpecialToTime,
+			PackageWeight:   sku.PackageWeight,
+			PackageLength:   sku.PackageLength,
+			PackageWidth:    sku.PackageWidth,
+			PackageHeight:   sku.PackageHeight,
+			Images:          NewPayloadImages(sku.Images),
+			SaleProp:        sku.SaleProp,
+		})
+	}
+
+	return req
+}
+
+type CreateProductResponse struct {
+	BaseResponse
+	Data struct {
+		ItemID  int              `json:"item_id"`
+		SkuList []CreatedSkuList `json:"sku_list"`
+	} `json:"data"`
+}
+
+type CreatedSkuList struct {
+	ShopSku   string `json:"shop_sku"`
+	SellerSku string `json:"seller_sku"`
+	SkuID     int    `json:"sku_id"`
+}
+
+// CreateProduct creates a product with its skus, ItemID and SkuID of the
+// payload are left empty.
+func (p *ProductService) CreateProduct(ctx context.Context, token string, payload *ProductRequest) (res *CreateProductResponse, err error) {
+	req, err := p.client.NewRequest(token, "POST", ApiNames["CreateProduct"], payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
+
+type UpdateProductResponse struct {
+	BaseResponse
+}
+
+// UpdateProduct updates the product ItemID, skus are matched by SkuID or
+// SellerSku and fields left empty are not changed.
+func (p *ProductService) UpdateProduct(ctx context.Context, token string, payload *ProductRequest) (res *UpdateProductResponse, err error) {
+	req, err := p.client.NewRequest(token, "POST", ApiNames["UpdateProduct"], payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
+
+type GetBrandsParams struct {
+	Offset string `url:"offset"`
+	Limit  string `url:"limit"`
+}
+
+type GetBrandsResponse struct {
+	BaseResponse
+	Data []Brand `json:"data"`
+}
+
+type Brand struct {
+	BrandID          int    `json:"brand_id"`
+	Name             string `json:"name"`
+	NameEn           string `json:"name_en"`
+	GlobalIdentifier string `json:"global_identifier"`
+}
+
+// GetBrands lists the brands a product can have, 100 from the first when
+// opts is nil.
+func (p *ProductService) GetBrands(ctx context.Context, token string, opts *GetBrandsParams) (res *GetBrandsResponse, err error) {
+	if opts == nil {
+		opts = &GetBrandsParams{
+			Limit:  "100",
+			Offset: "0",
+		}
+	}
+
+	u, err := addOptions(ApiNames["GetBrands"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := p.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
+
+type CategoryTreeParams struct {
+	LanguageCode string `url:"language_code,omitempty"`
+}
+
+type GetCategoryTreeResponse struct {
+	BaseResponse
+	Data []Category `json:"data"`
+}
+
+type Category struct {
+	CategoryID int        `json:"category_id"`
+	Name       string     `json:"name"`
+	Leaf       bool       `json:"leaf"`
+	Var        bool       `json:"var"`
+	Children   []Category `json:"children"`
+}
+
+// GetCategoryTree returns the categories of the region, products are created
+// in a leaf category.
+func (p *ProductService) GetCategoryTree(ctx context.Context, token string, opts *CategoryTreeParams) (res *GetCategoryTreeResponse, err error) {
+	u, err := addOptions(ApiNames["CategoryTree"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := p.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
+
+type CategoryAttributesParams struct {
+	PrimaryCategoryID string `url:"primary_category_id"`
+	LanguageCode      string `url:"language_code,omitempty"`
+}
+
+type GetCategoryAttributesResponse struct {
+	BaseResponse
+	Data []CategoryAttribute `json:"data"`
+}
+
+type CategoryAttribute struct {
+	ID            int                       `json:"id"`
+	Name          string                    `json:"name"`
+	Label         string                    `json:"label"`
+	InputType     string                    `json:"input_type"`
+	AttributeType string                    `json:"attribute_type"` // normal or sku
+	IsMandatory   int                       `json:"is_mandatory"`
+	IsSaleProp    int                       `json:"is_sale_prop"`
+	Options       []CategoryAttributeOption `json:"options"`
+	Advanced      struct {
+		IsKeyProp int `json:"is_key_prop"`
+	} `json:"advanced"`
+}
+
+type CategoryAttributeOption struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	EnName string `json:"en_name"`
+}
+
+// GetCategoryAttributes returns the attributes of a category, attributes of
+// type sku go into the skus of a ProductRequest and the others into its
+// Attributes.
+func (p *ProductService) GetCategoryAttributes(ctx context.Context, token string, opts *CategoryAttributesParams) (res *GetCategoryAttributesResponse, err error) {
+	u, err := addOptions(ApiNames["CategoryAttributes"], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := p.client.NewRequest(token, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
+
+type imageMigrateRequest struct {
+	XMLName xml.Name `xml:"Request"`
+	URL     string   `xml:"Image>Url"`
+}
+
+type MigrateImageResponse struct {
+	BaseResponse
+	Data struct {
+		Image struct {
+			HashCode string `json:"hash_code"`
+			URL      string `json:"url"`
+		} `json:"image"`
+	} `json:"data"`
+}
+
+// MigrateImage copies the image at imageURL to Lazada, products only take
+// images hosted by Lazada.
+func (p *ProductService) MigrateImage(ctx context.Context, token string, imageURL string) (res *MigrateImageResponse, err error) {
+	req, err := p.client.NewRequest(token, "POST", ApiNames["ImageMigrate"], imageMigrateRequest{URL: imageURL})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(jsonData, &res)
+
+	return res, nil
+}
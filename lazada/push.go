@@ -0,0 +1,218 @@
+package lazada
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// PushMessageType tells what a LazOP push is about.
+type PushMessageType int
+
+const (
+	PushTradeOrder       PushMessageType = 0
+	PushInstantMessaging PushMessageType = 2
+	PushProductQC        PushMessageType = 3
+)
+
+// maxPushBody is the largest push body the handler reads.
+const maxPushBody = 1 << 20
+
+// PushMessage is the envelope of every push, Data holds the type specific
+// part. Timestamp is in milliseconds.
+type PushMessage struct {
+	SellerID    string          `json:"seller_id"`
+	MessageType PushMessageType `json:"message_type"`
+	Timestamp   int64           `json:"timestamp"`
+	Site        string          `json:"site"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type TradeOrderPush struct {
+	SellerID         string          `json:"-"`
+	Timestamp        int64           `json:"-"`
+	BuyerID          json.Number     `json:"buyer_id"`
+	TradeOrderID     string          `json:"trade_order_id"`
+	TradeOrderLineID string          `json:"trade_order_line_id"`
+	OrderItemStatus  string          `json:"order_item_status"`
+	StatusUpdateTime int64           `json:"status_update_time"`
+	ExtraParams      json.RawMessage `json:"extra_params,omitempty"`
+}
+
+// ChatMessagePush is a new chat message, Message has the same shape as the
+// messages returned by ChatService.GetMessageList.
+type ChatMessagePush struct {
+	SellerID  string
+	Timestamp int64
+	Message   MessagesListData
+}
+
+type ProductQCPush struct {
+	SellerID     string      `json:"-"`
+	Timestamp    int64       `json:"-"`
+	ItemID       json.Number `json:"item_id"`
+	SellerSku    string      `json:"seller_sku"`
+	Status       string      `json:"status"`
+	RejectReason string      `json:"reject_reason"`
+}
+
+// VerifyPushSignature checks the Authorization header of a push, which is
+// HMAC-SHA256(secret, appKey + body) in hex of either case.
+func VerifyPushSignature(appKey, secret string, body []byte, authorization string) bool {
+	signature, err := hex.DecodeString(authorization)
+	if err != nil {
+		return false
+	}
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(appKey))
+	h.Write(body)
+	return hmac.Equal(h.Sum(nil), signature)
+}
+
+// PushHandler is an http.Handler receiving LazOP push messages for the app of
+// a client. It verifies the signature, decodes the message and calls the
+// callback registered for its type, or the OnMessage callback when there is
+// none. A callback error answers 500 so Lazada sends the push again.
+//
+// Register callbacks before serving, they are not guarded for concurrent
+// registration.
+type PushHandler struct {
+	appKey string
+	secret string
+
+	onMessage    func(ctx context.Context, m *PushMessage) error
+	onTradeOrder func(ctx context.Context, p *TradeOrderPush) error
+	onChat       func(ctx context.Context, p *ChatMessagePush) error
+	onProductQC  func(ctx context.Context, p *ProductQCPush) error
+}
+
+// NewPushHandler returns a handler for pushes signed with the app key and
+// secret of c.
+func NewPushHandler(c *Client) *PushHandler {
+	return &PushHandler{appKey: c.appKey, secret: c.secret}
+}
+
+// OnMessage sets the callback for pushes without a typed callback.
+func (h *PushHandler) OnMessage(fn func(ctx context.Context, m *PushMessage) error) {
+	h.onMessage = fn
+}
+
+func (h *PushHandler) OnTradeOrder(fn func(ctx context.Context, p *TradeOrderPush) error) {
+	h.onTradeOrder = fn
+}
+
+func (h *PushHandler) OnChatMessage(fn func(ctx context.Context, p *ChatMessagePush) error) {
+	h.onChat = fn
+}
+
+func (h *PushHandler) OnProductQC(fn func(ctx context.Context, p *ProductQCPush) error) {
+	h.onProductQC = fn
+}
+
+func (h *PushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBody))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if !VerifyPushSignature(h.appKey, h.secret, body, r.Header.Get("Authorization")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var m PushMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		http.Error(w, "invalid push body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), &m); err != nil {
+		status := http.StatusInternalServerError
+		var dataErr *PushDataError
+		if errors.As(err, &dataErr) {
+			// a retry would not decode any better
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Dispatch decodes a verified push and calls its callback, it is used by
+// ServeHTTP and is handy for pushes received through a queue.
+func (h *PushHandler) Dispatch(ctx context.Context, m *PushMessage) error {
+	switch m.MessageType {
+	case PushTradeOrder:
+		if h.onTradeOrder == nil {
+			break
+		}
+		p := &TradeOrderPush{SellerID: m.SellerID, Timestamp: m.Timestamp}
+		if err := decodePushData(m, p); err != nil {
+			return err
+		}
+		return h.onTradeOrder(ctx, p)
+
+	case PushInstantMessaging:
+		if h.onChat == nil {
+			break
+		}
+		p := &ChatMessagePush{SellerID: m.SellerID, Timestamp: m.Timestamp}
+		if err := decodePushData(m, &p.Message); err != nil {
+			return err
+		}
+		return h.onChat(ctx, p)
+
+	case PushProductQC:
+		if h.onProductQC == nil {
+			break
+		}
+		p := &ProductQCPush{SellerID: m.SellerID, Timestamp: m.Timestamp}
+		if err := decodePushData(m, p); err != nil {
+			return err
+		}
+		return h.onProductQC(ctx, p)
+	}
+
+	if h.onMessage != nil {
+		return h.onMessage(ctx, m)
+	}
+	return nil
+}
+
+// PushDataError is returned by Dispatch when the data of a push does not
+// match its message type.
+type PushDataError struct {
+	MessageType PushMessageType
+	Err         error
+}
+
+func (e *PushDataError) Error() string {
+	return fmt.Sprintf("lazada: decode push message type %d: %s", e.MessageType, e.Err)
+}
+
+func (e *PushDataError) Unwrap() error {
+	return e.Err
+}
+
+func decodePushData(m *PushMessage, v interface{}) error {
+	if err := json.Unmarshal(m.Data, v); err != nil {
+		return &PushDataError{MessageType: m.MessageType, Err: err}
+	}
+	return nil
+}
@@ -1,19 +1,29 @@
 package lazada
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"sort"
+)
 
 type StringMap map[string]string
 
-// StringMap marshals a map into XML.
+// StringMap marshals a map into XML, one child element per key in key order.
 func (s StringMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-	tokens := []xml.Token{}
+	tokens := []xml.Token{start}
 
-	for key, value := range s {
+	for _, key := range keys {
 		t := xml.StartElement{Name: xml.Name{Space: "", Local: key}}
-		tokens = append(tokens, t, xml.CharData(value), xml.EndElement{Name: t.Name})
+		tokens = append(tokens, t, xml.CharData(s[key]), xml.EndElement{Name: t.Name})
 	}
 
+	tokens = append(tokens, start.End())
+
 	for _, t := range tokens {
 		err := e.EncodeToken(t)
 		if err != nil {
@@ -1,5 +1,12 @@
 package lazada
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 func SplitFileToBlocks(file []byte, maxBlockSize int) [][]byte {
 	var blocks [][]byte
 	for start := 0; start < len(file); start += maxBlockSize {
@@ -11,3 +18,36 @@ func SplitFileToBlocks(file []byte, maxBlockSize int) [][]byte {
 	}
 	return blocks
 }
+
+// offsetLimit parses the string offset and limit of list parameters, an empty
+// offset is 0 and an empty limit is defaultLimit.
+func offsetLimit(offset, limit string, defaultLimit int) (int, int, error) {
+	o, l := 0, defaultLimit
+	var err error
+	if offset != "" {
+		if o, err = strconv.Atoi(offset); err != nil {
+			return 0, 0, fmt.Errorf("lazada: invalid offset %q: %w", offset, err)
+		}
+	}
+	if limit != "" {
+		if l, err = strconv.Atoi(limit); err != nil {
+			return 0, 0, fmt.Errorf("lazada: invalid limit %q: %w", limit, err)
+		}
+	}
+	if l <= 0 {
+		l = defaultLimit
+	}
+	return o, l, nil
+}
+
+// IDList encodes ids as the JSON array query parameters expect, "[1,2]".
+type IDList []int64
+
+func (l IDList) EncodeValues(key string, v *url.Values) error {
+	ids := make([]string, len(l))
+	for i, id := range l {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	v.Set(key, "["+strings.Join(ids, ",")+"]")
+	return nil
+}
@@ -0,0 +1,383 @@
+package lazada
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/upload"
+)
+
+const (
+	// DefaultUploadConcurrency is the number of blocks a VideoUploader
+	// uploads at once.
+	DefaultUploadConcurrency = 3
+
+	// DefaultBlockRetries is the number of times a VideoUploader retries a
+	// failed block.
+	DefaultBlockRetries = 3
+
+	// DefaultBlockBackoff is the wait before the first retry of a block, it
+	// doubles on each retry.
+	DefaultBlockBackoff = time.Second
+)
+
+// VideoUploadState is where a video upload stands: the upload and the ETags
+// of the blocks uploaded so far. A VideoUploader saves it to its store after
+// each block, so that an interrupted upload resumes with the missing blocks.
+type VideoUploadState struct {
+	UploadID  string `json:"upload_id"`
+	Size      int64  `json:"size"`
+	BlockSize int64  `json:"block_size"`
+	// ETags of the uploaded blocks by block number, from 0.
+	Parts map[int]string `json:"parts"`
+}
+
+// BlockCount returns the number of blocks of the upload.
+func (s *VideoUploadState) BlockCount() int {
+	if s.BlockSize <= 0 {
+		return 0
+	}
+	return int((s.Size + s.BlockSize - 1) / s.BlockSize)
+}
+
+// VideoParts returns the parts of CompleteCreateVideoRaw, in block order.
+func (s *VideoUploadState) VideoParts() []VideoPart {
+	blocks := make([]int, 0, len(s.Parts))
+	for n := range s.Parts {
+		blocks = append(blocks, n)
+	}
+	sort.Ints(blocks)
+
+	parts := make([]VideoPart, 0, len(blocks))
+	for _, n := range blocks {
+		parts = append(parts, VideoPart{PartNumber: n + 1, ETag: s.Parts[n]})
+	}
+	return parts
+}
+
+func (s *VideoUploadState) blockLen(n int) int64 {
+	start := int64(n) * s.BlockSize
+	if start+s.BlockSize > s.Size {
+		return s.Size - start
+	}
+	return s.BlockSize
+}
+
+func (s *VideoUploadState) uploadedBytes() int64 {
+	var total int64
+	for n := range s.Parts {
+		total += s.blockLen(n)
+	}
+	return total
+}
+
+// VideoUploadStore keeps the state of video uploads by key. Load returns nil
+// and no error when there is no upload for key.
+type VideoUploadStore = upload.Store[VideoUploadState]
+
+// MemoryVideoUploadStore keeps upload states in memory, it resumes uploads
+// within the process only.
+type MemoryVideoUploadStore = upload.MemoryStore[VideoUploadState]
+
+func NewMemoryVideoUploadStore() *MemoryVideoUploadStore {
+	return upload.NewMemoryStore[VideoUploadState]()
+}
+
+// FileVideoUploadStore keeps each upload state in a JSON file of dir, named
+// after the key, so uploads resume after a restart.
+type FileVideoUploadStore = upload.FileStore[VideoUploadState]
+
+func NewFileVideoUploadStore(dir string) *FileVideoUploadStore {
+	return upload.NewFileStore[VideoUploadState](dir)
+}
+
+// VideoUploadProgress reports the bytes of a video uploaded so far.
+type VideoUploadProgress struct {
+	UploadID      string
+	UploadedBytes int64
+	TotalBytes    int64
+	// Blocks uploaded so far, including those of an earlier attempt.
+	UploadedBlocks int
+	BlockCount     int
+}
+
+// VideoUploaderOption configures a VideoUploader.
+type VideoUploaderOption func(u *VideoUploader)
+
+// WithUploadConcurrency sets the number of blocks uploaded at once.
+func WithUploadConcurrency(n int) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		if n > 0 {
+			u.concurrency = n
+		}
+	}
+}
+
+// WithBlockSize sets the size of the blocks of new uploads, at most
+// MaxBlockSizeBytes.
+func WithBlockSize(n int64) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		if n > 0 && n <= MaxBlockSizeBytes {
+			u.blockSize = n
+		}
+	}
+}
+
+// WithBlockRetries sets how many times a failed block is retried and the
+// wait before the first retry, which doubles on each retry.
+func WithBlockRetries(retries int, backoff time.Duration) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		if retries >= 0 {
+			u.retries = retries
+		}
+		if backoff >= 0 {
+			u.backoff = backoff
+		}
+	}
+}
+
+// WithUploadStore saves the state of uploads with a key to store, see
+// VideoUploadRequest.Key.
+func WithUploadStore(store VideoUploadStore) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		u.store = store
+	}
+}
+
+// WithUploadProgress calls fn after each uploaded block, and once before the
+// first with the blocks of a resumed upload. Calls do not overlap.
+func WithUploadProgress(fn func(VideoUploadProgress)) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		u.progress = fn
+	}
+}
+
+// VideoUploader uploads videos in blocks from an io.ReaderAt, several blocks
+// at a time, retrying the blocks that fail. It is safe for concurrent use.
+type VideoUploader struct {
+	media       *MediaService
+	concurrency int
+	blockSize   int64
+	retries     int
+	backoff     time.Duration
+	store       VideoUploadStore
+	progress    func(VideoUploadProgress)
+}
+
+func (m *MediaService) NewVideoUploader(opts ...VideoUploaderOption) *VideoUploader {
+	u := &VideoUploader{
+		media:       m,
+		concurrency: DefaultUploadConcurrency,
+		blockSize:   MaxBlockSizeBytes,
+		retries:     DefaultBlockRetries,
+		backoff:     DefaultBlockBackoff,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+type VideoUploadRequest struct {
+	// Key names the upload in the store of the uploader, an upload with a
+	// saved state resumes from it. Uploads without key are not saved.
+	Key string
+
+	// UploadID is the upload of InitCreateVideo, the uploader calls it when
+	// empty and there is no saved state.
+	UploadID string
+
+	FileName   string
+	Title      string
+	CoverURL   string
+	VideoUsage string
+}
+
+// Upload uploads the size bytes of r and commits the video. The state is
+// deleted from the store once committed. On error it stays, and calling
+// Upload again with the same key and content uploads the missing blocks.
+func (u *VideoUploader) Upload(ctx context.Context, token string, r io.ReaderAt, size int64, req *VideoUploadRequest) (*UploadVideoResponse, error) {
+	if size <= 0 {
+		return nil, errors.New("lazada: video is empty")
+	}
+
+	state, err := u.start(ctx, token, size, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.uploadBlocks(ctx, token, r, req, state); err != nil {
+		return nil, err
+	}
+
+	commitResp, err := u.media.CompleteCreateVideoRaw(ctx, token, &CompleteCreateVideoRequest{
+		UploadID:   state.UploadID,
+		Title:      req.Title,
+		CoverURL:   req.CoverURL,
+		VideoUsage: req.VideoUsage,
+	}, state.VideoParts())
+	if err != nil {
+		return nil, fmt.Errorf("complete create video: %w", err)
+	}
+	if commitResp.VideoID == "" {
+		return nil, fmt.Errorf("complete create video: %w", ResponseError{
+			Code:      commitResp.Code,
+			Message:   firstNonEmpty(commitResp.Message, commitResp.ResultMessage),
+			RequestID: commitResp.RequestID,
+		})
+	}
+
+	if u.store != nil && req.Key != "" {
+		if err := u.store.Delete(ctx, req.Key); err != nil {
+			return nil, fmt.Errorf("lazada: delete upload state: %w", err)
+		}
+	}
+
+	return &UploadVideoResponse{
+		UploadID: state.UploadID,
+		VideoID:  commitResp.VideoID,
+	}, nil
+}
+
+// start returns the saved state of the upload, or a new one. A saved state of
+// another size is discarded with its upload.
+func (u *VideoUploader) start(ctx context.Context, token string, size int64, req *VideoUploadRequest) (*VideoUploadState, error) {
+	if u.store != nil && req.Key != "" {
+		state, err := u.store.Load(ctx, req.Key)
+		if err != nil {
+			return nil, fmt.Errorf("lazada: load upload state: %w", err)
+		}
+		if state != nil && state.UploadID != "" && state.Size == size && state.BlockSize > 0 {
+			if state.Parts == nil {
+				state.Parts = make(map[int]string)
+			}
+			return state, nil
+		}
+	}
+
+	uploadID := req.UploadID
+	if uploadID == "" {
+		res, err := u.media.InitCreateVideo(ctx, token, &InitCreateVideoParameter{FileName: req.FileName, FileBytes: size})
+		if err != nil {
+			return nil, fmt.Errorf("init create video: %w", err)
+		}
+		if res == nil || res.UploadID == "" {
+			return nil, fmt.Errorf("init create video returned empty upload_id")
+		}
+		uploadID = res.UploadID
+	}
+
+	state := &VideoUploadState{
+		UploadID:  uploadID,
+		Size:      size,
+		BlockSize: u.blockSize,
+		Parts:     make(map[int]string),
+	}
+	if err := u.save(ctx, req, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (u *VideoUploader) save(ctx context.Context, req *VideoUploadRequest, state *VideoUploadState) error {
+	if u.store == nil || req.Key == "" {
+		return nil
+	}
+	if err := u.store.Save(ctx, req.Key, state); err != nil {
+		return fmt.Errorf("lazada: save upload state: %w", err)
+	}
+	return nil
+}
+
+// uploadBlocks uploads the blocks missing from state, the first block to fail
+// all its retries stops the others.
+func (u *VideoUploader) uploadBlocks(ctx context.Context, token string, r io.ReaderAt, req *VideoUploadRequest, state *VideoUploadState) error {
+	blockCount := state.BlockCount()
+	var pending []int
+	for n := 0; n < blockCount; n++ {
+		if _, ok := state.Parts[n]; !ok {
+			pending = append(pending, n)
+		}
+	}
+
+	var mu sync.Mutex
+	report := func() {
+		if u.progress != nil {
+			u.progress(VideoUploadProgress{
+				UploadID:       state.UploadID,
+				UploadedBytes:  state.uploadedBytes(),
+				TotalBytes:     state.Size,
+				UploadedBlocks: len(state.Parts),
+				BlockCount:     blockCount,
+			})
+		}
+	}
+	if len(state.Parts) > 0 {
+		report()
+	}
+
+	return upload.Parallel(ctx, u.concurrency, pending, func(ctx context.Context, n int) error {
+		etag, err := u.uploadBlock(ctx, token, r, req, state, n, blockCount)
+		if err != nil {
+			return fmt.Errorf("upload block %d/%d: %w", n+1, blockCount, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		state.Parts[n] = etag
+		if err := u.save(ctx, req, state); err != nil {
+			return err
+		}
+		report()
+		return nil
+	})
+}
+
+// uploadBlock reads block n and uploads it, retrying on failure.
+func (u *VideoUploader) uploadBlock(ctx context.Context, token string, r io.ReaderAt, req *VideoUploadRequest, state *VideoUploadState, n, blockCount int) (string, error) {
+	data := make([]byte, state.blockLen(n))
+	read, err := r.ReadAt(data, int64(n)*state.BlockSize)
+	if read < len(data) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", fmt.Errorf("read: %w", err)
+	}
+
+	var etag string
+	err = upload.Retry(ctx, u.retries, u.backoff, func() error {
+		resp, err := u.media.UploadVideoBlockRaw(ctx, token, req.FileName, &UploadVideoBlockRequest{
+			UploadId:   state.UploadID,
+			BlockNo:    n,
+			BlockCount: blockCount,
+			File:       data,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.ETag == "" {
+			return ResponseError{
+				Code:      resp.Code,
+				Message:   firstNonEmpty(resp.Message, resp.ResultMessage),
+				RequestID: resp.RequestID,
+			}
+		}
+		etag = resp.ETag
+		return nil
+	})
+	return etag, err
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
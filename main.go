@@ -124,46 +124,25 @@ func getOneConversation() error {
 
 	log.Printf("shopID=%d conversationID=%s accessTokenLen=%d", shopID, conversationID, len(accessToken))
 
-	// Paginate through all conversations to find the target conversation_id
+	// Iterate through all conversations to find the target conversation_id
 	targetConversationID := conversationID
-	var nextTimeNano int64
-	pageSize := 50
 	found := false
 	totalScanned := 0
 
-	for {
-		params := shopee.GetConversationParamsRequest{
-			Direction: "latest",
-			Type:      "all",
-			PageSize:  pageSize,
-		}
-		if nextTimeNano > 0 {
-			params.NextTimeNano = nextTimeNano
-		}
-
-		listResp, err := shopeeClient.Chat.GetConversationList(shopID, accessToken, params)
+	ctx := context.Background()
+	for c, err := range shopeeClient.Chat.Conversations(ctx, shopID, accessToken, shopee.GetConversationParamsRequest{
+		Direction: "latest",
+		Type:      "all",
+		PageSize:  50,
+	}) {
 		if err != nil {
 			return fmt.Errorf("get conversation list page: %w", err)
 		}
 
-		for _, c := range listResp.Response.ConversationsList {
-			totalScanned++
-			if c.ConversationID == targetConversationID {
-				log.Printf("FOUND! conversation_id=%s to_name=%s", c.ConversationID, c.ToName)
-				found = true
-			}
-		}
-
-		log.Printf("Scanned %d conversations (page total: %d, more: %v)", totalScanned, len(listResp.Response.ConversationsList), listResp.Response.PageResult.More)
-
-		if !listResp.Response.PageResult.More || len(listResp.Response.ConversationsList) == 0 {
-			break
-		}
-
-		// Parse next cursor timestamp
-		if ts := listResp.Response.PageResult.NextCursor.NextMessageTimeNano; ts != "" && ts != "0" {
-			nextTimeNano, _ = strconv.ParseInt(ts, 10, 64)
-		} else {
+		totalScanned++
+		if c.ConversationID == targetConversationID {
+			log.Printf("FOUND! conversation_id=%s to_name=%s", c.ConversationID, c.ToName)
+			found = true
 			break
 		}
 	}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes the wait before a retry, doubling from Base up to Max.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// DefaultBackoff is the backoff used when none is set.
+var DefaultBackoff = Backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second}
+
+// Delay returns the wait before retry number attempt, counted from 0. It is
+// picked at random between half and all of the exponential delay so clients
+// throttled together do not retry together.
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Base
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + rand.N(half+1)
+}
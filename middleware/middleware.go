@@ -0,0 +1,177 @@
+// Package middleware provides the http.RoundTripper shared by the marketplace
+// clients for rate limiting and retries. Plug it in with the WithMiddleware
+// option of a client, e.g.
+//
+//	limiter := middleware.NewRateLimiter(
+//		middleware.Limit{Rate: 10, Burst: 10}, // partner
+//		middleware.Limit{Rate: 2, Burst: 2},   // each shop
+//	)
+//	client := shopee.NewClient(app, shopee.WithMiddleware(middleware.WithRateLimiter(limiter)))
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+)
+
+// DefaultMaxRetries is how many times a request is retried when no limit is
+// set.
+const DefaultMaxRetries = 3
+
+// Option is used to configure a Transport with options
+type Option func(t *Transport)
+
+// WithRateLimiter makes every request wait for a token of l first.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(t *Transport) {
+		t.limiter = l
+	}
+}
+
+// WithShopKey sets how the shop of a request is found, its rate limit bucket
+// is picked by the returned key. Requests with an empty key only count
+// against the partner bucket.
+func WithShopKey(fn func(req *http.Request) string) Option {
+	return func(t *Transport) {
+		t.shopKey = fn
+	}
+}
+
+// WithBackoff sets the delay between retries the marketplace did not give a
+// wait for.
+func WithBackoff(b Backoff) Option {
+	return func(t *Transport) {
+		t.backoff = b
+	}
+}
+
+// WithRetryable sets which round trips are retried, replacing the
+// marketplace specific one of the client.
+func WithRetryable(fn Retryable) Option {
+	return func(t *Transport) {
+		t.retryable = fn
+	}
+}
+
+// WithMaxRetries sets how many times a request is retried, 0 disables
+// retries.
+func WithMaxRetries(n int) Option {
+	return func(t *Transport) {
+		t.maxRetries = n
+	}
+}
+
+// Transport is an http.RoundTripper waiting on a RateLimiter before each
+// attempt and retrying the round trips its Retryable accepts. Requests with a
+// body are only retried when it can be read again through GetBody, which
+// http.NewRequest sets for in-memory bodies.
+type Transport struct {
+	base       http.RoundTripper
+	limiter    *RateLimiter
+	shopKey    func(req *http.Request) string
+	backoff    Backoff
+	retryable  Retryable
+	maxRetries int
+}
+
+// New wraps base, http.DefaultTransport when nil.
+func New(base http.RoundTripper, opts ...Option) *Transport {
+	t := &Transport{
+		base:       base,
+		backoff:    DefaultBackoff,
+		retryable:  DefaultRetryable,
+		maxRetries: DefaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var shop string
+	if t.limiter != nil && t.shopKey != nil {
+		shop = t.shopKey(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		try := req
+		if attempt > 0 {
+			var err error
+			if try, err = rewind(req, attempt); err != nil {
+				return nil, err
+			}
+		}
+
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx, shop); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.transport().RoundTrip(try)
+		if attempt >= t.maxRetries || !t.canRewind(req) {
+			return resp, err
+		}
+
+		retry, wait := t.retryable(try, resp, err)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if wait <= 0 {
+			wait = t.backoff.Delay(attempt)
+		}
+		if err := clock.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) transport() http.RoundTripper {
+	if t.base != nil {
+		return t.base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of req with a fresh body for another attempt.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	r := req.Clone(WithAttempt(req.Context(), attempt))
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+type attemptKey struct{}
+
+// WithAttempt returns a copy of ctx telling the transports below which
+// attempt of a call a request is, 0 for the first. Transport sets it on
+// retries, as do the WithRetry options of the clients.
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// Attempt returns the attempt set by WithAttempt, 0 when there is none.
+func Attempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+)
+
+// Limit is the rate of a token bucket, Rate requests per second with bursts of
+// up to Burst requests. A zero Rate means no limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter holds a token bucket for the partner, shared by every request,
+// and one per shop. Share one RateLimiter between the clients of a partner so
+// they draw from the same budget. The bucket of a shop is dropped once it is
+// full again, as a new one would be.
+type RateLimiter struct {
+	partner *bucket
+	shop    Limit
+
+	mu         sync.Mutex
+	shops      map[string]*bucket
+	swept      time.Time
+	sweepEvery time.Duration
+}
+
+func NewRateLimiter(partner, shop Limit) *RateLimiter {
+	l := &RateLimiter{
+		partner: newBucket(partner),
+		shop:    shop,
+		shops:   make(map[string]*bucket),
+		swept:   time.Now(),
+	}
+	// sweep idle shops every minute, or every refill of a bucket when longer
+	l.sweepEvery = time.Minute
+	if shop.Rate > 0 {
+		full := time.Duration(float64(max(shop.Burst, 1)) / shop.Rate * float64(time.Second))
+		l.sweepEvery = max(l.sweepEvery, full)
+	}
+	return l
+}
+
+// Wait blocks until both the partner bucket and the bucket of shop have a
+// token, or ctx is done. An empty shop only waits on the partner bucket.
+func (l *RateLimiter) Wait(ctx context.Context, shop string) error {
+	if err := l.partner.wait(ctx); err != nil {
+		return err
+	}
+	if shop == "" {
+		return nil
+	}
+	if err := l.shopBucket(shop).wait(ctx); err != nil {
+		// the request is not sent, the partner token is not used either
+		l.partner.refund()
+		return err
+	}
+	return nil
+}
+
+func (l *RateLimiter) shopBucket(shop string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := time.Now(); now.Sub(l.swept) >= l.sweepEvery {
+		l.swept = now
+		for key, b := range l.shops {
+			// a bucket handed out since the last sweep may not be waited on yet
+			if now.Sub(b.used) >= l.sweepEvery && b.full(now) {
+				delete(l.shops, key)
+			}
+		}
+	}
+
+	b, ok := l.shops[shop]
+	if !ok {
+		b = newBucket(l.shop)
+		l.shops[shop] = b
+	}
+	b.used = time.Now()
+	return b
+}
+
+type bucket struct {
+	limit Limit
+	// used is when a RateLimiter last handed the bucket out, guarded by the
+	// mutex of the RateLimiter.
+	used time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit Limit) *bucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &bucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// wait takes a token, sleeping until one is available. The token is reserved
+// before sleeping so concurrent callers queue up instead of racing.
+func (b *bucket) wait(ctx context.Context) error {
+	if b.limit.Rate <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	if max := float64(b.limit.Burst); b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	if err := clock.Sleep(ctx, delay); err != nil {
+		// give the reserved token back, the request is not sent
+		b.refund()
+		return err
+	}
+	return nil
+}
+
+// refund gives back a token taken by wait.
+func (b *bucket) refund() {
+	if b.limit.Rate <= 0 {
+		return
+	}
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// full tells whether b has refilled its burst by now, no token of it is
+// reserved then.
+func (b *bucket) full(now time.Time) bool {
+	if b.limit.Rate <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
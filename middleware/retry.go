@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retryable tells whether a round trip of req should be retried, and after
+// how long when the marketplace said so. A zero wait leaves the delay to the
+// backoff. resp is nil when err is not.
+//
+// Marketplaces report throttling in their own way, often with a 200 status
+// and an error code in the body. Their packages provide a Retryable checking
+// those codes before falling back to DefaultRetryable.
+type Retryable func(req *http.Request, resp *http.Response, err error) (retry bool, wait time.Duration)
+
+// DefaultRetryable retries 429 and 503 responses, honouring Retry-After.
+// Transport errors and 502 and 504 responses are retried for idempotent
+// methods only, as the marketplace may have handled the request already.
+func DefaultRetryable(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false, 0
+		}
+		return idempotent(req), 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, RetryAfter(resp.Header, "Retry-After")
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req), 0
+	}
+	return false, 0
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// RetryAfter reads a wait from the header key, given in seconds or as an HTTP
+// date. It returns 0 when the header is missing or invalid.
+func RetryAfter(h http.Header, key string) time.Duration {
+	v := h.Get(key)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// PeekBody reads the body of resp and puts it back, so a Retryable can look
+// for error codes without consuming it.
+func PeekBody(resp *http.Response) []byte {
+	if resp == nil || resp.Body == nil {
+		return nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil
+	}
+	return b
+}
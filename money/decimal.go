@@ -0,0 +1,275 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number. The zero Decimal is 0.
+//
+// It is kept as its canonical text, so Decimals compare with == and read as
+// numbers in test failures. Arithmetic does not overflow.
+type Decimal struct {
+	// s has no exponent, no leading zeros and no trailing fractional zeros,
+	// it is empty for 0.
+	s string
+}
+
+// ParseDecimal reads a decimal number such as "-12.90" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	coef, scale, err := parse(strings.TrimSpace(s))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("money: invalid decimal %q", s)
+	}
+	return fromBig(coef, scale), nil
+}
+
+// MustParseDecimal is ParseDecimal for constants, it panics on an invalid
+// number.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimal returns coef * 10^exp, NewDecimal(1290, -2) is 12.90.
+func NewDecimal(coef int64, exp int32) Decimal {
+	c := big.NewInt(coef)
+	if exp >= 0 {
+		return fromBig(c.Mul(c, pow10(exp)), 0)
+	}
+	return fromBig(c, -exp)
+}
+
+func DecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// DecimalFromFloat returns the shortest decimal that reads back as f, 0.1 is
+// 0.1 and not 0.1000000000000000055.
+func DecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		// NaN and infinities
+		return Decimal{}
+	}
+	return d
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return fromBig(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return fromBig(a.Sub(a, b), scale)
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	a, sa := d.big()
+	b, sb := o.big()
+	return fromBig(a.Mul(a, b), sa+sb)
+}
+
+func (d Decimal) Neg() Decimal {
+	a, scale := d.big()
+	return fromBig(a.Neg(a), scale)
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than o.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+// Sign returns -1, 0 or +1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	switch {
+	case d.s == "":
+		return 0
+	case d.s[0] == '-':
+		return -1
+	}
+	return 1
+}
+
+func (d Decimal) IsZero() bool {
+	return d.s == ""
+}
+
+// Round rounds d to places fractional digits, halves away from zero. Negative
+// places round to 0 fractional digits.
+func (d Decimal) Round(places int32) Decimal {
+	places = max(places, 0)
+	a, scale := d.big()
+	if scale <= places {
+		return d
+	}
+
+	q, r := new(big.Int).QuoRem(a, pow10(scale-places), new(big.Int))
+	// |r| * 2 >= 10^(scale-places) rounds away from zero
+	if r.Abs(r).Lsh(r, 1).Cmp(pow10(scale-places)) >= 0 {
+		q.Add(q, big.NewInt(int64(a.Sign())))
+	}
+	return fromBig(q, places)
+}
+
+// Float64 returns the nearest float, for APIs that take prices as numbers.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// StringFixed returns d rounded to places fractional digits, padded with
+// zeros: 12.9 is "12.90" with 2 places.
+func (d Decimal) StringFixed(places int32) string {
+	s := d.Round(places).String()
+	if places <= 0 {
+		return s
+	}
+
+	frac := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		frac = len(s) - i - 1
+	} else {
+		s += "."
+	}
+	return s + strings.Repeat("0", int(places)-frac)
+}
+
+// MarshalText writes d as a number, JSON encodes it as a string so no reader
+// loses precision.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(b []byte) error {
+	v, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalJSON reads a JSON number or string. Strings may use commas as
+// thousands separators, as Lazada sends "225,000.00", and an empty string or
+// null is 0.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+
+	return d.UnmarshalText(b)
+}
+
+// big returns the coefficient and scale of d, d is coef * 10^-scale.
+func (d Decimal) big() (*big.Int, int32) {
+	coef, scale, _ := parse(d.s)
+	return coef, scale
+}
+
+func align(d, o Decimal) (*big.Int, *big.Int, int32) {
+	a, sa := d.big()
+	b, sb := o.big()
+	switch {
+	case sa < sb:
+		a.Mul(a, pow10(sb-sa))
+		return a, b, sb
+	case sb < sa:
+		b.Mul(b, pow10(sa-sb))
+	}
+	return a, b, sa
+}
+
+const maxExponent = 64
+
+// parse reads [sign]digits[.digits][e[sign]digits], an empty string is 0.
+func parse(s string) (*big.Int, int32, error) {
+	if s == "" {
+		return new(big.Int), 0, nil
+	}
+
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return nil, 0, err
+		}
+		// no amount needs more, it keeps pow10 small
+		if e > maxExponent || e < -maxExponent {
+			return nil, 0, fmt.Errorf("exponent out of range")
+		}
+		mantissa, exp = s[:i], e
+	}
+
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, 0, fmt.Errorf("invalid decimal")
+	}
+
+	coef, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := int64(len(fracPart)) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return coef, int32(scale), nil
+}
+
+// fromBig returns the canonical Decimal of coef * 10^-scale.
+func fromBig(coef *big.Int, scale int32) Decimal {
+	if coef.Sign() == 0 {
+		return Decimal{}
+	}
+
+	s := new(big.Int).Abs(coef).String()
+	if scale > 0 {
+		if len(s) <= int(scale) {
+			s = strings.Repeat("0", int(scale)-len(s)+1) + s
+		}
+		s = strings.TrimRight(s[:len(s)-int(scale)]+"."+s[len(s)-int(scale):], "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	if coef.Sign() < 0 {
+		s = "-" + s
+	}
+	return Decimal{s: s}
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,210 @@
+// Package money provides Money, an exact decimal amount in a currency, for the
+// prices and amounts the marketplaces send as floats, integers or strings.
+//
+// Money reads every encoding the marketplaces use: a JSON number as Shopee
+// and Tokopedia send, a string as TikTok and Lazada send, and an object with
+// an amount and a currency as TikTok coupons are sent. A bare number has no
+// currency, the marketplace structs fill it in from the currency sent next to
+// it, see FillCurrency.
+//
+// Money is always written back as an object with the amount as a string,
+// {"amount":"150000","currency":"IDR"}, whatever encoding it was read from.
+// Re-encoding a marketplace response struct does not give the body the
+// marketplace sent.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when amounts in different currencies are
+// added or compared.
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+type Money struct {
+	Amount Decimal `json:"amount"`
+	// Currency is the ISO 4217 code, e.g. IDR. It is empty when the
+	// marketplace does not send it.
+	Currency string `json:"currency"`
+}
+
+func New(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse reads amount as ParseDecimal does.
+func Parse(amount, currency string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(d, currency), nil
+}
+
+// MustParse is Parse for constants, it panics on an invalid amount.
+func MustParse(amount, currency string) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func FromInt(amount int64, currency string) Money {
+	return New(DecimalFromInt(amount), currency)
+}
+
+// FromFloat reads amount as DecimalFromFloat does.
+func FromFloat(amount float64, currency string) Money {
+	return New(DecimalFromFloat(amount), currency)
+}
+
+// Add returns m + o. An amount without currency takes the currency of the
+// other, amounts in two currencies return ErrCurrencyMismatch.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := commonCurrency(m, o)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount.Add(o.Amount), currency), nil
+}
+
+// Sub returns m - o, with the currencies of Add.
+func (m Money) Sub(o Money) (Money, error) {
+	currency, err := commonCurrency(m, o)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount.Sub(o.Amount), currency), nil
+}
+
+// Mul returns m times n, e.g. a unit price times a quantity.
+func (m Money) Mul(n int64) Money {
+	return New(m.Amount.Mul(DecimalFromInt(n)), m.Currency)
+}
+
+func (m Money) Neg() Money {
+	return New(m.Amount.Neg(), m.Currency)
+}
+
+// Cmp compares the amounts of m and o, with the currencies of Add.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := commonCurrency(m, o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Round rounds m to the minor unit of its currency, see MinorDigits.
+func (m Money) Round() Money {
+	return New(m.Amount.Round(MinorDigits(m.Currency)), m.Currency)
+}
+
+// WithCurrency returns m in currency when m has none.
+func (m Money) WithCurrency(currency string) Money {
+	if m.Currency == "" {
+		m.Currency = currency
+	}
+	return m
+}
+
+// String returns the currency and the amount with the digits of the
+// currency, "IDR 150000" or "SGD 12.90".
+func (m Money) String() string {
+	amount := m.Amount.StringFixed(MinorDigits(m.Currency))
+	if m.Currency == "" {
+		return amount
+	}
+	return m.Currency + " " + amount
+}
+
+// Format returns the amount with the digits of the currency, thousands
+// grouped by group and decimals after point: Format(".", ",") of IDR 150000
+// is "150.000" and Format(",", ".") of SGD 1234.5 is "1,234.50".
+func (m Money) Format(group, point string) string {
+	s := m.Amount.StringFixed(MinorDigits(m.Currency))
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString(point)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// UnmarshalJSON reads an object with an amount and a currency, or a bare
+// number or string as Decimal does which keeps the currency of m.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' {
+		return m.Amount.UnmarshalJSON(b)
+	}
+
+	type object Money
+	if err := json.Unmarshal(b, (*object)(m)); err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	return nil
+}
+
+// Sum adds amounts as Add does, it is 0 without currency for no amounts.
+func Sum(amounts ...Money) (Money, error) {
+	var total Money
+	for _, m := range amounts {
+		var err error
+		if total, err = total.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// FillCurrency sets currency on the amounts without one. Marketplace structs
+// call it once decoded, when the currency is sent next to the amounts.
+func FillCurrency(currency string, amounts ...*Money) {
+	for _, m := range amounts {
+		*m = m.WithCurrency(currency)
+	}
+}
+
+// MinorDigits returns the number of fractional digits of currency: 0 for the
+// currencies the marketplaces price without cents, IDR and VND among them,
+// and 2 otherwise.
+func MinorDigits(currency string) int32 {
+	switch currency {
+	case "IDR", "VND", "JPY", "KRW", "CLP", "TWD":
+		return 0
+	}
+	return 2
+}
+
+func commonCurrency(m, o Money) (string, error) {
+	switch {
+	case m.Currency == "":
+		return o.Currency, nil
+	case o.Currency == "" || o.Currency == m.Currency:
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+}
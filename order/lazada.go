@@ -0,0 +1,101 @@
+package order
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+)
+
+// lazadaTimeLayout is the layout of created_at and updated_at.
+const lazadaTimeLayout = "2006-01-02 15:04:05 -0700"
+
+// FromLazada converts an order of GetOrders or GetOrder with its items from
+// GetOrderItems. Lazada has one item per unit, so every line has a quantity
+// of one, and tracks the status of each item, the order is as far as its
+// least advanced item. Lazada sends no order total.
+func FromLazada(o lazada.Orders, items []lazada.OrderItems) Order {
+	var currency string
+	if len(items) > 0 {
+		currency = items[0].Currency
+	}
+
+	out := Order{
+		ID:          strconv.FormatInt(o.OrderID, 10),
+		Marketplace: Lazada,
+		RawStatus:   strings.Join(o.Statuses, ","),
+		BuyerName:   joinNonEmpty(" ", o.CustomerFirstName, o.CustomerLastName),
+		BuyerNote:   o.BuyerNote,
+		ShippingAddress: Address{
+			Name:       joinNonEmpty(" ", o.AddressShipping.FirstName, o.AddressShipping.LastName),
+			Phone:      o.AddressShipping.Phone,
+			Line1:      o.AddressShipping.Address1,
+			Line2:      joinNonEmpty(", ", o.AddressShipping.Address2, o.AddressShipping.Address3),
+			District:   o.AddressShipping.Address4,
+			City:       o.AddressShipping.City,
+			Province:   o.AddressShipping.Address5,
+			PostalCode: o.AddressShipping.PostCode,
+			Country:    o.AddressShipping.Country,
+		},
+		Subtotal:      o.Price.WithCurrency(currency),
+		ShippingFee:   o.ShippingFee.WithCurrency(currency),
+		PaymentMethod: o.PaymentMethod,
+		COD:           o.PaymentMethod == "COD",
+		CreatedAt:     lazadaTime(o.CreatedAt),
+		UpdatedAt:     lazadaTime(o.UpdatedAt),
+		Raw:           o,
+	}
+	out.ShippingAddress.Full = joinNonEmpty(", ", out.ShippingAddress.Line1, out.ShippingAddress.Line2,
+		out.ShippingAddress.District, out.ShippingAddress.City, out.ShippingAddress.Province, out.ShippingAddress.PostalCode)
+
+	var statuses []Status
+	if len(items) == 0 {
+		for _, s := range o.Statuses {
+			statuses = append(statuses, MapStatus(Lazada, s))
+		}
+	}
+	for _, item := range items {
+		status := MapStatus(Lazada, item.Status)
+		statuses = append(statuses, status)
+
+		out.Lines = append(out.Lines, OrderLine{
+			ID:          strconv.FormatInt(item.OrderItemID, 10),
+			ProductID:   lazadaItemID(item.ShopSku),
+			VariantID:   item.SkuID,
+			SKU:         item.Sku,
+			Name:        item.Name,
+			VariantName: item.Variation,
+			ImageURL:    item.ProductMainImage,
+			Quantity:    1,
+			UnitPrice:   item.ItemPrice,
+			Status:      status,
+			RawStatus:   item.Status,
+		})
+		if out.TrackingNumber == "" {
+			out.Carrier = item.ShipmentProvider
+			out.TrackingNumber = item.TrackingCode
+		}
+	}
+	out.Status = combineStatus(statuses)
+
+	return out
+}
+
+func lazadaTime(s string) time.Time {
+	t, err := time.Parse(lazadaTimeLayout, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// lazadaItemID returns the item id of a shop sku, which reads
+// "<item id>_<country>-<sku id>".
+func lazadaItemID(shopSku string) string {
+	id, _, ok := strings.Cut(shopSku, "_")
+	if !ok {
+		return ""
+	}
+	return id
+}
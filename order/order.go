@@ -0,0 +1,102 @@
+// Package order provides one order model on top of the marketplace specific
+// order structs, so an order can be stored, shown and fulfilled the same way
+// whichever marketplace it comes from.
+//
+// The From functions convert an order as returned by a marketplace package,
+// they do not call any API.
+package order
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+type Marketplace string
+
+const (
+	Shopee    Marketplace = "shopee"
+	Lazada    Marketplace = "lazada"
+	Tiktok    Marketplace = "tiktok"
+	Tokopedia Marketplace = "tokopedia"
+)
+
+type Order struct {
+	ID          string
+	Marketplace Marketplace
+	Status      Status
+	// RawStatus is the status as sent by the marketplace.
+	RawStatus string
+
+	BuyerID         string
+	BuyerName       string
+	BuyerNote       string
+	ShippingAddress Address
+
+	Lines       []OrderLine
+	Subtotal    money.Money
+	ShippingFee money.Money
+	Total       money.Money
+
+	PaymentMethod  string
+	COD            bool
+	Carrier        string
+	TrackingNumber string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Raw holds the marketplace struct the order was built from.
+	Raw any
+}
+
+// OrderLine is a purchased sku. ProductID and VariantID are the ids of the
+// marketplace, SKU is the one of the seller.
+type OrderLine struct {
+	ID          string
+	ProductID   string
+	VariantID   string
+	SKU         string
+	Name        string
+	VariantName string
+	ImageURL    string
+	Quantity    int
+	UnitPrice   money.Money
+	// Status is set when the marketplace tracks lines separately, as Lazada
+	// does, and is empty otherwise.
+	Status    Status
+	RawStatus string
+}
+
+type Address struct {
+	Name       string
+	Phone      string
+	Line1      string
+	Line2      string
+	District   string
+	City       string
+	Province   string
+	PostalCode string
+	Country    string
+	// Full is the address in one line, as shown by the marketplace.
+	Full string
+}
+
+// unixTime returns the zero time for 0, marketplaces send 0 for unset times.
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
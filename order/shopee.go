@@ -0,0 +1,68 @@
+package order
+
+import (
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+)
+
+// FromShopee converts an order of GetOrderDetailByOrderSN. Shopee sends no
+// subtotal, and the shipping fee is the estimated one.
+func FromShopee(o shopee.OrderList) Order {
+	out := Order{
+		ID:            o.OrderSn,
+		Marketplace:   Shopee,
+		Status:        MapStatus(Shopee, o.OrderStatus),
+		RawStatus:     o.OrderStatus,
+		BuyerName:     o.BuyerUsername,
+		BuyerNote:     o.MessageToSeller,
+		ShippingFee:   o.EstimatedShippingFee,
+		Total:         o.TotalAmount,
+		PaymentMethod: o.PaymentMethod,
+		COD:           o.Cod,
+		Carrier:       o.ShippingCarrier,
+		CreatedAt:     unixTime(int64(o.CreateTime)),
+		UpdatedAt:     unixTime(int64(o.UpdateTime)),
+		Raw:           o,
+	}
+	if o.BuyerUserID != 0 {
+		out.BuyerID = strconv.FormatInt(o.BuyerUserID, 10)
+	}
+
+	if a := o.RecipientAddress; a != nil {
+		out.ShippingAddress = Address{
+			Name:       a.Name,
+			Phone:      a.Phone,
+			Line1:      a.Town,
+			District:   a.District,
+			City:       a.City,
+			Province:   a.State,
+			PostalCode: a.Zipcode,
+			Country:    a.Region,
+			Full:       a.FullAddress,
+		}
+	}
+
+	for _, item := range o.ItemList {
+		line := OrderLine{
+			ID:          strconv.FormatInt(item.OrderItemID, 10),
+			ProductID:   strconv.FormatInt(item.ItemID, 10),
+			VariantID:   strconv.FormatInt(item.ModelID, 10),
+			SKU:         item.ModelSku,
+			Name:        item.ItemName,
+			VariantName: item.ModelName,
+			Quantity:    item.ModelQuantityPurchased,
+			UnitPrice:   item.ModelDiscountedPrice,
+		}
+		if line.SKU == "" {
+			// items without variations only have an item sku
+			line.SKU = item.ItemSku
+		}
+		if item.ImageInfo != nil {
+			line.ImageURL = item.ImageInfo.ImageURL
+		}
+		out.Lines = append(out.Lines, line)
+	}
+
+	return out
+}
@@ -0,0 +1,159 @@
+package order
+
+// Status is the canonical state of an order or an order line.
+type Status string
+
+const (
+	StatusUnknown Status = "unknown"
+	// StatusUnpaid orders must not be fulfilled yet.
+	StatusUnpaid Status = "unpaid"
+	// StatusPending orders are paid but wait for the seller to accept them
+	// or for the marketplace to review them.
+	StatusPending Status = "pending"
+	// StatusReadyToShip orders are to be packed and handed to the carrier.
+	StatusReadyToShip Status = "ready_to_ship"
+	StatusShipped     Status = "shipped"
+	StatusDelivered   Status = "delivered"
+	StatusCompleted   Status = "completed"
+	// StatusInCancel orders have a cancellation request waiting for the
+	// seller.
+	StatusInCancel  Status = "in_cancel"
+	StatusCancelled Status = "cancelled"
+	StatusReturned  Status = "returned"
+)
+
+// statusTable maps the statuses of each marketplace to a Status. Tokopedia
+// statuses are numbers, they are keyed by their decimal text.
+var statusTable = map[Marketplace]map[string]Status{
+	Shopee: {
+		"UNPAID":             StatusUnpaid,
+		"INVOICE_PENDING":    StatusPending,
+		"READY_TO_SHIP":      StatusReadyToShip,
+		"PROCESSED":          StatusReadyToShip,
+		"RETRY_SHIP":         StatusReadyToShip,
+		"SHIPPED":            StatusShipped,
+		"TO_CONFIRM_RECEIVE": StatusDelivered,
+		"COMPLETED":          StatusCompleted,
+		"IN_CANCEL":          StatusInCancel,
+		"CANCELLED":          StatusCancelled,
+		"TO_RETURN":          StatusReturned,
+	},
+	Lazada: {
+		"unpaid":                StatusUnpaid,
+		"pending":               StatusPending,
+		"topack":                StatusPending,
+		"packed":                StatusReadyToShip,
+		"repacked":              StatusReadyToShip,
+		"toship":                StatusReadyToShip,
+		"ready_to_ship_pending": StatusReadyToShip,
+		"ready_to_ship":         StatusReadyToShip,
+		"shipped":               StatusShipped,
+		"delivered":             StatusDelivered,
+		"confirmed":             StatusCompleted,
+		"canceled":              StatusCancelled,
+		"failed":                StatusCancelled,
+		"lost_by_3pl":           StatusCancelled,
+		"damaged_by_3pl":        StatusCancelled,
+		"returned":              StatusReturned,
+		"shipped_back":          StatusReturned,
+		"shipped_back_success":  StatusReturned,
+	},
+	Tiktok: {
+		"UNPAID":              StatusUnpaid,
+		"ON_HOLD":             StatusPending,
+		"AWAITING_SHIPMENT":   StatusReadyToShip,
+		"AWAITING_COLLECTION": StatusReadyToShip,
+		"PARTIALLY_SHIPPING":  StatusShipped,
+		"IN_TRANSIT":          StatusShipped,
+		"DELIVERED":           StatusDelivered,
+		"COMPLETED":           StatusCompleted,
+		"CANCELLED":           StatusCancelled,
+	},
+	Tokopedia: {
+		"0":   StatusCancelled, // seller cancel
+		"2":   StatusCancelled, // rejected, replaced
+		"3":   StatusCancelled, // rejected, empty stock
+		"4":   StatusCancelled, // rejected, approval
+		"5":   StatusCancelled, // cancelled by fraud
+		"6":   StatusCancelled, // rejected, auto cancel out of stock
+		"10":  StatusCancelled, // rejected by seller
+		"11":  StatusPending,   // pending replacement
+		"15":  StatusCancelled, // instant cancel by buyer
+		"100": StatusUnpaid,
+		"103": StatusUnpaid,
+		"200": StatusUnpaid, // payment confirmation
+		"220": StatusPending,
+		"221": StatusPending, // waiting for partner approval
+		"400": StatusReadyToShip,
+		"450": StatusReadyToShip,
+		"500": StatusShipped,
+		"501": StatusShipped,
+		"520": StatusShipped, // invalid AWB
+		"530": StatusShipped, // AWB correction requested
+		"540": StatusDelivered,
+		"550": StatusReturned,
+		"600": StatusDelivered,
+		"601": StatusDelivered, // buyer opened a case
+		"690": StatusPending,   // fraud review
+		"691": StatusPending,
+		"695": StatusPending,
+		"698": StatusPending,
+		"699": StatusCancelled, // invalid or expired
+		"700": StatusCompleted,
+		"701": StatusCompleted,
+	},
+}
+
+// MapStatus returns the Status of a marketplace status, StatusUnknown when it
+// is not in the table.
+func MapStatus(m Marketplace, raw string) Status {
+	if s, ok := statusTable[m][raw]; ok {
+		return s
+	}
+	return StatusUnknown
+}
+
+// statusRank orders the statuses of lines being fulfilled, an order is as far
+// as its least advanced line.
+var statusRank = map[Status]int{
+	StatusUnpaid:      1,
+	StatusPending:     2,
+	StatusInCancel:    3,
+	StatusReadyToShip: 4,
+	StatusShipped:     5,
+	StatusDelivered:   6,
+	StatusCompleted:   7,
+}
+
+// combineStatus returns the status of an order from the status of its lines.
+// Cancelled and returned lines only count when every line is.
+func combineStatus(lines []Status) Status {
+	if len(lines) == 0 {
+		return StatusUnknown
+	}
+
+	var least Status
+	for _, s := range lines {
+		rank, ok := statusRank[s]
+		if !ok {
+			continue
+		}
+		if least == "" || rank < statusRank[least] {
+			least = s
+		}
+	}
+	if least != "" {
+		return least
+	}
+
+	status := StatusUnknown
+	for _, s := range lines {
+		switch s {
+		case StatusReturned:
+			return StatusReturned
+		case StatusCancelled:
+			status = StatusCancelled
+		}
+	}
+	return status
+}
@@ -0,0 +1,69 @@
+package order
+
+import (
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+)
+
+// FromTiktok converts an order of GetOrder or SearchOrders. TikTok has one
+// line item per unit, so every line has a quantity of one.
+func FromTiktok(o tiktok.Order) Order {
+	currency := o.Payment.Currency
+	out := Order{
+		ID:          o.ID,
+		Marketplace: Tiktok,
+		Status:      MapStatus(Tiktok, o.Status),
+		RawStatus:   o.Status,
+		BuyerID:     o.UserID,
+		BuyerNote:   o.BuyerMessage,
+		ShippingAddress: Address{
+			Name:       o.RecipientAddress.Name,
+			Phone:      o.RecipientAddress.PhoneNumber,
+			Line1:      o.RecipientAddress.AddressLine1,
+			Line2:      joinNonEmpty(", ", o.RecipientAddress.AddressLine2, o.RecipientAddress.AddressLine3, o.RecipientAddress.AddressLine4),
+			PostalCode: o.RecipientAddress.PostalCode,
+			Country:    o.RecipientAddress.RegionCode,
+			Full:       o.RecipientAddress.FullAddress,
+		},
+		Subtotal:       o.Payment.SubTotal,
+		ShippingFee:    o.Payment.ShippingFee,
+		Total:          o.Payment.TotalAmount,
+		PaymentMethod:  o.PaymentMethodName,
+		COD:            o.IsCod,
+		Carrier:        o.ShippingProvider,
+		TrackingNumber: o.TrackingNumber,
+		CreatedAt:      unixTime(o.CreateTime),
+		UpdatedAt:      unixTime(o.UpdateTime),
+		Raw:            o,
+	}
+	// TikTok only sends the name of the recipient
+	out.BuyerName = o.RecipientAddress.Name
+
+	// district_info goes from the country down, e.g. Country, Province,
+	// City, District
+	for _, d := range o.RecipientAddress.DistrictInfo {
+		switch d.AddressLevelName {
+		case "Province", "State":
+			out.ShippingAddress.Province = d.AddressName
+		case "City", "Regency":
+			out.ShippingAddress.City = d.AddressName
+		case "District":
+			out.ShippingAddress.District = d.AddressName
+		}
+	}
+
+	for _, item := range o.LineItems {
+		out.Lines = append(out.Lines, OrderLine{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			VariantID:   item.SkuID,
+			SKU:         item.SellerSku,
+			Name:        item.ProductName,
+			VariantName: item.SkuName,
+			ImageURL:    item.SkuImage,
+			Quantity:    1,
+			UnitPrice:   item.SalePrice.WithCurrency(currency),
+		})
+	}
+
+	return out
+}
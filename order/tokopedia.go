@@ -0,0 +1,53 @@
+package order
+
+import (
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+)
+
+// FromTokopedia converts an order of GetOrders or of the order notification
+// webhook. Buyer data is left as sent, encrypted when the app has encryption
+// enabled.
+func FromTokopedia(o tokopedia.Order) Order {
+	status := strconv.Itoa(o.OrderStatus)
+	out := Order{
+		ID:          strconv.FormatInt(o.OrderID, 10),
+		Marketplace: Tokopedia,
+		Status:      MapStatus(Tokopedia, status),
+		RawStatus:   status,
+		BuyerID:     strconv.FormatInt(o.Buyer.ID, 10),
+		BuyerName:   o.Buyer.Name,
+		ShippingAddress: Address{
+			Name:       o.Recipient.Name,
+			Phone:      o.Recipient.Phone,
+			Line1:      o.Recipient.Address.AddressFull,
+			District:   o.Recipient.Address.District,
+			City:       o.Recipient.Address.City,
+			Province:   o.Recipient.Address.Province,
+			PostalCode: o.Recipient.Address.PostalCode,
+			Country:    o.Recipient.Address.Country,
+			Full:       o.Recipient.Address.AddressFull,
+		},
+		Subtotal:       o.Amt.TtlProductPrice,
+		ShippingFee:    o.Amt.ShippingCost,
+		Total:          o.Amt.TtlAmount,
+		COD:            o.IsCodMitra,
+		Carrier:        joinNonEmpty(" ", o.Logistics.ShippingAgency, o.Logistics.ServiceType),
+		TrackingNumber: o.CustomFields.Awb,
+		CreatedAt:      unixTime(o.CreateTime),
+		Raw:            o,
+	}
+
+	for _, p := range o.Products {
+		out.Lines = append(out.Lines, OrderLine{
+			ProductID: strconv.FormatInt(p.ID, 10),
+			SKU:       p.Sku,
+			Name:      p.Name,
+			Quantity:  p.Quantity,
+			UnitPrice: p.Price,
+		})
+	}
+
+	return out
+}
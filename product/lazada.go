@@ -0,0 +1,189 @@
+package product
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+)
+
+var lazadaStatus = map[string]Status{
+	"active":    StatusActive,
+	"inactive":  StatusInactive,
+	"deleted":   StatusDeleted,
+	"pending":   StatusPending,
+	"pendingqc": StatusPending,
+	"suspended": StatusBanned,
+	"rejected":  StatusBanned,
+}
+
+// lazadaStatusOf maps the product and sku statuses of Lazada, which are sent
+// as Active, InActive or Pending QC.
+func lazadaStatusOf(raw string) Status {
+	key := strings.ToLower(strings.ReplaceAll(raw, " ", ""))
+	if s, ok := lazadaStatus[key]; ok {
+		return s
+	}
+	return StatusUnknown
+}
+
+// FromLazada converts a product of GetProducts. Lazada prices carry no
+// currency, it is the one of the country of the seller, e.g. IDR.
+//
+// The options are the sale properties of the skus, in the order their names
+// sort as Lazada sends them as a map.
+func FromLazada(p lazada.Products, currency string) Product {
+	out := Product{
+		ID:          strconv.Itoa(p.ItemID),
+		Marketplace: Lazada,
+		Name:        p.Attributes.Name,
+		Description: p.Attributes.Description,
+		CategoryID:  p.PrimaryCategory,
+		Brand:       p.Attributes.Brand,
+		Status:      lazadaStatusOf(p.Status),
+		RawStatus:   p.Status,
+		CreatedAt:   lazadaTime(p.CreatedTime),
+		UpdatedAt:   lazadaTime(p.UpdatedTime),
+		Raw:         p,
+	}
+	for _, url := range p.Images {
+		out.Images = append(out.Images, Image{URL: url})
+	}
+
+	for _, sku := range p.Skus {
+		v := Variant{
+			ID:    strconv.Itoa(sku.SkuID),
+			SKU:   sku.SellerSku,
+			Price: sku.Price.WithCurrency(currency),
+			Stock: Stock{
+				Available: int64(sku.Available),
+				Reserved:  int64(sku.Quantity - sku.Available),
+			},
+		}
+		v.SalePrice = v.Price
+		if sku.SpecialPrice.Amount.Sign() > 0 {
+			v.SalePrice = sku.SpecialPrice.WithCurrency(currency)
+		}
+		if v.Stock.Reserved < 0 {
+			v.Stock.Reserved = 0
+		}
+		for _, url := range sku.Images {
+			v.Images = append(v.Images, Image{URL: url})
+		}
+
+		names := make([]string, 0, len(sku.SaleProp))
+		for name := range sku.SaleProp {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) > 0 {
+			v.Options = make(map[string]string, len(names))
+		}
+		for _, name := range names {
+			v.Options[name] = sku.SaleProp[name]
+			out.addOption(name, sku.SaleProp[name])
+		}
+
+		if out.Weight == 0 {
+			if w, err := strconv.ParseFloat(sku.PackageWeight, 64); err == nil {
+				out.Weight = w
+			}
+		}
+		out.Variants = append(out.Variants, v)
+	}
+
+	return out
+}
+
+// lazadaTime reads the created and updated times of a product, milliseconds
+// since the epoch sent as a string or a number.
+func lazadaTime(v interface{}) time.Time {
+	var ms int64
+	switch t := v.(type) {
+	case string:
+		n, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		ms = n
+	case float64:
+		ms = int64(t)
+	}
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// ToLazada returns the payload of CreateProduct, or of UpdateProduct when p
+// comes from Lazada as it then keeps the item and sku ids. CategoryID must be
+// a Lazada category and the images hosted by Lazada, see MigrateImage. The
+// option names must be sale properties of the category, e.g. color_family.
+// A created sku gets the available stock of its variant, an updated one keeps
+// its stock.
+func ToLazada(p Product) (*lazada.ProductRequest, error) {
+	req := &lazada.ProductRequest{
+		Product: lazada.ProductPayload{
+			PrimaryCategory: p.CategoryID,
+			Images:          lazada.NewPayloadImages(imageURLs(p.Images)),
+			Attributes:      lazada.StringMap{"name": p.Name},
+		},
+	}
+	if p.Description != "" {
+		req.Product.Attributes["description"] = p.Description
+	}
+	if p.Brand != "" {
+		req.Product.Attributes["brand"] = p.Brand
+	}
+
+	ids := p.sameMarketplace(Lazada)
+	if ids && p.ID != "" {
+		itemID, err := strconv.Atoi(p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("product: lazada item id %q: %w", p.ID, err)
+		}
+		req.Product.ItemID = itemID
+	}
+
+	for _, v := range p.Variants {
+		sku := lazada.SkuPayload{
+			SellerSku: v.SKU,
+			Images:    lazada.NewPayloadImages(imageURLs(v.Images)),
+		}
+		if ids && v.ID != "" {
+			skuID, err := strconv.Atoi(v.ID)
+			if err != nil {
+				return nil, fmt.Errorf("product: lazada sku id %q: %w", v.ID, err)
+			}
+			sku.SkuID = skuID
+		}
+
+		if !ids {
+			quantity := int(v.Stock.Available)
+			sku.Quantity = &quantity
+		}
+		sku.Price = v.Price.Amount.Float64()
+		if !v.SalePrice.IsZero() && v.SalePrice.Amount != v.Price.Amount {
+			sku.SpecialPrice = v.SalePrice.Amount.Float64()
+		}
+		if p.Weight > 0 {
+			sku.PackageWeight = strconv.FormatFloat(p.Weight, 'f', -1, 64)
+		}
+
+		if len(p.Options) > 0 {
+			sku.SaleProp = lazada.StringMap{}
+			for _, o := range p.Options {
+				if _, err := v.optionIndex(o); err != nil {
+					return nil, err
+				}
+				sku.SaleProp[o.Name] = v.Options[o.Name]
+			}
+		}
+		req.Product.Skus = append(req.Product.Skus, sku)
+	}
+
+	return req, nil
+}
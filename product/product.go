@@ -0,0 +1,168 @@
+// Package product provides one product model on top of the marketplace
+// specific product structs, so one catalog can be listed across marketplaces.
+//
+// The From functions convert a product as returned by a marketplace package
+// and the To functions build the write requests of a marketplace, they do not
+// call any API.
+//
+// Variations are modelled as Options, e.g. Color and Size, and each Variant
+// names its value of every option. Shopee tier variations and the sale
+// properties of Lazada and TikTok skus are both converted to and from this.
+package product
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+type Marketplace string
+
+const (
+	Shopee    Marketplace = "shopee"
+	Lazada    Marketplace = "lazada"
+	Tiktok    Marketplace = "tiktok"
+	Tokopedia Marketplace = "tokopedia"
+)
+
+type Status string
+
+const (
+	StatusUnknown  Status = "unknown"
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+	StatusDraft    Status = "draft"
+	// StatusPending products are reviewed by the marketplace.
+	StatusPending Status = "pending"
+	StatusBanned  Status = "banned"
+	StatusDeleted Status = "deleted"
+)
+
+type Product struct {
+	ID          string
+	Marketplace Marketplace
+	Name        string
+	Description string
+	CategoryID  string
+	Brand       string
+	Status      Status
+	// RawStatus is the status as sent by the marketplace.
+	RawStatus string
+
+	Images []Image
+	// Options are the variation dimensions in the order of the marketplace,
+	// empty for a product without variations.
+	Options []Option
+	// Variants has one variant for a product without variations.
+	Variants []Variant
+
+	// Weight is the package weight in kilograms.
+	Weight float64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Raw holds the marketplace struct the product was built from.
+	Raw any
+}
+
+// Image is an image of a product. ID is the id of an image hosted by the
+// marketplace, as Shopee and TikTok writes need it.
+type Image struct {
+	ID  string
+	URL string
+}
+
+// Option is a variation dimension and its values, e.g. Color with Blue and
+// Black.
+type Option struct {
+	Name   string
+	Values []string
+}
+
+type Variant struct {
+	ID  string
+	SKU string
+	// Options maps the name of each option of the product to the value of
+	// the variant, e.g. Color to Blue.
+	Options map[string]string
+
+	// Price is the list price, SalePrice the price paid, equal to Price
+	// without a discount.
+	Price     money.Money
+	SalePrice money.Money
+	Stock     Stock
+	Images    []Image
+}
+
+// Stock is the stock of a variant over all warehouses.
+type Stock struct {
+	Available int64
+	Reserved  int64
+}
+
+// addOption records value for the option name, keeping the order in which
+// options and values are first seen.
+func (p *Product) addOption(name, value string) {
+	for i := range p.Options {
+		if p.Options[i].Name != name {
+			continue
+		}
+		for _, v := range p.Options[i].Values {
+			if v == value {
+				return
+			}
+		}
+		p.Options[i].Values = append(p.Options[i].Values, value)
+		return
+	}
+	p.Options = append(p.Options, Option{Name: name, Values: []string{value}})
+}
+
+// optionIndex returns the index of the value of option o for v.
+func (v Variant) optionIndex(o Option) (int, error) {
+	value, ok := v.Options[o.Name]
+	if !ok {
+		return 0, fmt.Errorf("product: variant %q has no value for option %q", v.SKU, o.Name)
+	}
+	for i, candidate := range o.Values {
+		if candidate == value {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("product: variant %q value %q is not a value of option %q", v.SKU, value, o.Name)
+}
+
+// sameMarketplace tells whether the ids of p are ids of m, ids are only sent
+// back to the marketplace they come from.
+func (p Product) sameMarketplace(m Marketplace) bool {
+	return p.Marketplace == m
+}
+
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func imageURLs(images []Image) []string {
+	var urls []string
+	for _, img := range images {
+		if img.URL != "" {
+			urls = append(urls, img.URL)
+		}
+	}
+	return urls
+}
+
+func imageIDs(images []Image) []string {
+	var ids []string
+	for _, img := range images {
+		if img.ID != "" {
+			ids = append(ids, img.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,189 @@
+package product
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+)
+
+var shopeeStatus = map[string]Status{
+	"NORMAL":        StatusActive,
+	"UNLIST":        StatusInactive,
+	"REVIEWING":     StatusPending,
+	"BANNED":        StatusBanned,
+	"SELLER_DELETE": StatusDeleted,
+	"SHOPEE_DELETE": StatusDeleted,
+}
+
+// FromShopee converts an item of GetProductById with its models of
+// GetModelList, models is nil for an item without variations. The variant of
+// an item without variations has the model id 0.
+func FromShopee(item shopee.ItemListData, models *shopee.GetModelListResponseData) Product {
+	var currency string
+	if len(item.PriceInfo) > 0 {
+		currency = item.PriceInfo[0].Currency
+	}
+
+	p := Product{
+		ID:          strconv.FormatInt(item.ItemID, 10),
+		Marketplace: Shopee,
+		Name:        item.ItemName,
+		Description: item.Description,
+		CategoryID:  strconv.FormatInt(item.CategoryID, 10),
+		Brand:       item.Brand.OriginalBrandName,
+		Status:      StatusUnknown,
+		RawStatus:   item.ItemStatus,
+		CreatedAt:   unixTime(item.CreateTime),
+		UpdatedAt:   unixTime(item.UpdateTime),
+		Raw:         item,
+	}
+	if s, ok := shopeeStatus[item.ItemStatus]; ok {
+		p.Status = s
+	}
+	// weight is read as a string, "1.000"
+	if w, err := strconv.ParseFloat(item.Weight, 64); err == nil {
+		p.Weight = w
+	}
+	for i, id := range item.Image.ImageIDList {
+		img := Image{ID: id}
+		if i < len(item.Image.ImageURLList) {
+			img.URL = item.Image.ImageURLList[i]
+		}
+		p.Images = append(p.Images, img)
+	}
+
+	if models == nil || len(models.Model) == 0 {
+		v := Variant{ID: "0", SKU: item.ItemSku}
+		v.Price, v.SalePrice = shopeePrices(item.PriceInfo, currency)
+		v.Stock = Stock{
+			Available: item.StockInfoV2.SummaryInfo.TotalAvailableStock,
+			Reserved:  item.StockInfoV2.SummaryInfo.TotalReservedStock,
+		}
+		p.Variants = []Variant{v}
+		return p
+	}
+
+	for _, tier := range models.TierVariation {
+		o := Option{Name: tier.Name}
+		for _, opt := range tier.OptionList {
+			o.Values = append(o.Values, opt.Option)
+		}
+		p.Options = append(p.Options, o)
+	}
+
+	for _, m := range models.Model {
+		v := Variant{
+			ID:      strconv.FormatUint(m.ModelID, 10),
+			SKU:     m.ModelSKU,
+			Options: make(map[string]string),
+		}
+		v.Price, v.SalePrice = shopeePrices(m.PriceInfo, currency)
+
+		for tier, index := range m.TierIndex {
+			if tier >= len(models.TierVariation) || index >= len(models.TierVariation[tier].OptionList) {
+				continue
+			}
+			opt := models.TierVariation[tier].OptionList[index]
+			v.Options[models.TierVariation[tier].Name] = opt.Option
+			// only options of the first tier have images
+			if opt.Image != nil {
+				v.Images = append(v.Images, Image{ID: opt.Image.ImageID, URL: opt.Image.ImageURL})
+			}
+		}
+
+		if m.StockInfoV2 != nil {
+			v.Stock = Stock{
+				Available: m.StockInfoV2.SummaryInfo.TotalAvailableStock,
+				Reserved:  m.StockInfoV2.SummaryInfo.TotalReservedStock,
+			}
+		} else {
+			for _, s := range m.StockInfo {
+				v.Stock.Available += int64(s.NormalStock)
+				v.Stock.Reserved += int64(s.ReservedStock)
+			}
+		}
+		p.Variants = append(p.Variants, v)
+	}
+
+	return p
+}
+
+// shopeePrices returns the original and current price, the prices of models
+// carry no currency and take the one of the item.
+func shopeePrices(info []shopee.PriceInfo, currency string) (money.Money, money.Money) {
+	if len(info) == 0 {
+		return money.Money{}, money.Money{}
+	}
+	return info[0].OriginalPrice.WithCurrency(currency), info[0].CurrentPrice.WithCurrency(currency)
+}
+
+// ToShopeeAddItem returns the request that creates p on Shopee. CategoryID
+// must be a Shopee category and the images Shopee image ids, see
+// UploadImage. Logistics, attributes and the brand are left to the caller.
+//
+// The price and stock of the first variant are used, a product with options
+// gets the price and stock of each variant from ToShopeeTierVariation once
+// the item exists.
+func ToShopeeAddItem(p Product) (shopee.AddItemRequest, error) {
+	req := shopee.AddItemRequest{
+		ItemName:    p.Name,
+		Description: p.Description,
+		Weight:      p.Weight,
+		Image:       shopee.Image{ImageIDList: imageIDs(p.Images)},
+	}
+
+	categoryID, err := strconv.ParseInt(p.CategoryID, 10, 64)
+	if err != nil {
+		return req, fmt.Errorf("product: shopee category %q: %w", p.CategoryID, err)
+	}
+	req.CategoryID = categoryID
+
+	if len(p.Variants) > 0 {
+		v := p.Variants[0]
+		req.OriginalPrice = v.Price.Amount.Float64()
+		if len(p.Options) == 0 {
+			req.ItemSku = v.SKU
+			req.SellerStock = []shopee.SellerStock{{Stock: v.Stock.Available}}
+		}
+	}
+
+	return req, nil
+}
+
+// ToShopeeTierVariation returns the request that adds the options and
+// variants of p to the Shopee item itemID. Every variant must have a value of
+// every option.
+func ToShopeeTierVariation(p Product, itemID int64) (shopee.InitTierVariationRequest, error) {
+	req := shopee.InitTierVariationRequest{ItemID: itemID}
+	if len(p.Options) == 0 {
+		return req, fmt.Errorf("product: %q has no options", p.Name)
+	}
+
+	for _, o := range p.Options {
+		tier := shopee.TierVariation{Name: o.Name}
+		for _, value := range o.Values {
+			tier.OptionList = append(tier.OptionList, shopee.TierVariationOption{Option: value})
+		}
+		req.TierVariation = append(req.TierVariation, tier)
+	}
+
+	for _, v := range p.Variants {
+		model := shopee.InitTierModel{
+			ModelSKU:      v.SKU,
+			SellerStock:   []shopee.SellerStock{{Stock: v.Stock.Available}},
+			OriginalPrice: v.Price.Amount.Float64(),
+		}
+		for _, o := range p.Options {
+			index, err := v.optionIndex(o)
+			if err != nil {
+				return req, err
+			}
+			model.TierIndex = append(model.TierIndex, index)
+		}
+		req.Model = append(req.Model, model)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,139 @@
+package product
+
+import (
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+)
+
+var tiktokStatus = map[string]Status{
+	"DRAFT":                StatusDraft,
+	"PENDING":              StatusPending,
+	"FAILED":               StatusBanned,
+	"ACTIVATE":             StatusActive,
+	"SELLER_DEACTIVATED":   StatusInactive,
+	"PLATFORM_DEACTIVATED": StatusBanned,
+	"FREEZE":               StatusBanned,
+	"DELETED":              StatusDeleted,
+}
+
+// FromTiktok converts a product of GetProductInfo. TikTok has one price per
+// sku, it is both the Price and the SalePrice of the variant.
+func FromTiktok(p tiktok.ProductData) Product {
+	out := Product{
+		ID:          p.ID,
+		Marketplace: Tiktok,
+		Name:        p.Title,
+		Description: p.Description,
+		Brand:       p.Brand.Name,
+		Status:      StatusUnknown,
+		RawStatus:   p.Status,
+		CreatedAt:   unixTime(p.CreateTime),
+		UpdatedAt:   unixTime(p.UpdateTime),
+		Raw:         p,
+	}
+	if s, ok := tiktokStatus[p.Status]; ok {
+		out.Status = s
+	}
+	// the leaf of the chain is the category of the product
+	for _, c := range p.CategoryChains {
+		if c.IsLeaf {
+			out.CategoryID = c.ID
+		}
+	}
+	for _, img := range p.MainImages {
+		out.Images = append(out.Images, tiktokImage(img))
+	}
+	if p.PackageWeight != nil {
+		if w, err := strconv.ParseFloat(p.PackageWeight.Value, 64); err == nil {
+			if p.PackageWeight.Unit == "GRAM" {
+				w /= 1000
+			}
+			out.Weight = w
+		}
+	}
+
+	for _, sku := range p.Skus {
+		v := Variant{ID: sku.ID, SKU: sku.SellerSku}
+		if sku.Price != nil {
+			v.Price = sku.Price.SalePrice
+			v.SalePrice = v.Price
+		}
+		for _, inv := range sku.Inventory {
+			v.Stock.Available += inv.Quantity
+		}
+		if len(sku.SalesAttributes) > 0 {
+			v.Options = make(map[string]string, len(sku.SalesAttributes))
+		}
+		for _, attr := range sku.SalesAttributes {
+			v.Options[attr.Name] = attr.ValueName
+			out.addOption(attr.Name, attr.ValueName)
+			if attr.SkuImg != nil {
+				v.Images = append(v.Images, tiktokImage(*attr.SkuImg))
+			}
+		}
+		out.Variants = append(out.Variants, v)
+	}
+
+	return out
+}
+
+func tiktokImage(img tiktok.MainImage) Image {
+	out := Image{ID: img.URI}
+	if len(img.Urls) > 0 {
+		out.URL = img.Urls[0]
+	}
+	return out
+}
+
+// ToTiktok returns the request of CreateProduct, or of EditProduct when p
+// comes from TikTok as it then keeps the sku ids. CategoryID must be a TikTok
+// category and the image ids URIs of images uploaded to TikTok, see
+// UploadImage. Options are sent by name, TikTok creates the values it does not
+// know. The available stock of a created variant is sent without a
+// warehouse, which TikTok reads as the default one, an edited variant keeps
+// its stock. A variant is sent at its Price, TikTok discounts
+// are promotions of their own.
+func ToTiktok(p Product) tiktok.ProductRequest {
+	req := tiktok.ProductRequest{
+		Title:       p.Name,
+		Description: p.Description,
+		CategoryID:  p.CategoryID,
+	}
+	for _, id := range imageIDs(p.Images) {
+		req.MainImages = append(req.MainImages, tiktok.MainImage{URI: id})
+	}
+	if p.Weight > 0 {
+		req.PackageWeight = &tiktok.PackageWeight{
+			Unit:  "KILOGRAM",
+			Value: strconv.FormatFloat(p.Weight, 'f', -1, 64),
+		}
+	}
+
+	ids := p.sameMarketplace(Tiktok)
+	for _, v := range p.Variants {
+		sku := tiktok.Skus{SellerSku: v.SKU}
+		if ids {
+			sku.ID = v.ID
+		} else {
+			sku.Inventory = []tiktok.Inventory{{Quantity: v.Stock.Available}}
+		}
+		if !v.Price.IsZero() {
+			sku.Price = &tiktok.Price{Amount: v.Price.Amount.String(), Currency: v.Price.Currency}
+		}
+
+		for i, o := range p.Options {
+			attr := tiktok.SalesAttribute{Name: o.Name, ValueName: v.Options[o.Name]}
+			// TikTok takes the images of the first sale attribute only
+			if i == 0 {
+				if images := imageIDs(v.Images); len(images) > 0 {
+					attr.SkuImg = &tiktok.MainImage{URI: images[0]}
+				}
+			}
+			sku.SalesAttributes = append(sku.SalesAttributes, attr)
+		}
+		req.Skus = append(req.Skus, sku)
+	}
+
+	return req
+}
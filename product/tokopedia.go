@@ -0,0 +1,79 @@
+package product
+
+import (
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+)
+
+var tokopediaStatus = map[int]Status{
+	-2: StatusBanned,
+	-1: StatusPending,
+	0:  StatusDeleted,
+	1:  StatusActive,
+	2:  StatusActive, // featured
+	3:  StatusInactive,
+}
+
+// tokopediaWeightKilogram is the weight unit of products weighed in
+// kilograms, other products are weighed in grams.
+const tokopediaWeightKilogram = 2
+
+// FromTokopedia converts a product of GetProductInfo. A product with
+// variations is a parent product whose variants are products of their own,
+// listed in Variant.ChildrenID: pass them as variants, the parent is not a
+// variant. Tokopedia does not name the options of the variants, Options stays
+// empty.
+//
+// Tokopedia has no product write API for this SDK, there is no ToTokopedia.
+func FromTokopedia(p tokopedia.ProductData, variants ...tokopedia.ProductData) Product {
+	out := Product{
+		ID:          strconv.Itoa(p.Basic.ProductID),
+		Marketplace: Tokopedia,
+		Name:        p.Basic.Name,
+		Description: p.Basic.ShortDesc,
+		CategoryID:  strconv.Itoa(p.Basic.ChildCategoryID),
+		Status:      StatusUnknown,
+		RawStatus:   strconv.Itoa(p.Basic.Status),
+		UpdatedAt:   unixTime(int64(p.Price.LastUpdateUnix)),
+		Raw:         p,
+	}
+	if s, ok := tokopediaStatus[p.Basic.Status]; ok {
+		out.Status = s
+	}
+	out.Weight = float64(p.Weight.Value)
+	if p.Weight.Unit != tokopediaWeightKilogram {
+		out.Weight /= 1000
+	}
+	out.Images = tokopediaImages(p)
+
+	if len(variants) == 0 {
+		variants = []tokopedia.ProductData{p}
+	}
+	for _, child := range variants {
+		v := Variant{
+			ID:    strconv.Itoa(child.Basic.ProductID),
+			SKU:   child.Other.Sku,
+			Price: child.Price.Value,
+			Stock: Stock{
+				Available: int64(child.Stock.Value),
+				Reserved:  int64(child.ReserveStock),
+			},
+		}
+		v.SalePrice = v.Price
+		if child.Basic.ProductID != p.Basic.ProductID {
+			v.Images = tokopediaImages(child)
+		}
+		out.Variants = append(out.Variants, v)
+	}
+
+	return out
+}
+
+func tokopediaImages(p tokopedia.ProductData) []Image {
+	var images []Image
+	for _, pic := range p.Pictures {
+		images = append(images, Image{ID: strconv.Itoa(pic.PicID), URL: pic.OriginalURL})
+	}
+	return images
+}
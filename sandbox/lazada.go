@@ -0,0 +1,492 @@
+package sandbox
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+)
+
+// Lazada is a fake Lazada Open Platform API. Requests are signed with the app
+// secret over the API path and the sorted query and form parameters, and
+// must carry the access token of a seller.
+type Lazada struct {
+	*server
+
+	appKey  string
+	secret  string
+	sellers map[string]*lazadaSeller
+}
+
+type lazadaSeller struct {
+	sessions []lazada.SessionListData
+	messages map[string][]lazada.MessagesListData
+	orders   []lazada.Orders
+	items    map[int64][]lazada.OrderItems
+	products []lazada.Products
+}
+
+// lazadaAmounts are the fields Lazada sends as bare strings.
+var lazadaAmounts = newAmountFields(true,
+	"item_price", "paid_price", "price", "shipping_amount", "shipping_fee",
+	"shipping_fee_discount_platform", "shipping_fee_discount_seller",
+	"shipping_fee_original", "shipping_service_cost", "special_price",
+	"supply_price", "tax_amount", "voucher", "voucher_amount",
+	"voucher_platform", "voucher_platform_lpi", "voucher_seller",
+	"voucher_seller_lpi", "wallet_credits",
+)
+
+// NewLazada starts a fake Lazada API for the app.
+func NewLazada(appKey, secret string) *Lazada {
+	s := &Lazada{appKey: appKey, secret: secret, sellers: map[string]*lazadaSeller{}}
+	s.server = newServer(s, lazadaAmounts)
+
+	s.handle("GET /rest/im/session/list", s.getSessionList)
+	s.handle("GET /rest/im/session/get", s.getSessionDetail)
+	s.handle("GET /rest/im/message/list", s.getMessageList)
+	s.handle("POST /rest/im/message/send", s.sendMessage)
+	s.handle("GET /rest/orders/get", s.getOrders)
+	s.handle("GET /rest/order/get", s.getOrder)
+	s.handle("GET /rest/order/items/get", s.getOrderItems)
+	s.handle("GET /rest/products/get", s.getProducts)
+	return s
+}
+
+// Client returns a client of the API, its BaseURL points at the server
+// whatever the region.
+func (s *Lazada) Client(opts ...lazada.Option) *lazada.Client {
+	c := lazada.NewClient(s.appKey, s.secret, lazada.Indonesia, opts...)
+	c.BaseURL, _ = url.Parse(s.URL() + "/")
+	return c
+}
+
+// ErrorFault returns a fault answering the Lazada error code with a 200
+// status, e.g. ApiCallLimit.
+func (s *Lazada) ErrorFault(code, message string) Fault {
+	b, _ := json.Marshal(lazada.ResponseError{Code: code, Type: "ISP", Message: message, RequestID: s.requestID()})
+	return Fault{Status: http.StatusOK, Body: string(b)}
+}
+
+// AddShop adds the shop of a seller authorized with token.
+func (s *Lazada) AddShop(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sellers[token] = &lazadaSeller{
+		messages: map[string][]lazada.MessagesListData{},
+		items:    map[int64][]lazada.OrderItems{},
+	}
+}
+
+// AddSession adds a chat session to the shop.
+func (s *Lazada) AddSession(token string, session lazada.SessionListData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seller := s.mustSeller(token)
+	seller.sessions = append(seller.sessions, session)
+}
+
+// AddMessage adds a message to its session in the shop.
+func (s *Lazada) AddMessage(token string, m lazada.MessagesListData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seller := s.mustSeller(token)
+	seller.messages[m.SessionID] = append(seller.messages[m.SessionID], m)
+}
+
+// AddOrder adds an order with its items to the shop.
+func (s *Lazada) AddOrder(token string, o lazada.Orders, items ...lazada.OrderItems) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seller := s.mustSeller(token)
+	seller.orders = append(seller.orders, o)
+	seller.items[o.OrderID] = append(seller.items[o.OrderID], items...)
+}
+
+// AddProduct adds a product to the shop.
+func (s *Lazada) AddProduct(token string, p lazada.Products) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seller := s.mustSeller(token)
+	seller.products = append(seller.products, p)
+}
+
+// Messages returns the messages of a session of the shop, sent ones
+// included.
+func (s *Lazada) Messages(token, sessionID string) []lazada.MessagesListData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mustSeller(token).messages[sessionID])
+}
+
+func (s *Lazada) mustSeller(token string) *lazadaSeller {
+	seller, ok := s.sellers[token]
+	if !ok {
+		panic("sandbox: unknown lazada access token, add its shop with AddShop")
+	}
+	return seller
+}
+
+// seller returns the seller of an authorized request, the caller holds mu.
+func (s *Lazada) seller(r *http.Request) *lazadaSeller {
+	return s.sellers[r.URL.Query().Get("access_token")]
+}
+
+func (s *Lazada) authorize(r *http.Request, body []byte) error {
+	q := r.URL.Query()
+	if q.Get("app_key") != s.appKey {
+		return &apiError{status: http.StatusOK, code: "InvalidApiKey", message: "The specified App Key is invalid"}
+	}
+	if q.Get("sign_method") != "sha256" {
+		return &apiError{status: http.StatusOK, code: "InvalidSignatureMethod", message: "The signature method is not supported"}
+	}
+	ms, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil || !withinSkew(ms/1000) {
+		return &apiError{status: http.StatusOK, code: "InvalidTimestamp", message: "The request timestamp is invalid"}
+	}
+
+	signed := url.Values{}
+	for k, vs := range q {
+		if k != "sign" {
+			signed[k] = vs
+		}
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return badParam("form")
+		}
+		for k, vs := range form {
+			signed[k] = append(signed[k], vs...)
+		}
+	}
+	if !hmac.Equal([]byte(s.sign(strings.TrimPrefix(r.URL.Path, "/rest"), signed)), []byte(q.Get("sign"))) {
+		return &apiError{status: http.StatusOK, code: "IncompleteSignature", message: "The request signature does not conform to platform standards"}
+	}
+
+	s.mu.Lock()
+	seller := s.seller(r)
+	s.mu.Unlock()
+	if seller == nil {
+		return &apiError{status: http.StatusOK, code: "IllegalAccessToken", message: "The specified access token is invalid or expired"}
+	}
+	return nil
+}
+
+// sign is the signature of the open platform, the HMAC-SHA256 of the API
+// path followed by the sorted parameters as key and value.
+func (s *Lazada) sign(api string, params url.Values) string {
+	var buf bytes.Buffer
+	buf.WriteString(api)
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range params[k] {
+			buf.WriteString(url.QueryEscape(k))
+			buf.WriteString(v)
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(buf.Bytes())
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s *Lazada) errorBody(e *apiError, requestID string) any {
+	code := e.code
+	if code == "" {
+		switch e.status {
+		case http.StatusTooManyRequests:
+			code = "ApiCallLimit"
+		case http.StatusBadRequest, http.StatusNotFound:
+			code = "InvalidParameter"
+		case http.StatusServiceUnavailable:
+			code = "ServiceTimeout"
+		default:
+			code = "InternalError"
+		}
+	}
+	message := e.message
+	if message == "" {
+		message = http.StatusText(e.status)
+	}
+	return lazada.ResponseError{Code: code, Type: "ISP", Message: message, RequestID: requestID}
+}
+
+func (s *Lazada) retryAfter(h http.Header, d time.Duration) {}
+
+// ok wraps data in a successful response, the caller holds mu.
+func (s *Lazada) ok(data any) any {
+	return struct {
+		Code      string `json:"code"`
+		Data      any    `json:"data"`
+		RequestID string `json:"request_id"`
+	}{"0", data, s.requestIDLocked()}
+}
+
+// getSessionList pages the sessions by their last message from start_time,
+// last_session_id picks up after the last one of the previous page.
+func (s *Lazada) getSessionList(r *http.Request) (any, error) {
+	start, size, last, err := lazadaCursor(r, "last_session_id")
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, more := after(s.seller(r).sessions, func(v lazada.SessionListData) (int64, string) {
+		return v.LastMessageTime, v.SessionID
+	}, start, last, size)
+
+	data := lazada.SessionListResponseData{SessionList: sessions, HasMore: more}
+	if len(sessions) > 0 {
+		data.NextStartTime = sessions[len(sessions)-1].LastMessageTime
+		data.LastSessionID = sessions[len(sessions)-1].SessionID
+	}
+	return s.ok(data), nil
+}
+
+func (s *Lazada) getSessionDetail(r *http.Request) (any, error) {
+	id := r.URL.Query().Get("session_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := s.seller(r).sessions
+	i := slices.IndexFunc(sessions, func(v lazada.SessionListData) bool { return v.SessionID == id })
+	if i < 0 {
+		return nil, notFound("session " + id)
+	}
+	return s.ok(sessions[i]), nil
+}
+
+// getMessageList pages the messages of a session by send time from
+// start_time, last_message_id picks up after the last one of the previous
+// page.
+func (s *Lazada) getMessageList(r *http.Request) (any, error) {
+	id := r.URL.Query().Get("session_id")
+	if id == "" {
+		return nil, &apiError{status: http.StatusBadRequest, code: "MissingParameter", message: "session_id is required"}
+	}
+	start, size, last, err := lazadaCursor(r, "last_message_id")
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages, more := after(s.seller(r).messages[id], func(v lazada.MessagesListData) (int64, string) {
+		return int64(v.SendTime), v.MessageID
+	}, start, last, size)
+
+	data := lazada.GetMessageResponseData{MessageList: messages, HasMore: more}
+	if len(messages) > 0 {
+		data.NextStartTime = messages[len(messages)-1].SendTime
+		data.LastMessageID = messages[len(messages)-1].MessageID
+	}
+	return s.ok(data), nil
+}
+
+// sendMessage adds a text message from the seller to the session.
+func (s *Lazada) sendMessage(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	id := q.Get("session_id")
+	template, err := intParam(r, "template_id", 0)
+	if err != nil || template == 0 {
+		return nil, &apiError{status: http.StatusBadRequest, code: "MissingParameter", message: "template_id is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seller := s.seller(r)
+	i := slices.IndexFunc(seller.sessions, func(v lazada.SessionListData) bool { return v.SessionID == id })
+	if i < 0 {
+		return nil, notFound("session " + id)
+	}
+	session := &seller.sessions[i]
+
+	content, _ := json.Marshal(map[string]string{"txt": q.Get("txt")})
+	now := time.Now().UnixMilli()
+	msg := lazada.MessagesListData{
+		FromAccountType: 2,
+		SessionID:       id,
+		MessageID:       strconv.FormatInt(s.newID(), 10),
+		Type:            strconv.Itoa(template),
+		Content:         string(content),
+		ToAccountID:     strconv.FormatInt(session.BuyerID, 10),
+		SendTime:        int(now),
+		ToAccountType:   1,
+		SiteID:          session.SiteID,
+		TemplateID:      template,
+		Status:          "0",
+	}
+	seller.messages[id] = append(seller.messages[id], msg)
+
+	session.LastMessageID = msg.MessageID
+	session.LastMessageTime = now
+	session.Summary = q.Get("txt")
+
+	resp := lazada.SendMessageResponse{}
+	resp.Data.MessageID = msg.MessageID
+	resp.Data.TemplateID = template
+	resp.Data.CurrentTime = now
+	return s.ok(resp.Data), nil
+}
+
+// getOrders pages the orders created from created_after, in the order they
+// were added.
+func (s *Lazada) getOrders(r *http.Request) (any, error) {
+	offset, err := intParam(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intParam(r, "limit", 100)
+	if err != nil {
+		return nil, err
+	}
+	var createdAfter time.Time
+	if v := r.URL.Query().Get("created_after"); v != "" {
+		if createdAfter, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, badParam("created_after")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []lazada.Orders
+	for _, o := range s.seller(r).orders {
+		if t, ok := parseLazadaTime(o.CreatedAt); ok && t.Before(createdAfter) {
+			continue
+		}
+		matched = append(matched, o)
+	}
+
+	orders := window(matched, offset, limit)
+	return s.ok(lazada.DataGetOrders{Count: len(orders), CountTotal: len(matched), Orders: orders}), nil
+}
+
+func (s *Lazada) getOrder(r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("order_id"), 10, 64)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, code: "MissingParameter", message: "order_id is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := s.seller(r).orders
+	i := slices.IndexFunc(orders, func(o lazada.Orders) bool { return o.OrderID == id })
+	if i < 0 {
+		return nil, notFound(fmt.Sprintf("order %d", id))
+	}
+	return s.ok(orders[i]), nil
+}
+
+func (s *Lazada) getOrderItems(r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("order_id"), 10, 64)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, code: "MissingParameter", message: "order_id is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, ok := s.seller(r).items[id]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("order %d", id))
+	}
+	return s.ok(items), nil
+}
+
+// getProducts pages the products, a filter other than all keeps the ones of
+// that status.
+func (s *Lazada) getProducts(r *http.Request) (any, error) {
+	offset, err := intParam(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intParam(r, "limit", 20)
+	if err != nil {
+		return nil, err
+	}
+	filter := r.URL.Query().Get("filter")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []lazada.Products
+	for _, p := range s.seller(r).products {
+		if filter == "" || filter == "all" || p.Status == filter {
+			matched = append(matched, p)
+		}
+	}
+	return s.ok(lazada.DataGetProducts{TotalProducts: len(matched), Products: window(matched, offset, limit)}), nil
+}
+
+// lazadaCursor reads the start_time, page_size and last id of a chat list.
+func lazadaCursor(r *http.Request, lastKey string) (start int64, size int, last string, err error) {
+	size, err = intParam(r, "page_size", 20)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if v := r.URL.Query().Get("start_time"); v != "" {
+		if start, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return 0, 0, "", badParam("start_time")
+		}
+	}
+	return start, size, r.URL.Query().Get(lastKey), nil
+}
+
+// after returns up to size items in (time, id) order from the cursor: from
+// start when last is empty, after the item (start, last) otherwise. It tells
+// whether more items follow.
+func after[T any](items []T, key func(T) (int64, string), start int64, last string, size int) ([]T, bool) {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		ta, ia := key(a)
+		tb, ib := key(b)
+		return cmp.Or(cmp.Compare(ta, tb), cmp.Compare(ia, ib))
+	})
+
+	out := sorted[:0]
+	for _, v := range sorted {
+		t, id := key(v)
+		if t > start || (t == start && (last == "" || id > last)) {
+			out = append(out, v)
+		}
+	}
+	if len(out) > size {
+		return out[:size], true
+	}
+	return out, false
+}
+
+// parseLazadaTime parses the times of Lazada orders, e.g.
+// 2024-01-02 15:04:05 +0700.
+func parseLazadaTime(v string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05 -0700", time.RFC3339} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
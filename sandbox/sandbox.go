@@ -0,0 +1,376 @@
+// Package sandbox runs fake marketplace APIs on local httptest servers, so
+// whole workflows can be tested without the network. A server checks each
+// request the way its marketplace does, signature and access token included,
+// answers from the shops, conversations, messages, orders and products added
+// to it, and fails requests with scripted faults.
+//
+//	s := sandbox.NewShopee(partnerID, partnerKey)
+//	defer s.Close()
+//
+//	s.AddShop(shopID, token, shopee.GetShopInfoResponse{ShopName: "shop"})
+//	s.Fail("/api/v2/shop/get_shop_info", sandbox.RateLimited(time.Second))
+//
+//	client := s.Client(shopee.WithRetry(2))
+//	info, err := client.Shop.GetShopInfo(shopID, token)
+//
+// Amounts are sent the way the marketplace sends them, numbers for Shopee and
+// Tokopedia and strings for Lazada and TikTok Shop.
+package sandbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault is a scripted failure, answered instead of a request.
+type Fault struct {
+	// Status is the HTTP status of the answer. With 200 the error comes in a
+	// successful response, as marketplaces often do.
+	Status int
+
+	// RetryAfter is sent in the Retry-After header when set, and in the
+	// header the marketplace uses for it.
+	RetryAfter time.Duration
+
+	// Body is sent as is, instead of the error body of the marketplace.
+	Body string
+}
+
+// RateLimited returns a 429 fault asking to retry after d.
+func RateLimited(d time.Duration) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfter: d}
+}
+
+// Unavailable returns a 503 fault.
+func Unavailable() Fault {
+	return Fault{Status: http.StatusServiceUnavailable}
+}
+
+// Request is a request received by a server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// apiError is an error answered by a marketplace. An empty code is filled in
+// by the marketplace from the status.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.code, e.message)
+}
+
+// marketplace is what a fake API plugs into server.
+type marketplace interface {
+	// authorize checks the signature and access token of r, failing with an
+	// *apiError.
+	authorize(r *http.Request, body []byte) error
+
+	// errorBody returns the body of e.
+	errorBody(e *apiError, requestID string) any
+
+	// retryAfter sets the wait of a throttled answer on h.
+	retryAfter(h http.Header, d time.Duration)
+}
+
+// handlerFunc answers a request with a value encoded as JSON or an error.
+type handlerFunc func(r *http.Request) (any, error)
+
+// server is the part common to the fake APIs.
+type server struct {
+	srv         *httptest.Server
+	mux         *http.ServeMux
+	marketplace marketplace
+
+	// amounts are the money.Money fields the marketplace sends bare
+	amounts amountFields
+
+	// mu guards the state of the marketplace too
+	mu       sync.Mutex
+	faults   map[string][]Fault
+	requests []Request
+	nextID   int64
+}
+
+func newServer(m marketplace, amounts amountFields) *server {
+	s := &server{
+		mux:         http.NewServeMux(),
+		marketplace: m,
+		amounts:     amounts,
+		faults:      map[string][]Fault{},
+		nextID:      1000,
+	}
+	s.handle("/", func(r *http.Request) (any, error) {
+		return nil, &apiError{status: http.StatusNotFound, message: "no such API " + r.URL.Path}
+	})
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *server) URL() string {
+	return s.srv.URL
+}
+
+// Close shuts the server down.
+func (s *server) Close() {
+	s.srv.Close()
+}
+
+// Fail answers the next requests to path with faults, one request each, in
+// order. The path is the one of the request, without the query.
+func (s *server) Fail(path string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[path] = append(s.faults[path], faults...)
+}
+
+// Requests returns the requests received so far, faulted ones included.
+func (s *server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	fault, faulted := s.takeFault(r.URL.Path)
+	s.mu.Unlock()
+
+	if faulted {
+		s.writeFault(w, fault)
+		return
+	}
+	if err := s.marketplace.authorize(r, body); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *server) takeFault(path string) (Fault, bool) {
+	faults := s.faults[path]
+	if len(faults) == 0 {
+		return Fault{}, false
+	}
+	s.faults[path] = faults[1:]
+	return faults[0], true
+}
+
+func (s *server) writeFault(w http.ResponseWriter, f Fault) {
+	if f.RetryAfter > 0 {
+		// whole seconds, rounded up so that a short wait is not sent as 0
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+		s.marketplace.retryAfter(w.Header(), f.RetryAfter)
+	}
+	if f.Body == "" {
+		s.writeError(w, &apiError{status: f.Status})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	io.WriteString(w, f.Body)
+}
+
+func (s *server) writeError(w http.ResponseWriter, err error) {
+	var e *apiError
+	if !errors.As(err, &e) {
+		e = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+	s.writeJSON(w, e.status, s.marketplace.errorBody(e, s.requestID()))
+}
+
+// handle routes pattern to h, patterns are the ones of http.ServeMux.
+func (s *server) handle(pattern string, h handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		v, err := h(r)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, v)
+	})
+}
+
+func (s *server) writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := s.encode(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// encode marshals v, sending the money.Money amounts of the SDK types the way
+// the marketplace does.
+func (s *server) encode(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(flattenAmounts(tree, s.amounts))
+}
+
+// amountFields are the JSON fields of a marketplace holding a bare amount,
+// sent as a string or as a number.
+type amountFields struct {
+	names     map[string]bool
+	asStrings bool
+}
+
+func newAmountFields(asStrings bool, names ...string) amountFields {
+	f := amountFields{names: map[string]bool{}, asStrings: asStrings}
+	for _, name := range names {
+		f.names[name] = true
+	}
+	return f
+}
+
+// flattenAmounts replaces the {"amount","currency"} objects of money.Money in
+// the fields of v named by amounts with their amount. Other objects are kept,
+// even with just these two fields, as TikTok Shop coupon amounts are sent.
+func flattenAmounts(v any, amounts amountFields) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if amount, ok := amountOf(e); ok && amounts.names[k] {
+				if amounts.asStrings {
+					v[k] = amount
+				} else {
+					v[k] = json.Number(amount)
+				}
+				continue
+			}
+			v[k] = flattenAmounts(e, amounts)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = flattenAmounts(e, amounts)
+		}
+	}
+	return v
+}
+
+// amountOf returns the amount of v when it is an encoded money.Money.
+func amountOf(v any) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 2 {
+		return "", false
+	}
+	amount, isAmount := m["amount"].(string)
+	_, hasCurrency := m["currency"].(string)
+	return amount, isAmount && hasCurrency
+}
+
+// newID returns a new id for a message or conversation, the caller holds mu.
+func (s *server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *server) requestID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requestIDLocked()
+}
+
+// requestIDLocked is requestID for a caller holding mu.
+func (s *server) requestIDLocked() string {
+	return fmt.Sprintf("sandbox-%d", s.newID())
+}
+
+// badParam returns the error of a missing or invalid request parameter.
+func badParam(name string) error {
+	return &apiError{status: http.StatusBadRequest, message: "invalid " + name}
+}
+
+// notFound returns the error of an unknown object.
+func notFound(what string) error {
+	return &apiError{status: http.StatusNotFound, message: what + " not found"}
+}
+
+// decodeBody decodes the JSON body of r into v.
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badParam("body: " + err.Error())
+	}
+	return nil
+}
+
+// intParam returns the query parameter key of r as an int, def when missing.
+func intParam(r *http.Request, key string, def int) (int, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, badParam(key)
+	}
+	return n, nil
+}
+
+// page returns the items of page number n of size, counted from 1.
+func page[T any](items []T, n, size int) []T {
+	if n < 1 || size < 1 {
+		return nil
+	}
+	return window(items, (n-1)*size, size)
+}
+
+// window returns at most size items from offset.
+func window[T any](items []T, offset, size int) []T {
+	if offset >= len(items) || offset < 0 {
+		return []T{}
+	}
+	return items[offset:min(offset+size, len(items))]
+}
+
+// withinSkew tells whether the unix time ts is close enough to now for a
+// signature to be accepted.
+func withinSkew(ts int64) bool {
+	d := time.Since(time.Unix(ts, 0))
+	return -5*time.Minute < d && d < 5*time.Minute
+}
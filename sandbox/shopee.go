@@ -0,0 +1,531 @@
+package sandbox
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+)
+
+// Shopee is a fake Shopee Open Platform v2 API. Requests are signed with the
+// partner key over partner_id, path, timestamp, access_token and shop_id and
+// must carry the token of their shop.
+type Shopee struct {
+	*server
+
+	partnerID  int
+	partnerKey string
+	shops      map[uint64]*shopeeShop
+}
+
+type shopeeShop struct {
+	token         string
+	info          shopee.GetShopInfoResponse
+	conversations []shopee.Conversation
+	messages      map[string][]shopee.Messages
+	orders        []shopee.OrderList
+	items         []shopee.ItemListData
+}
+
+// shopeeAmounts are the fields Shopee sends as bare numbers.
+var shopeeAmounts = newAmountFields(false,
+	"actual_shipping_fee", "amount_before_discount", "buyer_paid_shipping_fee",
+	"buyer_total_amount", "buyer_transaction_fee", "coins", "commission_fee",
+	"compensation_amount", "cost_of_goods_sold", "counter_offer_amount",
+	"cross_border_tax", "current_price", "discount_amount",
+	"discount_from_coin", "discount_from_voucher_seller",
+	"discount_from_voucher_shopee", "discounted_price", "drc_adjustable_refund",
+	"escrow_amount", "escrow_tax", "estimated_shipping_fee",
+	"final_escrow_product_gst", "final_escrow_shipping_gst",
+	"final_product_protection", "final_shipping_fee",
+	"inflated_price_of_current_price", "inflated_price_of_original_price",
+	"item_price", "latest_offer_amount", "max_price", "max_refundable_amount",
+	"min_basket_price", "model_discounted_price", "model_original_price",
+	"order_ams_commission_fee", "original_cost_of_goods_sold", "original_price",
+	"original_shopee_discount", "payment_promotion", "refund_amount",
+	"reverse_shipping_fee", "seller_coin_cash_back", "seller_discount",
+	"seller_lost_compensation", "seller_return_refund",
+	"seller_shipping_discount", "seller_transaction_fee", "selling_price",
+	"service_fee", "shipping_fee_discount_from_3pl", "shopee_discount",
+	"shopee_shipping_rebate", "sip_item_price", "total_amount",
+	"voucher_from_seller", "voucher_from_shopee",
+)
+
+// NewShopee starts a fake Shopee API for the partner.
+func NewShopee(partnerID int, partnerKey string) *Shopee {
+	s := &Shopee{partnerID: partnerID, partnerKey: partnerKey, shops: map[uint64]*shopeeShop{}}
+	s.server = newServer(s, shopeeAmounts)
+
+	s.handle("GET /api/v2/shop/get_shop_info", s.getShopInfo)
+	s.handle("GET /api/v2/sellerchat/get_conversation_list", s.getConversationList)
+	s.handle("GET /api/v2/sellerchat/get_one_conversation", s.getOneConversation)
+	s.handle("GET /api/v2/sellerchat/get_message", s.getMessage)
+	s.handle("POST /api/v2/sellerchat/send_message", s.sendMessage)
+	s.handle("GET /api/v2/order/get_order_list", s.getOrderList)
+	s.handle("GET /api/v2/order/get_order_detail", s.getOrderDetail)
+	s.handle("GET /api/v2/product/get_item_list", s.getItemList)
+	s.handle("GET /api/v2/product/get_item_base_info", s.getItemBaseInfo)
+	return s
+}
+
+// Client returns a client of the API.
+func (s *Shopee) Client(opts ...shopee.Option) *shopee.ShopeeClient {
+	return shopee.NewClient(shopee.AppConfig{
+		PartnerID:  s.partnerID,
+		PartnerKey: s.partnerKey,
+		APIURL:     s.URL(),
+	}, opts...)
+}
+
+// ErrorFault returns a fault answering the Shopee error code with a 200
+// status, e.g. error_rate_limit.
+func (s *Shopee) ErrorFault(code, message string) Fault {
+	b, _ := json.Marshal(shopee.BaseResponse{Error: code, Message: message, RequestID: s.requestID()})
+	return Fault{Status: http.StatusOK, Body: string(b)}
+}
+
+// AddShop adds a shop authorized with token.
+func (s *Shopee) AddShop(shopID uint64, token string, info shopee.GetShopInfoResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shops[shopID] = &shopeeShop{token: token, info: info, messages: map[string][]shopee.Messages{}}
+}
+
+// AddConversation adds a conversation to the shop.
+func (s *Shopee) AddConversation(shopID uint64, c shopee.Conversation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.conversations = append(shop.conversations, c)
+}
+
+// AddMessage adds a message to its conversation in the shop.
+func (s *Shopee) AddMessage(shopID uint64, m shopee.Messages) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.messages[m.ConversationID] = append(shop.messages[m.ConversationID], m)
+}
+
+// AddOrder adds an order to the shop.
+func (s *Shopee) AddOrder(shopID uint64, o shopee.OrderList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.orders = append(shop.orders, o)
+}
+
+// AddItem adds a product to the shop.
+func (s *Shopee) AddItem(shopID uint64, item shopee.ItemListData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.items = append(shop.items, item)
+}
+
+// Messages returns the messages of a conversation of the shop, sent ones
+// included.
+func (s *Shopee) Messages(shopID uint64, conversationID string) []shopee.Messages {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mustShop(shopID).messages[conversationID])
+}
+
+func (s *Shopee) mustShop(shopID uint64) *shopeeShop {
+	shop, ok := s.shops[shopID]
+	if !ok {
+		panic(fmt.Sprintf("sandbox: unknown shopee shop %d, add it with AddShop", shopID))
+	}
+	return shop
+}
+
+// shop returns the shop of an authorized request, the caller holds mu.
+func (s *Shopee) shop(r *http.Request) *shopeeShop {
+	id, _ := strconv.ParseUint(r.URL.Query().Get("shop_id"), 10, 64)
+	return s.shops[id]
+}
+
+func (s *Shopee) authorize(r *http.Request, body []byte) error {
+	q := r.URL.Query()
+	if q.Get("partner_id") != strconv.Itoa(s.partnerID) {
+		return &apiError{status: http.StatusForbidden, code: "error_param", message: "Invalid partner_id."}
+	}
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil || !withinSkew(ts) {
+		return &apiError{status: http.StatusForbidden, code: "error_param", message: "Invalid timestamp."}
+	}
+
+	base := fmt.Sprintf("%d%s%d", s.partnerID, r.URL.Path, ts)
+	shopID := q.Get("shop_id")
+	if shopID != "" {
+		base += q.Get("access_token") + shopID
+	}
+	mac := hmac.New(sha256.New, []byte(s.partnerKey))
+	mac.Write([]byte(base))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(q.Get("sign"))) {
+		return &apiError{status: http.StatusForbidden, code: "error_sign", message: "Wrong sign."}
+	}
+
+	if shopID == "" {
+		return &apiError{status: http.StatusForbidden, code: "error_param", message: "shop_id is required."}
+	}
+	s.mu.Lock()
+	shop := s.shop(r)
+	s.mu.Unlock()
+	if shop == nil || shop.token != q.Get("access_token") {
+		return &apiError{status: http.StatusForbidden, code: "error_auth", message: "Invalid access_token."}
+	}
+
+	if r.Method == http.MethodPost {
+		var partner struct {
+			PartnerID int `json:"partner_id"`
+		}
+		if json.Unmarshal(body, &partner) != nil || partner.PartnerID != s.partnerID {
+			return &apiError{status: http.StatusBadRequest, code: "error_param", message: "Invalid partner_id in body."}
+		}
+	}
+	return nil
+}
+
+func (s *Shopee) errorBody(e *apiError, requestID string) any {
+	code := e.code
+	if code == "" {
+		switch e.status {
+		case http.StatusTooManyRequests:
+			code = "error_rate_limit"
+		case http.StatusBadRequest:
+			code = "error_param"
+		case http.StatusNotFound:
+			code = "error_not_found"
+		default:
+			code = "error_server"
+		}
+	}
+	message := e.message
+	if message == "" {
+		message = http.StatusText(e.status)
+	}
+	return shopee.BaseResponse{Error: code, Message: message, RequestID: requestID}
+}
+
+func (s *Shopee) retryAfter(h http.Header, d time.Duration) {}
+
+func (s *Shopee) getShopInfo(r *http.Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info := s.shop(r).info
+	info.RequestID = s.requestIDLocked()
+	return info, nil
+}
+
+// getConversationList pages the conversations from the latest message,
+// next_timestamp_nano picks up before the last one of the previous page.
+func (s *Shopee) getConversationList(r *http.Request) (any, error) {
+	size, err := intParam(r, "page_size", 25)
+	if err != nil {
+		return nil, err
+	}
+	before, err := strconv.ParseInt(r.URL.Query().Get("next_timestamp_nano"), 10, 64)
+	if err != nil && r.URL.Query().Has("next_timestamp_nano") {
+		return nil, badParam("next_timestamp_nano")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conversations := slices.Clone(s.shop(r).conversations)
+	slices.SortStableFunc(conversations, func(a, b shopee.Conversation) int {
+		return -cmp.Compare(a.LastMessageTimestamp, b.LastMessageTimestamp)
+	})
+	if before != 0 {
+		conversations = slices.DeleteFunc(conversations, func(c shopee.Conversation) bool {
+			return c.LastMessageTimestamp >= before
+		})
+	}
+
+	resp := shopee.GetConversationResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.ConversationsList = window(conversations, 0, size)
+	resp.Response.PageResult.PageSize = size
+	if len(conversations) > size {
+		last := conversations[size-1]
+		resp.Response.PageResult.More = true
+		resp.Response.PageResult.NextCursor.NextMessageTimeNano = strconv.FormatInt(last.LastMessageTimestamp, 10)
+		resp.Response.PageResult.NextCursor.ConversationID = last.ConversationID
+	}
+	return resp, nil
+}
+
+func (s *Shopee) getOneConversation(r *http.Request) (any, error) {
+	id := r.URL.Query().Get("conversation_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop := s.shop(r)
+	i := slices.IndexFunc(shop.conversations, func(c shopee.Conversation) bool { return c.ConversationID == id })
+	if i < 0 {
+		return nil, notFound("conversation " + id)
+	}
+	resp := shopee.GetDetailConversation{Response: shop.conversations[i]}
+	resp.RequestID = s.requestIDLocked()
+	return resp, nil
+}
+
+// getMessage pages the messages of a conversation in the order they were
+// added, offset is the index of the first one.
+func (s *Shopee) getMessage(r *http.Request) (any, error) {
+	id := r.URL.Query().Get("conversation_id")
+	if id == "" {
+		return nil, badParam("conversation_id")
+	}
+	size, err := intParam(r, "page_size", 25)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := intParam(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := s.shop(r).messages[id]
+	resp := shopee.GetMessageResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.MessagesList = window(messages, offset, size)
+	resp.Response.PageResult.PageSize = size
+	if offset+size < len(messages) {
+		resp.Response.PageResult.NextOffset = strconv.Itoa(offset + size)
+	}
+	return resp, nil
+}
+
+// sendMessage adds the message to the conversation with the buyer, which is
+// started when there is none.
+func (s *Shopee) sendMessage(r *http.Request) (any, error) {
+	var req shopee.SendMessageRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	toID, err := req.ToID.Int64()
+	if err != nil || toID == 0 {
+		return nil, badParam("to_id")
+	}
+	if req.MessageType == "" {
+		return nil, badParam("message_type")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop := s.shop(r)
+	shopID, _ := strconv.ParseInt(r.URL.Query().Get("shop_id"), 10, 64)
+	i := slices.IndexFunc(shop.conversations, func(c shopee.Conversation) bool { return int64(c.ToID) == toID })
+	if i < 0 {
+		shop.conversations = append(shop.conversations, shopee.Conversation{
+			ConversationID: strconv.FormatInt(s.newID(), 10),
+			ToID:           int(toID),
+			ShopID:         int(shopID),
+		})
+		i = len(shop.conversations) - 1
+	}
+	conversation := &shop.conversations[i]
+
+	now := time.Now()
+	msg := shopee.Messages{
+		MessageID:        strconv.FormatInt(s.newID(), 10),
+		MessageType:      req.MessageType,
+		FromID:           shopID,
+		FromShopID:       shopID,
+		ToID:             toID,
+		ConversationID:   conversation.ConversationID,
+		CreatedTimeStamp: now.Unix(),
+		Status:           "normal",
+		Content: shopee.ContentMessage{
+			Text:             req.Content.Text,
+			StickerID:        req.Content.StickerID,
+			StickerPackageID: req.Content.StickerPackageID,
+			ImageURL:         req.Content.ImageURL,
+			VideoURL:         req.Content.VideoURL,
+			OrderSN:          req.Content.OrderSN,
+		},
+	}
+	shop.messages[conversation.ConversationID] = append(shop.messages[conversation.ConversationID], msg)
+
+	conversation.LatestMessageID = msg.MessageID
+	conversation.LatestMessageType = msg.MessageType
+	conversation.LatestMessageContent.Text = msg.Content.Text
+	conversation.LatestMessageFromID = int(shopID)
+	conversation.LastMessageTimestamp = now.UnixNano()
+
+	conversationID, _ := strconv.ParseInt(conversation.ConversationID, 10, 64)
+	resp := shopee.GetSendMessageResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.MessageID = msg.MessageID
+	resp.Response.ToID = int(toID)
+	resp.Response.MessageType = msg.MessageType
+	resp.Response.Content.Text = msg.Content.Text
+	resp.Response.ConversationID = conversationID
+	resp.Response.CreatedTimestamp = int(msg.CreatedTimeStamp)
+	return resp, nil
+}
+
+// getOrderList pages the orders created, or updated, in the time range in
+// the order they were added. The cursor is the index of the first one.
+func (s *Shopee) getOrderList(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	field := q.Get("time_range_field")
+	if field != "create_time" && field != "update_time" {
+		return nil, badParam("time_range_field")
+	}
+	from, err := intParam(r, "time_from", 0)
+	if err != nil {
+		return nil, err
+	}
+	to, err := intParam(r, "time_to", 0)
+	if err != nil {
+		return nil, err
+	}
+	size, err := intParam(r, "page_size", 20)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := intParam(r, "cursor", 0)
+	if err != nil {
+		return nil, err
+	}
+	status := q.Get("order_status")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []shopee.OrderSNList
+	for _, o := range s.shop(r).orders {
+		t := o.CreateTime
+		if field == "update_time" {
+			t = o.UpdateTime
+		}
+		if t < from || t > to || (status != "" && o.OrderStatus != status) {
+			continue
+		}
+		matched = append(matched, shopee.OrderSNList{OrderSn: o.OrderSn})
+	}
+
+	resp := shopee.GetListOrderResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.OrderList = window(matched, cursor, size)
+	if cursor+size < len(matched) {
+		resp.Response.More = true
+		resp.Response.NextCursor = strconv.Itoa(cursor + size)
+	}
+	return resp, nil
+}
+
+func (s *Shopee) getOrderDetail(r *http.Request) (any, error) {
+	sns := splitList(r.URL.Query()["order_sn_list"])
+	if len(sns) == 0 {
+		return nil, badParam("order_sn_list")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := shopee.GetOrderDetailResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.OrderListResponse.OrderList = []shopee.OrderList{}
+	for _, o := range s.shop(r).orders {
+		if slices.Contains(sns, o.OrderSn) {
+			resp.OrderListResponse.OrderList = append(resp.OrderListResponse.OrderList, o)
+		}
+	}
+	return resp, nil
+}
+
+func (s *Shopee) getItemList(r *http.Request) (any, error) {
+	offset, err := intParam(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	size, err := intParam(r, "page_size", 10)
+	if err != nil {
+		return nil, err
+	}
+	statuses := splitList(r.URL.Query()["item_status"])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []shopee.ItemProductList
+	for _, item := range s.shop(r).items {
+		if len(statuses) > 0 && !slices.Contains(statuses, item.ItemStatus) {
+			continue
+		}
+		matched = append(matched, shopee.ItemProductList{
+			ItemID:     item.ItemID,
+			ItemStatus: item.ItemStatus,
+			UpdateTime: int(item.UpdateTime),
+		})
+	}
+
+	resp := shopee.GetProductListResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.Item = window(matched, offset, size)
+	resp.Response.TotalCount = len(matched)
+	if offset+size < len(matched) {
+		resp.Response.HasNextPage = true
+		resp.Response.NextOffset = offset + size
+	}
+	return resp, nil
+}
+
+func (s *Shopee) getItemBaseInfo(r *http.Request) (any, error) {
+	var ids []int64
+	for _, v := range splitList(r.URL.Query()["item_id_list"]) {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, badParam("item_id_list")
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, badParam("item_id_list")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := shopee.GetProductResponse{}
+	resp.RequestID = s.requestIDLocked()
+	resp.Response.ItemList = []shopee.ItemListData{}
+	for _, item := range s.shop(r).items {
+		if slices.Contains(ids, item.ItemID) {
+			resp.Response.ItemList = append(resp.Response.ItemList, item)
+		}
+	}
+	return resp, nil
+}
+
+// splitList returns the values of a list parameter, sent repeated or comma
+// separated.
+func splitList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
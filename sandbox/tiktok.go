@@ -0,0 +1,532 @@
+package sandbox
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+)
+
+// TikTokVersion is the API version served by TikTok, any version is
+// accepted in paths.
+const TikTokVersion = "202309"
+
+// Error codes of TikTok answered by the sandbox.
+const (
+	tiktokInvalidParam    = 36009003
+	tiktokTooManyRequests = 36009004
+	tiktokInternalError   = 36009005
+	tiktokInvalidToken    = 105001
+	tiktokInvalidSign     = 106001
+	tiktokInvalidAppKey   = 106002
+)
+
+// TikTok is a fake TikTok Shop API. Requests are signed with the app secret
+// over the path, the sorted query and the JSON body, and carry the access
+// token of the shop given by shop_cipher in x-tts-access-token.
+type TikTok struct {
+	*server
+
+	appKey string
+	secret string
+	shops  map[string]*tiktokShop
+}
+
+type tiktokShop struct {
+	token         string
+	info          tiktok.Shops
+	conversations []tiktok.Conversations
+	messages      map[string][]tiktok.MessagesConversation
+	orders        []tiktok.Order
+	products      []tiktok.ProductData
+}
+
+// tiktokAmounts are the fields TikTok Shop sends as bare strings. Coupon
+// amounts such as reduction_amount are objects with a currency and stay so.
+var tiktokAmounts = newAmountFields(true,
+	"original_price", "original_shipping_fee", "original_total_product_price",
+	"platform_discount", "product_tax", "refund_shipping_fee",
+	"refund_subtotal", "refund_total", "retail_delivery_fee", "sale_price",
+	"seller_discount", "shipping_fee", "shipping_fee_platform_discount",
+	"shipping_fee_seller_discount", "shipping_fee_tax", "small_order_fee",
+	"sub_total", "tax", "tax_amount", "tax_exclusive_price", "total_amount",
+)
+
+// NewTikTok starts a fake TikTok Shop API for the app.
+func NewTikTok(appKey, secret string) *TikTok {
+	s := &TikTok{appKey: appKey, secret: secret, shops: map[string]*tiktokShop{}}
+	s.server = newServer(s, tiktokAmounts)
+
+	s.handle("GET /authorization/{version}/shops", s.getShops)
+	s.handle("GET /customer_service/{version}/conversations", s.getConversations)
+	s.handle("GET /customer_service/{version}/conversations/{id}/messages", s.getMessages)
+	s.handle("POST /customer_service/{version}/conversations/{id}/messages", s.sendMessage)
+	s.handle("GET /order/{version}/orders", s.getOrders)
+	s.handle("POST /order/{version}/orders/search", s.searchOrders)
+	s.handle("GET /product/{version}/products/{id}", s.getProduct)
+	s.handle("POST /product/{version}/products/search", s.searchProducts)
+	return s
+}
+
+// Client returns a client of the API at TikTokVersion.
+func (s *TikTok) Client(opts ...tiktok.Option) *tiktok.TiktokClient {
+	return tiktok.NewClient(tiktok.AppConfig{
+		AppKey:    s.appKey,
+		AppSecret: s.secret,
+		APIURL:    s.URL(),
+		Version:   TikTokVersion,
+	}, opts...)
+}
+
+// ErrorFault returns a fault answering the TikTok error code with a 200
+// status, e.g. 36009004 for throttling.
+func (s *TikTok) ErrorFault(code int, message string) Fault {
+	b, _ := s.encode(tiktok.BaseResponse{Code: code, Message: message, RequestID: s.requestID()})
+	return Fault{Status: http.StatusOK, Body: string(b)}
+}
+
+// AddShop adds a shop, known by its cipher, authorized with token.
+func (s *TikTok) AddShop(token string, info tiktok.Shops) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shops[info.Cipher] = &tiktokShop{
+		token:    token,
+		info:     info,
+		messages: map[string][]tiktok.MessagesConversation{},
+	}
+}
+
+// AddConversation adds a conversation to the shop.
+func (s *TikTok) AddConversation(cipher string, c tiktok.Conversations) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(cipher)
+	shop.conversations = append(shop.conversations, c)
+}
+
+// AddMessage adds a message to a conversation of the shop.
+func (s *TikTok) AddMessage(cipher, conversationID string, m tiktok.MessagesConversation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(cipher)
+	shop.messages[conversationID] = append(shop.messages[conversationID], m)
+}
+
+// AddOrder adds an order to the shop.
+func (s *TikTok) AddOrder(cipher string, o tiktok.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(cipher)
+	shop.orders = append(shop.orders, o)
+}
+
+// AddProduct adds a product to the shop.
+func (s *TikTok) AddProduct(cipher string, p tiktok.ProductData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(cipher)
+	shop.products = append(shop.products, p)
+}
+
+// Messages returns the messages of a conversation of the shop, sent ones
+// included.
+func (s *TikTok) Messages(cipher, conversationID string) []tiktok.MessagesConversation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mustShop(cipher).messages[conversationID])
+}
+
+func (s *TikTok) mustShop(cipher string) *tiktokShop {
+	shop, ok := s.shops[cipher]
+	if !ok {
+		panic("sandbox: unknown tiktok shop " + cipher + ", add it with AddShop")
+	}
+	return shop
+}
+
+// shop returns the shop of an authorized request, the caller holds mu.
+func (s *TikTok) shop(r *http.Request) *tiktokShop {
+	return s.shops[r.URL.Query().Get("shop_cipher")]
+}
+
+func (s *TikTok) authorize(r *http.Request, body []byte) error {
+	q := r.URL.Query()
+	if q.Get("app_key") != s.appKey {
+		return &apiError{status: http.StatusUnauthorized, code: strconv.Itoa(tiktokInvalidAppKey), message: "Invalid app_key"}
+	}
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil || !withinSkew(ts) {
+		return &apiError{status: http.StatusUnauthorized, code: strconv.Itoa(tiktokInvalidSign), message: "Invalid timestamp"}
+	}
+	if !hmac.Equal([]byte(s.sign(r, body)), []byte(q.Get("sign"))) {
+		return &apiError{status: http.StatusUnauthorized, code: strconv.Itoa(tiktokInvalidSign), message: "Invalid sign"}
+	}
+
+	token := r.Header.Get("x-tts-access-token")
+	invalidToken := &apiError{status: http.StatusUnauthorized, code: strconv.Itoa(tiktokInvalidToken), message: "Invalid access_token"}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the shops of a token are listed without a cipher
+	if strings.HasPrefix(r.URL.Path, "/authorization/") {
+		if len(s.shopsOf(token)) == 0 {
+			return invalidToken
+		}
+		return nil
+	}
+
+	if q.Get("shop_cipher") == "" {
+		return &apiError{status: http.StatusBadRequest, message: "shop_cipher is required"}
+	}
+	shop := s.shop(r)
+	if shop == nil || token == "" || shop.token != token {
+		return invalidToken
+	}
+	return nil
+}
+
+// sign is the signature of TikTok Shop, the HMAC-SHA256 of the path, the
+// sorted query as key and value and the body, wrapped in the app secret.
+func (s *TikTok) sign(r *http.Request, body []byte) string {
+	q := r.URL.Query()
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		if k != "sign" && k != "access_token" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var input strings.Builder
+	input.WriteString(s.secret)
+	input.WriteString(r.URL.Path)
+	for _, k := range keys {
+		input.WriteString(k)
+		input.WriteString(q.Get(k))
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "multipart/form-data" {
+		input.Write(body)
+	}
+	input.WriteString(s.secret)
+
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(input.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// shopsOf returns the shops authorized with token, the caller holds mu.
+func (s *TikTok) shopsOf(token string) []tiktok.Shops {
+	var shops []tiktok.Shops
+	for _, shop := range s.shops {
+		if token != "" && shop.token == token {
+			shops = append(shops, shop.info)
+		}
+	}
+	slices.SortFunc(shops, func(a, b tiktok.Shops) int { return cmp.Compare(a.Cipher, b.Cipher) })
+	return shops
+}
+
+func (s *TikTok) errorBody(e *apiError, requestID string) any {
+	code, err := strconv.Atoi(e.code)
+	if err != nil {
+		switch e.status {
+		case http.StatusTooManyRequests:
+			code = tiktokTooManyRequests
+		case http.StatusBadRequest, http.StatusNotFound:
+			code = tiktokInvalidParam
+		default:
+			code = tiktokInternalError
+		}
+	}
+	message := e.message
+	if message == "" {
+		message = http.StatusText(e.status)
+	}
+	return tiktok.BaseResponse{Code: code, Message: message, RequestID: requestID}
+}
+
+func (s *TikTok) retryAfter(h http.Header, d time.Duration) {}
+
+// ok wraps data in a successful response, the caller holds mu.
+func (s *TikTok) ok(data any) any {
+	return struct {
+		tiktok.BaseResponse
+		Data any `json:"data"`
+	}{tiktok.BaseResponse{Message: "Success", RequestID: s.requestIDLocked()}, data}
+}
+
+func (s *TikTok) getShops(r *http.Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ok(tiktok.DataShops{Shops: s.shopsOf(r.Header.Get("x-tts-access-token"))}), nil
+}
+
+// getConversations pages the conversations of the shop, the latest message
+// first.
+func (s *TikTok) getConversations(r *http.Request) (any, error) {
+	size, err := intParam(r, "page_size", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conversations := slices.Clone(s.shop(r).conversations)
+	slices.SortStableFunc(conversations, func(a, b tiktok.Conversations) int {
+		return cmp.Compare(latestMessageTime(b), latestMessageTime(a))
+	})
+
+	items, next, err := tokenPage(conversations, r.URL.Query().Get("page_token"), size)
+	if err != nil {
+		return nil, err
+	}
+	return s.ok(tiktok.DataGetConversations{Conversations: items, NextPageToken: next}), nil
+}
+
+func latestMessageTime(c tiktok.Conversations) int {
+	if c.LatestMessage == nil {
+		return c.CreateTime
+	}
+	return c.LatestMessage.CreateTime
+}
+
+// getMessages pages the messages of a conversation by create time, the
+// latest first unless sort_order is ASC.
+func (s *TikTok) getMessages(r *http.Request) (any, error) {
+	size, err := intParam(r, "page_size", 10)
+	if err != nil {
+		return nil, err
+	}
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop := s.shop(r)
+	if !slices.ContainsFunc(shop.conversations, func(c tiktok.Conversations) bool { return c.ID == id }) {
+		return nil, notFound("conversation " + id)
+	}
+
+	messages := slices.Clone(shop.messages[id])
+	asc := strings.EqualFold(r.URL.Query().Get("sort_order"), "ASC")
+	slices.SortStableFunc(messages, func(a, b tiktok.MessagesConversation) int {
+		if asc {
+			return cmp.Compare(a.CreateTime, b.CreateTime)
+		}
+		return cmp.Compare(b.CreateTime, a.CreateTime)
+	})
+
+	items, next, err := tokenPage(messages, r.URL.Query().Get("page_token"), size)
+	if err != nil {
+		return nil, err
+	}
+	return s.ok(tiktok.DataConversationMessages{Messages: items, NextPageToken: next}), nil
+}
+
+// sendMessage adds a message from the shop to the conversation.
+func (s *TikTok) sendMessage(r *http.Request) (any, error) {
+	var body tiktok.SendMessageToConversationIDReq
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	if body.TypeMessage == "" || body.Content == "" {
+		return nil, badParam("type or content")
+	}
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop := s.shop(r)
+	i := slices.IndexFunc(shop.conversations, func(c tiktok.Conversations) bool { return c.ID == id })
+	if i < 0 {
+		return nil, notFound("conversation " + id)
+	}
+	conversation := &shop.conversations[i]
+	if !conversation.CanSendMessage {
+		return nil, &apiError{status: http.StatusBadRequest, message: "the conversation does not accept messages"}
+	}
+
+	sender := &tiktok.Sender{ImUserID: shop.info.ID, Nickname: shop.info.Name, Role: "SHOP"}
+	msg := tiktok.MessagesConversation{
+		Content:    body.Content,
+		CreateTime: int(time.Now().Unix()),
+		ID:         strconv.FormatInt(s.newID(), 10),
+		IsVisible:  true,
+		Sender:     sender,
+		Type:       body.TypeMessage,
+	}
+	shop.messages[id] = append(shop.messages[id], msg)
+
+	latest := tiktok.LatestMessage(msg)
+	conversation.LatestMessage = &latest
+
+	return s.ok(tiktok.DataSendMsgResponse{MessageID: msg.ID}), nil
+}
+
+// getOrders returns the orders of ids, separated by commas or repeated.
+func (s *TikTok) getOrders(r *http.Request) (any, error) {
+	ids := splitList(r.URL.Query()["ids"])
+	if len(ids) == 0 {
+		return nil, badParam("ids")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := []tiktok.Order{}
+	for _, o := range s.shop(r).orders {
+		if slices.Contains(ids, o.ID) {
+			orders = append(orders, o)
+		}
+	}
+	return s.ok(tiktok.OrderData{Orders: orders}), nil
+}
+
+// searchOrders pages the orders matching the body, sorted by sort_field,
+// the latest first unless sort_order is ASC.
+func (s *TikTok) searchOrders(r *http.Request) (any, error) {
+	var body tiktok.SearchOrdersBody
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	size, err := intParam(r, "page_size", 20)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+	field := q.Get("sort_field")
+	if field != "" && field != "create_time" && field != "update_time" {
+		return nil, badParam("sort_field")
+	}
+	asc := strings.EqualFold(q.Get("sort_order"), "ASC")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var orders []tiktok.Order
+	for _, o := range s.shop(r).orders {
+		if body.OrderStatus != "" && o.Status != body.OrderStatus ||
+			!inRange(o.CreateTime, body.CreateTimeGe, body.CreateTimeLt) ||
+			!inRange(o.UpdateTime, body.UpdateTimeGe, body.UpdateTimeLt) {
+			continue
+		}
+		orders = append(orders, o)
+	}
+	slices.SortStableFunc(orders, func(a, b tiktok.Order) int {
+		ta, tb := a.CreateTime, b.CreateTime
+		if field == "update_time" {
+			ta, tb = a.UpdateTime, b.UpdateTime
+		}
+		if asc {
+			return cmp.Compare(ta, tb)
+		}
+		return cmp.Compare(tb, ta)
+	})
+
+	items, next, err := tokenPage(orders, q.Get("page_token"), size)
+	if err != nil {
+		return nil, err
+	}
+	return s.ok(tiktok.SearchOrdersData{NextPageToken: next, TotalCount: len(orders), Orders: items}), nil
+}
+
+func (s *TikTok) getProduct(r *http.Request) (any, error) {
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	products := s.shop(r).products
+	i := slices.IndexFunc(products, func(p tiktok.ProductData) bool { return p.ID == id })
+	if i < 0 {
+		return nil, notFound("product " + id)
+	}
+	return s.ok(products[i]), nil
+}
+
+// searchProducts pages the products matching the body in the order they
+// were added.
+func (s *TikTok) searchProducts(r *http.Request) (any, error) {
+	var body tiktok.SearchProductsBody
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	size, err := intParam(r, "page_size", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var products []tiktok.ProductData
+	for _, p := range s.shop(r).products {
+		if body.Status != "" && p.Status != body.Status ||
+			!inRange(p.CreateTime, body.CreateTimeGe, through(body.CreateTimeLe)) ||
+			!inRange(p.UpdateTime, body.UpdateTimeGe, through(body.UpdateTimeLe)) ||
+			len(body.SellerSkus) > 0 && !slices.ContainsFunc(p.Skus, func(sku tiktok.Skus) bool {
+				return slices.Contains(body.SellerSkus, sku.SellerSku)
+			}) {
+			continue
+		}
+		products = append(products, p)
+	}
+
+	items, next, err := tokenPage(products, r.URL.Query().Get("page_token"), size)
+	if err != nil {
+		return nil, err
+	}
+
+	var data tiktok.SearchProductsResponse
+	data.Data.NextPageToken = next
+	data.Data.TotalCount = len(products)
+	data.Data.Products = items
+	return s.ok(data.Data), nil
+}
+
+// inRange tells whether ge <= t < lt, a zero bound is not checked.
+func inRange(t, ge, lt int64) bool {
+	return (ge == 0 || t >= ge) && (lt == 0 || t < lt)
+}
+
+// through returns the exclusive bound of the inclusive bound le.
+func through(le int64) int64 {
+	if le == 0 {
+		return 0
+	}
+	return le + 1
+}
+
+// tokenPage returns size items from the page token, the offset of the page
+// as a string, and the token of the next page, empty after the last one.
+func tokenPage[T any](items []T, token string, size int) ([]T, string, error) {
+	offset := 0
+	if token != "" {
+		var err error
+		if offset, err = strconv.Atoi(token); err != nil || offset < 0 {
+			return nil, "", badParam("page_token")
+		}
+	}
+	if size < 1 {
+		return nil, "", badParam("page_size")
+	}
+
+	out := window(items, offset, size)
+	next := ""
+	if offset+size < len(items) {
+		next = strconv.Itoa(offset + size)
+	}
+	return out, next, nil
+}
@@ -0,0 +1,459 @@
+package sandbox
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+)
+
+// Tokopedia is a fake Tokopedia API of a fulfillment service (fs). Requests
+// carry the access token of the app as a bearer token, which gives access
+// to the shops added with it.
+type Tokopedia struct {
+	*server
+
+	fsID  int
+	shops map[int]*tokopediaShop
+}
+
+type tokopediaShop struct {
+	token    string
+	info     tokopedia.ShopData
+	messages []tokopedia.MessageData
+	replies  map[int][]tokopedia.ReplyData
+	orders   []tokopedia.Order
+	details  []tokopedia.OrderDetail
+	products []tokopedia.ProductData
+}
+
+// tokopediaResponse is the envelope of Tokopedia responses.
+type tokopediaResponse struct {
+	Header tokopediaHeader `json:"header"`
+	Data   any             `json:"data"`
+}
+
+// tokopediaHeader is the header of Tokopedia responses, a reason tells an
+// error.
+type tokopediaHeader struct {
+	ProcessTime float64 `json:"process_time"`
+	Messages    string  `json:"messages"`
+	Reason      string  `json:"reason,omitempty"`
+	ErrorCode   string  `json:"error_code,omitempty"`
+}
+
+// tokopediaAmounts are the fields Tokopedia sends as bare numbers.
+var tokopediaAmounts = newAmountFields(false,
+	"discount_amount", "idr", "insurance_cost", "insurance_price", "item_price",
+	"normal_price", "open_amt", "price", "product_price", "shipping_cost",
+	"shipping_price", "subtotal_price", "toppoints_amount", "total_price",
+	"ttl_amount", "ttl_product_price", "value", "voucher_amount",
+)
+
+// NewTokopedia starts a fake Tokopedia API for the fs.
+func NewTokopedia(fsID int) *Tokopedia {
+	s := &Tokopedia{fsID: fsID, shops: map[int]*tokopediaShop{}}
+	s.server = newServer(s, tokopediaAmounts)
+
+	s.handle("GET /v1/chat/fs/{fs}/messages", s.getMessages)
+	s.handle("GET /v1/chat/fs/{fs}/messages/{msg}/replies", s.getReplies)
+	s.handle("POST /v1/chat/fs/{fs}/messages/{msg}/reply", s.sendMessage)
+	s.handle("GET /v2/order/list", s.getOrders)
+	s.handle("GET /v2/fs/{fs}/order", s.getOrder)
+	s.handle("GET /inventory/v1/fs/{fs}/product/info", s.getProductInfo)
+	s.handle("GET /v1/shop/fs/{fs}/shop-info", s.getShopInfo)
+	return s
+}
+
+// Client returns a client of the API for the fs.
+func (s *Tokopedia) Client(opts ...tokopedia.Option) *tokopedia.TokopediaClient {
+	return tokopedia.NewClient(tokopedia.AppConfig{
+		FsID:   s.fsID,
+		APIURL: s.URL(),
+	}, opts...)
+}
+
+// ErrorFault returns a fault answering the error with a 200 status, the
+// reason is what makes the client fail.
+func (s *Tokopedia) ErrorFault(code, reason string) Fault {
+	b, _ := s.encode(s.errorBody(&apiError{code: code, message: reason}, ""))
+	return Fault{Status: http.StatusOK, Body: string(b)}
+}
+
+// AddShop adds a shop, known by its ShopID, the access token gives access
+// to.
+func (s *Tokopedia) AddShop(token string, info tokopedia.ShopData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shops[info.ShopID] = &tokopediaShop{
+		token:   token,
+		info:    info,
+		replies: map[int][]tokopedia.ReplyData{},
+	}
+}
+
+// AddMessage adds a chat to the shop.
+func (s *Tokopedia) AddMessage(shopID int, m tokopedia.MessageData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.messages = append(shop.messages, m)
+}
+
+// AddReply adds a reply to a chat of the shop.
+func (s *Tokopedia) AddReply(shopID, msgID int, r tokopedia.ReplyData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.replies[msgID] = append(shop.replies[msgID], r)
+}
+
+// AddOrder adds an order to the shop, as listed and as detailed. The id and
+// invoice of detail default to the ones of o.
+func (s *Tokopedia) AddOrder(shopID int, o tokopedia.Order, detail tokopedia.OrderDetail) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	if detail.OrderID == 0 {
+		detail.OrderID = o.OrderID
+	}
+	if detail.InvoiceNumber == "" {
+		detail.InvoiceNumber = o.InvoiceRefNum
+	}
+	shop.orders = append(shop.orders, o)
+	shop.details = append(shop.details, detail)
+}
+
+// AddProduct adds a product to the shop.
+func (s *Tokopedia) AddProduct(shopID int, p tokopedia.ProductData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	shop := s.mustShop(shopID)
+	shop.products = append(shop.products, p)
+}
+
+// Replies returns the replies of a chat of the shop, sent ones included.
+func (s *Tokopedia) Replies(shopID, msgID int) []tokopedia.ReplyData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mustShop(shopID).replies[msgID])
+}
+
+func (s *Tokopedia) mustShop(shopID int) *tokopediaShop {
+	shop, ok := s.shops[shopID]
+	if !ok {
+		panic("sandbox: unknown tokopedia shop " + strconv.Itoa(shopID) + ", add it with AddShop")
+	}
+	return shop
+}
+
+// shopsOf returns the shops of the access token of r, the caller holds mu.
+func (s *Tokopedia) shopsOf(r *http.Request) []*tokopediaShop {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	var shops []*tokopediaShop
+	for _, shop := range s.shops {
+		if shop.token == token {
+			shops = append(shops, shop)
+		}
+	}
+	slices.SortFunc(shops, func(a, b *tokopediaShop) int { return cmp.Compare(a.info.ShopID, b.info.ShopID) })
+	return shops
+}
+
+// shopOf returns the shop of shopID when the access token of r gives access
+// to it, the caller holds mu.
+func (s *Tokopedia) shopOf(r *http.Request, shopID int) (*tokopediaShop, error) {
+	for _, shop := range s.shopsOf(r) {
+		if shop.info.ShopID == shopID {
+			return shop, nil
+		}
+	}
+	return nil, &apiError{status: http.StatusForbidden, message: "shop " + strconv.Itoa(shopID) + " is not authorized"}
+}
+
+func (s *Tokopedia) authorize(r *http.Request, body []byte) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return &apiError{status: http.StatusUnauthorized, message: "missing bearer token"}
+	}
+
+	// the fs is in the path, after fs, or else in fs_id
+	fs := r.URL.Query().Get("fs_id")
+	parts := strings.Split(r.URL.Path, "/")
+	if i := slices.Index(parts, "fs"); i >= 0 && i+1 < len(parts) {
+		fs = parts[i+1]
+	}
+	if fs != strconv.Itoa(s.fsID) {
+		return &apiError{status: http.StatusForbidden, message: "invalid fs_id " + fs}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.shopsOf(r)) == 0 {
+		return &apiError{status: http.StatusUnauthorized, message: "invalid access token"}
+	}
+	if v := r.URL.Query().Get("shop_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return badParam("shop_id")
+		}
+		if _, err := s.shopOf(r, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Tokopedia) errorBody(e *apiError, requestID string) any {
+	code := e.code
+	if code == "" {
+		code = strings.ToUpper(strings.ReplaceAll(http.StatusText(e.status), " ", "_"))
+	}
+	reason := e.message
+	if reason == "" {
+		reason = http.StatusText(e.status)
+	}
+	return tokopediaResponse{Header: tokopediaHeader{Messages: "Your request failed", Reason: reason, ErrorCode: code}}
+}
+
+// retryAfter sets the header Tokopedia tells the wait in.
+func (s *Tokopedia) retryAfter(h http.Header, d time.Duration) {
+	h.Set("X-Ratelimit-Full-Reset-After", strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+}
+
+// tokopediaOK wraps data in a successful response.
+func tokopediaOK(data any) any {
+	return tokopediaResponse{
+		Header: tokopediaHeader{ProcessTime: 0.01, Messages: "Your request has been processed successfully"},
+		Data:   data,
+	}
+}
+
+// pageParams reads page and per_page, counted from 1.
+func pageParams(r *http.Request) (n, size int, err error) {
+	if n, err = intParam(r, "page", 1); err != nil {
+		return 0, 0, err
+	}
+	if size, err = intParam(r, "per_page", 20); err != nil {
+		return 0, 0, err
+	}
+	return n, size, nil
+}
+
+// getMessages pages the chats of the shop, the latest reply first.
+func (s *Tokopedia) getMessages(r *http.Request) (any, error) {
+	shopID, err := intParam(r, "shop_id", 0)
+	if err != nil || shopID == 0 {
+		return nil, badParam("shop_id")
+	}
+	n, size, err := pageParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, err := s.shopOf(r, shopID)
+	if err != nil {
+		return nil, err
+	}
+	messages := slices.Clone(shop.messages)
+	slices.SortStableFunc(messages, func(a, b tokopedia.MessageData) int {
+		return cmp.Compare(b.Attributes.LastReplyTime, a.Attributes.LastReplyTime)
+	})
+	return tokopediaOK(page(messages, n, size)), nil
+}
+
+// getReplies pages the replies of a chat of the shop, the oldest first.
+func (s *Tokopedia) getReplies(r *http.Request) (any, error) {
+	shopID, err := intParam(r, "shop_id", 0)
+	if err != nil || shopID == 0 {
+		return nil, badParam("shop_id")
+	}
+	msgID, err := strconv.Atoi(r.PathValue("msg"))
+	if err != nil {
+		return nil, badParam("msg_id")
+	}
+	n, size, err := pageParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, err := s.shopOf(r, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(shop.messages, func(m tokopedia.MessageData) bool { return m.MsgID == msgID }) {
+		return nil, notFound("chat " + r.PathValue("msg"))
+	}
+
+	replies := slices.Clone(shop.replies[msgID])
+	slices.SortStableFunc(replies, func(a, b tokopedia.ReplyData) int { return cmp.Compare(a.ReplyTime, b.ReplyTime) })
+	return tokopediaOK(page(replies, n, size)), nil
+}
+
+// sendMessage adds a reply from the shop to the chat.
+func (s *Tokopedia) sendMessage(r *http.Request) (any, error) {
+	var body tokopedia.SendMessageBody
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	msgID, err := strconv.Atoi(r.PathValue("msg"))
+	if err != nil || body.MsgID != 0 && body.MsgID != msgID {
+		return nil, badParam("msg_id")
+	}
+	if body.Message == "" {
+		return nil, badParam("message")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, err := s.shopOf(r, body.ShopID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(shop.messages, func(m tokopedia.MessageData) bool { return m.MsgID == msgID })
+	if i < 0 {
+		return nil, notFound("chat " + r.PathValue("msg"))
+	}
+
+	now := time.Now().UnixMilli()
+	reply := tokopedia.ReplyData{
+		MsgID:      msgID,
+		SenderID:   shop.info.UserID,
+		Role:       "shop",
+		Msg:        body.Message,
+		ReplyTime:  now,
+		ReplyID:    int(s.newID()),
+		SenderName: shop.info.ShopName,
+		IsOpposite: true,
+	}
+	shop.replies[msgID] = append(shop.replies[msgID], reply)
+
+	chat := &shop.messages[i].Attributes
+	chat.LastReplyMsg = body.Message
+	chat.LastReplyTime = now
+
+	return tokopediaOK(tokopedia.SendMessageResponseData{
+		MsgID:     int64(msgID),
+		SenderID:  shop.info.UserID,
+		Msg:       body.Message,
+		ReplyTime: now,
+		From:      shop.info.ShopName,
+	}), nil
+}
+
+// getOrders pages the orders created from from_date to to_date, at most 3
+// days apart, of the shop or else of all the shops of the token.
+func (s *Tokopedia) getOrders(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	from, err := strconv.ParseInt(q.Get("from_date"), 10, 64)
+	if err != nil {
+		return nil, badParam("from_date")
+	}
+	to, err := strconv.ParseInt(q.Get("to_date"), 10, 64)
+	if err != nil || to < from || to-from > int64(3*24*time.Hour/time.Second) {
+		return nil, badParam("to_date")
+	}
+	n, size, err := pageParams(r)
+	if err != nil {
+		return nil, err
+	}
+	shopID, _ := intParam(r, "shop_id", 0)
+	status, err := intParam(r, "status", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := []tokopedia.Order{}
+	for _, shop := range s.shopsOf(r) {
+		if shopID != 0 && shop.info.ShopID != shopID {
+			continue
+		}
+		for _, o := range shop.orders {
+			if o.CreateTime < from || o.CreateTime > to || status != 0 && o.OrderStatus != status {
+				continue
+			}
+			orders = append(orders, o)
+		}
+	}
+	return tokopediaOK(page(orders, n, size)), nil
+}
+
+// getOrder finds an order of the shops of the token by order_id or
+// invoice_num.
+func (s *Tokopedia) getOrder(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	invoice := q.Get("invoice_num")
+	var orderID int64
+	if v := q.Get("order_id"); v != "" {
+		var err error
+		if orderID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, badParam("order_id")
+		}
+	}
+	if orderID == 0 && invoice == "" {
+		return nil, badParam("order_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, shop := range s.shopsOf(r) {
+		for _, d := range shop.details {
+			if orderID != 0 && d.OrderID == orderID || orderID == 0 && d.InvoiceNumber == invoice {
+				return tokopediaOK(d), nil
+			}
+		}
+	}
+	return nil, notFound("order")
+}
+
+func (s *Tokopedia) getProductInfo(r *http.Request) (any, error) {
+	productID, err := intParam(r, "product_id", 0)
+	if err != nil || productID == 0 {
+		return nil, badParam("product_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, shop := range s.shopsOf(r) {
+		for _, p := range shop.products {
+			if p.Basic.ProductID == productID {
+				return tokopediaOK([]tokopedia.ProductData{p}), nil
+			}
+		}
+	}
+	return nil, notFound("product " + strconv.Itoa(productID))
+}
+
+// getShopInfo returns the shop of shop_id, or all the shops of the token
+// without one.
+func (s *Tokopedia) getShopInfo(r *http.Request) (any, error) {
+	shopID, _ := intParam(r, "shop_id", 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shops := []tokopedia.ShopData{}
+	for _, shop := range s.shopsOf(r) {
+		if shopID == 0 || shop.info.ShopID == shopID {
+			shops = append(shops, shop.info)
+		}
+	}
+	return tokopediaOK(shops), nil
+}
@@ -1,6 +1,7 @@
 package shopee
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -9,7 +10,9 @@ type AuthService interface {
 	GetAuthURL() (string, error)
 	GetCancelAuthURL() (string, error)
 	GetAccessToken(uint64, uint64, string) (*AccessTokenResponse, error)
+	GetAccessTokenWithContext(ctx context.Context, sid uint64, aid uint64, code string) (*AccessTokenResponse, error)
 	RefreshAccessToken(uint64, uint64, string) (*RefreshAccessTokenResponse, error)
+	RefreshAccessTokenWithContext(ctx context.Context, sid uint64, aid uint64, refresh string) (*RefreshAccessTokenResponse, error)
 }
 
 type AccessTokenResponse struct {
@@ -52,6 +55,10 @@ func (s *AuthServiceOp) GetCancelAuthURL() (string, error) {
 }
 
 func (s *AuthServiceOp) GetAccessToken(sid uint64, aid uint64, code string) (*AccessTokenResponse, error) {
+	return s.GetAccessTokenWithContext(context.Background(), sid, aid, code)
+}
+
+func (s *AuthServiceOp) GetAccessTokenWithContext(ctx context.Context, sid uint64, aid uint64, code string) (*AccessTokenResponse, error) {
 	path := "/auth/token/get"
 	params := map[string]interface{}{
 		"code": code,
@@ -63,11 +70,15 @@ func (s *AuthServiceOp) GetAccessToken(sid uint64, aid uint64, code string) (*Ac
 	}
 
 	resp := new(AccessTokenResponse)
-	err := s.client.Post(path, params, resp)
+	err := s.client.public().PostWithContext(ctx, path, params, resp)
 	return resp, err
 }
 
 func (s *AuthServiceOp) RefreshAccessToken(sid uint64, aid uint64, refresh string) (*RefreshAccessTokenResponse, error) {
+	return s.RefreshAccessTokenWithContext(context.Background(), sid, aid, refresh)
+}
+
+func (s *AuthServiceOp) RefreshAccessTokenWithContext(ctx context.Context, sid uint64, aid uint64, refresh string) (*RefreshAccessTokenResponse, error) {
 	path := "/auth/access_token/get"
 	params := map[string]interface{}{
 		"refresh_token": refresh,
@@ -79,6 +90,6 @@ func (s *AuthServiceOp) RefreshAccessToken(sid uint64, aid uint64, refresh strin
 	}
 
 	resp := new(RefreshAccessTokenResponse)
-	err := s.client.Post(path, params, resp)
+	err := s.client.public().PostWithContext(ctx, path, params, resp)
 	return resp, err
 }
@@ -1,29 +1,45 @@
 package shopee
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type ChatService interface {
 	GetMessage(shopID uint64, token string, params GetMessageParamsRequest) (*GetMessageResponse, error)
+	GetMessageWithContext(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) (*GetMessageResponse, error)
 	GetConversationList(shopID uint64, token string, params GetConversationParamsRequest) (*GetConversationResponse, error)
+	GetConversationListWithContext(ctx context.Context, shopID uint64, token string, params GetConversationParamsRequest) (*GetConversationResponse, error)
+	Conversations(ctx context.Context, shopID uint64, token string, params GetConversationParamsRequest) iter.Seq2[Conversation, error]
+	Messages(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) iter.Seq2[Messages, error]
 	GetOneConversation(shopID uint64, token string, params GetMessageParamsRequest) (*GetDetailConversation, error)
+	GetOneConversationWithContext(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) (*GetDetailConversation, error)
 	SendMessage(shopID uint64, token string, request SendMessageRequest) (*GetSendMessageResponse, error)
+	SendMessageWithContext(ctx context.Context, shopID uint64, token string, request SendMessageRequest) (*GetSendMessageResponse, error)
 	UploadImage(shopID uint64, token string, filename string) (*UploadImageResponse, error)
+	UploadImageWithContext(ctx context.Context, shopID uint64, token string, filename string) (*UploadImageResponse, error)
 	UploadVideo(shopID uint64, token string, filename string, fileBytes []byte) (*UploadVideoResponse, error)
+	UploadVideoWithContext(ctx context.Context, shopID uint64, token string, filename string, fileBytes []byte) (*UploadVideoResponse, error)
 	GetStickerPack() (*StickerPacksResponse, error)
+	GetStickerPackWithContext(ctx context.Context) (*StickerPacksResponse, error)
 	GetListStickerByPID(stickerPackageID string) (*ListStickerByPID, error)
+	GetListStickerByPIDWithContext(ctx context.Context, stickerPackageID string) (*ListStickerByPID, error)
 	GetStickerByPIDAndSID(stickerPackageID, stickerID string) string
 	ReadConversation(shopID uint64, token string, params ReadMessageRequest) (*ReadMessageResponse, error)
+	ReadConversationWithContext(ctx context.Context, shopID uint64, token string, request ReadMessageRequest) (*ReadMessageResponse, error)
 	UnreadConversation(shopID uint64, token string, request UnreadMessageRequest) (*UnreadMessageResponse, error)
+	UnreadConversationWithContext(ctx context.Context, shopID uint64, token string, request UnreadMessageRequest) (*UnreadMessageResponse, error)
 	GetVideoByVidID(shopID uint64, token string, params GetVideoParamRequest) (*GetVideoByIDResponse, error)
+	GetVideoByVidIDWithContext(ctx context.Context, shopID uint64, token string, params GetVideoParamRequest) (*GetVideoByIDResponse, error)
 }
 
 type GetMessageParamsRequest struct {
@@ -40,7 +56,13 @@ type GetMessageResponse struct {
 }
 
 type GetMessageDataResponse struct {
-	MessagesList []Messages `json:"messages"`
+	MessagesList []Messages        `json:"messages"`
+	PageResult   MessagePageResult `json:"page_result"`
+}
+
+type MessagePageResult struct {
+	PageSize   int    `json:"page_size"`
+	NextOffset string `json:"next_offset"`
 }
 
 type Messages struct {
@@ -77,6 +99,7 @@ type ContentMessage struct {
 	StickerPackageID string        `json:"sticker_package_id,omitempty"`
 	ItemID           int64         `json:"item_id,omitempty"`
 	OrderID          int64         `json:"order_id,omitempty"`
+	OrderSN          string        `json:"order_sn,omitempty"`
 	VideoURL         string        `json:"video_url,omitempty"`
 	ImageURL         string        `json:"image_url,omitempty"`
 	VoucherID        string        `json:"voucher_id,omitempty"`
@@ -94,13 +117,46 @@ type ChatServiceOp struct {
 }
 
 func (s *ChatServiceOp) GetMessage(shopID uint64, token string, params GetMessageParamsRequest) (*GetMessageResponse, error) {
+	return s.GetMessageWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ChatServiceOp) GetMessageWithContext(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) (*GetMessageResponse, error) {
 	path := "/sellerchat/get_message"
 
 	resp := new(GetMessageResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
+// Messages iterates over the messages of a conversation, following
+// next_offset from page to page. A failed page is yielded as an error and
+// ends the iteration.
+func (s *ChatServiceOp) Messages(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) iter.Seq2[Messages, error] {
+	return func(yield func(Messages, error) bool) {
+		// every range starts from the first page
+		params := params
+		for {
+			resp, err := s.GetMessageWithContext(ctx, shopID, token, params)
+			if err != nil {
+				yield(Messages{}, err)
+				return
+			}
+
+			for _, m := range resp.Response.MessagesList {
+				if !yield(m, nil) {
+					return
+				}
+			}
+
+			next := resp.Response.PageResult.NextOffset
+			if len(resp.Response.MessagesList) == 0 || next == "" || next == "0" || next == params.Offset {
+				return
+			}
+			params.Offset = next
+		}
+	}
+}
+
 type GetConversationParamsRequest struct {
 	Direction    string `url:"direction"` // latest/older
 	Type         string `url:"type"`
@@ -129,6 +185,10 @@ type ConversationPageResult struct {
 }
 
 func (s *ChatServiceOp) GetConversationList(shopID uint64, token string, params GetConversationParamsRequest) (*GetConversationResponse, error) {
+	return s.GetConversationListWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ChatServiceOp) GetConversationListWithContext(ctx context.Context, shopID uint64, token string, params GetConversationParamsRequest) (*GetConversationResponse, error) {
 	path := "/sellerchat/get_conversation_list"
 
 	opt := GetConversationParamsRequest{
@@ -139,10 +199,46 @@ func (s *ChatServiceOp) GetConversationList(shopID uint64, token string, params
 	}
 
 	resp := new(GetConversationResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, opt)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
+// Conversations iterates over the conversations of a shop, following
+// next_cursor from page to page. A failed page is yielded as an error and
+// ends the iteration.
+func (s *ChatServiceOp) Conversations(ctx context.Context, shopID uint64, token string, params GetConversationParamsRequest) iter.Seq2[Conversation, error] {
+	return func(yield func(Conversation, error) bool) {
+		params := params
+		for {
+			resp, err := s.GetConversationListWithContext(ctx, shopID, token, params)
+			if err != nil {
+				yield(Conversation{}, err)
+				return
+			}
+
+			for _, c := range resp.Response.ConversationsList {
+				if !yield(c, nil) {
+					return
+				}
+			}
+
+			page := resp.Response.PageResult
+			if !page.More || len(resp.Response.ConversationsList) == 0 {
+				return
+			}
+			next, err := strconv.ParseInt(page.NextCursor.NextMessageTimeNano, 10, 64)
+			if err != nil {
+				yield(Conversation{}, fmt.Errorf("shopee: invalid conversation cursor %q: %w", page.NextCursor.NextMessageTimeNano, err))
+				return
+			}
+			if next == 0 || next == params.NextTimeNano {
+				return
+			}
+			params.NextTimeNano = next
+		}
+	}
+}
+
 type GetSendMessageResponse struct {
 	BaseResponse
 
@@ -193,6 +289,10 @@ type ContentSendMessage struct {
 }
 
 func (s *ChatServiceOp) SendMessage(shopID uint64, token string, request SendMessageRequest) (*GetSendMessageResponse, error) {
+	return s.SendMessageWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ChatServiceOp) SendMessageWithContext(ctx context.Context, shopID uint64, token string, request SendMessageRequest) (*GetSendMessageResponse, error) {
 	path := "/sellerchat/send_message"
 	resp := new(GetSendMessageResponse)
 	req, err := StructToMap(request)
@@ -200,7 +300,7 @@ func (s *ChatServiceOp) SendMessage(shopID uint64, token string, request SendMes
 		return nil, err
 	}
 
-	err = s.client.WithShop(uint64(shopID), token).Post(path, req, resp)
+	err = s.client.ForShop(uint64(shopID), token).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -220,10 +320,14 @@ type UploadImageDataResponse struct {
 }
 
 func (s *ChatServiceOp) UploadImage(shopID uint64, token string, filename string) (*UploadImageResponse, error) {
+	return s.UploadImageWithContext(context.Background(), shopID, token, filename)
+}
+
+func (s *ChatServiceOp) UploadImageWithContext(ctx context.Context, shopID uint64, token string, filename string) (*UploadImageResponse, error) {
 	path := "/sellerchat/upload_image"
 
 	resp := new(UploadImageResponse)
-	err := s.client.WithShop(uint64(shopID), token).Upload(path, "file", filename, resp)
+	err := s.client.ForShop(uint64(shopID), token).UploadWithContext(ctx, path, "file", filename, resp)
 	return resp, err
 }
 
@@ -255,10 +359,14 @@ type Conversation struct {
 
 // Use GetMessageParamsRequest, need param convesation_id
 func (s *ChatServiceOp) GetOneConversation(shopID uint64, token string, params GetMessageParamsRequest) (*GetDetailConversation, error) {
+	return s.GetOneConversationWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ChatServiceOp) GetOneConversationWithContext(ctx context.Context, shopID uint64, token string, params GetMessageParamsRequest) (*GetDetailConversation, error) {
 	path := "/sellerchat/get_one_conversation"
 
 	resp := new(GetDetailConversation)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -272,6 +380,10 @@ type Packs struct {
 }
 
 func (s *ChatServiceOp) GetStickerPack() (*StickerPacksResponse, error) {
+	return s.GetStickerPackWithContext(context.Background())
+}
+
+func (s *ChatServiceOp) GetStickerPackWithContext(ctx context.Context) (*StickerPacksResponse, error) {
 	var client = &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -282,7 +394,7 @@ func (s *ChatServiceOp) GetStickerPack() (*StickerPacksResponse, error) {
 	}
 
 	url := "https://deo.shopeemobile.com/shopee/shopee-sticker-live-id/manifest.json"
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -335,6 +447,10 @@ type Stickers struct {
 }
 
 func (s *ChatServiceOp) GetListStickerByPID(stickerPackageID string) (*ListStickerByPID, error) {
+	return s.GetListStickerByPIDWithContext(context.Background(), stickerPackageID)
+}
+
+func (s *ChatServiceOp) GetListStickerByPIDWithContext(ctx context.Context, stickerPackageID string) (*ListStickerByPID, error) {
 	var client = &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -345,7 +461,7 @@ func (s *ChatServiceOp) GetListStickerByPID(stickerPackageID string) (*ListStick
 	}
 
 	url := fmt.Sprintf("https://deo.shopeemobile.com/shopee/shopee-sticker-live-id/packs/%s/%s.json", stickerPackageID, stickerPackageID)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -403,6 +519,10 @@ type ReadMessageRequest struct {
 
 // ReadConversation for read message from buyer by last_read_message_id from response get message
 func (s *ChatServiceOp) ReadConversation(shopID uint64, token string, request ReadMessageRequest) (*ReadMessageResponse, error) {
+	return s.ReadConversationWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ChatServiceOp) ReadConversationWithContext(ctx context.Context, shopID uint64, token string, request ReadMessageRequest) (*ReadMessageResponse, error) {
 	path := "/sellerchat/read_conversation"
 	req, err := StructToMap(request)
 	if err != nil {
@@ -410,7 +530,7 @@ func (s *ChatServiceOp) ReadConversation(shopID uint64, token string, request Re
 	}
 
 	resp := new(ReadMessageResponse)
-	err = s.client.WithShop(uint64(shopID), token).Post(path, req, resp)
+	err = s.client.ForShop(uint64(shopID), token).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -426,13 +546,17 @@ type UnreadMessageRequest struct {
 
 // UnreadConversation for mark a conversation from buyer as unread
 func (s *ChatServiceOp) UnreadConversation(shopID uint64, token string, request UnreadMessageRequest) (*UnreadMessageResponse, error) {
+	return s.UnreadConversationWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ChatServiceOp) UnreadConversationWithContext(ctx context.Context, shopID uint64, token string, request UnreadMessageRequest) (*UnreadMessageResponse, error) {
 	path := "/sellerchat/unread_conversation"
 	resp := new(UnreadMessageResponse)
 	req, err := StructToMap(request)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(uint64(shopID), token).Post(path, req, resp)
+	err = s.client.ForShop(uint64(shopID), token).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -447,9 +571,13 @@ type DataUploadVideo struct {
 }
 
 func (s *ChatServiceOp) UploadVideo(shopID uint64, token string, filename string, fileBytes []byte) (*UploadVideoResponse, error) {
+	return s.UploadVideoWithContext(context.Background(), shopID, token, filename, fileBytes)
+}
+
+func (s *ChatServiceOp) UploadVideoWithContext(ctx context.Context, shopID uint64, token string, filename string, fileBytes []byte) (*UploadVideoResponse, error) {
 	path := "/sellerchat/upload_video"
 	resp := new(UploadVideoResponse)
-	err := s.client.WithShop(uint64(shopID), token).UploadVideo(path, filename, fileBytes, resp)
+	err := s.client.ForShop(uint64(shopID), token).UploadVideoWithContext(ctx, path, filename, fileBytes, resp)
 	return resp, err
 }
 
@@ -458,9 +586,13 @@ type GetVideoParamRequest struct {
 }
 
 func (s *ChatServiceOp) GetVideoByVidID(shopID uint64, token string, params GetVideoParamRequest) (*GetVideoByIDResponse, error) {
+	return s.GetVideoByVidIDWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ChatServiceOp) GetVideoByVidIDWithContext(ctx context.Context, shopID uint64, token string, params GetVideoParamRequest) (*GetVideoByIDResponse, error) {
 	path := "/sellerchat/get_video_upload_result"
 	resp := new(GetVideoByIDResponse)
-	err := s.client.WithShop(shopID, token).Get(path, resp, params)
+	err := s.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -0,0 +1,47 @@
+package shopee
+
+import (
+	"strings"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+)
+
+// Codes categorizes the error codes of Shopee, see apierror.
+var Codes = apierror.Codes{
+	"error_auth":           apierror.ErrTokenExpired,
+	"error_sign":           apierror.ErrUnauthorized,
+	"error_permission":     apierror.ErrUnauthorized,
+	"error_param":          apierror.ErrInvalidParam,
+	"error_not_found":      apierror.ErrNotFound,
+	"error_item_not_found": apierror.ErrNotFound,
+	"error_rate_limit":     apierror.ErrRateLimited,
+	"error_busy":           apierror.ErrUnavailable,
+	"error_server":         apierror.ErrUnavailable,
+}
+
+var _ apierror.Error = ResponseError{}
+
+// GetMarketplace returns apierror.Shopee
+func (e ResponseError) GetMarketplace() apierror.Marketplace {
+	return apierror.Shopee
+}
+
+// GetCode returns the error code, Shopee ends some with a dot.
+func (e ResponseError) GetCode() string {
+	return strings.TrimSuffix(e.Code, ".")
+}
+
+// GetRequestID returns the request id of the call
+func (e ResponseError) GetRequestID() string {
+	return e.RequestID
+}
+
+// Retryable tells whether the call may succeed later
+func (e ResponseError) Retryable() bool {
+	return Codes.Retryable(e.Status, e.GetCode())
+}
+
+// Is matches the apierror category of e.
+func (e ResponseError) Is(target error) bool {
+	return Codes.Match(e.Status, e.GetCode(), target)
+}
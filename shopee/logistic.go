@@ -1,7 +1,14 @@
 package shopee
 
+import "context"
+
 type LogisticService interface {
 	GetTrackingInfo(shopID uint64, token string, params GetTrackingInfoParamsRequest) (*GetTrackingInfoResponse, error)
+	GetTrackingInfoWithContext(ctx context.Context, shopID uint64, token string, params GetTrackingInfoParamsRequest) (*GetTrackingInfoResponse, error)
+	GetShippingParameter(shopID uint64, token string, params GetShippingParameterParamsRequest) (*GetShippingParameterResponse, error)
+	GetShippingParameterWithContext(ctx context.Context, shopID uint64, token string, params GetShippingParameterParamsRequest) (*GetShippingParameterResponse, error)
+	ShipOrder(shopID uint64, token string, request ShipOrderRequest) (*ShipOrderResponse, error)
+	ShipOrderWithContext(ctx context.Context, shopID uint64, token string, request ShipOrderRequest) (*ShipOrderResponse, error)
 }
 
 type GetTrackingInfoParamsRequest struct {
@@ -9,11 +16,24 @@ type GetTrackingInfoParamsRequest struct {
 	PackageNumber string `url:"package_number,omitempty"`
 }
 
-// Response Get Detail Order
+// Response Get Tracking Info
 type (
 	GetTrackingInfoResponse struct {
 		BaseResponse
-		GetTrackingInfoData any `json:"response"`
+		GetTrackingInfoData TrackingInfoData `json:"response"`
+	}
+
+	TrackingInfoData struct {
+		OrderSn         string         `json:"order_sn"`
+		PackageNumber   string         `json:"package_number"`
+		LogisticsStatus string         `json:"logistics_status"`
+		TrackingInfo    []TrackingInfo `json:"tracking_info"`
+	}
+
+	TrackingInfo struct {
+		UpdateTime      int64  `json:"update_time"`
+		Description     string `json:"description"`
+		LogisticsStatus string `json:"logistics_status"`
 	}
 )
 
@@ -22,8 +42,140 @@ type LogisticServiceOp struct {
 }
 
 func (o *LogisticServiceOp) GetTrackingInfo(shopID uint64, token string, params GetTrackingInfoParamsRequest) (*GetTrackingInfoResponse, error) {
+	return o.GetTrackingInfoWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *LogisticServiceOp) GetTrackingInfoWithContext(ctx context.Context, shopID uint64, token string, params GetTrackingInfoParamsRequest) (*GetTrackingInfoResponse, error) {
 	path := "/logistics/get_tracking_info"
 	resp := new(GetTrackingInfoResponse)
-	err := o.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := o.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+type GetShippingParameterParamsRequest struct {
+	OrderSN       string `url:"order_sn"`
+	PackageNumber string `url:"package_number,omitempty"`
+}
+
+// Response Get Shipping Parameter, InfoNeeded lists the fields ShipOrder needs
+// for each shipping method.
+type (
+	GetShippingParameterResponse struct {
+		BaseResponse
+		Response ShippingParameterData `json:"response"`
+	}
+
+	ShippingParameterData struct {
+		InfoNeeded ShippingInfoNeeded `json:"info_needed"`
+		Dropoff    *DropoffParameter  `json:"dropoff"`
+		Pickup     *PickupParameter   `json:"pickup"`
+	}
+
+	ShippingInfoNeeded struct {
+		Dropoff       []string `json:"dropoff"`
+		Pickup        []string `json:"pickup"`
+		NonIntegrated []string `json:"non_integrated"`
+	}
+
+	DropoffParameter struct {
+		BranchList []DropoffBranch `json:"branch_list"`
+		SlugList   []DropoffSlug   `json:"slug_list"`
+	}
+
+	DropoffBranch struct {
+		BranchID int64  `json:"branch_id"`
+		Region   string `json:"region"`
+		State    string `json:"state"`
+		City     string `json:"city"`
+		Address  string `json:"address"`
+		Zipcode  string `json:"zipcode"`
+		District string `json:"district"`
+		Town     string `json:"town"`
+	}
+
+	DropoffSlug struct {
+		Slug     string `json:"slug"`
+		SlugName string `json:"slug_name"`
+	}
+
+	PickupParameter struct {
+		AddressList []PickupAddress `json:"address_list"`
+	}
+
+	PickupAddress struct {
+		AddressID    int64            `json:"address_id"`
+		Region       string           `json:"region"`
+		State        string           `json:"state"`
+		City         string           `json:"city"`
+		District     string           `json:"district"`
+		Town         string           `json:"town"`
+		Address      string           `json:"address"`
+		Zipcode      string           `json:"zipcode"`
+		AddressFlag  []string         `json:"address_flag"`
+		TimeSlotList []PickupTimeSlot `json:"time_slot_list"`
+	}
+
+	PickupTimeSlot struct {
+		Date         int64  `json:"date"`
+		TimeText     string `json:"time_text"`
+		PickupTimeID string `json:"pickup_time_id"`
+	}
+)
+
+func (o *LogisticServiceOp) GetShippingParameter(shopID uint64, token string, params GetShippingParameterParamsRequest) (*GetShippingParameterResponse, error) {
+	return o.GetShippingParameterWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *LogisticServiceOp) GetShippingParameterWithContext(ctx context.Context, shopID uint64, token string, params GetShippingParameterParamsRequest) (*GetShippingParameterResponse, error) {
+	path := "/logistics/get_shipping_parameter"
+	resp := new(GetShippingParameterResponse)
+	err := o.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+// ShipOrderRequest ships an order with exactly one of Pickup, Dropoff or
+// NonIntegrated, as returned by GetShippingParameter.
+type ShipOrderRequest struct {
+	OrderSN       string                  `json:"order_sn"`
+	PackageNumber string                  `json:"package_number,omitempty"`
+	Pickup        *ShipOrderPickup        `json:"pickup,omitempty"`
+	Dropoff       *ShipOrderDropoff       `json:"dropoff,omitempty"`
+	NonIntegrated *ShipOrderNonIntegrated `json:"non_integrated,omitempty"`
+}
+
+type ShipOrderPickup struct {
+	AddressID      int64  `json:"address_id"`
+	PickupTimeID   string `json:"pickup_time_id,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+}
+
+type ShipOrderDropoff struct {
+	BranchID       int64  `json:"branch_id,omitempty"`
+	SenderRealName string `json:"sender_real_name,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	Slug           string `json:"slug,omitempty"`
+}
+
+type ShipOrderNonIntegrated struct {
+	TrackingNumber string `json:"tracking_number"`
+}
+
+type ShipOrderResponse struct {
+	BaseResponse
+}
+
+func (o *LogisticServiceOp) ShipOrder(shopID uint64, token string, request ShipOrderRequest) (*ShipOrderResponse, error) {
+	return o.ShipOrderWithContext(context.Background(), shopID, token, request)
+}
+
+func (o *LogisticServiceOp) ShipOrderWithContext(ctx context.Context, shopID uint64, token string, request ShipOrderRequest) (*ShipOrderResponse, error) {
+	path := "/logistics/ship_order"
+	resp := new(ShipOrderResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
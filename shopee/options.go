@@ -1,23 +1,100 @@
 package shopee
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 	"golang.org/x/net/proxy"
 )
 
 // Option is used to configure client with options
 type Option func(c *ShopeeClient)
 
+// TokenSource returns the access token of a shop, or of a merchant for
+// merchant APIs. shopID is the decimal shop or merchant id.
+type TokenSource interface {
+	AccessToken(ctx context.Context, shopID string) (string, error)
+}
+
+// WithTokenSource makes service calls given an empty access token fetch it
+// from src, so callers only need the shop id.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *ShopeeClient) {
+		c.tokenSource = src
+	}
+}
+
 func WithRetry(retries int) Option {
 	return func(c *ShopeeClient) {
 		c.retries = retries
 	}
 }
 
+// WithMiddleware routes requests through a middleware.Transport, retrying
+// Shopee throttling and busy errors and rate limiting by partner and shop.
+// It wraps the transport set by the other options whatever their order, and
+// turns WithRetry off.
+func WithMiddleware(opts ...middleware.Option) Option {
+	return func(c *ShopeeClient) {
+		c.middleware = append([]middleware.Option{
+			middleware.WithRetryable(Retryable),
+			middleware.WithShopKey(shopKey),
+		}, opts...)
+	}
+}
+
+// WithRecorder records the calls of the client to r or replays them, see cassette.
+func WithRecorder(r *cassette.Recorder) Option {
+	return func(c *ShopeeClient) {
+		c.recorder = r
+	}
+}
+
+// WithTelemetry traces and measures every call with OpenTelemetry, see telemetry.
+func WithTelemetry(opts ...telemetry.Option) Option {
+	return func(c *ShopeeClient) {
+		c.telemetry = append([]telemetry.Option{}, opts...)
+	}
+}
+
+// Retryable is the middleware.Retryable of Shopee, it also retries the
+// throttling and busy errors Shopee returns with a 200 status.
+func Retryable(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err == nil && resp.StatusCode == http.StatusOK {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(middleware.PeekBody(resp), &body) == nil {
+			switch body.Error {
+			case "error_rate_limit", "error_busy":
+				return true, middleware.RetryAfter(resp.Header, "Retry-After")
+			}
+		}
+	}
+	return middleware.DefaultRetryable(req, resp, err)
+}
+
+// shopKey picks the rate limit bucket of a request by its shop or merchant.
+func shopKey(req *http.Request) string {
+	q := req.URL.Query()
+	if id := q.Get("shop_id"); id != "" {
+		return "shop:" + id
+	}
+	if id := q.Get("merchant_id"); id != "" {
+		return "merchant:" + id
+	}
+	return ""
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *ShopeeClient) {
 		c.log = logger
@@ -55,3 +132,24 @@ func WithSocks5(socksAddress string) Option {
 		c.Client.Transport = &http.Transport{Dial: dialer.Dial}
 	}
 }
+
+// telemetryMarketplace describes the calls of Shopee to the telemetry
+// transport.
+var telemetryMarketplace = telemetry.Marketplace{
+	Name: string(apierror.Shopee),
+	ShopKey: func(req *http.Request) string {
+		q := req.URL.Query()
+		if id := q.Get("shop_id"); id != "" {
+			return id
+		}
+		return q.Get("merchant_id")
+	},
+	Describe: func(resp *http.Response) (string, string) {
+		var body struct {
+			Error     string `json:"error"`
+			RequestID string `json:"request_id"`
+		}
+		json.Unmarshal(middleware.PeekBody(resp), &body)
+		return strings.TrimSuffix(body.Error, "."), body.RequestID
+	},
+}
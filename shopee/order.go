@@ -1,9 +1,27 @@
 package shopee
 
+import (
+	"context"
+	"encoding/json"
+	"iter"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
 type OrderService interface {
 	GetOrderDetailByOrderSN(shopID uint64, token string, params GetOrderDetailParamsRequest) (*GetOrderDetailResponse, error)
+	GetOrderDetailByOrderSNWithContext(ctx context.Context, shopID uint64, token string, params GetOrderDetailParamsRequest) (*GetOrderDetailResponse, error)
 	GetListOrder(shopID uint64, token string, params GetListOrderParamsRequest) (*GetListOrderResponse, error)
+	GetListOrderWithContext(ctx context.Context, shopID uint64, token string, params GetListOrderParamsRequest) (*GetListOrderResponse, error)
+	All(ctx context.Context, shopID uint64, token string, params GetListOrderParamsRequest) iter.Seq2[OrderSNList, error]
 	DownloadInvoiceByOrderID(shopID uint64, token string, params DownloadInvoiceParamsRequest) error
+	DownloadInvoiceByOrderIDWithContext(ctx context.Context, shopID uint64, token string, params DownloadInvoiceParamsRequest) error
+	GetShipmentList(shopID uint64, token string, params GetShipmentListParamsRequest) (*GetShipmentListResponse, error)
+	GetShipmentListWithContext(ctx context.Context, shopID uint64, token string, params GetShipmentListParamsRequest) (*GetShipmentListResponse, error)
+	CancelOrder(shopID uint64, token string, request CancelOrderRequest) (*CancelOrderResponse, error)
+	CancelOrderWithContext(ctx context.Context, shopID uint64, token string, request CancelOrderRequest) (*CancelOrderResponse, error)
+	HandleBuyerCancellation(shopID uint64, token string, request HandleBuyerCancellationRequest) (*HandleBuyerCancellationResponse, error)
+	HandleBuyerCancellationWithContext(ctx context.Context, shopID uint64, token string, request HandleBuyerCancellationRequest) (*HandleBuyerCancellationResponse, error)
 }
 
 type GetOrderDetailParamsRequest struct {
@@ -24,59 +42,95 @@ type (
 	}
 
 	ItemList struct {
-		ItemID                 int64      `json:"item_id"`
-		ItemName               string     `json:"item_name"`
-		ItemSku                string     `json:"item_sku"`
-		ModelID                int64      `json:"model_id"`
-		ModelName              string     `json:"model_name"`
-		ModelSku               string     `json:"model_sku"`
-		ModelQuantityPurchased int        `json:"model_quantity_purchased"`
-		ModelOriginalPrice     int        `json:"model_original_price"`
-		ModelDiscountedPrice   int        `json:"model_discounted_price"`
-		Wholesale              bool       `json:"wholesale"`
-		Weight                 float64    `json:"weight"`
-		AddOnDeal              bool       `json:"add_on_deal"`
-		MainItem               bool       `json:"main_item"`
-		AddOnDealID            int        `json:"add_on_deal_id"`
-		PromotionType          string     `json:"promotion_type"`
-		PromotionID            int        `json:"promotion_id"`
-		OrderItemID            int64      `json:"order_item_id"`
-		PromotionGroupID       int        `json:"promotion_group_id"`
-		ImageInfo              *ImageInfo `json:"image_info"`
-		ProductLocationID      []string   `json:"product_location_id"`
-		IsPrescriptionItem     bool       `json:"is_prescription_item"`
-		IsB2COwnedItem         bool       `json:"is_b2c_owned_item"`
+		ItemID                 int64       `json:"item_id"`
+		ItemName               string      `json:"item_name"`
+		ItemSku                string      `json:"item_sku"`
+		ModelID                int64       `json:"model_id"`
+		ModelName              string      `json:"model_name"`
+		ModelSku               string      `json:"model_sku"`
+		ModelQuantityPurchased int         `json:"model_quantity_purchased"`
+		ModelOriginalPrice     money.Money `json:"model_original_price"`
+		ModelDiscountedPrice   money.Money `json:"model_discounted_price"`
+		Wholesale              bool        `json:"wholesale"`
+		Weight                 float64     `json:"weight"`
+		AddOnDeal              bool        `json:"add_on_deal"`
+		MainItem               bool        `json:"main_item"`
+		AddOnDealID            int         `json:"add_on_deal_id"`
+		PromotionType          string      `json:"promotion_type"`
+		PromotionID            int         `json:"promotion_id"`
+		OrderItemID            int64       `json:"order_item_id"`
+		PromotionGroupID       int         `json:"promotion_group_id"`
+		ImageInfo              *ImageInfo  `json:"image_info"`
+		ProductLocationID      []string    `json:"product_location_id"`
+		IsPrescriptionItem     bool        `json:"is_prescription_item"`
+		IsB2COwnedItem         bool        `json:"is_b2c_owned_item"`
 	}
 
 	OrderList struct {
-		Cod                bool        `json:"cod"`
-		CreateTime         int         `json:"create_time"`
-		Currency           string      `json:"currency"`
-		DaysToShip         int         `json:"days_to_ship"`
-		ItemList           []ItemList  `json:"item_list"`
-		InvoiceData        interface{} `json:"invoice_info_list"`
-		MessageToSeller    string      `json:"message_to_seller"`
-		OrderSn            string      `json:"order_sn"`
-		OrderStatus        string      `json:"order_status"`
-		PaymentMethod      string      `json:"payment_method"`
-		Region             string      `json:"region"`
-		ReverseShippingFee int         `json:"reverse_shipping_fee"`
-		ShipByDate         int         `json:"ship_by_date"`
-		ShippingCarrier    string      `json:"shipping_carrier"`
-		TotalAmount        int         `json:"total_amount"`
-		UpdateTime         int         `json:"update_time"`
+		BuyerUserID          int64             `json:"buyer_user_id"`
+		BuyerUsername        string            `json:"buyer_username"`
+		RecipientAddress     *RecipientAddress `json:"recipient_address"`
+		EstimatedShippingFee money.Money       `json:"estimated_shipping_fee"`
+		PayTime              int64             `json:"pay_time"`
+		Cod                  bool              `json:"cod"`
+		CreateTime           int               `json:"create_time"`
+		Currency             string            `json:"currency"`
+		DaysToShip           int               `json:"days_to_ship"`
+		ItemList             []ItemList        `json:"item_list"`
+		InvoiceData          interface{}       `json:"invoice_info_list"`
+		MessageToSeller      string            `json:"message_to_seller"`
+		OrderSn              string            `json:"order_sn"`
+		OrderStatus          string            `json:"order_status"`
+		PaymentMethod        string            `json:"payment_method"`
+		Region               string            `json:"region"`
+		ReverseShippingFee   money.Money       `json:"reverse_shipping_fee"`
+		ShipByDate           int               `json:"ship_by_date"`
+		ShippingCarrier      string            `json:"shipping_carrier"`
+		TotalAmount          money.Money       `json:"total_amount"`
+		UpdateTime           int               `json:"update_time"`
 	}
 )
 
+// UnmarshalJSON sets the currency of the order on its amounts and the prices
+// of its items.
+func (o *OrderList) UnmarshalJSON(b []byte) error {
+	type orderList OrderList
+	if err := json.Unmarshal(b, (*orderList)(o)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(o.Currency, &o.EstimatedShippingFee, &o.ReverseShippingFee, &o.TotalAmount)
+	for i := range o.ItemList {
+		money.FillCurrency(o.Currency, &o.ItemList[i].ModelOriginalPrice, &o.ItemList[i].ModelDiscountedPrice)
+	}
+	return nil
+}
+
+type RecipientAddress struct {
+	Name        string `json:"name"`
+	Phone       string `json:"phone"`
+	Town        string `json:"town"`
+	District    string `json:"district"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	Region      string `json:"region"`
+	Zipcode     string `json:"zipcode"`
+	FullAddress string `json:"full_address"`
+}
+
 type OrderServiceOp struct {
 	client *ShopeeClient
 }
 
 func (o *OrderServiceOp) GetOrderDetailByOrderSN(shopID uint64, token string, params GetOrderDetailParamsRequest) (*GetOrderDetailResponse, error) {
+	return o.GetOrderDetailByOrderSNWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *OrderServiceOp) GetOrderDetailByOrderSNWithContext(ctx context.Context, shopID uint64, token string, params GetOrderDetailParamsRequest) (*GetOrderDetailResponse, error) {
 	path := "/order/get_order_detail"
 	params.ResponseOptionalFields = "buyer_user_id,buyer_username,estimated_shipping_fee,recipient_address,actual_shipping_fee,goods_to_declare,note,note_update_time,item_list,pay_time,dropshipper, dropshipper_phone,split_up,buyer_cancel_reason,cancel_by,cancel_reason,actual_shipping_fee_confirmed,buyer_cpf_id,fulfillment_flag,pickup_done_time,package_list,shipping_carrier,payment_method,total_amount,buyer_username,invoice_info_list,no_plastic_packing,order_chargeable_weight_gram,edt,return_due_date"
 	resp := new(GetOrderDetailResponse)
-	err := o.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := o.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -85,8 +139,12 @@ type DownloadInvoiceParamsRequest struct {
 }
 
 func (o *OrderServiceOp) DownloadInvoiceByOrderID(shopID uint64, token string, params DownloadInvoiceParamsRequest) error {
+	return o.DownloadInvoiceByOrderIDWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *OrderServiceOp) DownloadInvoiceByOrderIDWithContext(ctx context.Context, shopID uint64, token string, params DownloadInvoiceParamsRequest) error {
 	path := "/order/download_invoice_doc"
-	err := o.client.WithShop(uint64(shopID), token).Get(path, "", params)
+	err := o.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, "", params)
 	return err
 }
 
@@ -96,6 +154,7 @@ type GetListOrderParamsRequest struct {
 	TimeTo         int    `url:"time_to"`
 	PageSize       int    `url:"page_size"`
 	OrderStatus    string `url:"order_status,omitempty"` // UNPAID/READY_TO_SHIP/PROCESSED/SHIPPED/COMPLETED/IN_CANCEL/CANCELLED/INVOICE_PENDING
+	Cursor         string `url:"cursor,omitempty"`       // next_cursor of the previous page
 }
 
 type GetListOrderResponse struct {
@@ -113,8 +172,136 @@ type OrderSNList struct {
 }
 
 func (o *OrderServiceOp) GetListOrder(shopID uint64, token string, params GetListOrderParamsRequest) (*GetListOrderResponse, error) {
+	return o.GetListOrderWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *OrderServiceOp) GetListOrderWithContext(ctx context.Context, shopID uint64, token string, params GetListOrderParamsRequest) (*GetListOrderResponse, error) {
 	path := "/order/get_order_list"
 	resp := new(GetListOrderResponse)
-	err := o.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := o.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+// All iterates over the orders matching params, following next_cursor from
+// page to page. A failed page is yielded as an error and ends the iteration.
+func (o *OrderServiceOp) All(ctx context.Context, shopID uint64, token string, params GetListOrderParamsRequest) iter.Seq2[OrderSNList, error] {
+	return func(yield func(OrderSNList, error) bool) {
+		params := params
+		for {
+			resp, err := o.GetListOrderWithContext(ctx, shopID, token, params)
+			if err != nil {
+				yield(OrderSNList{}, err)
+				return
+			}
+
+			for _, order := range resp.Response.OrderList {
+				if !yield(order, nil) {
+					return
+				}
+			}
+
+			next := resp.Response.NextCursor
+			if !resp.Response.More || next == "" || next == params.Cursor {
+				return
+			}
+			params.Cursor = next
+		}
+	}
+}
+
+type GetShipmentListParamsRequest struct {
+	PageSize int    `url:"page_size"`
+	Cursor   string `url:"cursor,omitempty"` // next_cursor of the previous page
+}
+
+// Response Get Shipment List, the orders ready to ship
+type (
+	GetShipmentListResponse struct {
+		BaseResponse
+		Response ShipmentListData `json:"response"`
+	}
+
+	ShipmentListData struct {
+		More       bool           `json:"more"`
+		NextCursor string         `json:"next_cursor"`
+		OrderList  []ShipmentList `json:"order_list"`
+	}
+
+	ShipmentList struct {
+		OrderSn       string `json:"order_sn"`
+		PackageNumber string `json:"package_number"`
+	}
+)
+
+func (o *OrderServiceOp) GetShipmentList(shopID uint64, token string, params GetShipmentListParamsRequest) (*GetShipmentListResponse, error) {
+	return o.GetShipmentListWithContext(context.Background(), shopID, token, params)
+}
+
+func (o *OrderServiceOp) GetShipmentListWithContext(ctx context.Context, shopID uint64, token string, params GetShipmentListParamsRequest) (*GetShipmentListResponse, error) {
+	path := "/order/get_shipment_list"
+	resp := new(GetShipmentListResponse)
+	err := o.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+type CancelOrderRequest struct {
+	OrderSN      string            `json:"order_sn"`
+	CancelReason string            `json:"cancel_reason"`       // OUT_OF_STOCK/UNDELIVERABLE_AREA, CUSTOMER_REQUEST is only used by some regions
+	ItemList     []CancelOrderItem `json:"item_list,omitempty"` // required for OUT_OF_STOCK
+}
+
+type CancelOrderItem struct {
+	ItemID  int64 `json:"item_id"`
+	ModelID int64 `json:"model_id"`
+}
+
+type CancelOrderResponse struct {
+	BaseResponse
+	Response struct {
+		UpdateTime int64 `json:"update_time"`
+	} `json:"response"`
+}
+
+func (o *OrderServiceOp) CancelOrder(shopID uint64, token string, request CancelOrderRequest) (*CancelOrderResponse, error) {
+	return o.CancelOrderWithContext(context.Background(), shopID, token, request)
+}
+
+func (o *OrderServiceOp) CancelOrderWithContext(ctx context.Context, shopID uint64, token string, request CancelOrderRequest) (*CancelOrderResponse, error) {
+	path := "/order/cancel_order"
+	resp := new(CancelOrderResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type HandleBuyerCancellationRequest struct {
+	OrderSN   string `json:"order_sn"`
+	Operation string `json:"operation"` // ACCEPT/REJECT
+}
+
+type HandleBuyerCancellationResponse struct {
+	BaseResponse
+	Response struct {
+		UpdateTime int64 `json:"update_time"`
+	} `json:"response"`
+}
+
+func (o *OrderServiceOp) HandleBuyerCancellation(shopID uint64, token string, request HandleBuyerCancellationRequest) (*HandleBuyerCancellationResponse, error) {
+	return o.HandleBuyerCancellationWithContext(context.Background(), shopID, token, request)
+}
+
+func (o *OrderServiceOp) HandleBuyerCancellationWithContext(ctx context.Context, shopID uint64, token string, request HandleBuyerCancellationRequest) (*HandleBuyerCancellationResponse, error) {
+	path := "/order/handle_buyer_cancellation"
+	resp := new(HandleBuyerCancellationResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
@@ -0,0 +1,109 @@
+package shopee
+
+import (
+	"context"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+type PaymentService interface {
+	GetEscrowDetail(shopID uint64, token string, params GetEscrowDetailParamsRequest) (*GetEscrowDetailResponse, error)
+	GetEscrowDetailWithContext(ctx context.Context, shopID uint64, token string, params GetEscrowDetailParamsRequest) (*GetEscrowDetailResponse, error)
+}
+
+type PaymentServiceOp struct {
+	client *ShopeeClient
+}
+
+type GetEscrowDetailParamsRequest struct {
+	OrderSN string `url:"order_sn"`
+}
+
+// Response Get Escrow Detail, the amounts the seller is paid for an order.
+// Shopee sends them without a currency, the one of the order applies.
+type (
+	GetEscrowDetailResponse struct {
+		BaseResponse
+		Response EscrowDetail `json:"response"`
+	}
+
+	EscrowDetail struct {
+		OrderSn           string      `json:"order_sn"`
+		BuyerUserName     string      `json:"buyer_user_name"`
+		ReturnOrderSnList []string    `json:"return_order_sn_list"`
+		OrderIncome       OrderIncome `json:"order_income"`
+	}
+
+	OrderIncome struct {
+		EscrowAmount            money.Money  `json:"escrow_amount"`
+		BuyerTotalAmount        money.Money  `json:"buyer_total_amount"`
+		OriginalPrice           money.Money  `json:"original_price"`
+		SellerDiscount          money.Money  `json:"seller_discount"`
+		ShopeeDiscount          money.Money  `json:"shopee_discount"`
+		VoucherFromSeller       money.Money  `json:"voucher_from_seller"`
+		VoucherFromShopee       money.Money  `json:"voucher_from_shopee"`
+		Coins                   money.Money  `json:"coins"`
+		BuyerPaidShippingFee    money.Money  `json:"buyer_paid_shipping_fee"`
+		BuyerTransactionFee     money.Money  `json:"buyer_transaction_fee"`
+		CrossBorderTax          money.Money  `json:"cross_border_tax"`
+		PaymentPromotion        money.Money  `json:"payment_promotion"`
+		CommissionFee           money.Money  `json:"commission_fee"`
+		ServiceFee              money.Money  `json:"service_fee"`
+		SellerTransactionFee    money.Money  `json:"seller_transaction_fee"`
+		SellerLostCompensation  money.Money  `json:"seller_lost_compensation"`
+		SellerCoinCashBack      money.Money  `json:"seller_coin_cash_back"`
+		EscrowTax               money.Money  `json:"escrow_tax"`
+		FinalShippingFee        money.Money  `json:"final_shipping_fee"`
+		ActualShippingFee       money.Money  `json:"actual_shipping_fee"`
+		EstimatedShippingFee    money.Money  `json:"estimated_shipping_fee"`
+		ShopeeShippingRebate    money.Money  `json:"shopee_shipping_rebate"`
+		ShippingFeeDiscount     money.Money  `json:"shipping_fee_discount_from_3pl"`
+		SellerShippingDiscount  money.Money  `json:"seller_shipping_discount"`
+		ReverseShippingFee      money.Money  `json:"reverse_shipping_fee"`
+		SellerReturnRefund      money.Money  `json:"seller_return_refund"`
+		DrcAdjustableRefund     money.Money  `json:"drc_adjustable_refund"`
+		CostOfGoodsSold         money.Money  `json:"cost_of_goods_sold"`
+		OriginalCostOfGoodsSold money.Money  `json:"original_cost_of_goods_sold"`
+		OriginalShopeeDiscount  money.Money  `json:"original_shopee_discount"`
+		FinalProductProtection  money.Money  `json:"final_product_protection"`
+		FinalEscrowProductGst   money.Money  `json:"final_escrow_product_gst"`
+		FinalEscrowShippingGst  money.Money  `json:"final_escrow_shipping_gst"`
+		OrderAmsCommissionFee   money.Money  `json:"order_ams_commission_fee"`
+		BuyerPaymentMethod      string       `json:"buyer_payment_method"`
+		InstalmentPlan          string       `json:"instalment_plan"`
+		SellerVoucherCode       []string     `json:"seller_voucher_code"`
+		Items                   []EscrowItem `json:"items"`
+	}
+
+	EscrowItem struct {
+		ItemID                    int64       `json:"item_id"`
+		ItemName                  string      `json:"item_name"`
+		ItemSku                   string      `json:"item_sku"`
+		ModelID                   int64       `json:"model_id"`
+		ModelName                 string      `json:"model_name"`
+		ModelSku                  string      `json:"model_sku"`
+		OriginalPrice             money.Money `json:"original_price"`
+		SellingPrice              money.Money `json:"selling_price"`
+		DiscountedPrice           money.Money `json:"discounted_price"`
+		SellerDiscount            money.Money `json:"seller_discount"`
+		ShopeeDiscount            money.Money `json:"shopee_discount"`
+		DiscountFromCoin          money.Money `json:"discount_from_coin"`
+		DiscountFromVoucherShopee money.Money `json:"discount_from_voucher_shopee"`
+		DiscountFromVoucherSeller money.Money `json:"discount_from_voucher_seller"`
+		ActivityType              string      `json:"activity_type"`
+		ActivityID                int64       `json:"activity_id"`
+		IsMainItem                bool        `json:"is_main_item"`
+		QuantityPurchased         int         `json:"quantity_purchased"`
+	}
+)
+
+func (s *PaymentServiceOp) GetEscrowDetail(shopID uint64, token string, params GetEscrowDetailParamsRequest) (*GetEscrowDetailResponse, error) {
+	return s.GetEscrowDetailWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *PaymentServiceOp) GetEscrowDetailWithContext(ctx context.Context, shopID uint64, token string, params GetEscrowDetailParamsRequest) (*GetEscrowDetailResponse, error) {
+	path := "/payment/get_escrow_detail"
+	resp := new(GetEscrowDetailResponse)
+	err := s.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
@@ -1,10 +1,42 @@
 package shopee
 
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
 type ProductService interface {
 	GetProductById(shopID uint64, token string, params GetProductParamRequest) (*GetProductResponse, error)
+	GetProductByIdWithContext(ctx context.Context, shopID uint64, token string, params GetProductParamRequest) (*GetProductResponse, error)
 	GetModelList(shopID uint64, token string, itemID uint64) (*GetModelListResponse, error)
+	GetModelListWithContext(ctx context.Context, shopID uint64, token string, itemID uint64) (*GetModelListResponse, error)
 	GetProductWithSearch(shopID uint64, token string, paramRequest GetProductWithSearchRequest) (*GetProductWithSearchResponse, error)
+	GetProductWithSearchWithContext(ctx context.Context, shopID uint64, token string, paramRequest GetProductWithSearchRequest) (*GetProductWithSearchResponse, error)
 	GetProductlList(shopID uint64, token string, paramRequest GetProductListParamRequest) (*GetProductListResponse, error)
+	GetProductlListWithContext(ctx context.Context, shopID uint64, token string, paramRequest GetProductListParamRequest) (*GetProductListResponse, error)
+	All(ctx context.Context, shopID uint64, token string, paramRequest GetProductListParamRequest) iter.Seq2[ItemProductList, error]
+	AddItem(shopID uint64, token string, request AddItemRequest) (*AddItemResponse, error)
+	AddItemWithContext(ctx context.Context, shopID uint64, token string, request AddItemRequest) (*AddItemResponse, error)
+	UpdateItem(shopID uint64, token string, request UpdateItemRequest) (*UpdateItemResponse, error)
+	UpdateItemWithContext(ctx context.Context, shopID uint64, token string, request UpdateItemRequest) (*UpdateItemResponse, error)
+	DeleteItem(shopID uint64, token string, itemID int64) (*DeleteItemResponse, error)
+	DeleteItemWithContext(ctx context.Context, shopID uint64, token string, itemID int64) (*DeleteItemResponse, error)
+	UnlistItem(shopID uint64, token string, request UnlistItemRequest) (*UnlistItemResponse, error)
+	UnlistItemWithContext(ctx context.Context, shopID uint64, token string, request UnlistItemRequest) (*UnlistItemResponse, error)
+	InitTierVariation(shopID uint64, token string, request InitTierVariationRequest) (*InitTierVariationResponse, error)
+	InitTierVariationWithContext(ctx context.Context, shopID uint64, token string, request InitTierVariationRequest) (*InitTierVariationResponse, error)
+	UpdateTierVariation(shopID uint64, token string, request UpdateTierVariationRequest) (*UpdateTierVariationResponse, error)
+	UpdateTierVariationWithContext(ctx context.Context, shopID uint64, token string, request UpdateTierVariationRequest) (*UpdateTierVariationResponse, error)
+	UpdateStock(shopID uint64, token string, request UpdateStockRequest) (*UpdateStockResponse, error)
+	UpdateStockWithContext(ctx context.Context, shopID uint64, token string, request UpdateStockRequest) (*UpdateStockResponse, error)
+	UpdatePrice(shopID uint64, token string, request UpdatePriceRequest) (*UpdatePriceResponse, error)
+	UpdatePriceWithContext(ctx context.Context, shopID uint64, token string, request UpdatePriceRequest) (*UpdatePriceResponse, error)
+	UploadImage(shopID uint64, token string, filename string) (*UploadMediaImageResponse, error)
+	UploadImageWithContext(ctx context.Context, shopID uint64, token string, filename string) (*UploadMediaImageResponse, error)
 }
 
 type GetProductResponse struct {
@@ -21,6 +53,7 @@ type ItemListData struct {
 	CategoryID      int64           `json:"category_id"`
 	ItemName        string          `json:"item_name"`
 	ItemSku         string          `json:"item_sku"`
+	Description     string          `json:"description"`
 	CreateTime      int64           `json:"create_time"`
 	UpdateTime      int64           `json:"update_time"`
 	AttributeList   []AttributeList `json:"attribute_list"`
@@ -86,7 +119,7 @@ type Dimension struct {
 }
 
 type Image struct {
-	ImageURLList []string `json:"image_url_list"`
+	ImageURLList []string `json:"image_url_list,omitempty"`
 	ImageIDList  []string `json:"image_id_list"`
 }
 
@@ -104,14 +137,27 @@ type PreOrder struct {
 	DaysToShip int64 `json:"days_to_ship"`
 }
 
+// PriceInfo is the price of an item or a model. The price of a model carries
+// no currency, it is the one of its item.
 type PriceInfo struct {
-	Currency                     string  `json:"currency"`
-	OriginalPrice                float64 `json:"original_price"`
-	CurrentPrice                 float64 `json:"current_price"`
-	InflatedPriceOfOriginalPrice float64 `json:"inflated_price_of_original_price"`
-	InflatedPriceOfCurrentPrice  float64 `json:"inflated_price_of_current_price"`
-	SipItemPrice                 float64 `json:"sip_item_price"`
-	SipItemPriceSource           string  `json:"sip_item_price_source"`
+	Currency                     string      `json:"currency"`
+	OriginalPrice                money.Money `json:"original_price"`
+	CurrentPrice                 money.Money `json:"current_price"`
+	InflatedPriceOfOriginalPrice money.Money `json:"inflated_price_of_original_price"`
+	InflatedPriceOfCurrentPrice  money.Money `json:"inflated_price_of_current_price"`
+	SipItemPrice                 money.Money `json:"sip_item_price"`
+	SipItemPriceSource           string      `json:"sip_item_price_source"`
+}
+
+func (p *PriceInfo) UnmarshalJSON(b []byte) error {
+	type priceInfo PriceInfo
+	if err := json.Unmarshal(b, (*priceInfo)(p)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(p.Currency, &p.OriginalPrice, &p.CurrentPrice,
+		&p.InflatedPriceOfOriginalPrice, &p.InflatedPriceOfCurrentPrice, &p.SipItemPrice)
+	return nil
 }
 
 type StockInfoV2 struct {
@@ -148,10 +194,14 @@ type ProductServiceOp struct {
 }
 
 func (s *ProductServiceOp) GetProductById(shopID uint64, token string, params GetProductParamRequest) (*GetProductResponse, error) {
+	return s.GetProductByIdWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ProductServiceOp) GetProductByIdWithContext(ctx context.Context, shopID uint64, token string, params GetProductParamRequest) (*GetProductResponse, error) {
 	path := "/product/get_item_base_info"
 
 	resp := new(GetProductResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -203,6 +253,10 @@ type StockInfo struct {
 }
 
 func (s *ProductServiceOp) GetModelList(shopID uint64, token string, itemID uint64) (*GetModelListResponse, error) {
+	return s.GetModelListWithContext(context.Background(), shopID, token, itemID)
+}
+
+func (s *ProductServiceOp) GetModelListWithContext(ctx context.Context, shopID uint64, token string, itemID uint64) (*GetModelListResponse, error) {
 	path := "/product/get_model_list"
 
 	opt := GetModelListRequest{
@@ -210,7 +264,7 @@ func (s *ProductServiceOp) GetModelList(shopID uint64, token string, itemID uint
 	}
 
 	resp := new(GetModelListResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, opt)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -240,13 +294,45 @@ type ItemProductList struct {
 }
 
 func (s *ProductServiceOp) GetProductlList(shopID uint64, token string, paramRequest GetProductListParamRequest) (*GetProductListResponse, error) {
+	return s.GetProductlListWithContext(context.Background(), shopID, token, paramRequest)
+}
+
+func (s *ProductServiceOp) GetProductlListWithContext(ctx context.Context, shopID uint64, token string, paramRequest GetProductListParamRequest) (*GetProductListResponse, error) {
 	path := "/product/get_item_list"
 
 	resp := new(GetProductListResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, paramRequest)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, paramRequest)
 	return resp, err
 }
 
+// All iterates over the items matching paramRequest, following next_offset
+// from page to page. A failed page is yielded as an error and ends the
+// iteration.
+func (s *ProductServiceOp) All(ctx context.Context, shopID uint64, token string, paramRequest GetProductListParamRequest) iter.Seq2[ItemProductList, error] {
+	return func(yield func(ItemProductList, error) bool) {
+		paramRequest := paramRequest
+		for {
+			resp, err := s.GetProductlListWithContext(ctx, shopID, token, paramRequest)
+			if err != nil {
+				yield(ItemProductList{}, err)
+				return
+			}
+
+			for _, item := range resp.Response.Item {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next := resp.Response.NextOffset
+			if !resp.Response.HasNextPage || next <= paramRequest.Offset {
+				return
+			}
+			paramRequest.Offset = next
+		}
+	}
+}
+
 type GetProductWithSearchResponse struct {
 	BaseResponse
 
@@ -274,9 +360,381 @@ type ItemProductListWithSearch struct {
 }
 
 func (s *ProductServiceOp) GetProductWithSearch(shopID uint64, token string, paramRequest GetProductWithSearchRequest) (*GetProductWithSearchResponse, error) {
+	return s.GetProductWithSearchWithContext(context.Background(), shopID, token, paramRequest)
+}
+
+func (s *ProductServiceOp) GetProductWithSearchWithContext(ctx context.Context, shopID uint64, token string, paramRequest GetProductWithSearchRequest) (*GetProductWithSearchResponse, error) {
 	path := "/product/search_item"
 
 	resp := new(GetProductWithSearchResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, paramRequest)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, paramRequest)
+	return resp, err
+}
+
+// AddItemRequest creates an item, the fields share the types of ItemListData.
+// Price and stock of an item with variations are set by InitTierVariation.
+type AddItemRequest struct {
+	CategoryID      int64            `json:"category_id"`
+	ItemName        string           `json:"item_name"`
+	ItemSku         string           `json:"item_sku,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	DescriptionType string           `json:"description_type,omitempty"`
+	DescriptionInfo *DescriptionInfo `json:"description_info,omitempty"`
+	OriginalPrice   float64          `json:"original_price"`
+	SellerStock     []SellerStock    `json:"seller_stock,omitempty"`
+	Weight          float64          `json:"weight"`
+	Dimension       *Dimension       `json:"dimension,omitempty"`
+	Image           Image            `json:"image"`
+	LogisticInfo    []LogisticInfo   `json:"logistic_info"`
+	AttributeList   []AttributeList  `json:"attribute_list,omitempty"`
+	PreOrder        *PreOrder        `json:"pre_order,omitempty"`
+	Condition       string           `json:"condition,omitempty"`
+	ItemStatus      string           `json:"item_status,omitempty"`
+	Brand           *Brand           `json:"brand,omitempty"`
+	TaxInfo         *TaxInfo         `json:"tax_info,omitempty"`
+}
+
+// UpdateItemRequest changes the item ItemID, zero fields are left as they
+// are. Price and stock are changed by UpdatePrice and UpdateStock.
+type UpdateItemRequest struct {
+	ItemID          int64            `json:"item_id"`
+	CategoryID      int64            `json:"category_id,omitempty"`
+	ItemName        string           `json:"item_name,omitempty"`
+	ItemSku         string           `json:"item_sku,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	DescriptionType string           `json:"description_type,omitempty"`
+	DescriptionInfo *DescriptionInfo `json:"description_info,omitempty"`
+	Weight          float64          `json:"weight,omitempty"`
+	Dimension       *Dimension       `json:"dimension,omitempty"`
+	Image           *Image           `json:"image,omitempty"`
+	LogisticInfo    []LogisticInfo   `json:"logistic_info,omitempty"`
+	AttributeList   []AttributeList  `json:"attribute_list,omitempty"`
+	PreOrder        *PreOrder        `json:"pre_order,omitempty"`
+	Condition       string           `json:"condition,omitempty"`
+	ItemStatus      string           `json:"item_status,omitempty"`
+	Brand           *Brand           `json:"brand,omitempty"`
+	TaxInfo         *TaxInfo         `json:"tax_info,omitempty"`
+}
+
+// UpdateItemRequest returns the request that writes i back unchanged, for a
+// read, modify, write round trip from GetProductById.
+func (i ItemListData) UpdateItemRequest() UpdateItemRequest {
+	req := UpdateItemRequest{
+		ItemID:          i.ItemID,
+		CategoryID:      i.CategoryID,
+		ItemName:        i.ItemName,
+		ItemSku:         i.ItemSku,
+		Description:     i.Description,
+		DescriptionType: i.DescriptionType,
+		LogisticInfo:    i.LogisticInfo,
+		AttributeList:   i.AttributeList,
+		PreOrder:        &i.PreOrder,
+		Condition:       i.Condition,
+		ItemStatus:      i.ItemStatus,
+	}
+
+	// weight is read as a string, "1.000"
+	if w, err := strconv.ParseFloat(i.Weight, 64); err == nil {
+		req.Weight = w
+	}
+	if i.Dimension != (Dimension{}) {
+		req.Dimension = &i.Dimension
+	}
+	if len(i.Image.ImageIDList) > 0 {
+		req.Image = &Image{ImageIDList: i.Image.ImageIDList}
+	}
+	if len(i.DescriptionInfo.ExtendedDescription.FieldList) > 0 {
+		req.DescriptionInfo = &i.DescriptionInfo
+	}
+	if i.Brand != (Brand{}) {
+		req.Brand = &i.Brand
+	}
+	if i.TaxInfo != (TaxInfo{}) {
+		req.TaxInfo = &i.TaxInfo
+	}
+	return req
+}
+
+// ItemWriteData is the item returned by AddItem and UpdateItem.
+type ItemWriteData struct {
+	ItemID     int64  `json:"item_id"`
+	ItemName   string `json:"item_name"`
+	ItemSku    string `json:"item_sku"`
+	ItemStatus string `json:"item_status"`
+	CategoryID int64  `json:"category_id"`
+	CreateTime int64  `json:"create_time"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+type AddItemResponse struct {
+	BaseResponse
+	Response ItemWriteData `json:"response"`
+}
+
+func (s *ProductServiceOp) AddItem(shopID uint64, token string, request AddItemRequest) (*AddItemResponse, error) {
+	return s.AddItemWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) AddItemWithContext(ctx context.Context, shopID uint64, token string, request AddItemRequest) (*AddItemResponse, error) {
+	path := "/product/add_item"
+	resp := new(AddItemResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type UpdateItemResponse struct {
+	BaseResponse
+	Response ItemWriteData `json:"response"`
+}
+
+func (s *ProductServiceOp) UpdateItem(shopID uint64, token string, request UpdateItemRequest) (*UpdateItemResponse, error) {
+	return s.UpdateItemWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) UpdateItemWithContext(ctx context.Context, shopID uint64, token string, request UpdateItemRequest) (*UpdateItemResponse, error) {
+	path := "/product/update_item"
+	resp := new(UpdateItemResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type DeleteItemResponse struct {
+	BaseResponse
+}
+
+func (s *ProductServiceOp) DeleteItem(shopID uint64, token string, itemID int64) (*DeleteItemResponse, error) {
+	return s.DeleteItemWithContext(context.Background(), shopID, token, itemID)
+}
+
+func (s *ProductServiceOp) DeleteItemWithContext(ctx context.Context, shopID uint64, token string, itemID int64) (*DeleteItemResponse, error) {
+	path := "/product/delete_item"
+	resp := new(DeleteItemResponse)
+	req := map[string]interface{}{"item_id": itemID}
+	err := s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// UnlistItemRequest unlists the items with Unlist set and lists the others
+// again.
+type UnlistItemRequest struct {
+	ItemList []UnlistItem `json:"item_list"`
+}
+
+type UnlistItem struct {
+	ItemID int64 `json:"item_id"`
+	Unlist bool  `json:"unlist"`
+}
+
+type UnlistItemResponse struct {
+	BaseResponse
+	Response struct {
+		FailureList []ItemFailure `json:"failure_list"`
+		SuccessList []UnlistItem  `json:"success_list"`
+	} `json:"response"`
+}
+
+type ItemFailure struct {
+	ItemID       int64  `json:"item_id"`
+	FailedReason string `json:"failed_reason"`
+}
+
+func (s *ProductServiceOp) UnlistItem(shopID uint64, token string, request UnlistItemRequest) (*UnlistItemResponse, error) {
+	return s.UnlistItemWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) UnlistItemWithContext(ctx context.Context, shopID uint64, token string, request UnlistItemRequest) (*UnlistItemResponse, error) {
+	path := "/product/unlist_item"
+	resp := new(UnlistItemResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// InitTierVariationRequest turns an item without variations into one with
+// variations, Model lists every combination of TierVariation options.
+type InitTierVariationRequest struct {
+	ItemID        int64           `json:"item_id"`
+	TierVariation []TierVariation `json:"tier_variation"`
+	Model         []InitTierModel `json:"model"`
+}
+
+type InitTierModel struct {
+	TierIndex     []int         `json:"tier_index"`
+	OriginalPrice float64       `json:"original_price"`
+	ModelSKU      string        `json:"model_sku,omitempty"`
+	SellerStock   []SellerStock `json:"seller_stock,omitempty"`
+}
+
+type InitTierVariationResponse struct {
+	BaseResponse
+	Response struct {
+		ItemID        int64           `json:"item_id"`
+		TierVariation []TierVariation `json:"tier_variation"`
+		Model         []Model         `json:"model"`
+	} `json:"response"`
+}
+
+func (s *ProductServiceOp) InitTierVariation(shopID uint64, token string, request InitTierVariationRequest) (*InitTierVariationResponse, error) {
+	return s.InitTierVariationWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) InitTierVariationWithContext(ctx context.Context, shopID uint64, token string, request InitTierVariationRequest) (*InitTierVariationResponse, error) {
+	path := "/product/init_tier_variation"
+	resp := new(InitTierVariationResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// UpdateTierVariationRequest renames tiers and options of an item, the
+// TierVariation of GetModelList can be sent back after changing it.
+type UpdateTierVariationRequest struct {
+	ItemID        int64           `json:"item_id"`
+	TierVariation []TierVariation `json:"tier_variation"`
+}
+
+type UpdateTierVariationResponse struct {
+	BaseResponse
+}
+
+func (s *ProductServiceOp) UpdateTierVariation(shopID uint64, token string, request UpdateTierVariationRequest) (*UpdateTierVariationResponse, error) {
+	return s.UpdateTierVariationWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) UpdateTierVariationWithContext(ctx context.Context, shopID uint64, token string, request UpdateTierVariationRequest) (*UpdateTierVariationResponse, error) {
+	path := "/product/update_tier_variation"
+	resp := new(UpdateTierVariationResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// UpdateStockRequest sets the seller stock of an item, ModelID is 0 for an
+// item without variations.
+type UpdateStockRequest struct {
+	ItemID    int64        `json:"item_id"`
+	StockList []ModelStock `json:"stock_list"`
+}
+
+type ModelStock struct {
+	ModelID     uint64        `json:"model_id"`
+	SellerStock []SellerStock `json:"seller_stock"`
+}
+
+type ModelFailure struct {
+	ModelID      uint64 `json:"model_id"`
+	FailedReason string `json:"failed_reason"`
+}
+
+type UpdateStockResponse struct {
+	BaseResponse
+	Response struct {
+		FailureList []ModelFailure `json:"failure_list"`
+		SuccessList []ModelStock   `json:"success_list"`
+	} `json:"response"`
+}
+
+func (s *ProductServiceOp) UpdateStock(shopID uint64, token string, request UpdateStockRequest) (*UpdateStockResponse, error) {
+	return s.UpdateStockWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) UpdateStockWithContext(ctx context.Context, shopID uint64, token string, request UpdateStockRequest) (*UpdateStockResponse, error) {
+	path := "/product/update_stock"
+	resp := new(UpdateStockResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// UpdatePriceRequest sets the original price of an item, ModelID is 0 for an
+// item without variations.
+type UpdatePriceRequest struct {
+	ItemID    int64        `json:"item_id"`
+	PriceList []ModelPrice `json:"price_list"`
+}
+
+type ModelPrice struct {
+	ModelID       uint64  `json:"model_id"`
+	OriginalPrice float64 `json:"original_price"`
+}
+
+type UpdatePriceResponse struct {
+	BaseResponse
+	Response struct {
+		FailureList []ModelFailure `json:"failure_list"`
+		SuccessList []ModelPrice   `json:"success_list"`
+	} `json:"response"`
+}
+
+func (s *ProductServiceOp) UpdatePrice(shopID uint64, token string, request UpdatePriceRequest) (*UpdatePriceResponse, error) {
+	return s.UpdatePriceWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ProductServiceOp) UpdatePriceWithContext(ctx context.Context, shopID uint64, token string, request UpdatePriceRequest) (*UpdatePriceResponse, error) {
+	path := "/product/update_price"
+	resp := new(UpdatePriceResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type UploadMediaImageResponse struct {
+	BaseResponse
+	Response struct {
+		ImageInfo MediaImageInfo `json:"image_info"`
+	} `json:"response"`
+}
+
+type MediaImageInfo struct {
+	ImageID      string          `json:"image_id"`
+	ImageURLList []MediaImageURL `json:"image_url_list"`
+}
+
+type MediaImageURL struct {
+	ImageURLRegion string `json:"image_url_region"`
+	ImageURL       string `json:"image_url"`
+}
+
+// UploadImage uploads the image at the URL filename to the media space, the
+// returned image_id goes into Image.ImageIDList of AddItem and UpdateItem.
+func (s *ProductServiceOp) UploadImage(shopID uint64, token string, filename string) (*UploadMediaImageResponse, error) {
+	return s.UploadImageWithContext(context.Background(), shopID, token, filename)
+}
+
+func (s *ProductServiceOp) UploadImageWithContext(ctx context.Context, shopID uint64, token string, filename string) (*UploadMediaImageResponse, error) {
+	path := "/media_space/upload_image"
+
+	resp := new(UploadMediaImageResponse)
+	err := s.client.ForShop(shopID, token).UploadWithContext(ctx, path, "image", filename, resp)
 	return resp, err
 }
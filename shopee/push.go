@@ -0,0 +1,309 @@
+package shopee
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// https://open.shopee.com/developer-guide/20
+type PushCode int
+
+const (
+	PushShopAuthorization          PushCode = 1
+	PushShopDeauthorization        PushCode = 2
+	PushOrderStatus                PushCode = 3
+	PushOrderTrackingNo            PushCode = 4
+	PushShopeeUpdates              PushCode = 5
+	PushBannedItem                 PushCode = 6
+	PushItemPromotion              PushCode = 7
+	PushReservedStockChange        PushCode = 8
+	PushPromotionUpdate            PushCode = 9
+	PushWebchat                    PushCode = 10
+	PushVideoUpload                PushCode = 11
+	PushOpenAPIAuthorizationExpiry PushCode = 12
+)
+
+// maxPushBody is the largest push body the handler reads.
+const maxPushBody = 1 << 20
+
+// PushEvent is the envelope of every push, Data holds the code specific part.
+type PushEvent struct {
+	Code       PushCode        `json:"code"`
+	ShopID     uint64          `json:"shop_id"`
+	MerchantID uint64          `json:"merchant_id,omitempty"`
+	Timestamp  int64           `json:"timestamp"`
+	Data       json.RawMessage `json:"data,omitempty"`
+
+	// set on shop authorization and deauthorization pushes
+	Success int    `json:"success,omitempty"`
+	Extra   string `json:"extra,omitempty"`
+}
+
+type ShopAuthorizationPush struct {
+	ShopID     uint64
+	MerchantID uint64
+	Success    bool
+	Extra      string
+	Timestamp  int64
+}
+
+type OrderStatusPush struct {
+	ShopID            uint64 `json:"-"`
+	Timestamp         int64  `json:"-"`
+	OrderSN           string `json:"ordersn"`
+	Status            string `json:"status"`
+	CompletedScenario string `json:"completed_scenario,omitempty"`
+	UpdateTime        int64  `json:"update_time"`
+}
+
+type OrderTrackingNoPush struct {
+	ShopID        uint64 `json:"-"`
+	Timestamp     int64  `json:"-"`
+	OrderSN       string `json:"ordersn"`
+	ForderID      string `json:"forder_id"`
+	PackageNumber string `json:"package_number"`
+	TrackingNo    string `json:"tracking_no"`
+}
+
+// WebchatPush is a chat event, Message is set when Type is "message".
+type WebchatPush struct {
+	ShopID    uint64          `json:"-"`
+	Timestamp int64           `json:"-"`
+	Type      string          `json:"type"`
+	Content   json.RawMessage `json:"content"`
+	Message   *Messages       `json:"-"`
+}
+
+type AuthorizationExpiryPush struct {
+	Timestamp          int64    `json:"-"`
+	MerchantExpireSoon []uint64 `json:"merchant_expire_soon"`
+	ShopExpireSoon     []uint64 `json:"shop_expire_soon"`
+	ExpireBefore       int64    `json:"expire_before"`
+	PageNo             int      `json:"page_no"`
+	TotalPage          int      `json:"total_page"`
+}
+
+// VerifyPushSignature checks the Authorization header of a push, which is
+// HMAC-SHA256(partnerKey, callbackURL + "|" + body) in hex of either case.
+func VerifyPushSignature(partnerKey, callbackURL string, body []byte, authorization string) bool {
+	signature, err := hex.DecodeString(authorization)
+	if err != nil {
+		return false
+	}
+	h := hmac.New(sha256.New, []byte(partnerKey))
+	h.Write([]byte(callbackURL + "|"))
+	h.Write(body)
+	return hmac.Equal(h.Sum(nil), signature)
+}
+
+// PushHandler is an http.Handler receiving Shopee push notifications. It
+// verifies the signature, decodes the push and calls the callback registered
+// for its code, or the OnPush callback when there is none. A callback error
+// answers 500 so Shopee sends the push again.
+//
+// Register callbacks before serving, they are not guarded for concurrent
+// registration.
+type PushHandler struct {
+	partnerKey  string
+	callbackURL string
+
+	onPush                func(ctx context.Context, e *PushEvent) error
+	onShopAuthorization   func(ctx context.Context, e *ShopAuthorizationPush) error
+	onShopDeauthorization func(ctx context.Context, e *ShopAuthorizationPush) error
+	onOrderStatus         func(ctx context.Context, e *OrderStatusPush) error
+	onOrderTrackingNo     func(ctx context.Context, e *OrderTrackingNoPush) error
+	onWebchat             func(ctx context.Context, e *WebchatPush) error
+	onAuthorizationExpiry func(ctx context.Context, e *AuthorizationExpiryPush) error
+}
+
+// NewPushHandler returns a handler for pushes signed with the partner key of
+// app. callbackURL must be the URL set in the Shopee console, it is part of
+// the signature. When empty, the URL is rebuilt from the request, which only
+// works when no proxy rewrites it.
+func NewPushHandler(app AppConfig, callbackURL string) *PushHandler {
+	return &PushHandler{partnerKey: app.PartnerKey, callbackURL: callbackURL}
+}
+
+// OnPush sets the callback for pushes without a typed callback.
+func (h *PushHandler) OnPush(fn func(ctx context.Context, e *PushEvent) error) {
+	h.onPush = fn
+}
+
+func (h *PushHandler) OnShopAuthorization(fn func(ctx context.Context, e *ShopAuthorizationPush) error) {
+	h.onShopAuthorization = fn
+}
+
+func (h *PushHandler) OnShopDeauthorization(fn func(ctx context.Context, e *ShopAuthorizationPush) error) {
+	h.onShopDeauthorization = fn
+}
+
+func (h *PushHandler) OnOrderStatus(fn func(ctx context.Context, e *OrderStatusPush) error) {
+	h.onOrderStatus = fn
+}
+
+func (h *PushHandler) OnOrderTrackingNo(fn func(ctx context.Context, e *OrderTrackingNoPush) error) {
+	h.onOrderTrackingNo = fn
+}
+
+func (h *PushHandler) OnWebchat(fn func(ctx context.Context, e *WebchatPush) error) {
+	h.onWebchat = fn
+}
+
+func (h *PushHandler) OnAuthorizationExpiry(fn func(ctx context.Context, e *AuthorizationExpiryPush) error) {
+	h.onAuthorizationExpiry = fn
+}
+
+func (h *PushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBody))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if !VerifyPushSignature(h.partnerKey, h.requestURL(r), body, r.Header.Get("Authorization")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var e PushEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		http.Error(w, "invalid push body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), &e); err != nil {
+		status := http.StatusInternalServerError
+		var dataErr *PushDataError
+		if errors.As(err, &dataErr) {
+			// a retry would not decode any better
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *PushHandler) requestURL(r *http.Request) string {
+	if h.callbackURL != "" {
+		return h.callbackURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+}
+
+// Dispatch decodes a verified push and calls its callback, it is used by
+// ServeHTTP and is handy for pushes received through a queue.
+func (h *PushHandler) Dispatch(ctx context.Context, e *PushEvent) error {
+	switch e.Code {
+	case PushShopAuthorization, PushShopDeauthorization:
+		fn := h.onShopAuthorization
+		if e.Code == PushShopDeauthorization {
+			fn = h.onShopDeauthorization
+		}
+		if fn == nil {
+			break
+		}
+		return fn(ctx, &ShopAuthorizationPush{
+			ShopID:     e.ShopID,
+			MerchantID: e.MerchantID,
+			Success:    e.Success == 1,
+			Extra:      e.Extra,
+			Timestamp:  e.Timestamp,
+		})
+
+	case PushOrderStatus:
+		if h.onOrderStatus == nil {
+			break
+		}
+		data := &OrderStatusPush{ShopID: e.ShopID, Timestamp: e.Timestamp}
+		if err := decodePushData(e, data); err != nil {
+			return err
+		}
+		return h.onOrderStatus(ctx, data)
+
+	case PushOrderTrackingNo:
+		if h.onOrderTrackingNo == nil {
+			break
+		}
+		data := &OrderTrackingNoPush{ShopID: e.ShopID, Timestamp: e.Timestamp}
+		if err := decodePushData(e, data); err != nil {
+			return err
+		}
+		return h.onOrderTrackingNo(ctx, data)
+
+	case PushWebchat:
+		if h.onWebchat == nil {
+			break
+		}
+		data := &WebchatPush{ShopID: e.ShopID, Timestamp: e.Timestamp}
+		if err := decodePushData(e, data); err != nil {
+			return err
+		}
+		if data.Type == "message" {
+			data.Message = new(Messages)
+			if err := json.Unmarshal(data.Content, data.Message); err != nil {
+				return &PushDataError{Code: e.Code, Err: err}
+			}
+		}
+		return h.onWebchat(ctx, data)
+
+	case PushOpenAPIAuthorizationExpiry:
+		if h.onAuthorizationExpiry == nil {
+			break
+		}
+		data := &AuthorizationExpiryPush{Timestamp: e.Timestamp}
+		if err := decodePushData(e, data); err != nil {
+			return err
+		}
+		return h.onAuthorizationExpiry(ctx, data)
+	}
+
+	if h.onPush != nil {
+		return h.onPush(ctx, e)
+	}
+	return nil
+}
+
+// PushDataError is returned by Dispatch when the data of a push does not
+// match its code.
+type PushDataError struct {
+	Code PushCode
+	Err  error
+}
+
+func (e *PushDataError) Error() string {
+	return fmt.Sprintf("shopee: decode push code %d: %s", e.Code, e.Err)
+}
+
+func (e *PushDataError) Unwrap() error {
+	return e.Err
+}
+
+func decodePushData(e *PushEvent, v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return &PushDataError{Code: e.Code, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package shopee
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+type ReturnService interface {
+	GetReturnList(shopID uint64, token string, params GetReturnListParamsRequest) (*GetReturnListResponse, error)
+	GetReturnListWithContext(ctx context.Context, shopID uint64, token string, params GetReturnListParamsRequest) (*GetReturnListResponse, error)
+	GetReturnDetail(shopID uint64, token string, params GetReturnDetailParamsRequest) (*GetReturnDetailResponse, error)
+	GetReturnDetailWithContext(ctx context.Context, shopID uint64, token string, params GetReturnDetailParamsRequest) (*GetReturnDetailResponse, error)
+	Confirm(shopID uint64, token string, request ConfirmReturnRequest) (*ConfirmReturnResponse, error)
+	ConfirmWithContext(ctx context.Context, shopID uint64, token string, request ConfirmReturnRequest) (*ConfirmReturnResponse, error)
+	Dispute(shopID uint64, token string, request DisputeReturnRequest) (*DisputeReturnResponse, error)
+	DisputeWithContext(ctx context.Context, shopID uint64, token string, request DisputeReturnRequest) (*DisputeReturnResponse, error)
+}
+
+type ReturnServiceOp struct {
+	client *ShopeeClient
+}
+
+type GetReturnListParamsRequest struct {
+	PageNo                   int    `url:"page_no"`
+	PageSize                 int    `url:"page_size"`
+	CreateTimeFrom           int64  `url:"create_time_from,omitempty"` // epoch based
+	CreateTimeTo             int64  `url:"create_time_to,omitempty"`
+	UpdateTimeFrom           int64  `url:"update_time_from,omitempty"`
+	UpdateTimeTo             int64  `url:"update_time_to,omitempty"`
+	Status                   string `url:"status,omitempty"` // REQUESTED/ACCEPTED/CANCELLED/JUDGING/CLOSED/PROCESSING/SELLER_DISPUTE
+	NegotiationStatus        string `url:"negotiation_status,omitempty"`
+	SellerProofStatus        string `url:"seller_proof_status,omitempty"`
+	SellerCompensationStatus string `url:"seller_compensation_status,omitempty"`
+}
+
+// Response Get Return List and Get Return Detail
+type (
+	GetReturnListResponse struct {
+		BaseResponse
+		Response ReturnListData `json:"response"`
+	}
+
+	ReturnListData struct {
+		More   bool     `json:"more"`
+		Return []Return `json:"return"`
+	}
+
+	GetReturnDetailResponse struct {
+		BaseResponse
+		Response Return `json:"response"`
+	}
+
+	Return struct {
+		ReturnSn             string              `json:"return_sn"`
+		OrderSn              string              `json:"order_sn"`
+		Status               string              `json:"status"`
+		Reason               string              `json:"reason"`
+		TextReason           string              `json:"text_reason"`
+		Image                []string            `json:"image"`
+		BuyerVideos          []ReturnBuyerVideo  `json:"buyer_videos"`
+		RefundAmount         money.Money         `json:"refund_amount"`
+		AmountBeforeDiscount money.Money         `json:"amount_before_discount"`
+		Currency             string              `json:"currency"`
+		CreateTime           int64               `json:"create_time"`
+		UpdateTime           int64               `json:"update_time"`
+		DueDate              int64               `json:"due_date"`
+		ReturnShipDueDate    int64               `json:"return_ship_due_date"`
+		ReturnSellerDueDate  int64               `json:"return_seller_due_date"`
+		TrackingNumber       string              `json:"tracking_number"`
+		NeedsLogistics       bool                `json:"needs_logistics"`
+		LogisticsStatus      string              `json:"logistics_status"`
+		DisputeReason        []int               `json:"dispute_reason"`
+		DisputeTextReason    []string            `json:"dispute_text_reason"`
+		User                 ReturnUser          `json:"user"`
+		Item                 []ReturnItem        `json:"item"`
+		Negotiation          *ReturnNegotiation  `json:"negotiation"`
+		SellerProof          *ReturnSellerProof  `json:"seller_proof"`
+		SellerCompensation   *ReturnCompensation `json:"seller_compensation"`
+	}
+
+	ReturnBuyerVideo struct {
+		ThumbnailURL string `json:"thumbnail_url"`
+		VideoURL     string `json:"video_url"`
+	}
+
+	ReturnUser struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Portrait string `json:"portrait"`
+	}
+
+	ReturnItem struct {
+		ItemID       int64       `json:"item_id"`
+		ModelID      int64       `json:"model_id"`
+		Name         string      `json:"name"`
+		Images       []string    `json:"images"`
+		Amount       int         `json:"amount"`
+		ItemPrice    money.Money `json:"item_price"`
+		IsAddOnDeal  bool        `json:"is_add_on_deal"`
+		IsMainItem   bool        `json:"is_main_item"`
+		AddOnDealID  int64       `json:"add_on_deal_id"`
+		ItemSku      string      `json:"item_sku"`
+		VariationSku string      `json:"variation_sku"`
+		RefundAmount money.Money `json:"refund_amount"`
+	}
+
+	ReturnNegotiation struct {
+		NegotiationStatus   string      `json:"negotiation_status"`
+		LatestSolution      string      `json:"latest_solution"`
+		LatestOfferAmount   money.Money `json:"latest_offer_amount"`
+		LatestOfferCreator  string      `json:"latest_offer_creator"`
+		CounterOfferAmount  money.Money `json:"counter_offer_amount"`
+		OfferDueDate        int64       `json:"offer_due_date"`
+		MaxRefundableAmount money.Money `json:"max_refundable_amount"`
+	}
+
+	ReturnSellerProof struct {
+		SellerProofStatus      string `json:"seller_proof_status"`
+		SellerEvidenceDeadline int64  `json:"seller_evidence_deadline"`
+	}
+
+	ReturnCompensation struct {
+		SellerCompensationStatus  string      `json:"seller_compensation_status"`
+		SellerCompensationDueDate int64       `json:"seller_compensation_due_date"`
+		CompensationAmount        money.Money `json:"compensation_amount"`
+	}
+)
+
+// UnmarshalJSON sets the currency of the return on its amounts and the ones of
+// its items, negotiation and compensation.
+func (r *Return) UnmarshalJSON(b []byte) error {
+	type ret Return
+	if err := json.Unmarshal(b, (*ret)(r)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(r.Currency, &r.RefundAmount, &r.AmountBeforeDiscount)
+	for i := range r.Item {
+		money.FillCurrency(r.Currency, &r.Item[i].ItemPrice, &r.Item[i].RefundAmount)
+	}
+	if r.Negotiation != nil {
+		money.FillCurrency(r.Currency, &r.Negotiation.LatestOfferAmount,
+			&r.Negotiation.CounterOfferAmount, &r.Negotiation.MaxRefundableAmount)
+	}
+	if r.SellerCompensation != nil {
+		money.FillCurrency(r.Currency, &r.SellerCompensation.CompensationAmount)
+	}
+	return nil
+}
+
+func (s *ReturnServiceOp) GetReturnList(shopID uint64, token string, params GetReturnListParamsRequest) (*GetReturnListResponse, error) {
+	return s.GetReturnListWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ReturnServiceOp) GetReturnListWithContext(ctx context.Context, shopID uint64, token string, params GetReturnListParamsRequest) (*GetReturnListResponse, error) {
+	path := "/returns/get_return_list"
+	resp := new(GetReturnListResponse)
+	err := s.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+type GetReturnDetailParamsRequest struct {
+	ReturnSN string `url:"return_sn"`
+}
+
+func (s *ReturnServiceOp) GetReturnDetail(shopID uint64, token string, params GetReturnDetailParamsRequest) (*GetReturnDetailResponse, error) {
+	return s.GetReturnDetailWithContext(context.Background(), shopID, token, params)
+}
+
+func (s *ReturnServiceOp) GetReturnDetailWithContext(ctx context.Context, shopID uint64, token string, params GetReturnDetailParamsRequest) (*GetReturnDetailResponse, error) {
+	path := "/returns/get_return_detail"
+	resp := new(GetReturnDetailResponse)
+	err := s.client.ForShop(shopID, token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+// ConfirmReturnRequest accepts a return, refunding the buyer.
+type ConfirmReturnRequest struct {
+	ReturnSN string `json:"return_sn"`
+}
+
+type ConfirmReturnResponse struct {
+	BaseResponse
+	Response struct {
+		ReturnSN string `json:"return_sn"`
+	} `json:"response"`
+}
+
+func (s *ReturnServiceOp) Confirm(shopID uint64, token string, request ConfirmReturnRequest) (*ConfirmReturnResponse, error) {
+	return s.ConfirmWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ReturnServiceOp) ConfirmWithContext(ctx context.Context, shopID uint64, token string, request ConfirmReturnRequest) (*ConfirmReturnResponse, error) {
+	path := "/returns/confirm"
+	resp := new(ConfirmReturnResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// DisputeReturnRequest raises a dispute on a return, Images are the URLs of
+// the evidence.
+type DisputeReturnRequest struct {
+	ReturnSN          string   `json:"return_sn"`
+	Email             string   `json:"email"`
+	DisputeReason     int      `json:"dispute_reason"`
+	DisputeTextReason string   `json:"dispute_text_reason,omitempty"`
+	Images            []string `json:"images,omitempty"`
+}
+
+type DisputeReturnResponse struct {
+	BaseResponse
+	Response struct {
+		ReturnSN string `json:"return_sn"`
+	} `json:"response"`
+}
+
+func (s *ReturnServiceOp) Dispute(shopID uint64, token string, request DisputeReturnRequest) (*DisputeReturnResponse, error) {
+	return s.DisputeWithContext(context.Background(), shopID, token, request)
+}
+
+func (s *ReturnServiceOp) DisputeWithContext(ctx context.Context, shopID uint64, token string, request DisputeReturnRequest) (*DisputeReturnResponse, error) {
+	path := "/returns/dispute"
+	resp := new(DisputeReturnResponse)
+	req, err := StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.ForShop(shopID, token).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
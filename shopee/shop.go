@@ -1,7 +1,10 @@
 package shopee
 
+import "context"
+
 type ShopService interface {
 	GetShopInfo(shopID uint64, token string) (*GetShopInfoResponse, error)
+	GetShopInfoWithContext(ctx context.Context, shopID uint64, token string) (*GetShopInfoResponse, error)
 }
 
 type GetShopInfoResponse struct {
@@ -25,8 +28,12 @@ type ShopServiceOp struct {
 }
 
 func (s *ShopServiceOp) GetShopInfo(shopID uint64, token string) (*GetShopInfoResponse, error) {
+	return s.GetShopInfoWithContext(context.Background(), shopID, token)
+}
+
+func (s *ShopServiceOp) GetShopInfoWithContext(ctx context.Context, shopID uint64, token string) (*GetShopInfoResponse, error) {
 	path := "/shop/get_shop_info"
 	resp := new(GetShopInfoResponse)
-	err := s.client.WithShop(uint64(shopID), token).Get(path, resp, nil)
+	err := s.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, nil)
 	return resp, err
 }
@@ -2,6 +2,7 @@ package shopee
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -19,6 +20,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 	"github.com/google/go-querystring/query"
 )
 
@@ -43,9 +48,22 @@ type ShopeeClient struct {
 	baseURL   *url.URL
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
 
+	// looks up shop tokens when a call has none, see WithTokenSource
+	tokenSource TokenSource
+
+	// wraps the transport once options are applied, see WithMiddleware
+	middleware []middleware.Option
+
+	// records or replays the transport, see WithRecorder
+	recorder *cassette.Recorder
+
+	// traces and measures calls, see WithTelemetry
+	telemetry []telemetry.Option
+
+	// Deprecated: set by WithShop, WithMerchant and WithToken, use ForShop or
+	// ForMerchant instead.
 	ShopID      uint64
 	MerchantID  uint64
 	AccessToken string
@@ -57,6 +75,8 @@ type ShopeeClient struct {
 	Shop        ShopService
 	Voucher     VoucherService
 	Logistic    LogisticService
+	Return      ReturnService
+	Payment     PaymentService
 }
 
 // A general response error
@@ -64,6 +84,11 @@ type ResponseError struct {
 	Status  int
 	Message string
 	Errors  []string
+
+	// Code is the error field of the body, e.g. error_auth, and RequestID
+	// the request_id.
+	Code      string
+	RequestID string
 }
 
 // NewClient returns a new Shopee API client with an already authenticated  and
@@ -89,10 +114,22 @@ func NewClient(app AppConfig, opts ...Option) *ShopeeClient {
 	c.Shop = &ShopServiceOp{client: c}
 	c.Voucher = &VoucherServiceOp{client: c}
 	c.Logistic = &LogisticServiceOp{client: c}
+	c.Return = &ReturnServiceOp{client: c}
+	c.Payment = &PaymentServiceOp{client: c}
 	// apply any options
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.recorder != nil {
+		c.Client.Transport = c.recorder.Wrap(c.Client.Transport)
+	}
+	if c.telemetry != nil {
+		c.Client.Transport = telemetry.New(c.Client.Transport, telemetryMarketplace, c.telemetry...)
+	}
+	if c.middleware != nil {
+		c.Client.Transport = middleware.New(c.Client.Transport, c.middleware...)
+		c.retries = 0 // the middleware retries instead
+	}
 
 	return c
 }
@@ -151,6 +188,17 @@ type RateLimitError struct {
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body and it's ok.
 func (c *ShopeeClient) NewRequest(method, relPath string, body, options, headers interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options, headers)
+}
+
+// NewRequestWithContext is NewRequest with a context, the context controls the
+// whole lifetime of the request, including retries.
+func (c *ShopeeClient) NewRequestWithContext(ctx context.Context, method, relPath string, body, options, headers interface{}) (*http.Request, error) {
+	return c.newRequest(ctx, method, relPath, body, options, c.credential())
+}
+
+// newRequest creates an API request signed for shop.
+func (c *ShopeeClient) newRequest(ctx context.Context, method, relPath string, body, options interface{}, shop shopCredential) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -183,7 +231,7 @@ func (c *ShopeeClient) NewRequest(method, relPath string, body, options, headers
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		return nil, err
 	}
@@ -191,30 +239,119 @@ func (c *ShopeeClient) NewRequest(method, relPath string, body, options, headers
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 
-	c.makeSignature(req)
+	c.makeSignature(req, shop)
 
 	return req, nil
 }
 
+// WithShop sets the shop used by the next request. It mutates the shared
+// client, so concurrent requests can be sent for each other's shop.
+//
+// Deprecated: use ForShop.
 func (c *ShopeeClient) WithShop(sid uint64, tok string) *ShopeeClient {
 	c.ShopID = sid
 	c.AccessToken = tok
 	return c
 }
 
+// WithMerchant sets the merchant used by the next request, mutating the
+// client as WithShop does.
+//
+// Deprecated: use ForMerchant.
 func (c *ShopeeClient) WithMerchant(mid uint64, tok string) *ShopeeClient {
 	c.MerchantID = mid
 	c.AccessToken = tok
 	return c
 }
 
+// WithToken sets the access token used by the next request, mutating the
+// client as WithShop does.
+//
+// Deprecated: use ForShop or ForMerchant.
 func (c *ShopeeClient) WithToken(tok string) *ShopeeClient {
 	c.AccessToken = tok
 	return c
 }
 
+// shopCredential identifies who a request is made for. The zero value is used
+// for public APIs.
+type shopCredential struct {
+	shopID      uint64
+	merchantID  uint64
+	accessToken string
+}
+
+// ShopClient performs requests on behalf of one shop or merchant. It never
+// changes after creation, so a single ShopeeClient can serve many shops from
+// concurrent goroutines.
+type ShopClient struct {
+	client *ShopeeClient
+	shop   shopCredential
+}
+
+// ForShop returns a ShopClient calling shop APIs for shop sid with token tok.
+func (c *ShopeeClient) ForShop(sid uint64, tok string) *ShopClient {
+	return &ShopClient{client: c, shop: shopCredential{shopID: sid, accessToken: tok}}
+}
+
+// ForMerchant returns a ShopClient calling merchant APIs for merchant mid with
+// token tok.
+func (c *ShopeeClient) ForMerchant(mid uint64, tok string) *ShopClient {
+	return &ShopClient{client: c, shop: shopCredential{merchantID: mid, accessToken: tok}}
+}
+
+// public returns a ShopClient for APIs that are not tied to a shop.
+func (c *ShopeeClient) public() *ShopClient {
+	return &ShopClient{client: c}
+}
+
+// resolve returns the credential of s, asking the token source for the access
+// token when the shop was given without one.
+func (s *ShopClient) resolve(ctx context.Context) (shopCredential, error) {
+	shop := s.shop
+	if shop.accessToken != "" || s.client.tokenSource == nil {
+		return shop, nil
+	}
+
+	var id uint64
+	switch {
+	case shop.shopID != 0:
+		id = shop.shopID
+	case shop.merchantID != 0:
+		id = shop.merchantID
+	default:
+		return shop, nil
+	}
+
+	tok, err := s.client.tokenSource.AccessToken(ctx, strconv.FormatUint(id, 10))
+	if err != nil {
+		return shop, fmt.Errorf("shopee: access token of %d: %w", id, err)
+	}
+	shop.accessToken = tok
+	return shop, nil
+}
+
+// credential returns the shop set with the deprecated WithShop, WithMerchant
+// and WithToken.
+func (c *ShopeeClient) credential() shopCredential {
+	return shopCredential{shopID: c.ShopID, merchantID: c.MerchantID, accessToken: c.AccessToken}
+}
+
+// takeCredential returns the shop set with the deprecated setters and clears it
+// for the next call. Nothing is written when no shop was set, so public calls
+// stay free of data races.
+func (c *ShopeeClient) takeCredential() shopCredential {
+	shop := c.credential()
+	if shop != (shopCredential{}) {
+		c.ShopID = 0
+		c.MerchantID = 0
+		c.AccessToken = ""
+	}
+	return shop
+}
+
 // https://open.shopee.com/documents?module=87&type=2&id=58&version=2
-func (c *ShopeeClient) makeSignature(req *http.Request) (string, int64) {
+func (c *ShopeeClient) makeSignature(req *http.Request, shop shopCredential) (string, int64) {
 	ts := time.Now().Unix()
 	path := req.URL.Path
 
@@ -225,16 +362,16 @@ func (c *ShopeeClient) makeSignature(req *http.Request) (string, int64) {
 	query := u.Query()
 	query.Add("partner_id", fmt.Sprintf("%v", c.appConfig.PartnerID))
 
-	if c.ShopID != 0 {
+	if shop.shopID != 0 {
 		// Shop APIs: partner_id, api path, timestamp, access_token, shop_id
-		baseStr = fmt.Sprintf("%d%s%d%s%d", c.appConfig.PartnerID, path, ts, c.AccessToken, c.ShopID)
-		query.Add("shop_id", fmt.Sprintf("%v", c.ShopID))
-		query.Add("access_token", c.AccessToken)
-	} else if c.MerchantID != 0 {
+		baseStr = fmt.Sprintf("%d%s%d%s%d", c.appConfig.PartnerID, path, ts, shop.accessToken, shop.shopID)
+		query.Add("shop_id", fmt.Sprintf("%v", shop.shopID))
+		query.Add("access_token", shop.accessToken)
+	} else if shop.merchantID != 0 {
 		// Merchant APIs: partner_id, api path, timestamp, access_token, merchant_id
-		baseStr = fmt.Sprintf("%d%s%d%s%d", c.appConfig.PartnerID, path, ts, c.AccessToken, c.MerchantID)
-		query.Add("merchant_id", fmt.Sprintf("%v", c.MerchantID))
-		query.Add("access_token", c.AccessToken)
+		baseStr = fmt.Sprintf("%d%s%d%s%d", c.appConfig.PartnerID, path, ts, shop.accessToken, shop.merchantID)
+		query.Add("merchant_id", fmt.Sprintf("%v", shop.merchantID))
+		query.Add("access_token", shop.accessToken)
 	} else {
 		// Public APIs: partner_id, api path, timestamp
 		baseStr = fmt.Sprintf("%d%s%d", c.appConfig.PartnerID, path, ts)
@@ -258,13 +395,18 @@ func (c *ShopeeClient) doGetHeaders(req *http.Request, v any, skipBody bool) (ht
 	var err error
 
 	retries := c.retries
-	c.attempts = 0
+	attempts := 0
 	c.logRequest(req, skipBody)
 
 	for {
-		c.attempts++
+		attempts++
 
-		resp, err = c.Client.Do(req)
+		// not every transport checks the context before sending
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		resp, err = c.Client.Do(req.WithContext(middleware.WithAttempt(req.Context(), attempts-1)))
 		c.logResponse(resp)
 		if err != nil {
 			return nil, err // http client errors, not api responses
@@ -286,8 +428,10 @@ func (c *ShopeeClient) doGetHeaders(req *http.Request, v any, skipBody bool) (ht
 			// back off and retry
 
 			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
+			c.log.Debugf("rate limited on attempt %d, waiting %s", attempts, wait.String())
+			if err := clock.Sleep(req.Context(), wait); err != nil {
+				return nil, err
+			}
 			retries--
 			continue
 		}
@@ -295,7 +439,7 @@ func (c *ShopeeClient) doGetHeaders(req *http.Request, v any, skipBody bool) (ht
 		var doRetry bool
 		switch resp.StatusCode {
 		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
+			c.log.Debugf("service unavailable on attempt %d, retrying", attempts)
 			doRetry = true
 			retries--
 		}
@@ -382,8 +526,9 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 // {"error":"error_category_is_block.","message":"Category is restricted","request_id":"97994a47af37a22da79cb910bfd9841a"}
 func CheckResponseError(r *http.Response) error {
 	shopeeError := struct {
-		Error   string `json:"error"`
-		Message string `json:"message"`
+		Error     string `json:"error"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
 	}{}
 
 	bodyBytes, err := io.ReadAll(r.Body)
@@ -412,8 +557,10 @@ func CheckResponseError(r *http.Response) error {
 	}
 
 	responseError := ResponseError{
-		Status:  r.StatusCode,
-		Message: fmt.Sprintf("shopee-%s [%s]", shopeeError.Error, shopeeError.Message),
+		Status:    r.StatusCode,
+		Message:   fmt.Sprintf("shopee-%s [%s]", shopeeError.Error, shopeeError.Message),
+		Code:      shopeeError.Error,
+		RequestID: shopeeError.RequestID,
 	}
 
 	return wrapSpecificError(r, responseError)
@@ -426,14 +573,31 @@ func CheckResponseError(r *http.Response) error {
 // If the data argument is non-nil, it will be used as the body of the request
 // for POST and PUT requests.
 func (c *ShopeeClient) CreateAndDo(method, relPath string, data, options, headers, resource any) error {
-	defer func() {
-		// clear for next call
-		c.ShopID = 0
-		c.MerchantID = 0
-		c.AccessToken = ""
-	}()
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, headers, resource)
+}
+
+// CreateAndDoWithContext is CreateAndDo with a context for cancellation and
+// deadlines.
+func (c *ShopeeClient) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, headers, resource any) error {
+	s := &ShopClient{client: c, shop: c.takeCredential()}
+	return s.CreateAndDoWithContext(ctx, method, relPath, data, options, headers, resource)
+}
 
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, headers, resource)
+// CreateAndDo performs a web request to Shopee for the shop of s, see
+// ShopeeClient.CreateAndDo.
+func (s *ShopClient) CreateAndDo(method, relPath string, data, options, headers, resource any) error {
+	return s.CreateAndDoWithContext(context.Background(), method, relPath, data, options, headers, resource)
+}
+
+// CreateAndDoWithContext is CreateAndDo with a context for cancellation and
+// deadlines.
+func (s *ShopClient) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, headers, resource any) error {
+	shop, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.createAndDoGetHeaders(ctx, method, relPath, data, options, headers, resource, shop)
 	if err != nil {
 		return err
 	}
@@ -441,7 +605,7 @@ func (c *ShopeeClient) CreateAndDo(method, relPath string, data, options, header
 }
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *ShopeeClient) createAndDoGetHeaders(method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
+func (c *ShopeeClient) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, headers, resource interface{}, shop shopCredential) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -450,12 +614,16 @@ func (c *ShopeeClient) createAndDoGetHeaders(method, relPath string, data, optio
 	relPath = path.Join("api/v2", relPath)
 
 	if data != nil {
-		params := data.(map[string]interface{})
+		// copy, the caller may share its map between goroutines
+		params := make(map[string]interface{})
+		for k, v := range data.(map[string]interface{}) {
+			params[k] = v
+		}
 		params["partner_id"] = c.appConfig.PartnerID
 		data = params
 	}
 
-	req, err := c.NewRequest(method, relPath, data, options, headers)
+	req, err := c.newRequest(ctx, method, relPath, data, options, shop)
 	if err != nil {
 		return nil, err
 	}
@@ -469,32 +637,138 @@ func (c *ShopeeClient) Get(path string, resource, options interface{}) error {
 	return c.CreateAndDo("GET", path, nil, options, nil, resource)
 }
 
+// GetWithContext is Get with a context.
+func (c *ShopeeClient) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, nil, resource)
+}
+
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *ShopeeClient) Post(path string, data, resource interface{}) error {
 	return c.CreateAndDo("POST", path, data, nil, nil, resource)
 }
 
+// PostWithContext is Post with a context.
+func (c *ShopeeClient) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, nil, resource)
+}
+
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *ShopeeClient) Put(path string, data, resource interface{}) error {
 	return c.CreateAndDo("PUT", path, data, nil, nil, resource)
 }
 
+// PutWithContext is Put with a context.
+func (c *ShopeeClient) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, nil, resource)
+}
+
 // Delete performs a DELETE request for the given path
 func (c *ShopeeClient) Delete(path string) error {
 	return c.CreateAndDo("DELETE", path, nil, nil, nil, nil)
 }
 
+// DeleteWithContext is Delete with a context.
+func (c *ShopeeClient) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
 // Upload performs a Upload request for the given path and saves the result in the
 // given resource.
 func (c *ShopeeClient) Upload(relPath, fieldname, filename string, resource interface{}) error {
-	req, err := c.NewfileUploadRequest(relPath, fieldname, filename)
+	return c.UploadWithContext(context.Background(), relPath, fieldname, filename, resource)
+}
+
+// UploadWithContext is Upload with a context.
+func (c *ShopeeClient) UploadWithContext(ctx context.Context, relPath, fieldname, filename string, resource interface{}) error {
+	s := &ShopClient{client: c, shop: c.takeCredential()}
+	return s.UploadWithContext(ctx, relPath, fieldname, filename, resource)
+}
+
+// Get performs a GET request for the shop of s.
+func (s *ShopClient) Get(path string, resource, options interface{}) error {
+	return s.CreateAndDo("GET", path, nil, options, nil, resource)
+}
+
+// GetWithContext is Get with a context.
+func (s *ShopClient) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return s.CreateAndDoWithContext(ctx, "GET", path, nil, options, nil, resource)
+}
+
+// Post performs a POST request for the shop of s.
+func (s *ShopClient) Post(path string, data, resource interface{}) error {
+	return s.CreateAndDo("POST", path, data, nil, nil, resource)
+}
+
+// PostWithContext is Post with a context.
+func (s *ShopClient) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return s.CreateAndDoWithContext(ctx, "POST", path, data, nil, nil, resource)
+}
+
+// Put performs a PUT request for the shop of s.
+func (s *ShopClient) Put(path string, data, resource interface{}) error {
+	return s.CreateAndDo("PUT", path, data, nil, nil, resource)
+}
+
+// PutWithContext is Put with a context.
+func (s *ShopClient) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return s.CreateAndDoWithContext(ctx, "PUT", path, data, nil, nil, resource)
+}
+
+// Delete performs a DELETE request for the shop of s.
+func (s *ShopClient) Delete(path string) error {
+	return s.CreateAndDo("DELETE", path, nil, nil, nil, nil)
+}
+
+// DeleteWithContext is Delete with a context.
+func (s *ShopClient) DeleteWithContext(ctx context.Context, path string) error {
+	return s.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// Upload performs a Upload request for the shop of s.
+func (s *ShopClient) Upload(relPath, fieldname, filename string, resource interface{}) error {
+	return s.UploadWithContext(context.Background(), relPath, fieldname, filename, resource)
+}
+
+// UploadWithContext is Upload with a context, the context also covers the
+// download of filename.
+func (s *ShopClient) UploadWithContext(ctx context.Context, relPath, fieldname, filename string, resource interface{}) error {
+	shop, err := s.resolve(ctx)
 	if err != nil {
 		return err
 	}
 
-	if _, err := c.doGetHeaders(req, resource, true); err != nil {
+	req, err := s.client.newFileUploadRequest(ctx, relPath, fieldname, filename, shop)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.doGetHeaders(req, resource, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UploadVideo performs a video upload request for the shop of s.
+func (s *ShopClient) UploadVideo(relPath, filename string, fileBytes []byte, resource interface{}) error {
+	return s.UploadVideoWithContext(context.Background(), relPath, filename, fileBytes, resource)
+}
+
+// UploadVideoWithContext is UploadVideo with a context.
+func (s *ShopClient) UploadVideoWithContext(ctx context.Context, relPath, filename string, fileBytes []byte, resource interface{}) error {
+	shop, err := s.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newFileUploadVideo(ctx, relPath, filename, fileBytes, shop)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.doGetHeaders(req, resource, false); err != nil {
 		return err
 	}
 
@@ -503,6 +777,10 @@ func (c *ShopeeClient) Upload(relPath, fieldname, filename string, resource inte
 
 // Creates a new file upload http request with optional extra params
 func (c *ShopeeClient) NewfileUploadRequest(relPath, paramName, filename string) (*http.Request, error) {
+	return c.newFileUploadRequest(context.Background(), relPath, paramName, filename, c.credential())
+}
+
+func (c *ShopeeClient) newFileUploadRequest(ctx context.Context, relPath, paramName, filename string, shop shopCredential) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -520,7 +798,11 @@ func (c *ShopeeClient) NewfileUploadRequest(relPath, paramName, filename string)
 	uri := u.String()
 
 	// Replace os.Open with http.Get to fetch data from the URL
-	resp, err := http.Get(filename)
+	fileReq, err := http.NewRequestWithContext(ctx, http.MethodGet, filename, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(fileReq)
 	if err != nil {
 		return nil, err
 	}
@@ -543,7 +825,7 @@ func (c *ShopeeClient) NewfileUploadRequest(relPath, paramName, filename string)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", uri, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, body)
 	if err != nil {
 		return nil, err
 	}
@@ -552,11 +834,15 @@ func (c *ShopeeClient) NewfileUploadRequest(relPath, paramName, filename string)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", UserAgent)
 
-	c.makeSignature(req)
+	c.makeSignature(req, shop)
 	return req, nil
 }
 
 func (c *ShopeeClient) NewfileUploadVideo(relPath, fileName string, fileBytes []byte) (*http.Request, error) {
+	return c.newFileUploadVideo(context.Background(), relPath, fileName, fileBytes, c.credential())
+}
+
+func (c *ShopeeClient) newFileUploadVideo(ctx context.Context, relPath, fileName string, fileBytes []byte, shop shopCredential) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -582,26 +868,23 @@ func (c *ShopeeClient) NewfileUploadVideo(relPath, fileName string, fileBytes []
 	part.Write(fileBytes)
 	writer.Close()
 
-	req, err := http.NewRequest("POST", uri, &buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, &buf)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	c.makeSignature(req)
+	c.makeSignature(req, shop)
 
 	return req, nil
 }
 
 func (c *ShopeeClient) UploadVideo(relPath, filename string, fileBytes []byte, resource interface{}) error {
-	req, err := c.NewfileUploadVideo(relPath, filename, fileBytes)
-	if err != nil {
-		return err
-	}
-
-	if _, err := c.doGetHeaders(req, resource, false); err != nil {
-		return err
-	}
+	return c.UploadVideoWithContext(context.Background(), relPath, filename, fileBytes, resource)
+}
 
-	return nil
+// UploadVideoWithContext is UploadVideo with a context.
+func (c *ShopeeClient) UploadVideoWithContext(ctx context.Context, relPath, filename string, fileBytes []byte, resource interface{}) error {
+	s := &ShopClient{client: c, shop: c.takeCredential()}
+	return s.UploadVideoWithContext(ctx, relPath, filename, fileBytes, resource)
 }
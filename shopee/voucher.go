@@ -1,8 +1,16 @@
 package shopee
 
+import (
+	"context"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
 type VoucherService interface {
 	GetListVoucherByStatus(shopID uint64, token string, params GetVoucherListParam) (*GetVoucherListResponse, error)
+	GetListVoucherByStatusWithContext(ctx context.Context, shopID uint64, token string, params GetVoucherListParam) (*GetVoucherListResponse, error)
 	GetDetailVoucher(shopID uint64, token string, params GetDetailVoucherParam) (*GetVoucherDetailResponse, error)
+	GetDetailVoucherWithContext(ctx context.Context, shopID uint64, token string, params GetDetailVoucherParam) (*GetVoucherDetailResponse, error)
 }
 
 type GetVoucherListParam struct {
@@ -25,22 +33,24 @@ type VoucherListResponse struct {
 	VoucherList []VoucherList `json:"voucher_list"`
 }
 
+// VoucherList is a voucher of the shop. Shopee sends the amounts of vouchers
+// without a currency, it is the one of the shop.
 type VoucherList struct {
-	VoucherID        int64  `json:"voucher_id"`
-	VoucherCode      string `json:"voucher_code"`
-	VoucherName      string `json:"voucher_name"`
-	VoucherType      int    `json:"voucher_type"`
-	RewardType       int    `json:"reward_type"`
-	UsageQuantity    int    `json:"usage_quantity"`
-	CurrentUsage     int    `json:"current_usage"`
-	StartTime        int    `json:"start_time"`
-	EndTime          int    `json:"end_time"`
-	IsAdmin          bool   `json:"is_admin"`
-	VoucherPurpose   int    `json:"voucher_purpose"`
-	DiscountAmount   int    `json:"discount_amount,omitempty"`
-	TargetVoucher    int    `json:"target_voucher"`
-	DisplayStartTime int    `json:"display_start_time"`
-	Percentage       int    `json:"percentage,omitempty"`
+	VoucherID        int64       `json:"voucher_id"`
+	VoucherCode      string      `json:"voucher_code"`
+	VoucherName      string      `json:"voucher_name"`
+	VoucherType      int         `json:"voucher_type"`
+	RewardType       int         `json:"reward_type"`
+	UsageQuantity    int         `json:"usage_quantity"`
+	CurrentUsage     int         `json:"current_usage"`
+	StartTime        int         `json:"start_time"`
+	EndTime          int         `json:"end_time"`
+	IsAdmin          bool        `json:"is_admin"`
+	VoucherPurpose   int         `json:"voucher_purpose"`
+	DiscountAmount   money.Money `json:"discount_amount,omitzero"`
+	TargetVoucher    int         `json:"target_voucher"`
+	DisplayStartTime int         `json:"display_start_time"`
+	Percentage       int         `json:"percentage,omitempty"`
 }
 
 type VoucherServiceOp struct {
@@ -48,9 +58,13 @@ type VoucherServiceOp struct {
 }
 
 func (v *VoucherServiceOp) GetListVoucherByStatus(shopID uint64, token string, params GetVoucherListParam) (*GetVoucherListResponse, error) {
+	return v.GetListVoucherByStatusWithContext(context.Background(), shopID, token, params)
+}
+
+func (v *VoucherServiceOp) GetListVoucherByStatusWithContext(ctx context.Context, shopID uint64, token string, params GetVoucherListParam) (*GetVoucherListResponse, error) {
 	path := "/voucher/get_voucher_list"
 	resp := new(GetVoucherListResponse)
-	err := v.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := v.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -59,31 +73,36 @@ type GetVoucherDetailResponse struct {
 	Response DataVoucherDetail `json:"response"`
 }
 
+// DataVoucherDetail amounts carry no currency, as VoucherList ones.
 type DataVoucherDetail struct {
-	CurrentUsage       int    `json:"current_usage"`
-	DiscountAmount     int    `json:"discount_amount"`
-	DisplayChannelList []int  `json:"display_channel_list"`
-	DisplayStartTime   int    `json:"display_start_time"`
-	EndTime            int    `json:"end_time"`
-	IsAdmin            bool   `json:"is_admin"`
-	MaxPrice           int    `json:"max_price"`
-	MinBasketPrice     int    `json:"min_basket_price"`
-	Percentage         int    `json:"percentage"`
-	RewardType         int    `json:"reward_type"`
-	StartTime          int    `json:"start_time"`
-	TargetVoucher      int    `json:"target_voucher"`
-	UsageQuantity      int    `json:"usage_quantity"`
-	Usecase            int    `json:"usecase"`
-	VoucherCode        string `json:"voucher_code"`
-	VoucherID          int64  `json:"voucher_id"`
-	VoucherName        string `json:"voucher_name"`
-	VoucherPurpose     int    `json:"voucher_purpose"`
-	VoucherType        int    `json:"voucher_type"`
+	CurrentUsage       int         `json:"current_usage"`
+	DiscountAmount     money.Money `json:"discount_amount"`
+	DisplayChannelList []int       `json:"display_channel_list"`
+	DisplayStartTime   int         `json:"display_start_time"`
+	EndTime            int         `json:"end_time"`
+	IsAdmin            bool        `json:"is_admin"`
+	MaxPrice           money.Money `json:"max_price"`
+	MinBasketPrice     money.Money `json:"min_basket_price"`
+	Percentage         int         `json:"percentage"`
+	RewardType         int         `json:"reward_type"`
+	StartTime          int         `json:"start_time"`
+	TargetVoucher      int         `json:"target_voucher"`
+	UsageQuantity      int         `json:"usage_quantity"`
+	Usecase            int         `json:"usecase"`
+	VoucherCode        string      `json:"voucher_code"`
+	VoucherID          int64       `json:"voucher_id"`
+	VoucherName        string      `json:"voucher_name"`
+	VoucherPurpose     int         `json:"voucher_purpose"`
+	VoucherType        int         `json:"voucher_type"`
 }
 
 func (v *VoucherServiceOp) GetDetailVoucher(shopID uint64, token string, params GetDetailVoucherParam) (*GetVoucherDetailResponse, error) {
+	return v.GetDetailVoucherWithContext(context.Background(), shopID, token, params)
+}
+
+func (v *VoucherServiceOp) GetDetailVoucherWithContext(ctx context.Context, shopID uint64, token string, params GetDetailVoucherParam) (*GetVoucherDetailResponse, error) {
 	path := "/voucher/get_voucher"
 	resp := new(GetVoucherDetailResponse)
-	err := v.client.WithShop(uint64(shopID), token).Get(path, resp, params)
+	err := v.client.ForShop(uint64(shopID), token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
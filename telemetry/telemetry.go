@@ -0,0 +1,233 @@
+// Package telemetry traces and measures the calls of the marketplace clients
+// with OpenTelemetry. Turn it on with the WithTelemetry option of a client,
+// which uses the global providers unless told otherwise:
+//
+//	client := shopee.NewClient(app, shopee.WithTelemetry(
+//		telemetry.WithTracerProvider(tp),
+//		telemetry.WithMeterProvider(mp),
+//	))
+//
+// Every attempt of a call is a client span named by its method and route,
+// with the attributes below, and is counted in the RequestsMetric counter and
+// the DurationMetric histogram by marketplace, method, route, status and
+// error code. The shop is left out of the metrics, whose series would grow
+// with the shops, unless WithShopMetrics is given.
+//
+// Whatever the order of the options, a client wraps the telemetry transport
+// around the transport set by its other options and its WithRecorder
+// recorder, and the transport of its WithMiddleware option around telemetry. A retried
+// call is therefore a span for each attempt, and time spent waiting on the
+// rate limiter or between retries is not measured.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
+
+// Metric names.
+const (
+	RequestsMetric = "marketplace.client.requests"
+	DurationMetric = "marketplace.client.request.duration"
+)
+
+// Attribute keys of the spans and metrics.
+const (
+	MarketplaceKey  = attribute.Key("marketplace.name")
+	ShopIDKey       = attribute.Key("marketplace.shop_id")
+	ErrorCodeKey    = attribute.Key("marketplace.error_code")
+	RequestIDKey    = attribute.Key("marketplace.request_id")
+	MethodKey       = attribute.Key("http.request.method")
+	PathKey         = attribute.Key("url.path")
+	RouteKey        = attribute.Key("http.route")
+	StatusKey       = attribute.Key("http.response.status_code")
+	RetryAttemptKey = attribute.Key("http.request.resend_count")
+)
+
+// Option is used to configure a Transport with options
+type Option func(c *config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	shopMetrics    bool
+}
+
+// WithTracerProvider sets the provider of the tracer, otel.GetTracerProvider
+// when unset.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the provider of the meter, otel.GetMeterProvider
+// when unset.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithShopMetrics adds the shop to the attributes of the metrics, for a
+// backend that copes with a series per shop.
+func WithShopMetrics() Option {
+	return func(c *config) {
+		c.shopMetrics = true
+	}
+}
+
+// Marketplace tells a Transport about the calls of a marketplace, the
+// clients fill it in for theirs.
+type Marketplace struct {
+	Name string
+	// ShopKey returns the shop a request is made for, empty for none.
+	ShopKey func(req *http.Request) string
+	// Route returns the path with its ids replaced, so metrics stay few.
+	// The path is kept when nil.
+	Route func(path string) string
+	// Describe returns the error code and the request id of a response,
+	// it must leave the body readable, see middleware.PeekBody.
+	Describe func(resp *http.Response) (code, requestID string)
+}
+
+// Transport is an http.RoundTripper tracing and measuring each round trip.
+type Transport struct {
+	base        http.RoundTripper
+	marketplace Marketplace
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	shopMetrics bool
+}
+
+// New wraps base, http.DefaultTransport when nil.
+func New(base http.RoundTripper, m Marketplace, opts ...Option) *Transport {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	requests, err := meter.Int64Counter(RequestsMetric,
+		metric.WithDescription("Calls made to the marketplace APIs."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram(DurationMetric,
+		metric.WithDescription("Duration of the calls made to the marketplace APIs."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &Transport{
+		base:        base,
+		marketplace: m,
+		tracer:      c.tracerProvider.Tracer(ScopeName),
+		requests:    requests,
+		duration:    duration,
+		shopMetrics: c.shopMetrics,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := req.URL.Path
+	if t.marketplace.Route != nil {
+		route = t.marketplace.Route(route)
+	}
+	attrs := []attribute.KeyValue{
+		MarketplaceKey.String(t.marketplace.Name),
+		MethodKey.String(req.Method),
+		RouteKey.String(route),
+	}
+	spanAttrs := []attribute.KeyValue{
+		PathKey.String(req.URL.Path),
+		RetryAttemptKey.Int(middleware.Attempt(req.Context())),
+	}
+	if t.marketplace.ShopKey != nil {
+		if shop := t.marketplace.ShopKey(req); shop != "" {
+			if t.shopMetrics {
+				attrs = append(attrs, ShopIDKey.String(shop))
+			} else {
+				spanAttrs = append(spanAttrs, ShopIDKey.String(shop))
+			}
+		}
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(spanAttrs...))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.transport().RoundTrip(req.WithContext(ctx))
+	elapsed := time.Since(start).Seconds()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.record(ctx, elapsed, attrs)
+		return resp, err
+	}
+
+	var code, requestID string
+	if t.marketplace.Describe != nil {
+		code, requestID = t.marketplace.Describe(resp)
+	}
+	n := len(attrs)
+	attrs = append(attrs, StatusKey.Int(resp.StatusCode))
+	if code != "" {
+		attrs = append(attrs, ErrorCodeKey.String(code))
+	}
+	span.SetAttributes(attrs[n:]...)
+	if requestID != "" {
+		span.SetAttributes(RequestIDKey.String(requestID))
+	}
+	if code != "" || resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, errorDescription(resp.StatusCode, code))
+	}
+	t.record(ctx, elapsed, attrs)
+	return resp, nil
+}
+
+func (t *Transport) record(ctx context.Context, elapsed float64, attrs []attribute.KeyValue) {
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	if t.requests != nil {
+		t.requests.Add(ctx, 1, set)
+	}
+	if t.duration != nil {
+		t.duration.Record(ctx, elapsed, set)
+	}
+}
+
+func (t *Transport) transport() http.RoundTripper {
+	if t.base != nil {
+		return t.base
+	}
+	return http.DefaultTransport
+}
+
+func errorDescription(status int, code string) string {
+	if code != "" {
+		return code
+	}
+	return strconv.Itoa(status) + " " + http.StatusText(status)
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func asAPIError(t *testing.T, err error) apierror.Error {
+	var apiErr apierror.Error
+	require.ErrorAs(t, err, &apiErr)
+	return apiErr
+}
+
+func Test_CodesCategory(t *testing.T) {
+	codes := apierror.Codes{"expired": apierror.ErrTokenExpired}
+
+	assert.Equal(t, apierror.ErrTokenExpired, codes.Category(http.StatusOK, "expired"))
+	// the code wins over the status
+	assert.Equal(t, apierror.ErrTokenExpired, codes.Category(http.StatusBadRequest, "expired"))
+	assert.Equal(t, apierror.ErrNotFound, codes.Category(http.StatusNotFound, "other"))
+	assert.Equal(t, apierror.ErrRateLimited, codes.Category(http.StatusTooManyRequests, ""))
+	assert.Nil(t, codes.Category(http.StatusOK, "other"))
+
+	assert.True(t, apierror.IsRetryable(apierror.ErrRateLimited))
+	assert.True(t, apierror.IsRetryable(apierror.ErrUnavailable))
+	assert.False(t, apierror.IsRetryable(apierror.ErrTokenExpired))
+	assert.False(t, apierror.IsRetryable(nil))
+}
+
+func Test_ShopeeErrors(t *testing.T) {
+	s := sandbox.NewShopee(2001234, "partner-key")
+	defer s.Close()
+	s.AddShop(77001, "shopee-token", shopee.GetShopInfoResponse{})
+	path := "/api/v2/shop/get_shop_info"
+
+	_, err := s.Client().Shop.GetShopInfo(77001, "stolen")
+	assert.ErrorIs(t, err, apierror.ErrTokenExpired)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, apierror.Shopee, apiErr.GetMarketplace())
+	assert.Equal(t, "error_auth", apiErr.GetCode())
+	assert.NotEmpty(t, apiErr.GetRequestID())
+	assert.False(t, apiErr.Retryable())
+
+	s.Fail(path, s.ErrorFault("error_busy", "busy"))
+	_, err = s.Client().Shop.GetShopInfo(77001, "shopee-token")
+	assert.ErrorIs(t, err, apierror.ErrUnavailable)
+	apiErr = asAPIError(t, err)
+	assert.Equal(t, http.StatusOK, apiErr.GetStatus())
+	assert.True(t, apiErr.Retryable())
+
+	// a RateLimitError is categorized like the ResponseError it embeds
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	_, err = s.Client().Shop.GetShopInfo(77001, "shopee-token")
+	var rateErr shopee.RateLimitError
+	require.ErrorAs(t, err, &rateErr)
+	assert.ErrorIs(t, err, apierror.ErrRateLimited)
+	assert.False(t, errors.Is(err, apierror.ErrTokenExpired))
+}
+
+func Test_LazadaErrors(t *testing.T) {
+	s := sandbox.NewLazada("100200", "lazada-secret")
+	defer s.Close()
+	s.AddShop("lazada-token")
+	ctx := context.Background()
+	params := &lazada.GetProductsParams{Offset: "0", Limit: "10"}
+
+	_, err := s.Client().Product.GetProducts(ctx, "stolen", params)
+	assert.ErrorIs(t, err, apierror.ErrTokenExpired)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, apierror.Lazada, apiErr.GetMarketplace())
+	assert.Equal(t, "IllegalAccessToken", apiErr.GetCode())
+	assert.Equal(t, http.StatusOK, apiErr.GetStatus())
+	assert.NotEmpty(t, apiErr.GetRequestID())
+
+	// a failed status is the same ResponseError value
+	s.Fail("/rest/products/get", sandbox.Unavailable())
+	_, err = s.Client().Product.GetProducts(ctx, "lazada-token", params)
+	var respErr lazada.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusServiceUnavailable, respErr.Status)
+	assert.ErrorIs(t, err, apierror.ErrUnavailable)
+	assert.True(t, respErr.Retryable())
+	// as it was matched before
+	var respErrPtr *lazada.ResponseError
+	require.ErrorAs(t, err, &respErrPtr)
+	assert.Equal(t, http.StatusServiceUnavailable, respErrPtr.Status)
+
+	s.Fail("/rest/products/get", s.ErrorFault("ApiCallLimit", "too many calls"))
+	_, err = s.Client().Product.GetProducts(ctx, "lazada-token", params)
+	assert.ErrorIs(t, err, apierror.ErrRateLimited)
+}
+
+func Test_TikTokErrors(t *testing.T) {
+	s := sandbox.NewTikTok("tiktok-app", "tiktok-secret")
+	defer s.Close()
+	s.AddShop("tiktok-token", tiktok.Shops{Cipher: "cipher-1"})
+
+	bad := tiktok.NewClient(tiktok.AppConfig{AppKey: "tiktok-app", AppSecret: "wrong", APIURL: s.URL(), Version: sandbox.TikTokVersion})
+	_, err := bad.Auth.GetAuthorizationShop("tiktok-token", "")
+	assert.ErrorIs(t, err, apierror.ErrUnauthorized)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, apierror.Tiktok, apiErr.GetMarketplace())
+	assert.Equal(t, http.StatusUnauthorized, apiErr.GetStatus())
+	assert.Equal(t, "106001", apiErr.GetCode())
+
+	client := s.Client()
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "stolen", ShopCipher: "cipher-1"})
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"1"}})
+	assert.ErrorIs(t, err, apierror.ErrTokenExpired)
+	var respErr tiktok.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.NotEmpty(t, respErr.RequestID)
+
+	s.Fail("/order/"+sandbox.TikTokVersion+"/orders", s.ErrorFault(36009004, "too many requests"))
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "tiktok-token", ShopCipher: "cipher-1"})
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"1"}})
+	assert.ErrorIs(t, err, apierror.ErrRateLimited)
+	assert.True(t, asAPIError(t, err).Retryable())
+}
+
+func Test_TokopediaErrors(t *testing.T) {
+	s := sandbox.NewTokopedia(15001)
+	defer s.Close()
+	s.AddShop("tokopedia-token", tokopedia.ShopData{ShopID: 480001})
+
+	_, err := s.Client().Shop.GetShopInfo("stolen", tokopedia.ShopParams{ShopID: 480001})
+	assert.ErrorIs(t, err, apierror.ErrTokenExpired)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, apierror.Tokopedia, apiErr.GetMarketplace())
+	assert.Equal(t, http.StatusUnauthorized, apiErr.GetStatus())
+
+	_, err = s.Client().Shop.GetShopInfo("tokopedia-token", tokopedia.ShopParams{ShopID: 1})
+	assert.ErrorIs(t, err, apierror.ErrUnauthorized)
+
+	path := "/inventory/v1/fs/15001/product/info"
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	_, err = s.Client().Product.GetProductInfo("tokopedia-token", 123)
+	assert.ErrorIs(t, err, apierror.ErrRateLimited)
+	assert.True(t, asAPIError(t, err).Retryable())
+
+	// an unknown code in a 200 body is in no category
+	s.Fail(path, s.ErrorFault("PRODUCT_LOCKED", "product is locked"))
+	_, err = s.Client().Product.GetProductInfo("tokopedia-token", 123)
+	apiErr = asAPIError(t, err)
+	assert.Equal(t, "PRODUCT_LOCKED", apiErr.GetCode())
+	assert.False(t, apiErr.Retryable())
+	for _, category := range []error{apierror.ErrTokenExpired, apierror.ErrUnauthorized, apierror.ErrRateLimited, apierror.ErrNotFound, apierror.ErrInvalidParam, apierror.ErrUnavailable} {
+		assert.False(t, errors.Is(err, category))
+	}
+}
+
+func Test_TikTokNonJSONErrors(t *testing.T) {
+	status := http.StatusBadGateway
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "2")
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, "<html><body>gateway error</body></html>")
+	}))
+	defer srv.Close()
+
+	client := tiktok.NewClient(tiktok.AppConfig{AppKey: "tiktok-app", AppSecret: "tiktok-secret", APIURL: srv.URL, Version: sandbox.TikTokVersion})
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "tiktok-token", ShopCipher: "cipher-1"})
+
+	_, err := client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"1"}})
+	assert.ErrorIs(t, err, apierror.ErrUnavailable)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, apierror.Tiktok, apiErr.GetMarketplace())
+	assert.Equal(t, http.StatusBadGateway, apiErr.GetStatus())
+	assert.True(t, apiErr.Retryable())
+
+	status = http.StatusTooManyRequests
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"1"}})
+	var rateErr tiktok.RateLimitError
+	require.ErrorAs(t, err, &rateErr)
+	assert.Equal(t, 2, rateErr.RetryAfter)
+	assert.ErrorIs(t, err, apierror.ErrRateLimited)
+}
+
+func Test_TokopediaErrorCode(t *testing.T) {
+	for body, want := range map[string]tokopedia.ErrorCode{
+		`{"error_code":"PRODUCT_LOCKED"}`: "PRODUCT_LOCKED",
+		`{"error_code":0}`:                "",
+		`{"error_code":40001}`:            "40001",
+		`{"error_code":null}`:             "",
+		`{}`:                              "",
+	} {
+		var header tokopedia.HeaderResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &header), body)
+		assert.Equal(t, want, header.ErrorCode, body)
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/auth"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/jarcoal/httpmock"
+)
+
+const (
+	shopID    = 1238762
+	shopIDStr = "1238762"
+)
+
+var (
+	store        *auth.MemoryStore
+	refresher    *auth.Refresher
+	shopeeClient *shopee.ShopeeClient
+	shopeeApp    = shopee.AppConfig{
+		PartnerID:   12345678,
+		PartnerKey:  "hush",
+		RedirectURL: "https://example.com/callback",
+		APIURL:      "https://partner.test-stable.shopeemobile.com",
+	}
+)
+
+func setup() {
+	store = auth.NewMemoryStore()
+
+	// the refresher gets its own client, the API client depends on it
+	authClient := shopee.NewClient(shopeeApp)
+	refresher = auth.NewRefresher(store, auth.WithShopee(authClient.Auth))
+	shopeeClient = shopee.NewClient(shopeeApp, shopee.WithTokenSource(refresher.Source(auth.Shopee)))
+
+	httpmock.ActivateNonDefault(authClient.Client)
+	httpmock.ActivateNonDefault(shopeeClient.Client)
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+func shopeeToken(accessToken string, expiresIn time.Duration) *auth.Token {
+	return &auth.Token{
+		Marketplace:  auth.Shopee,
+		ShopID:       shopIDStr,
+		AccessToken:  accessToken,
+		RefreshToken: "refreshtoken",
+		ExpiresAt:    time.Now().Add(expiresIn),
+	}
+}
+
+func loadFixture(filename string) []byte {
+	f, err := ioutil.ReadFile("../../mockdata/auth/" + filename)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot load fixture %v", filename))
+	}
+	return f
+}
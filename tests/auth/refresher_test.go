@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/auth"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	refreshURL  = fmt.Sprintf("%s/api/v2/auth/access_token/get", shopeeApp.APIURL)
+	shopInfoURL = fmt.Sprintf("%s/api/v2/shop/get_shop_info", shopeeApp.APIURL)
+)
+
+func Test_ShopeeCallUsesStoredToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, store.Save(context.Background(), shopeeToken("storedtoken", time.Hour)))
+
+	var gotToken string
+	httpmock.RegisterResponder("GET", shopInfoURL, func(req *http.Request) (*http.Response, error) {
+		gotToken = req.URL.Query().Get("access_token")
+		return httpmock.NewBytesResponse(200, loadFixture("shopee_shop_info.json")), nil
+	})
+
+	res, err := shopeeClient.Shop.GetShopInfo(shopID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Toko Test", res.ShopName)
+	assert.Equal(t, "storedtoken", gotToken)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST "+refreshURL])
+}
+
+func Test_ShopeeCallRefreshesExpiringToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, shopeeToken("oldtoken", time.Minute)))
+
+	httpmock.RegisterResponder("POST", refreshURL,
+		httpmock.NewBytesResponder(200, loadFixture("shopee_refresh_access_token.json")))
+
+	var gotToken string
+	httpmock.RegisterResponder("GET", shopInfoURL, func(req *http.Request) (*http.Response, error) {
+		gotToken = req.URL.Query().Get("access_token")
+		return httpmock.NewBytesResponse(200, loadFixture("shopee_shop_info.json")), nil
+	})
+
+	_, err := shopeeClient.Shop.GetShopInfoWithContext(ctx, shopID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "newaccesstoken", gotToken)
+
+	saved, err := store.Get(ctx, auth.Shopee, shopIDStr)
+	require.NoError(t, err)
+	assert.Equal(t, "newaccesstoken", saved.AccessToken)
+	assert.Equal(t, "newrefreshtoken", saved.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(4*time.Hour), saved.ExpiresAt, time.Minute)
+}
+
+func Test_ConcurrentCallsRefreshOnce(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, shopeeToken("oldtoken", 0)))
+
+	httpmock.RegisterResponder("POST", refreshURL,
+		httpmock.NewBytesResponder(200, loadFixture("shopee_refresh_access_token.json")))
+
+	src := refresher.Source(auth.Shopee)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tok, err := src.AccessToken(ctx, shopIDStr)
+			assert.NoError(t, err)
+			tokens[i] = tok
+		}(i)
+	}
+	wg.Wait()
+
+	for _, tok := range tokens {
+		assert.Equal(t, "newaccesstoken", tok)
+	}
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST "+refreshURL])
+}
+
+func Test_RefreshDue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, shopeeToken("oldtoken", time.Minute)))
+	require.NoError(t, store.Save(ctx, &auth.Token{
+		Marketplace: auth.Shopee,
+		ShopID:      "99",
+		AccessToken: "freshtoken",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}))
+
+	httpmock.RegisterResponder("POST", refreshURL,
+		httpmock.NewBytesResponder(200, loadFixture("shopee_refresh_access_token.json")))
+
+	require.NoError(t, refresher.RefreshDue(ctx))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST "+refreshURL])
+
+	fresh, err := store.Get(ctx, auth.Shopee, "99")
+	require.NoError(t, err)
+	assert.Equal(t, "freshtoken", fresh.AccessToken)
+}
+
+func Test_ExpiredRefreshToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx := context.Background()
+	tok := shopeeToken("oldtoken", -time.Minute)
+	tok.RefreshExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, store.Save(ctx, tok))
+
+	_, err := refresher.Token(ctx, auth.Shopee, shopIDStr)
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+}
+
+func Test_LazadaSellerToken(t *testing.T) {
+	store := auth.NewMemoryStore()
+	refresher := auth.NewRefresher(store)
+	client := lazada.NewClient("2910038", "111189237912738971283187318", lazada.Indonesia,
+		lazada.WithTokenSource(refresher.Source(auth.Lazada)))
+	httpmock.ActivateNonDefault(client.Client)
+	defer httpmock.DeactivateAndReset()
+
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, &auth.Token{
+		Marketplace: auth.Lazada,
+		ShopID:      "1001",
+		AccessToken: "sellertoken",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}))
+
+	var gotToken string
+	httpmock.RegisterResponder("GET", `=~/rest/im/session/list`, func(req *http.Request) (*http.Response, error) {
+		gotToken = req.URL.Query().Get("access_token")
+		return httpmock.NewBytesResponse(200, loadFixture("lazada_session_list.json")), nil
+	})
+
+	_, err := client.Chat.GetSessionList(lazada.WithSeller(ctx, "1001"), "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sellertoken", gotToken)
+
+	// the seller wins over a client token, which is kept for calls without one
+	client.NewTokenClient("clienttoken")
+	_, err = client.Chat.GetSessionList(lazada.WithSeller(ctx, "1001"), "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sellertoken", gotToken)
+
+	_, err = client.Chat.GetSessionList(ctx, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "clienttoken", gotToken)
+}
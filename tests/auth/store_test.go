@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/auth"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStore(t *testing.T, s auth.TokenStore) {
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, auth.Shopee, shopIDStr)
+	assert.ErrorIs(t, err, auth.ErrTokenNotFound)
+
+	tok := shopeeToken("accesstoken", time.Hour)
+	require.NoError(t, s.Save(ctx, tok))
+	require.NoError(t, s.Save(ctx, &auth.Token{
+		Marketplace: auth.Lazada,
+		ShopID:      shopIDStr,
+		AccessToken: "lazadatoken",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}))
+
+	got, err := s.Get(ctx, auth.Shopee, shopIDStr)
+	require.NoError(t, err)
+	assert.Equal(t, "accesstoken", got.AccessToken)
+	assert.Equal(t, "refreshtoken", got.RefreshToken)
+	assert.WithinDuration(t, tok.ExpiresAt, got.ExpiresAt, time.Second)
+
+	tok.AccessToken = "newaccesstoken"
+	require.NoError(t, s.Save(ctx, tok))
+	got, err = s.Get(ctx, auth.Shopee, shopIDStr)
+	require.NoError(t, err)
+	assert.Equal(t, "newaccesstoken", got.AccessToken)
+
+	tokens, err := s.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	require.NoError(t, s.Delete(ctx, auth.Shopee, shopIDStr))
+	_, err = s.Get(ctx, auth.Shopee, shopIDStr)
+	assert.ErrorIs(t, err, auth.ErrTokenNotFound)
+
+	got, err = s.Get(ctx, auth.Lazada, shopIDStr)
+	require.NoError(t, err)
+	assert.Equal(t, "lazadatoken", got.AccessToken)
+}
+
+func Test_MemoryStore(t *testing.T) {
+	testStore(t, auth.NewMemoryStore())
+}
+
+func Test_FileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	testStore(t, auth.NewFileStore(path))
+
+	// a new store on the same file sees what the first one saved
+	got, err := auth.NewFileStore(path).Get(context.Background(), auth.Lazada, shopIDStr)
+	require.NoError(t, err)
+	assert.Equal(t, "lazadatoken", got.AccessToken)
+}
+
+func Test_PostgresStore(t *testing.T) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	table := "marketplace_token_test"
+	s := auth.NewPostgresStore(pool, table)
+	require.NoError(t, s.Migrate(ctx))
+	defer pool.Exec(ctx, "DROP TABLE "+table)
+
+	testStore(t, s)
+}
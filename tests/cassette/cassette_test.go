@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecorder(t *testing.T, path string, mode cassette.Mode) *cassette.Recorder {
+	rec, err := cassette.New(path, mode)
+	require.NoError(t, err)
+	return rec
+}
+
+// assertRedacted checks the cassette keeps none of the secrets.
+func assertRedacted(t *testing.T, path string, secrets ...string) {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range secrets {
+		assert.NotContains(t, string(b), secret)
+	}
+	assert.Contains(t, string(b), cassette.Redacted)
+}
+
+func Test_CassetteShopee(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shopee.json")
+	s := sandbox.NewShopee(2001234, "partner-key")
+	s.AddShop(77001, "shopee-token", shopee.GetShopInfoResponse{ShopName: "recorded shop"})
+	s.Fail("/api/v2/shop/get_shop_info", sandbox.Unavailable())
+
+	// every attempt of a retried call is recorded
+	backoff := middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: time.Millisecond})
+	rec := newRecorder(t, path, cassette.ModeRecord)
+	info, err := s.Client(shopee.WithRecorder(rec), shopee.WithMiddleware(backoff)).Shop.GetShopInfo(77001, "shopee-token")
+	require.NoError(t, err)
+	assert.Equal(t, "recorded shop", info.ShopName)
+	s.Close()
+
+	interactions := rec.Cassette().Interactions
+	require.Len(t, interactions, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, interactions[0].Response.Status)
+	assert.Contains(t, interactions[1].Request.URL, "access_token="+cassette.Redacted)
+	assert.Contains(t, interactions[1].Request.URL, "sign="+cassette.Redacted)
+	assertRedacted(t, path, "shopee-token")
+
+	// the server is gone, the cassette answers with a new timestamp and sign
+	rec = newRecorder(t, path, cassette.ModeReplay)
+	client := s.Client(shopee.WithRecorder(rec), shopee.WithMiddleware(backoff))
+	info, err = client.Shop.GetShopInfo(77001, "shopee-token")
+	require.NoError(t, err)
+	assert.Equal(t, "recorded shop", info.ShopName)
+
+	// each interaction is replayed once
+	_, err = client.Shop.GetShopInfo(77001, "shopee-token")
+	assert.ErrorContains(t, err, "no recorded response for GET /api/v2/shop/get_shop_info")
+}
+
+func Test_CassetteLazada(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazada.json")
+	s := sandbox.NewLazada("100200", "lazada-secret")
+	s.AddShop("lazada-token")
+	s.AddProduct("lazada-token", lazada.Products{ItemID: 1, Status: "Active"})
+	ctx := context.Background()
+	params := &lazada.GetProductsParams{Filter: "Active", Offset: "0", Limit: "10"}
+
+	rec := newRecorder(t, path, cassette.ModeRecord)
+	client := s.Client()
+	client.Client.Transport = rec.Wrap(client.Client.Transport)
+	_, err := client.Product.GetProducts(ctx, "lazada-token", params)
+	require.NoError(t, err)
+	s.Close()
+	assertRedacted(t, path, "lazada-token")
+
+	rec = newRecorder(t, path, cassette.ModeReplay)
+	client = s.Client()
+	client.Client.Transport = rec
+	products, err := client.Product.GetProducts(ctx, "lazada-token", params)
+	require.NoError(t, err)
+	require.Len(t, products.Data.Products, 1)
+	assert.Equal(t, 1, products.Data.Products[0].ItemID)
+
+	// other parameters are another request
+	_, err = client.Product.GetProducts(ctx, "lazada-token", &lazada.GetProductsParams{Filter: "InActive", Offset: "0", Limit: "10"})
+	assert.ErrorContains(t, err, "no recorded response for GET /rest/products/get")
+}
+
+func Test_CassetteTikTok(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tiktok.json")
+	s := sandbox.NewTikTok("tiktok-app", "tiktok-secret")
+	s.AddShop("tiktok-token", tiktok.Shops{Cipher: "cipher-1", ID: "7001"})
+	s.AddOrder("cipher-1", tiktok.Order{ID: "o1", Status: "AWAITING_SHIPMENT"})
+	shop := tiktok.CommonParamRequest{AccessToken: "tiktok-token", ShopCipher: "cipher-1"}
+	body := tiktok.SearchOrdersBody{OrderStatus: "AWAITING_SHIPMENT"}
+
+	rec := newRecorder(t, path, cassette.ModeRecord)
+	client := s.Client(tiktok.WithRecorder(rec))
+	client.WithCommonParamRequest(shop)
+	_, err := client.Order.SearchOrders(tiktok.SearchOrdersParams{PageSize: 10}, body)
+	require.NoError(t, err)
+	s.Close()
+	assertRedacted(t, path, "tiktok-token")
+	assert.Equal(t, cassette.Redacted, rec.Cassette().Interactions[0].Request.Header.Get("X-Tts-Access-Token"))
+
+	rec = newRecorder(t, path, cassette.ModeReplay)
+	client = s.Client(tiktok.WithRecorder(rec))
+	client.WithCommonParamRequest(shop)
+	orders, err := client.Order.SearchOrders(tiktok.SearchOrdersParams{PageSize: 10}, body)
+	require.NoError(t, err)
+	require.Len(t, orders.Data.Orders, 1)
+	assert.Equal(t, "o1", orders.Data.Orders[0].ID)
+}
+
+func Test_CassetteTokopedia(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokopedia.json")
+	s := sandbox.NewTokopedia(15001)
+	s.AddShop("tokopedia-token", tokopedia.ShopData{ShopID: 480001, ShopName: "recorded shop"})
+
+	rec := newRecorder(t, path, cassette.ModeRecord)
+	_, err := s.Client(tokopedia.WithRecorder(rec)).Shop.GetShopInfo("tokopedia-token", tokopedia.ShopParams{ShopID: 480001})
+	require.NoError(t, err)
+	s.Close()
+	assertRedacted(t, path, "tokopedia-token")
+
+	rec = newRecorder(t, path, cassette.ModeReplay)
+	shops, err := s.Client(tokopedia.WithRecorder(rec)).Shop.GetShopInfo("tokopedia-token", tokopedia.ShopParams{ShopID: 480001})
+	require.NoError(t, err)
+	require.Len(t, shops.Data, 1)
+	assert.Equal(t, "recorded shop", shops.Data[0].ShopName)
+}
+
+func Test_CassetteRedactsBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"data":{"access_token":"new-token","refresh_token":"new-refresh","expire_in":14400,"shop_id":900719925474099123}}`)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "token.json")
+	rec := newRecorder(t, path, cassette.ModeRecord)
+	client := &http.Client{Transport: rec}
+
+	form := "app_key=1&app_secret=top-secret&refresh_token=old-refresh&timestamp=1"
+	resp, err := client.Post(server.URL+"/token", "application/x-www-form-urlencoded", strings.NewReader(form))
+	require.NoError(t, err)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	// the client still gets the tokens
+	assert.Contains(t, string(b), "new-token")
+
+	interaction := rec.Cassette().Interactions[0]
+	assert.Equal(t, "app_key=1&app_secret=REDACTED&refresh_token=REDACTED&timestamp=1", interaction.Request.Body)
+	assert.JSONEq(t, `{"data":{"access_token":"REDACTED","refresh_token":"REDACTED","expire_in":14400,"shop_id":900719925474099123}}`, interaction.Response.Body)
+	assertRedacted(t, path, "top-secret", "old-refresh", "new-token", "new-refresh")
+
+	// a form with another timestamp and secret matches
+	rec = newRecorder(t, path, cassette.ModeReplay)
+	client = &http.Client{Transport: rec}
+	resp, err = client.Post("http://replay.invalid/token", "application/x-www-form-urlencoded",
+		strings.NewReader("timestamp=2&app_secret=other&refresh_token=other&app_key=1"))
+	require.NoError(t, err)
+	b, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Contains(t, string(b), `"shop_id":900719925474099123`)
+}
+
+func Test_CassetteReplayMissing(t *testing.T) {
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func Test_CassetteTokopediaNative(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokopedia-native.json")
+	recorded := &cassette.Cassette{Interactions: []cassette.Interaction{{
+		Request: cassette.Request{
+			Method: http.MethodGet,
+			URL:    "https://fs.tokopedia.test/v1/chat/fs/15001/messages?page=1&per_page=10&shop_id=480001",
+		},
+		Response: cassette.Response{
+			Status: http.StatusOK,
+			Header: http.Header{"Content-Type": {"application/json"}},
+			Body:   `{"data":[{"message_key":"480001~7001","msg_id":7001}]}`,
+		},
+	}}}
+	require.NoError(t, recorded.Save(path))
+
+	// replayed calls never dial the socks proxy
+	rec := newRecorder(t, path, cassette.ModeReplay)
+	client := tokopedia.NewClient(tokopedia.AppConfig{FsID: 15001, APIURL: "https://fs.tokopedia.test"}, tokopedia.WithRecorder(rec))
+	handler, err := tokopedia.NewTokopediaHTTPHandler(client.WithShopID("480001"), "127.0.0.1:1")
+	require.NoError(t, err)
+
+	messages, err := handler.GetListMessages(tokopedia.GetMessagesParams{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, messages.Data, 1)
+	assert.Equal(t, 7001, messages.Data[0].MsgID)
+}
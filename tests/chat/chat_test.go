@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/chat"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_ShopeeListConversations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/sellerchat/get_conversation_list", shopeeApp.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("shopee/get_conversation_resp.json")))
+
+	svc := chat.NewShopee(shopeeClient, shopID, accessToken)
+	page, err := svc.ListConversations(context.Background(), chat.ListConversationsParams{PageSize: 5})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+
+	if !page.HasMore || page.NextCursor != "1612792485343461649" {
+		t.Errorf("page cursor returned %q (more %v), expected %q", page.NextCursor, page.HasMore, "1612792485343461649")
+	}
+
+	conv := page.Conversations[0]
+	if conv.ID != "38732689394223980" {
+		t.Errorf("Conversation.ID returned %+v, expected %+v", conv.ID, "38732689394223980")
+	}
+	if conv.Marketplace != chat.Shopee || conv.BuyerID != "9030508" || conv.LastMessageType != chat.MessageText {
+		t.Errorf("Conversation returned %+v", conv)
+	}
+	if page.Conversations[1].LastMessageType != chat.MessageProduct {
+		t.Errorf("LastMessageType returned %+v, expected %+v", page.Conversations[1].LastMessageType, chat.MessageProduct)
+	}
+}
+
+func Test_ShopeeSendMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/sellerchat/send_message", shopeeApp.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("shopee/send_message_resp.json")))
+
+	svc := chat.NewShopee(shopeeClient, shopID, accessToken)
+	msg, err := svc.SendMessage(context.Background(), chat.SendMessage{
+		ConversationID: "38732414516304685",
+		ToID:           "9018093",
+		Type:           chat.MessageText,
+		Text:           "Hi, Guys!",
+	})
+	if err != nil {
+		t.Fatalf("SendMessage error: %s", err)
+	}
+
+	if msg.ID != "2065633925008539788" || msg.ConversationID != "38732414516304685" || msg.Text != "Hi, Guys!" {
+		t.Errorf("SendMessage returned %+v", msg)
+	}
+}
+
+func Test_SendUnsupportedMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	svc := chat.NewShopee(shopeeClient, shopID, accessToken)
+	_, err := svc.SendMessage(context.Background(), chat.SendMessage{Type: chat.MessageVoucher})
+	if !errors.Is(err, chat.ErrUnsupported) {
+		t.Errorf("SendMessage error returned %v, expected %v", err, chat.ErrUnsupported)
+	}
+
+	toped := chat.NewTokopedia(tokopedia.NewClient(tokopedia.AppConfig{APIURL: "https://fs.tokopedia.net"}), 1, accessToken)
+	err = toped.MarkAsRead(context.Background(), "1", "")
+
+	var unsupportedErr *chat.UnsupportedError
+	if !errors.As(err, &unsupportedErr) || unsupportedErr.Marketplace != chat.Tokopedia {
+		t.Errorf("MarkAsRead error returned %v, expected tokopedia UnsupportedError", err)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+)
+
+const (
+	shopID      = 1238762
+	accessToken = "accesstoken"
+	shopCipher  = "shopcipher"
+)
+
+var (
+	shopeeClient *shopee.ShopeeClient
+	shopeeApp    = shopee.AppConfig{
+		PartnerID:   12345678,
+		PartnerKey:  "hush",
+		RedirectURL: "https://example.com/callback",
+		APIURL:      "https://partner.test-stable.shopeemobile.com",
+	}
+
+	lazadaClient *lazada.Client
+
+	tiktokClient *tiktok.TiktokClient
+	tiktokApp    = tiktok.AppConfig{
+		AppKey:    "appkey",
+		AppSecret: "appsecret",
+		APIURL:    tiktok.OpenAPIURL,
+		Version:   "202309",
+	}
+
+	tokopediaClient *tokopedia.TokopediaClient
+	tokopediaApp    = tokopedia.AppConfig{
+		ClientID:     "clientid",
+		ClientSecret: "clientsecret",
+		FsID:         13004,
+		APIURL:       tokopedia.APIURL,
+	}
+)
+
+func setup() {
+	shopeeClient = shopee.NewClient(shopeeApp, shopee.WithRetry(3))
+	httpmock.ActivateNonDefault(shopeeClient.Client)
+
+	lazadaClient = lazada.NewClient("2910038", "111189237912738971283187318", lazada.Indonesia)
+	httpmock.ActivateNonDefault(lazadaClient.Client)
+
+	tiktokClient = tiktok.NewClient(tiktokApp)
+	httpmock.ActivateNonDefault(tiktokClient.Client)
+
+	tokopediaClient = tokopedia.NewClient(tokopediaApp)
+	httpmock.ActivateNonDefault(tokopediaClient.Client)
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+func loadFixture(filename string) []byte {
+	f, err := ioutil.ReadFile("../../mockdata/" + filename)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot load fixture %v", filename))
+	}
+	return f
+}
+
+// regexpPath matches path on the TikTok API.
+func regexpPath(path string) *regexp.Regexp {
+	return regexp.MustCompile("^" + regexp.QuoteMeta(tiktok.OpenAPIURL) + path + `(\?.*)?$`)
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/chat"
+	"github.com/jarcoal/httpmock"
+)
+
+const lazadaToken = "50000600116cWYzTphDtTDshMBux1993574eoq9YzkugHtfWTiXeDQ7OzvDLRkFx"
+
+// lazadaResponder answers fixture and keeps the query of the last request in
+// query.
+func lazadaResponder(fixture string, query *url.Values) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		*query = req.URL.Query()
+		return httpmock.NewBytesResponse(200, loadFixture(fixture)), nil
+	}
+}
+
+func Test_LazadaListConversations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`/im/session/list`),
+		lazadaResponder("lazada/get_sessions_resp.json", &query))
+
+	svc := chat.NewLazada(lazadaClient, lazadaToken)
+	page, err := svc.ListConversations(context.Background(), chat.ListConversationsParams{PageSize: 5})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+
+	if query.Get("page_size") != "5" || query.Get("start_time") == "" || query.Has("last_session_id") {
+		t.Errorf("first page query returned %v", query)
+	}
+	if !page.HasMore || page.NextCursor != "1623399917434:100094063_2_1011822749_1_103" {
+		t.Errorf("page cursor returned %q (more %v), expected %q", page.NextCursor, page.HasMore, "1623399917434:100094063_2_1011822749_1_103")
+	}
+
+	conv := page.Conversations[0]
+	if conv.ID != "100094063_2_1011822749_1_103" || conv.Marketplace != chat.Lazada || conv.BuyerID != "1011822749" || conv.UnreadCount != 2 {
+		t.Errorf("Conversation returned %+v", conv)
+	}
+
+	// the cursor gives the start time and the last session of the next page
+	_, err = svc.ListConversations(context.Background(), chat.ListConversationsParams{Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+	if query.Get("start_time") != "1623399917434" || query.Get("last_session_id") != "100094063_2_1011822749_1_103" {
+		t.Errorf("next page query returned %v", query)
+	}
+
+	_, err = svc.ListConversations(context.Background(), chat.ListConversationsParams{Cursor: "bogus"})
+	if err == nil {
+		t.Errorf("ListConversations with an invalid cursor returned no error")
+	}
+}
+
+func Test_LazadaListMessages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`/im/message/list`),
+		lazadaResponder("lazada/get_message_list_resp.json", &query))
+
+	svc := chat.NewLazada(lazadaClient, lazadaToken)
+	page, err := svc.ListMessages(context.Background(), chat.ListMessagesParams{
+		ConversationID: "100094063_2_1011822749_1_103",
+		Cursor:         "1623399917000:24jFlAu0BtRbP47189",
+	})
+	if err != nil {
+		t.Fatalf("ListMessages error: %s", err)
+	}
+
+	if query.Get("session_id") != "100094063_2_1011822749_1_103" || query.Get("start_time") != "1623399917000" || query.Get("last_message_id") != "24jFlAu0BtRbP47189" {
+		t.Errorf("ListMessages query returned %v", query)
+	}
+	if !page.HasMore || page.NextCursor != "1623399917438:24jFlAu0BtRbP47193" {
+		t.Errorf("page cursor returned %q (more %v), expected %q", page.NextCursor, page.HasMore, "1623399917438:24jFlAu0BtRbP47193")
+	}
+	if len(page.Messages) != 4 {
+		t.Fatalf("ListMessages returned %d messages, expected 4", len(page.Messages))
+	}
+
+	text, product, image, order := page.Messages[0], page.Messages[1], page.Messages[2], page.Messages[3]
+	if text.Type != chat.MessageText || text.Text != "is this in stock?" || !text.FromBuyer || text.SenderID != "1011822749" {
+		t.Errorf("text message returned %+v", text)
+	}
+	if product.Type != chat.MessageProduct || product.ProductID != "1928374650" {
+		t.Errorf("product message returned %+v", product)
+	}
+	if image.Type != chat.MessageImage || image.ImageURL != "https://sg-live-02.slatic.net/p/shirt.jpg" || image.FromBuyer {
+		t.Errorf("image message returned %+v", image)
+	}
+	if order.Type != chat.MessageOrder || order.OrderID != "502928374650" {
+		t.Errorf("order message returned %+v", order)
+	}
+}
+
+func Test_LazadaSendMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterRegexpResponder("POST", regexp.MustCompile(`/im/message/send`),
+		lazadaResponder("lazada/send_message_resp.json", &query))
+
+	svc := chat.NewLazada(lazadaClient, lazadaToken)
+	msg, err := svc.SendMessage(context.Background(), chat.SendMessage{
+		ConversationID: "100094063_2_1011822749_1_103",
+		Type:           chat.MessageProduct,
+		ProductID:      "1928374650",
+	})
+	if err != nil {
+		t.Fatalf("SendMessage error: %s", err)
+	}
+
+	if query.Get("session_id") != "100094063_2_1011822749_1_103" || query.Get("template_id") != "10006" || query.Get("item_id") != "1928374650" {
+		t.Errorf("SendMessage query returned %v", query)
+	}
+	if msg.ID != "23hR7YH0BtkiN00001" || msg.ConversationID != "100094063_2_1011822749_1_103" || msg.ProductID != "1928374650" {
+		t.Errorf("SendMessage returned %+v", msg)
+	}
+}
+
+func Test_LazadaMarkAsRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query, detailQuery url.Values
+	httpmock.RegisterRegexpResponder("POST", regexp.MustCompile(`/im/session/read`),
+		lazadaResponder("lazada/read_session_resp.json", &query))
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`/im/session/get`),
+		lazadaResponder("lazada/get_session_detail_resp.json", &detailQuery))
+
+	svc := chat.NewLazada(lazadaClient, lazadaToken)
+	if err := svc.MarkAsRead(context.Background(), "100094063_2_1011822749_1_103", "24jFlAu0BtRbP47189"); err != nil {
+		t.Fatalf("MarkAsRead error: %s", err)
+	}
+	if query.Get("session_id") != "100094063_2_1011822749_1_103" || query.Get("last_read_message_id") != "24jFlAu0BtRbP47189" {
+		t.Errorf("ReadSession query returned %v", query)
+	}
+	if detailQuery != nil {
+		t.Errorf("MarkAsRead with a message id fetched the session")
+	}
+
+	// without a message id the last message of the session is read
+	if err := svc.MarkAsRead(context.Background(), "100094063_2_1011822749_1_103", ""); err != nil {
+		t.Fatalf("MarkAsRead error: %s", err)
+	}
+	if detailQuery.Get("session_id") != "100094063_2_1011822749_1_103" || query.Get("last_read_message_id") != "24jFlAu0BtRbP47190" {
+		t.Errorf("ReadSession query returned %v", query)
+	}
+}
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/chat"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+)
+
+// tiktokResponder answers fixture as JSON and keeps the last request in req.
+func tiktokResponder(fixture string, req **http.Request) httpmock.Responder {
+	return func(r *http.Request) (*http.Response, error) {
+		*req = r
+		resp := httpmock.NewBytesResponse(200, loadFixture(fixture))
+		resp.Header.Set("Content-Type", "application/json")
+		return resp, nil
+	}
+}
+
+func Test_TiktokListConversations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterRegexpResponder("GET", regexpPath("/customer_service/202309/conversations"),
+		tiktokResponder("tiktok/get_conversations_resp.json", &req))
+
+	svc := chat.NewTiktok(tiktokClient, shopCipher, accessToken)
+	page, err := svc.ListConversations(context.Background(), chat.ListConversationsParams{Cursor: "b2Zmc2V0PTA=", PageSize: 5})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+
+	query := req.URL.Query()
+	if query.Get("page_token") != "b2Zmc2V0PTA=" || query.Get("page_size") != "5" || query.Get("shop_cipher") != shopCipher {
+		t.Errorf("ListConversations query returned %v", query)
+	}
+	if req.Header.Get("x-tts-access-token") != accessToken {
+		t.Errorf("access token returned %q, expected %q", req.Header.Get("x-tts-access-token"), accessToken)
+	}
+	if !page.HasMore || page.NextCursor != "b2Zmc2V0PTIw" {
+		t.Errorf("page cursor returned %q (more %v), expected %q", page.NextCursor, page.HasMore, "b2Zmc2V0PTIw")
+	}
+
+	conv := page.Conversations[0]
+	if conv.ID != "576486316948490001" || conv.Marketplace != chat.Tiktok || conv.BuyerID != "7494560109732825005" || conv.BuyerName != "Buyer" || conv.UnreadCount != 1 {
+		t.Errorf("Conversation returned %+v", conv)
+	}
+	if conv.LastMessageID != "7221056129283326250" || conv.LastMessageType != chat.MessageText || conv.LastMessageText != "is this in stock?" {
+		t.Errorf("Conversation last message returned %+v", conv)
+	}
+}
+
+func Test_TiktokListMessages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterRegexpResponder("GET", regexpPath("/customer_service/202309/conversations/576486316948490001/messages"),
+		tiktokResponder("tiktok/get_conversation_messages_resp.json", &req))
+
+	svc := chat.NewTiktok(tiktokClient, shopCipher, accessToken)
+	page, err := svc.ListMessages(context.Background(), chat.ListMessagesParams{ConversationID: "576486316948490001"})
+	if err != nil {
+		t.Fatalf("ListMessages error: %s", err)
+	}
+
+	if req.URL.Query().Has("page_token") {
+		t.Errorf("first page query returned %v", req.URL.Query())
+	}
+	if page.HasMore || page.NextCursor != "" {
+		t.Errorf("page cursor returned %q (more %v), expected the last page", page.NextCursor, page.HasMore)
+	}
+	if len(page.Messages) != 4 {
+		t.Fatalf("ListMessages returned %d messages, expected 4", len(page.Messages))
+	}
+
+	text, image, product, order := page.Messages[0], page.Messages[1], page.Messages[2], page.Messages[3]
+	if text.Type != chat.MessageText || text.Text != "is this in stock?" || !text.FromBuyer || text.ConversationID != "576486316948490001" {
+		t.Errorf("text message returned %+v", text)
+	}
+	if image.Type != chat.MessageImage || image.ImageURL != "https://p16-oec-va.ibyteimg.com/shirt.jpeg" || image.FromBuyer {
+		t.Errorf("image message returned %+v", image)
+	}
+	if product.Type != chat.MessageProduct || product.ProductID != "1729382256910270000" {
+		t.Errorf("product message returned %+v", product)
+	}
+	if order.Type != chat.MessageOrder || order.OrderID != "576461413038785000" {
+		t.Errorf("order message returned %+v", order)
+	}
+}
+
+func Test_TiktokSendMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterRegexpResponder("POST", regexpPath("/customer_service/202309/conversations/576486316948490001/messages"),
+		tiktokResponder("tiktok/send_message_resp.json", &req))
+
+	svc := chat.NewTiktok(tiktokClient, shopCipher, accessToken)
+	msg, err := svc.SendMessage(context.Background(), chat.SendMessage{
+		ConversationID: "576486316948490001",
+		Type:           chat.MessageImage,
+		ImageURL:       "https://p16-oec-va.ibyteimg.com/shirt.jpeg",
+		ImageWidth:     600,
+		ImageHeight:    800,
+	})
+	if err != nil {
+		t.Fatalf("SendMessage error: %s", err)
+	}
+
+	var body struct {
+		Type    string `json:"type"`
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("decode SendMessage body: %s", err)
+	}
+	if body.Type != "IMAGE" || body.Content != `{"url":"https://p16-oec-va.ibyteimg.com/shirt.jpeg","width":600,"height":800}` {
+		t.Errorf("SendMessage body returned %+v", body)
+	}
+	if msg.ID != "7221056129283326260" || msg.ConversationID != "576486316948490001" || msg.Type != chat.MessageImage {
+		t.Errorf("SendMessage returned %+v", msg)
+	}
+}
+
+func Test_TiktokMarkAsRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterRegexpResponder("POST", regexpPath("/customer_service/202309/conversations/576486316948490001/messages/read"),
+		tiktokResponder("tiktok/read_message_resp.json", &req))
+
+	svc := chat.NewTiktok(tiktokClient, shopCipher, accessToken)
+	if err := svc.MarkAsRead(context.Background(), "576486316948490001", ""); err != nil {
+		t.Fatalf("MarkAsRead error: %s", err)
+	}
+	if req == nil || req.URL.Query().Get("shop_cipher") != shopCipher {
+		t.Errorf("MarkAsRead request returned %v", req)
+	}
+}
+
+func Test_TiktokSharedClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tokens := map[string]string{"cipher-a": "token-a", "cipher-b": "token-b"}
+	var mismatches atomic.Int32
+	httpmock.RegisterRegexpResponder("POST", regexpPath("/customer_service/202309/conversations/[^/]+/messages"),
+		func(req *http.Request) (*http.Response, error) {
+			cipher := req.URL.Query().Get("shop_cipher")
+			if cipher == "" || req.Header.Get("x-tts-access-token") != tokens[cipher] {
+				mismatches.Add(1)
+			}
+			resp := httpmock.NewStringResponse(200, `{"code":0,"data":{"message_id":"1"}}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+	// both shops share one client
+	services := []chat.Service{
+		chat.NewTiktok(tiktokClient, "cipher-a", "token-a"),
+		chat.NewTiktok(tiktokClient, "cipher-b", "token-b"),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for _, svc := range services {
+			wg.Add(1)
+			go func(svc chat.Service) {
+				defer wg.Done()
+				_, err := svc.SendMessage(context.Background(), chat.SendMessage{
+					ConversationID: fmt.Sprint(i),
+					Type:           chat.MessageText,
+					Text:           "hi",
+				})
+				if err != nil {
+					t.Errorf("SendMessage error: %s", err)
+				}
+			}(svc)
+		}
+
+		// direct calls of the client do not interfere either
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := tiktok.WithShop(context.Background(), tiktok.CommonParamRequest{ShopCipher: "cipher-b", AccessToken: "token-b"})
+			_, err := tiktokClient.Chat.SendMessageToConversationIDWithContext(ctx, fmt.Sprint(i), tiktok.SendMessageToConversationIDReq{
+				TypeMessage: tiktok.TypeMessageText,
+				Content:     `{"content":"hi"}`,
+			})
+			if err != nil {
+				t.Errorf("Chat.SendMessageToConversationIDWithContext error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := mismatches.Load(); n > 0 {
+		t.Errorf("%d messages were sent with the credentials of another shop", n)
+	}
+	if tiktokClient.ShopCipher != "" || tiktokClient.AccessToken != "" {
+		t.Errorf("the services set the shop %q on the client", tiktokClient.ShopCipher)
+	}
+}
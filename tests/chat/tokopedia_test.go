@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/chat"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+)
+
+const tokopediaShopID = 480001
+
+// tokopediaResponder answers fixture and keeps the last request in req.
+func tokopediaResponder(fixture string, req **http.Request) httpmock.Responder {
+	return func(r *http.Request) (*http.Response, error) {
+		*req = r
+		return httpmock.NewBytesResponse(200, loadFixture(fixture)), nil
+	}
+}
+
+func Test_TokopediaListConversations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/v1/chat/fs/%d/messages", tokopediaApp.APIURL, tokopediaApp.FsID),
+		tokopediaResponder("tokopedia/get_messages_resp.json", &req))
+
+	svc := chat.NewTokopedia(tokopediaClient, tokopediaShopID, accessToken)
+	page, err := svc.ListConversations(context.Background(), chat.ListConversationsParams{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+
+	query := req.URL.Query()
+	if query.Get("page") != "1" || query.Get("per_page") != "2" || query.Get("shop_id") != "480001" {
+		t.Errorf("ListConversations query returned %v", query)
+	}
+	// a full page may be followed by another one
+	if !page.HasMore || page.NextCursor != "2" {
+		t.Errorf("page cursor returned %q (more %v), expected %q", page.NextCursor, page.HasMore, "2")
+	}
+
+	conv := page.Conversations[0]
+	if conv.ID != "2360940371" || conv.Marketplace != chat.Tokopedia || conv.BuyerID != "211073127" || conv.BuyerName != "Dira" || conv.UnreadCount != 1 {
+		t.Errorf("Conversation returned %+v", conv)
+	}
+	if conv.LastMessageText != "Barang ready kak?" || conv.LastMessageAt.UnixMilli() != 1686453834982 {
+		t.Errorf("Conversation last message returned %+v", conv)
+	}
+
+	_, err = svc.ListConversations(context.Background(), chat.ListConversationsParams{Cursor: page.NextCursor, PageSize: 5})
+	if err != nil {
+		t.Fatalf("ListConversations error: %s", err)
+	}
+	if req.URL.Query().Get("page") != "2" {
+		t.Errorf("next page query returned %v", req.URL.Query())
+	}
+}
+
+func Test_TokopediaListMessages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/v1/chat/fs/%d/messages/2360940371/replies", tokopediaApp.APIURL, tokopediaApp.FsID),
+		tokopediaResponder("tokopedia/get_replies_resp.json", &req))
+
+	svc := chat.NewTokopedia(tokopediaClient, tokopediaShopID, accessToken)
+	page, err := svc.ListMessages(context.Background(), chat.ListMessagesParams{ConversationID: "2360940371"})
+	if err != nil {
+		t.Fatalf("ListMessages error: %s", err)
+	}
+
+	if req.Header.Get("Authorization") != "Bearer "+accessToken {
+		t.Errorf("Authorization returned %q", req.Header.Get("Authorization"))
+	}
+	// a short page is the last one
+	if page.HasMore || page.NextCursor != "" {
+		t.Errorf("page cursor returned %q (more %v), expected the last page", page.NextCursor, page.HasMore)
+	}
+	if len(page.Messages) != 3 {
+		t.Fatalf("ListMessages returned %d messages, expected 3", len(page.Messages))
+	}
+
+	text, product, image := page.Messages[0], page.Messages[1], page.Messages[2]
+	if text.ID != "7812001" || text.Type != chat.MessageText || text.Text != "Barang ready kak?" || !text.FromBuyer {
+		t.Errorf("text message returned %+v", text)
+	}
+	if product.Type != chat.MessageProduct || product.ProductID != "15123456" {
+		t.Errorf("product message returned %+v", product)
+	}
+	if image.Type != chat.MessageImage || image.ImageURL != "https://images.tokopedia.net/img/chat/shirt.jpg" || image.FromBuyer {
+		t.Errorf("image message returned %+v", image)
+	}
+
+	_, err = svc.ListMessages(context.Background(), chat.ListMessagesParams{ConversationID: "not-a-number"})
+	if err == nil {
+		t.Errorf("ListMessages with an invalid conversation id returned no error")
+	}
+}
+
+func Test_TokopediaSendMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var req *http.Request
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/v1/chat/fs/%d/messages/2360940371/reply", tokopediaApp.APIURL, tokopediaApp.FsID),
+		tokopediaResponder("tokopedia/send_message_resp.json", &req))
+
+	svc := chat.NewTokopedia(tokopediaClient, tokopediaShopID, accessToken)
+	msg, err := svc.SendMessage(context.Background(), chat.SendMessage{
+		ConversationID: "2360940371",
+		Type:           chat.MessageText,
+		Text:           "Halo ada yang bisa dibantu? dira",
+	})
+	if err != nil {
+		t.Fatalf("SendMessage error: %s", err)
+	}
+
+	var body tokopedia.SendMessageBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("decode SendMessage body: %s", err)
+	}
+	if body.MsgID != 2360940371 || body.ShopID != tokopediaShopID || body.Message != "Halo ada yang bisa dibantu? dira" {
+		t.Errorf("SendMessage body returned %+v", body)
+	}
+	if msg.ID != "" || msg.ConversationID != "2360940371" || msg.SenderID != "211073127" || msg.Text != "Halo ada yang bisa dibantu? dira" {
+		t.Errorf("SendMessage returned %+v", msg)
+	}
+
+	_, err = svc.SendMessage(context.Background(), chat.SendMessage{ConversationID: "2360940371", Type: chat.MessageImage})
+	if !errors.Is(err, chat.ErrUnsupported) {
+		t.Errorf("SendMessage error returned %v, expected %v", err, chat.ErrUnsupported)
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const iterToken = "50000600116cWYzTphDtTDshMBux1993574eoq9YzkugHtfWTiXeDQ7OzvDLRkFx"
+
+func Test_SessionsIteratesPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/im/session/list`,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			switch {
+			case q.Get("last_session_id") == "":
+				return httpmock.NewStringResponse(200, `{"code":"0","success":true,"data":{"session_list":[{"session_id":"s1"},{"session_id":"s2"}],
+					"has_more":true,"next_start_time":1700000000000,"last_session_id":"s2"}}`), nil
+			case q.Get("last_session_id") == "s2" && q.Get("start_time") == "1700000000000":
+				return httpmock.NewStringResponse(200, `{"code":"0","success":true,"data":{"session_list":[{"session_id":"s3"}],"has_more":false}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"code":"MissingParameter","message":"unexpected page"}`), nil
+		})
+
+	sessions := client.Chat.Sessions(context.Background(), iterToken, &lazada.SessionListQuery{StartTime: 1690000000000, PageSize: 2})
+	var ids []string
+	for s, err := range sessions {
+		if !assert.Nil(t, err) {
+			return
+		}
+		ids = append(ids, s.SessionID)
+	}
+
+	assert.Equal(t, []string{"s1", "s2", "s3"}, ids)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	// ranging again starts from the first page
+	ids = nil
+	for s, err := range sessions {
+		if !assert.Nil(t, err) {
+			return
+		}
+		ids = append(ids, s.SessionID)
+	}
+	assert.Equal(t, []string{"s1", "s2", "s3"}, ids)
+}
+
+func Test_OrderAllIteratesOffsets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/orders/get`,
+		func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Query().Get("offset") {
+			case "0":
+				return httpmock.NewStringResponse(200, `{"code":"0","data":{"count":2,"countTotal":3,"orders":[{"order_id":1},{"order_id":2}]}}`), nil
+			case "2":
+				return httpmock.NewStringResponse(200, `{"code":"0","data":{"count":1,"countTotal":3,"orders":[{"order_id":3}]}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"code":"0","data":{"count":0,"countTotal":3,"orders":[]}}`), nil
+		})
+
+	var ids []int64
+	for o, err := range client.Order.All(context.Background(), iterToken, lazada.GetOrdersParam{
+		CreatedAfter: "2023-11-01T00:00:00+07:00",
+		Limit:        "2",
+	}) {
+		if !assert.Nil(t, err) {
+			return
+		}
+		ids = append(ids, o.OrderID)
+	}
+
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func Test_OrderAllStopsOnError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/orders/get`,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("offset") == "0" {
+				return httpmock.NewStringResponse(200, `{"code":"0","data":{"count":2,"countTotal":4,"orders":[{"order_id":1},{"order_id":2}]}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"code":"IllegalAccessToken","type":"ISV","message":"The specified access token is invalid or expired"}`), nil
+		})
+
+	var (
+		n    int
+		errs []error
+	)
+	for _, err := range client.Order.All(context.Background(), iterToken, lazada.GetOrdersParam{Limit: "2"}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n++
+	}
+
+	assert.Equal(t, 2, n)
+	assert.Len(t, errs, 1, fmt.Sprint(errs))
+}
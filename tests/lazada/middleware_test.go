@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MiddlewareRetriesApiCallLimit(t *testing.T) {
+	// the middleware wraps the default transport, mock that one
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	c := lazada.NewClient("2910038", "111189237912738971283187318", lazada.Indonesia, lazada.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+
+	httpmock.RegisterResponder("GET", `=~/orders/get`, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(200, `{"code":"ApiCallLimit","type":"ISP","message":"This request has exceeded the limit"}`),
+		httpmock.NewStringResponse(200, `{"code":"0","data":{"count":1,"countTotal":1,"orders":[{"order_id":1}]}}`),
+	}))
+
+	res, err := c.Order.GetOrders(context.Background(), iterToken, &lazada.GetOrdersParam{Limit: "10"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, res.Data.Orders, 1)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func Test_MiddlewareLimitsBySeller(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	limiter := middleware.NewRateLimiter(
+		middleware.Limit{Rate: 100, Burst: 100},
+		middleware.Limit{Rate: 0.01, Burst: 1},
+	)
+	c := lazada.NewClient("2910038", "111189237912738971283187318", lazada.Indonesia, lazada.WithMiddleware(
+		middleware.WithRateLimiter(limiter),
+	))
+	httpmock.RegisterResponder("GET", `=~/orders/get`,
+		httpmock.NewStringResponder(200, `{"code":"0","data":{"count":0,"countTotal":0,"orders":[]}}`))
+
+	get := func(ctx context.Context, token string) error {
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := c.Order.GetOrders(ctx, token, &lazada.GetOrdersParam{Limit: "10"})
+		return err
+	}
+
+	// without a seller the token picks no bucket, a refreshed token gets no
+	// fresh one either
+	assert.Nil(t, get(context.Background(), "token-1"))
+	assert.Nil(t, get(context.Background(), "token-1"))
+	assert.Nil(t, get(context.Background(), "token-2"))
+
+	seller := lazada.WithSeller(context.Background(), "seller-1")
+	assert.Nil(t, get(seller, "token-1"))
+	assert.ErrorIs(t, get(seller, "token-2"), context.DeadlineExceeded)
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrderItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/order/items/get`,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("order_id") != "1003" {
+				return httpmock.NewStringResponse(200, `{"code":"MissingParameter"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"code":"0","data":[{"order_id":1003,"order_item_id":501,"sku":"KP-01-GR","status":"pending"},
+				{"order_id":1003,"order_item_id":502,"sku":"KP-01-BL","status":"pending"}]}`), nil
+		})
+
+	res, err := client.Order.GetOrderItems(context.Background(), iterToken, &lazada.GetOrderParam{OrderID: 1003})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 2)
+	assert.Equal(t, int64(502), res.Data[1].OrderItemID)
+	assert.Equal(t, "KP-01-BL", res.Data[1].Sku)
+}
+
+func Test_PackOrderItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterResponder("POST", `=~/order/pack`,
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(200, `{"code":"0","data":{"order_items":[
+				{"order_item_id":501,"package_id":"FP031","shipment_provider":"LEX ID","tracking_number":"LXAD-1"},
+				{"order_item_id":502,"package_id":"FP031","shipment_provider":"LEX ID","tracking_number":"LXAD-1"}]}}`), nil
+		})
+
+	res, err := client.Order.Pack(context.Background(), iterToken, &lazada.PackParam{
+		OrderItemIDs:     lazada.IDList{501, 502},
+		DeliveryType:     "dropship",
+		ShippingProvider: "LEX ID",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[501,502]", query.Get("order_item_ids"))
+	assert.Equal(t, "dropship", query.Get("delivery_type"))
+	assert.NotEmpty(t, query.Get("sign"))
+	require.Len(t, res.Data.OrderItems, 2)
+	assert.Equal(t, "LXAD-1", res.Data.OrderItems[0].TrackingNumber)
+}
+
+func Test_CancelValidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/order/cancel/validate`,
+		httpmock.NewStringResponder(200, `{"code":"0","data":{"tip_type":"INFO","reason_options":[{"reason_id":10000,"reason_name":"Out of stock"}]}}`))
+
+	res, err := client.Order.CancelValidate(context.Background(), iterToken, &lazada.CancelValidateParam{
+		OrderID:         1003,
+		OrderItemIDList: lazada.IDList{501},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []lazada.CancelReason{{ReasonID: 10000, ReasonName: "Out of stock"}}, res.Data.ReasonOptions)
+}
+
+func Test_GetDocumentShippingLabel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pdf := []byte("%PDF-1.4 label")
+	httpmock.RegisterResponder("GET", `=~/order/document/get`,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("doc_type") != lazada.DocumentShippingLabel {
+				return httpmock.NewStringResponse(200, `{"code":"MissingParameter"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"code":"0","data":{"document":{"document_type":"shippingLabel",
+				"mime_type":"application/pdf","file":"`+base64.StdEncoding.EncodeToString(pdf)+`"}}}`), nil
+		})
+
+	res, err := client.Order.GetDocument(context.Background(), iterToken, &lazada.GetDocumentParam{
+		DocType:      lazada.DocumentShippingLabel,
+		OrderItemIDs: lazada.IDList{501},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/pdf", res.Data.Document.MimeType)
+	file, err := res.Data.Document.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, pdf, file)
+}
+
+func Test_GetShipmentProviders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/shipment/providers/get`,
+		httpmock.NewStringResponder(200, `{"code":"0","data":{"shipment_providers":[{"name":"LEX ID","cod":1,"is_default":1,"api_integration":1}]}}`))
+
+	res, err := client.Order.GetShipmentProviders(context.Background(), iterToken)
+	require.NoError(t, err)
+
+	require.Len(t, res.Data.ShipmentProviders, 1)
+	assert.Equal(t, "LEX ID", res.Data.ShipmentProviders[0].Name)
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewProductRequestXML(t *testing.T) {
+	req := lazada.NewProductRequest(lazada.Products{
+		ItemID:          3035021463,
+		PrimaryCategory: "10002019",
+		Images:          []string{"https://id-live.slatic.net/p/a.jpg"},
+		Attributes:      lazada.Attributes{Name: "Kaos Polos", Brand: "No Brand"},
+		Skus: []lazada.Skus{{
+			SkuID:         14206455127,
+			SellerSku:     "KP-01-GR",
+			Quantity:      4,
+			Price:         money.FromInt(35000, ""),
+			PackageWeight: "0.2",
+		}},
+	})
+	req.Product.Attributes["fabric_type"] = "Cotton"
+	req.Product.Skus[0].SaleProp = lazada.StringMap{"color_family": "Green"}
+	// the stock of the read is not written back
+	assert.Nil(t, req.Product.Skus[0].Quantity)
+
+	out, err := xml.Marshal(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, `<Request><Product><ItemId>3035021463</ItemId><PrimaryCategory>10002019</PrimaryCategory>`+
+		`<Images><Image>https://id-live.slatic.net/p/a.jpg</Image></Images>`+
+		`<Attributes><brand>No Brand</brand><fabric_type>Cotton</fabric_type><name>Kaos Polos</name></Attributes>`+
+		`<Skus><Sku><SkuId>14206455127</SkuId><SellerSku>KP-01-GR</SellerSku><price>35000</price>`+
+		`<package_weight>0.2</package_weight><saleProp><color_family>Green</color_family></saleProp></Sku></Skus></Product></Request>`, string(out))
+}
+
+func Test_CreateProductSignsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query, form url.Values
+	httpmock.RegisterResponder("POST", `=~/product/create`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			query = req.URL.Query()
+			if form, err = url.ParseQuery(string(body)); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"code":"0","request_id":"0b11","data":{"item_id":3035021463,
+				"sku_list":[{"shop_sku":"3035021463_ID-14206455127","seller_sku":"KP-01-GR","sku_id":14206455127}]}}`), nil
+		})
+
+	payload := lazada.NewProductRequest(lazada.Products{
+		PrimaryCategory: "10002019",
+		Attributes:      lazada.Attributes{Name: "Kaos Polos"},
+		Skus:            []lazada.Skus{{SellerSku: "KP-01-GR", Price: money.FromInt(35000, "")}},
+	})
+	quantity := 5
+	payload.Product.Skus[0].Quantity = &quantity
+
+	res, err := client.Product.CreateProduct(context.Background(), iterToken, payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3035021463, res.Data.ItemID)
+	assert.Equal(t, "3035021463_ID-14206455127", res.Data.SkuList[0].ShopSku)
+
+	assert.Equal(t, iterToken, query.Get("access_token"))
+	assert.True(t, strings.HasPrefix(form.Get("payload"), xml.Header+"<Request><Product>"))
+	assert.Contains(t, form.Get("payload"), "<SellerSku>KP-01-GR</SellerSku><quantity>5</quantity>")
+
+	// the signature covers the query and the payload
+	signed := url.Values{"payload": form["payload"]}
+	for k, vs := range query {
+		if k != "sign" {
+			signed[k] = vs
+		}
+	}
+	assert.Equal(t, client.Signature("/product/create", signed), query.Get("sign"))
+}
+
+func Test_MigrateImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var payload string
+	httpmock.RegisterResponder("POST", `=~/image/migrate`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseForm(); err != nil {
+				return nil, err
+			}
+			payload = req.PostForm.Get("payload")
+			return httpmock.NewStringResponse(200, `{"code":"0","data":{"image":{"hash_code":"a1b2","url":"https://id-live.slatic.net/p/a1b2.jpg"}}}`), nil
+		})
+
+	res, err := client.Product.MigrateImage(context.Background(), iterToken, "https://example.com/shirt.jpg")
+	require.NoError(t, err)
+
+	assert.Equal(t, xml.Header+"<Request><Image><Url>https://example.com/shirt.jpg</Url></Image></Request>", payload)
+	assert.Equal(t, "https://id-live.slatic.net/p/a1b2.jpg", res.Data.Image.URL)
+}
+
+func Test_GetCategoryTree(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/category/tree/get`,
+		httpmock.NewStringResponder(200, `{"code":"0","data":[{"category_id":1,"name":"Fashion","leaf":false,
+			"children":[{"category_id":10002019,"name":"T-Shirts","leaf":true,"var":true}]}]}`))
+
+	res, err := client.Product.GetCategoryTree(context.Background(), iterToken, &lazada.CategoryTreeParams{LanguageCode: "id_ID"})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, []lazada.Category{{CategoryID: 10002019, Name: "T-Shirts", Leaf: true, Var: true}}, res.Data[0].Children)
+}
+
+func Test_GetCategoryAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/category/attributes/get`,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("primary_category_id") != "10002019" {
+				return httpmock.NewStringResponse(200, `{"code":"MissingParameter"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_category_attributes_resp.json")), nil
+		})
+
+	res, err := client.Product.GetCategoryAttributes(context.Background(), iterToken, &lazada.CategoryAttributesParams{PrimaryCategoryID: "10002019"})
+	require.NoError(t, err)
+
+	require.Len(t, res.Data, 2)
+	assert.Equal(t, "sku", res.Data[1].AttributeType)
+	assert.Equal(t, 1, res.Data[1].IsSaleProp)
+	assert.Equal(t, "Green", res.Data[1].Options[0].Name)
+}
+
+func Test_GetBrands(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", `=~/brands/get`,
+		httpmock.NewStringResponder(200, `{"code":"0","data":[{"brand_id":30768,"name":"No Brand","global_identifier":"no_brand"}]}`))
+
+	res, err := client.Product.GetBrands(context.Background(), iterToken, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []lazada.Brand{{BrandID: 30768, Name: "No Brand", GlobalIdentifier: "no_brand"}}, res.Data)
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pushAppKey = "2910038"
+	pushSecret = "111189237912738971283187318"
+)
+
+func signPush(body []byte) string {
+	h := hmac.New(sha256.New, []byte(pushSecret))
+	h.Write([]byte(pushAppKey))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sendPush(h http.Handler, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "https://example.com/lazada/push", bytes.NewReader(body))
+	req.Header.Set("Authorization", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func newPushHandler() *lazada.PushHandler {
+	return lazada.NewPushHandler(lazada.NewClient(pushAppKey, pushSecret, lazada.Indonesia))
+}
+
+func Test_PushTradeOrder(t *testing.T) {
+	h := newPushHandler()
+
+	var got *lazada.TradeOrderPush
+	h.OnTradeOrder(func(ctx context.Context, p *lazada.TradeOrderPush) error {
+		got = p
+		return nil
+	})
+
+	body := loadFixture("push_trade_order.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "1001", got.SellerID)
+	assert.Equal(t, "260422900198362", got.TradeOrderID)
+	assert.Equal(t, "pending", got.OrderItemStatus)
+	assert.Equal(t, "6143281", got.BuyerID.String())
+}
+
+func Test_PushChatMessage(t *testing.T) {
+	h := newPushHandler()
+
+	var got *lazada.ChatMessagePush
+	h.OnChatMessage(func(ctx context.Context, p *lazada.ChatMessagePush) error {
+		got = p
+		return nil
+	})
+
+	body := loadFixture("push_chat_message.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "100000090_2_1001_1_6143281_103", got.Message.SessionID)
+	assert.Equal(t, 1, got.Message.TemplateID)
+	assert.JSONEq(t, `{"txt":"Kak, bisa kirim hari ini?"}`, got.Message.Content)
+}
+
+func Test_PushProductQC(t *testing.T) {
+	h := newPushHandler()
+
+	var got *lazada.ProductQCPush
+	h.OnProductQC(func(ctx context.Context, p *lazada.ProductQCPush) error {
+		got = p
+		return nil
+	})
+
+	body := loadFixture("push_product_qc.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "2104520395", got.ItemID.String())
+	assert.Equal(t, "rejected", got.Status)
+	assert.Equal(t, "Main image contains watermark", got.RejectReason)
+}
+
+func Test_PushFallback(t *testing.T) {
+	h := newPushHandler()
+
+	var got *lazada.PushMessage
+	h.OnMessage(func(ctx context.Context, m *lazada.PushMessage) error {
+		got = m
+		return nil
+	})
+
+	body := loadFixture("push_unknown.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, lazada.PushMessageType(10), got.MessageType)
+}
+
+func Test_PushUppercaseSignature(t *testing.T) {
+	body := loadFixture("push_unknown.json")
+	assert.True(t, lazada.VerifyPushSignature(pushAppKey, pushSecret, body, strings.ToUpper(signPush(body))))
+
+	h := newPushHandler()
+	called := false
+	h.OnMessage(func(ctx context.Context, m *lazada.PushMessage) error {
+		called = true
+		return nil
+	})
+	rec := sendPush(h, body, strings.ToUpper(signPush(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+
+	assert.False(t, lazada.VerifyPushSignature(pushAppKey, pushSecret, body, "not hex"))
+}
+
+func Test_PushInvalidSignature(t *testing.T) {
+	h := newPushHandler()
+
+	called := false
+	h.OnMessage(func(ctx context.Context, m *lazada.PushMessage) error {
+		called = true
+		return nil
+	})
+
+	body := loadFixture("push_unknown.json")
+	rec := sendPush(h, append(body, ' '), signPush(body))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = sendPush(h, body, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func Test_PushCallbackError(t *testing.T) {
+	h := newPushHandler()
+	h.OnTradeOrder(func(ctx context.Context, p *lazada.TradeOrderPush) error {
+		return errors.New("database is down")
+	})
+
+	body := loadFixture("push_trade_order.json")
+	rec := sendPush(h, body, signPush(body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	bad := []byte(`{"seller_id":"1001","message_type":0,"data":{"trade_order_id":260422900198362},"timestamp":1603766859530}`)
+	rec = sendPush(h, bad, signPush(bad))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
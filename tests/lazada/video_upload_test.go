@@ -0,0 +1,233 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const videoToken = "50000600116cWYzTphDtTDshMBux1993574eoq9YzkugHtfWTiXeDQ7OzvDLRkFx"
+
+// videoServer answers the block upload calls, failing each block the number
+// of times set in failures.
+type videoServer struct {
+	mu       sync.Mutex
+	failures map[string]int
+	blocks   map[string][]byte
+	parts    string
+}
+
+func registerVideoServer(failures map[string]int) *videoServer {
+	s := &videoServer{failures: failures, blocks: map[string][]byte{}}
+
+	httpmock.RegisterResponder("POST", `=~/media/video/block/create`,
+		httpmock.NewStringResponder(200, `{"code":"0","upload_id":"upload-1","request_id":"req-init"}`))
+
+	httpmock.RegisterResponder("POST", `=~/media/video/block/upload`,
+		func(req *http.Request) (*http.Response, error) {
+			blockNo := req.URL.Query().Get("blockNo")
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return nil, err
+			}
+			var data bytes.Buffer
+			data.ReadFrom(file)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.failures[blockNo] > 0 {
+				s.failures[blockNo]--
+				return httpmock.NewStringResponse(200, `{"code":"ServiceTimeout","message":"timeout","request_id":"req-fail"}`), nil
+			}
+			s.blocks[blockNo] = data.Bytes()
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"code":"0","e_tag":"etag-%s","request_id":"req-block"}`, blockNo)), nil
+		})
+
+	httpmock.RegisterResponder("POST", `=~/media/video/block/commit`,
+		func(req *http.Request) (*http.Response, error) {
+			s.mu.Lock()
+			s.parts = req.URL.Query().Get("parts")
+			s.mu.Unlock()
+			return httpmock.NewStringResponse(200, `{"code":"0","video_id":"video-1","request_id":"req-commit"}`), nil
+		})
+
+	return s
+}
+
+func Test_VideoUploaderUploadsBlocks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerVideoServer(map[string]int{"1": 2})
+	store := lazada.NewMemoryVideoUploadStore()
+
+	var progress []lazada.VideoUploadProgress
+	uploader := client.Media.NewVideoUploader(
+		lazada.WithBlockSize(4),
+		lazada.WithUploadConcurrency(2),
+		lazada.WithBlockRetries(2, 0),
+		lazada.WithUploadStore(store),
+		lazada.WithUploadProgress(func(p lazada.VideoUploadProgress) {
+			progress = append(progress, p)
+		}),
+	)
+
+	video := []byte("0123456789")
+	res, err := uploader.Upload(context.Background(), videoToken, bytes.NewReader(video), int64(len(video)), &lazada.VideoUploadRequest{
+		Key:      "video.mp4",
+		FileName: "video.mp4",
+		Title:    "video",
+		CoverURL: "https://img.lazcdn.com/cover.jpg",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &lazada.UploadVideoResponse{UploadID: "upload-1", VideoID: "video-1"}, res)
+
+	assert.Equal(t, map[string][]byte{"0": []byte("0123"), "1": []byte("4567"), "2": []byte("89")}, server.blocks)
+	assert.JSONEq(t, `[{"partNumber":1,"eTag":"etag-0"},{"partNumber":2,"eTag":"etag-1"},{"partNumber":3,"eTag":"etag-2"}]`, server.parts)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 5, calls[`POST =~/media/video/block/upload`])
+
+	require.Len(t, progress, 3)
+	assert.Equal(t, lazada.VideoUploadProgress{
+		UploadID:       "upload-1",
+		UploadedBytes:  10,
+		TotalBytes:     10,
+		UploadedBlocks: 3,
+		BlockCount:     3,
+	}, progress[2])
+
+	state, err := store.Load(context.Background(), "video.mp4")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func Test_VideoUploaderKeepsStateOnFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerVideoServer(map[string]int{"2": 10})
+	store := lazada.NewMemoryVideoUploadStore()
+	uploader := client.Media.NewVideoUploader(
+		lazada.WithBlockSize(4),
+		lazada.WithUploadConcurrency(1),
+		lazada.WithBlockRetries(1, 0),
+		lazada.WithUploadStore(store),
+	)
+
+	video := []byte("0123456789")
+	req := &lazada.VideoUploadRequest{Key: "video.mp4", FileName: "video.mp4", Title: "video"}
+	_, err := uploader.Upload(context.Background(), videoToken, bytes.NewReader(video), int64(len(video)), req)
+
+	var respErr lazada.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "ServiceTimeout", respErr.Code)
+	assert.Empty(t, server.parts)
+
+	state, err := store.Load(context.Background(), "video.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, &lazada.VideoUploadState{
+		UploadID:  "upload-1",
+		Size:      10,
+		BlockSize: 4,
+		Parts:     map[int]string{0: "etag-0", 1: "etag-1"},
+	}, state)
+
+	// the block succeeds on the next attempt, only it is uploaded again
+	server.failures["2"] = 0
+	httpmock.ZeroCallCounters()
+
+	res, err := uploader.Upload(context.Background(), videoToken, bytes.NewReader(video), int64(len(video)), req)
+	require.NoError(t, err)
+	assert.Equal(t, "video-1", res.VideoID)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 0, calls[`POST =~/media/video/block/create`])
+	assert.Equal(t, 1, calls[`POST =~/media/video/block/upload`])
+	assert.JSONEq(t, `[{"partNumber":1,"eTag":"etag-0"},{"partNumber":2,"eTag":"etag-1"},{"partNumber":3,"eTag":"etag-2"}]`, server.parts)
+}
+
+func Test_VideoUploaderResumesFromFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerVideoServer(nil)
+	store := lazada.NewFileVideoUploadStore(t.TempDir())
+	require.NoError(t, store.Save(context.Background(), "video.mp4", &lazada.VideoUploadState{
+		UploadID:  "upload-0",
+		Size:      10,
+		BlockSize: 4,
+		Parts:     map[int]string{0: "etag-old", 2: "etag-old"},
+	}))
+
+	var progress []lazada.VideoUploadProgress
+	uploader := client.Media.NewVideoUploader(
+		lazada.WithUploadStore(store),
+		lazada.WithUploadProgress(func(p lazada.VideoUploadProgress) {
+			progress = append(progress, p)
+		}),
+	)
+
+	video := []byte("0123456789")
+	res, err := uploader.Upload(context.Background(), videoToken, bytes.NewReader(video), int64(len(video)), &lazada.VideoUploadRequest{
+		Key:      "video.mp4",
+		FileName: "video.mp4",
+		Title:    "video",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &lazada.UploadVideoResponse{UploadID: "upload-0", VideoID: "video-1"}, res)
+
+	assert.Equal(t, map[string][]byte{"1": []byte("4567")}, server.blocks)
+	assert.JSONEq(t, `[{"partNumber":1,"eTag":"etag-old"},{"partNumber":2,"eTag":"etag-1"},{"partNumber":3,"eTag":"etag-old"}]`, server.parts)
+
+	require.Len(t, progress, 2)
+	assert.Equal(t, int64(6), progress[0].UploadedBytes)
+	assert.Equal(t, int64(10), progress[1].UploadedBytes)
+
+	state, err := store.Load(context.Background(), "video.mp4")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func Test_UploadVideo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerVideoServer(nil)
+
+	res, err := client.Media.UploadVideo(context.Background(), videoToken, "video.mp4", "video", "https://img.lazcdn.com/cover.jpg", "upload-1", []byte("0123456789"))
+	require.NoError(t, err)
+	assert.Equal(t, "video-1", res.VideoID)
+	assert.Equal(t, map[string][]byte{"0": []byte("0123456789")}, server.blocks)
+}
+
+func Test_VideoUploaderStopsOnExpiredToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerVideoServer(nil)
+	httpmock.RegisterResponder("POST", `=~/media/video/block/upload`,
+		httpmock.NewStringResponder(200, `{"code":"IllegalAccessToken","message":"expired","request_id":"req-fail"}`))
+	uploader := client.Media.NewVideoUploader(
+		lazada.WithBlockSize(4),
+		lazada.WithUploadConcurrency(1),
+		lazada.WithBlockRetries(3, 0),
+	)
+
+	video := []byte("0123")
+	_, err := uploader.Upload(context.Background(), videoToken, bytes.NewReader(video), int64(len(video)), &lazada.VideoUploadRequest{FileName: "video.mp4", Title: "video"})
+	assert.ErrorIs(t, err, apierror.ErrTokenExpired)
+
+	// an error retrying cannot fix is not retried
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, calls[`POST =~/media/video/block/upload`])
+}
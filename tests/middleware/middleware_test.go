@@ -0,0 +1,242 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testURL = "https://api.example.com/v1/items"
+
+var fastBackoff = middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond})
+
+func Test_RetriesThrottledRequest(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(429, `{"message":"slow down"}`),
+		httpmock.NewStringResponse(503, `{"message":"unavailable"}`),
+		httpmock.NewStringResponse(200, `{"ok":true}`),
+	}))
+
+	client := &http.Client{Transport: middleware.New(mock, fastBackoff)}
+	resp, err := client.Get(testURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 3, mock.GetTotalCallCount())
+}
+
+func Test_RetryStopsAtMaxRetries(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(429, `{"message":"slow down"}`))
+
+	client := &http.Client{Transport: middleware.New(mock, fastBackoff, middleware.WithMaxRetries(2))}
+	resp, err := client.Get(testURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.Equal(t, `{"message":"slow down"}`, string(body))
+	assert.Equal(t, 3, mock.GetTotalCallCount())
+}
+
+func Test_RetryResendsBody(t *testing.T) {
+	var bodies []string
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("POST", testURL, func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			return httpmock.NewStringResponse(503, ""), nil
+		}
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	client := &http.Client{Transport: middleware.New(mock, fastBackoff)}
+	resp, err := client.Post(testURL, "application/json", strings.NewReader(`{"item_id":1}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{`{"item_id":1}`, `{"item_id":1}`}, bodies)
+}
+
+func Test_BadGatewayRetriedForIdempotentMethodsOnly(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(502, ""))
+	mock.RegisterResponder("POST", testURL, httpmock.NewStringResponder(502, ""))
+
+	client := &http.Client{Transport: middleware.New(mock, fastBackoff, middleware.WithMaxRetries(1))}
+
+	resp, err := client.Get(testURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = client.Post(testURL, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	info := mock.GetCallCountInfo()
+	assert.Equal(t, 2, info["GET "+testURL])
+	assert.Equal(t, 1, info["POST "+testURL])
+}
+
+func Test_CustomRetryable(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(200, `{"error":"busy"}`),
+		httpmock.NewStringResponse(200, `{"error":""}`),
+	}))
+
+	busy := func(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+		if err == nil && strings.Contains(string(middleware.PeekBody(resp)), `"busy"`) {
+			return true, time.Millisecond
+		}
+		return middleware.DefaultRetryable(req, resp, err)
+	}
+
+	client := &http.Client{Transport: middleware.New(mock, middleware.WithRetryable(busy))}
+	resp, err := client.Get(testURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"error":""}`, string(body))
+	assert.Equal(t, 2, mock.GetTotalCallCount())
+}
+
+func Test_RetryWaitHonoursContext(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(429, "")
+		resp.Header.Set("Retry-After", "30")
+		return resp, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", testURL, nil)
+	start := time.Now()
+	_, err := (&http.Client{Transport: middleware.New(mock)}).Do(req)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "error returned %v, expected %v", err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, 1, mock.GetTotalCallCount())
+}
+
+func Test_RateLimiterPerShop(t *testing.T) {
+	limiter := middleware.NewRateLimiter(
+		middleware.Limit{},
+		middleware.Limit{Rate: 20, Burst: 1},
+	)
+	ctx := context.Background()
+
+	// another shop has its own bucket
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, "shop-1"))
+	require.NoError(t, limiter.Wait(ctx, "shop-2"))
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+	// the same shop waits for its bucket to refill
+	start = time.Now()
+	require.NoError(t, limiter.Wait(ctx, "shop-1"))
+	require.NoError(t, limiter.Wait(ctx, "shop-1"))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func Test_RateLimiterPartnerShared(t *testing.T) {
+	limiter := middleware.NewRateLimiter(
+		middleware.Limit{Rate: 20, Burst: 1},
+		middleware.Limit{},
+	)
+
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(200, ""))
+	shopKey := middleware.WithShopKey(func(req *http.Request) string { return req.URL.Query().Get("shop_id") })
+
+	a := &http.Client{Transport: middleware.New(mock, middleware.WithRateLimiter(limiter), shopKey)}
+	b := &http.Client{Transport: middleware.New(mock, middleware.WithRateLimiter(limiter), shopKey)}
+
+	start := time.Now()
+	for _, c := range []*http.Client{a, b, a} {
+		resp, err := c.Get(testURL + "?shop_id=1")
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func Test_RateLimiterHonoursContext(t *testing.T) {
+	limiter := middleware.NewRateLimiter(middleware.Limit{Rate: 0.1, Burst: 1}, middleware.Limit{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, limiter.Wait(ctx, ""))
+	err := limiter.Wait(ctx, "")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "error returned %v, expected %v", err, context.DeadlineExceeded)
+}
+
+func Test_RateLimiterRefundsPartner(t *testing.T) {
+	limiter := middleware.NewRateLimiter(
+		middleware.Limit{Rate: 20, Burst: 2},
+		middleware.Limit{Rate: 0.1, Burst: 1},
+	)
+	require.NoError(t, limiter.Wait(context.Background(), "shop-1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, "shop-1"), context.DeadlineExceeded)
+
+	// the partner token of the failed wait is back
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), ""))
+	assert.Less(t, time.Since(start), 25*time.Millisecond)
+}
+
+func Test_BackoffDelay(t *testing.T) {
+	b := middleware.Backoff{Base: 100 * time.Millisecond, Max: time.Second}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			d := b.Delay(attempt)
+			assert.GreaterOrEqual(t, d, max/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, d, max, "attempt %d", attempt)
+		}
+	}
+}
+
+func Test_RetryAfter(t *testing.T) {
+	h := http.Header{}
+	assert.Equal(t, time.Duration(0), middleware.RetryAfter(h, "Retry-After"))
+
+	h.Set("Retry-After", "1.5")
+	assert.Equal(t, 1500*time.Millisecond, middleware.RetryAfter(h, "Retry-After"))
+
+	h.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Minute), float64(middleware.RetryAfter(h, "Retry-After")), float64(2*time.Second))
+}
+
+func Test_TokopediaRetryableWaitsForReset(t *testing.T) {
+	req := httptest.NewRequest("GET", testURL, nil)
+	resp := httpmock.NewStringResponse(429, "")
+	resp.Header.Set("X-Ratelimit-Full-Reset-After", "2")
+
+	retry, wait := tokopedia.Retryable(req, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, 2*time.Second, wait)
+}
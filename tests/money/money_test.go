@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "150000", want: "150000"},
+		{in: "12.90", want: "12.9"},
+		{in: "-0.50", want: "-0.5"},
+		{in: "+7", want: "7"},
+		{in: "000120.000", want: "120"},
+		{in: ".5", want: "0.5"},
+		{in: "1.5e3", want: "1500"},
+		{in: "15E-3", want: "0.015"},
+		{in: "-0", want: "0"},
+		{in: "", want: "0"},
+		{in: " 42 ", want: "42"},
+		{in: "1,000", err: true},
+		{in: "12.9.1", err: true},
+		{in: "abc", err: true},
+		{in: "-", err: true},
+		{in: "1e999", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := money.ParseDecimal(tt.in)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+
+	// canonical values compare with ==
+	assert.True(t, money.MustParseDecimal("75000.00") == money.MustParseDecimal("75000"))
+	assert.True(t, money.MustParseDecimal("0") == money.Decimal{})
+}
+
+func Test_DecimalArithmetic(t *testing.T) {
+	d := money.MustParseDecimal
+
+	assert.Equal(t, d("0.3"), d("0.1").Add(d("0.2")))
+	assert.Equal(t, d("0.3"), money.DecimalFromFloat(0.1).Add(money.DecimalFromFloat(0.2)))
+	assert.Equal(t, d("-0.01"), d("12.89").Sub(d("12.9")))
+	assert.Equal(t, d("3.375"), d("1.5").Mul(d("2.25")))
+	assert.Equal(t, d("12.9"), money.NewDecimal(1290, -2))
+	assert.Equal(t, d("1500"), money.NewDecimal(15, 2))
+	assert.Equal(t, d("-5"), d("5").Neg())
+
+	assert.Equal(t, -1, d("9.99").Cmp(d("10")))
+	assert.Equal(t, 0, d("10.0").Cmp(d("10")))
+	assert.Equal(t, 1, d("0.001").Sign())
+	assert.True(t, d("0.000").IsZero())
+
+	// int64 would overflow
+	big := d("9223372036854775807")
+	assert.Equal(t, "18446744073709551614", big.Add(big).String())
+
+	assert.Equal(t, 12.9, d("12.90").Float64())
+}
+
+func Test_DecimalRound(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int32
+		want   string
+		fixed  string
+	}{
+		{in: "12.345", places: 2, want: "12.35", fixed: "12.35"},
+		{in: "12.344", places: 2, want: "12.34", fixed: "12.34"},
+		{in: "-12.345", places: 2, want: "-12.35", fixed: "-12.35"},
+		{in: "0.5", places: 0, want: "1", fixed: "1"},
+		{in: "-0.5", places: 0, want: "-1", fixed: "-1"},
+		{in: "12.9", places: 2, want: "12.9", fixed: "12.90"},
+		{in: "7", places: 2, want: "7", fixed: "7.00"},
+		{in: "0.004", places: 2, want: "0", fixed: "0.00"},
+	}
+
+	for _, tt := range tests {
+		d := money.MustParseDecimal(tt.in)
+		assert.Equal(t, tt.want, d.Round(tt.places).String(), tt.in)
+		assert.Equal(t, tt.fixed, d.StringFixed(tt.places), tt.in)
+	}
+}
+
+func Test_Money(t *testing.T) {
+	idr := money.MustParse("150000", "IDR")
+
+	sum, err := idr.Add(money.MustParse("9000", "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("159000", "IDR"), sum)
+
+	// an amount without currency takes the other one
+	sum, err = money.MustParse("1000", "").Add(idr)
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("151000", "IDR"), sum)
+
+	_, err = idr.Add(money.MustParse("10", "SGD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+	_, err = idr.Cmp(money.MustParse("10", "SGD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	diff, err := idr.Sub(money.FromInt(150001, "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, money.FromInt(-1, "IDR"), diff)
+
+	total, err := money.Sum(money.FromFloat(0.1, "SGD"), money.FromFloat(0.2, "SGD"), money.FromInt(3, "SGD").Neg())
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("-2.7", "SGD"), total)
+
+	assert.Equal(t, money.MustParse("225000", "IDR"), money.MustParse("75000", "IDR").Mul(3))
+	assert.Equal(t, money.MustParse("12.35", "SGD"), money.MustParse("12.345", "SGD").Round())
+	assert.Equal(t, money.MustParse("12345", "IDR"), money.MustParse("12344.5", "IDR").Round())
+	assert.Equal(t, money.MustParse("1", "IDR"), money.MustParse("1", "").WithCurrency("IDR"))
+	assert.Equal(t, money.MustParse("1", "SGD"), money.MustParse("1", "SGD").WithCurrency("IDR"))
+}
+
+func Test_MoneyFormat(t *testing.T) {
+	assert.Equal(t, "IDR 150000", money.MustParse("150000", "IDR").String())
+	assert.Equal(t, "SGD 12.90", money.MustParse("12.9", "SGD").String())
+	assert.Equal(t, "12.90", money.MustParse("12.9", "").String())
+
+	assert.Equal(t, "150.000", money.MustParse("150000", "IDR").Format(".", ","))
+	assert.Equal(t, "1,234.50", money.MustParse("1234.5", "SGD").Format(",", "."))
+	assert.Equal(t, "-1,234,567.00", money.MustParse("-1234567", "MYR").Format(",", "."))
+	assert.Equal(t, "999", money.MustParse("999", "IDR").Format(".", ","))
+}
+
+func Test_MoneyJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want money.Money
+	}{
+		{name: "shopee number", in: `75000.5`, want: money.MustParse("75000.5", "")},
+		{name: "tokopedia integer", in: `159000`, want: money.MustParse("159000", "")},
+		{name: "tiktok string", in: `"75000"`, want: money.MustParse("75000", "")},
+		{name: "lazada thousands", in: `"225,000.00"`, want: money.MustParse("225000", "")},
+		{name: "empty string", in: `""`, want: money.Money{}},
+		{name: "null", in: `null`, want: money.Money{}},
+		{name: "object", in: `{"amount":"10000","currency":"IDR"}`, want: money.MustParse("10000", "IDR")},
+		{name: "object with number", in: `{"amount":1.5,"currency":"MYR"}`, want: money.MustParse("1.5", "MYR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m money.Money
+			require.NoError(t, json.Unmarshal([]byte(tt.in), &m))
+			assert.Equal(t, tt.want, m)
+		})
+	}
+
+	var m money.Money
+	assert.Error(t, json.Unmarshal([]byte(`"12abc"`), &m))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &m))
+
+	out, err := json.Marshal(money.MustParse("12.90", "SGD"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"12.9","currency":"SGD"}`, string(out))
+
+	var back money.Money
+	require.NoError(t, json.Unmarshal(out, &back))
+	assert.Equal(t, money.MustParse("12.9", "SGD"), back)
+}
+
+func Test_MarketplaceCurrency(t *testing.T) {
+	t.Run("shopee order", func(t *testing.T) {
+		var o shopee.OrderList
+		require.NoError(t, json.Unmarshal([]byte(`{"currency":"MYR","total_amount":12.9,
+			"item_list":[{"model_original_price":15,"model_discounted_price":12.9}]}`), &o))
+		assert.Equal(t, money.MustParse("12.9", "MYR"), o.TotalAmount)
+		assert.Equal(t, money.MustParse("15", "MYR"), o.ItemList[0].ModelOriginalPrice)
+	})
+
+	t.Run("shopee price", func(t *testing.T) {
+		var p shopee.PriceInfo
+		require.NoError(t, json.Unmarshal([]byte(`{"currency":"SGD","original_price":0.1,"current_price":0.07}`), &p))
+		assert.Equal(t, money.MustParse("0.1", "SGD"), p.OriginalPrice)
+		assert.Equal(t, money.MustParse("0.07", "SGD"), p.CurrentPrice)
+	})
+
+	t.Run("lazada order item", func(t *testing.T) {
+		var item lazada.OrderItems
+		require.NoError(t, json.Unmarshal([]byte(`{"currency":"PHP","item_price":"1,299.50","paid_price":1199.5,
+			"supply_price":900,"supply_price_currency":"USD"}`), &item))
+		assert.Equal(t, money.MustParse("1299.5", "PHP"), item.ItemPrice)
+		assert.Equal(t, money.MustParse("1199.5", "PHP"), item.PaidPrice)
+		assert.Equal(t, money.MustParse("900", "USD"), item.SupplyPrice)
+	})
+
+	t.Run("tiktok", func(t *testing.T) {
+		var p tiktok.Payment
+		require.NoError(t, json.Unmarshal([]byte(`{"currency":"IDR","total_amount":"159000","sub_total":"150000"}`), &p))
+		assert.Equal(t, money.MustParse("159000", "IDR"), p.TotalAmount)
+
+		var c tiktok.Discount
+		require.NoError(t, json.Unmarshal([]byte(`{"reduction_amount":{"amount":"10000","currency":"IDR"}}`), &c))
+		assert.Equal(t, money.MustParse("10000", "IDR"), c.ReductionAmount)
+	})
+
+	t.Run("tokopedia", func(t *testing.T) {
+		var o tokopedia.Order
+		require.NoError(t, json.Unmarshal([]byte(`{"amt":{"ttl_amount":159000},
+			"products":[{"price":75000,"currency":"Rp"}]}`), &o))
+		assert.Equal(t, money.MustParse("159000", "IDR"), o.Amt.TtlAmount)
+		assert.Equal(t, money.MustParse("75000", "IDR"), o.Products[0].Price)
+	})
+}
@@ -0,0 +1,216 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/order"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadMockData(t *testing.T, filename string, out any) {
+	t.Helper()
+	f, err := os.ReadFile("../../mockdata/" + filename)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot load fixture %v", filename))
+	}
+	require.NoError(t, json.Unmarshal(f, out))
+}
+
+func Test_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(t *testing.T) order.Order
+
+		id          string
+		status      order.Status
+		rawStatus   string
+		total       money.Money
+		city        string
+		lines       int
+		firstLine   order.OrderLine
+		carrier     string
+		createdAt   int64
+		buyerName   string
+		shippingFee money.Money
+	}{
+		{
+			name: "shopee",
+			convert: func(t *testing.T) order.Order {
+				var resp shopee.GetOrderDetailResponse
+				loadMockData(t, "shopee/get_order_detail_resp.json", &resp)
+				return order.FromShopee(resp.OrderListResponse.OrderList[0])
+			},
+			id:          "231115ABCD1234",
+			status:      order.StatusReadyToShip,
+			rawStatus:   "READY_TO_SHIP",
+			total:       money.MustParse("159000", "IDR"),
+			shippingFee: money.MustParse("9000", "IDR"),
+			city:        "Kota Jakarta Pusat",
+			lines:       1,
+			firstLine: order.OrderLine{
+				ID: "3400133011", ProductID: "3400133011", VariantID: "10001", SKU: "KP-01-BL-L",
+				Name: "Kaos Polos", VariantName: "Biru,L", ImageURL: "https://cf.shopee.co.id/file/id-11134207-7r98o",
+				Quantity: 2, UnitPrice: money.MustParse("75000", "IDR"),
+			},
+			carrier:   "SPX Standard",
+			createdAt: 1700010000,
+			buyerName: "budi.s",
+		},
+		{
+			name: "lazada",
+			convert: func(t *testing.T) order.Order {
+				var o lazada.GetOrderResponse
+				var items lazada.GetOrderItemsResponse
+				loadMockData(t, "lazada/get_order_resp.json", &o)
+				loadMockData(t, "lazada/get_order_items_resp.json", &items)
+				return order.FromLazada(o.Data, items.Data)
+			},
+			id:          "260422900198362",
+			status:      order.StatusPending,
+			rawStatus:   "pending,canceled",
+			shippingFee: money.MustParse("9000", "IDR"),
+			city:        "Kota Jakarta Pusat",
+			lines:       3,
+			firstLine: order.OrderLine{
+				ID: "260422900298362", ProductID: "2765431", VariantID: "11554321", SKU: "KP-01-BL-L",
+				Name: "Kaos Polos", VariantName: "Color:Biru, Size:L", ImageURL: "https://id-live.slatic.net/p/kaos-biru.jpg",
+				Quantity: 1, UnitPrice: money.MustParse("75000", "IDR"),
+				Status: order.StatusPending, RawStatus: "pending",
+			},
+			carrier:   "LEX ID",
+			createdAt: 1700017200,
+			buyerName: "Budi Santoso",
+		},
+		{
+			name: "tiktok",
+			convert: func(t *testing.T) order.Order {
+				var resp tiktok.GetOrderResponse
+				loadMockData(t, "tiktok/get_order_detail_resp.json", &resp)
+				return order.FromTiktok(resp.Data.Orders[0])
+			},
+			id:          "576461413038785752",
+			status:      order.StatusReadyToShip,
+			rawStatus:   "AWAITING_SHIPMENT",
+			total:       money.MustParse("159000", "IDR"),
+			shippingFee: money.MustParse("9000", "IDR"),
+			city:        "Jakarta Pusat",
+			lines:       2,
+			firstLine: order.OrderLine{
+				ID: "577086512123755123", ProductID: "1729582718312380123", VariantID: "2729382476852921123", SKU: "KP-01-BL-L",
+				Name: "Kaos Polos", VariantName: "Biru, L", ImageURL: "https://p16-oec-va.ibyteimg.com/tos-maliva-i-o3syd03w52-us/kaos-biru.jpeg",
+				Quantity: 1, UnitPrice: money.MustParse("75000", "IDR"),
+			},
+			carrier:   "J&T Express",
+			createdAt: 1700010000,
+			buyerName: "Budi",
+		},
+		{
+			name: "tokopedia",
+			convert: func(t *testing.T) order.Order {
+				var resp tokopedia.GetOrdersResponse
+				loadMockData(t, "tokopedia/get_orders_resp.json", &resp)
+				return order.FromTokopedia(resp.Data[0])
+			},
+			id:          "48297285",
+			status:      order.StatusPending,
+			rawStatus:   "220",
+			total:       money.MustParse("159000", "IDR"),
+			shippingFee: money.MustParse("9000", "IDR"),
+			city:        "Kota Administrasi Jakarta Pusat",
+			lines:       1,
+			firstLine: order.OrderLine{
+				ProductID: "15236812", SKU: "KP-01-BL-L", Name: "Kaos Polos Biru L",
+				Quantity: 2, UnitPrice: money.MustParse("75000", "IDR"),
+			},
+			carrier:   "JNE Reguler",
+			createdAt: 1700010000,
+			buyerName: "Budi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.convert(t)
+
+			assert.Equal(t, tt.id, o.ID)
+			assert.Equal(t, order.Marketplace(tt.name), o.Marketplace)
+			assert.Equal(t, tt.status, o.Status)
+			assert.Equal(t, tt.rawStatus, o.RawStatus)
+			assert.Equal(t, tt.total, o.Total)
+			assert.Equal(t, tt.shippingFee, o.ShippingFee)
+			assert.Equal(t, tt.city, o.ShippingAddress.City)
+			assert.Equal(t, tt.carrier, o.Carrier)
+			assert.Equal(t, tt.buyerName, o.BuyerName)
+			assert.True(t, time.Unix(tt.createdAt, 0).Equal(o.CreatedAt), "CreatedAt %s", o.CreatedAt)
+			assert.NotNil(t, o.Raw)
+
+			require.Len(t, o.Lines, tt.lines)
+			assert.Equal(t, tt.firstLine, o.Lines[0])
+		})
+	}
+}
+
+func Test_MapStatus(t *testing.T) {
+	tests := []struct {
+		marketplace order.Marketplace
+		raw         string
+		want        order.Status
+	}{
+		{order.Shopee, "UNPAID", order.StatusUnpaid},
+		{order.Shopee, "READY_TO_SHIP", order.StatusReadyToShip},
+		{order.Shopee, "PROCESSED", order.StatusReadyToShip},
+		{order.Shopee, "IN_CANCEL", order.StatusInCancel},
+		{order.Shopee, "TO_CONFIRM_RECEIVE", order.StatusDelivered},
+		{order.Lazada, "ready_to_ship", order.StatusReadyToShip},
+		{order.Lazada, "confirmed", order.StatusCompleted},
+		{order.Lazada, "shipped_back", order.StatusReturned},
+		{order.Tiktok, "AWAITING_SHIPMENT", order.StatusReadyToShip},
+		{order.Tiktok, "IN_TRANSIT", order.StatusShipped},
+		{order.Tiktok, "CANCELLED", order.StatusCancelled},
+		{order.Tokopedia, "400", order.StatusReadyToShip},
+		{order.Tokopedia, "10", order.StatusCancelled},
+		{order.Tokopedia, "700", order.StatusCompleted},
+		// the table is case sensitive and per marketplace
+		{order.Lazada, "READY_TO_SHIP", order.StatusUnknown},
+		{order.Tiktok, "NOT_A_STATUS", order.StatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.marketplace, tt.raw), func(t *testing.T) {
+			assert.Equal(t, tt.want, order.MapStatus(tt.marketplace, tt.raw))
+		})
+	}
+}
+
+func Test_LazadaOrderStatusFromItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		want  order.Status
+	}{
+		{"least advanced item", []string{"shipped", "ready_to_ship"}, order.StatusReadyToShip},
+		{"cancelled items are ignored", []string{"canceled", "delivered"}, order.StatusDelivered},
+		{"all cancelled", []string{"canceled", "canceled"}, order.StatusCancelled},
+		{"returned wins over cancelled", []string{"canceled", "returned"}, order.StatusReturned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []lazada.OrderItems
+			for _, s := range tt.items {
+				items = append(items, lazada.OrderItems{Status: s})
+			}
+			assert.Equal(t, tt.want, order.FromLazada(lazada.Orders{}, items).Status)
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package tests
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/product"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadMockData(t *testing.T, filename string, out any) {
+	t.Helper()
+	f, err := os.ReadFile("../../mockdata/" + filename)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot load fixture %v", filename))
+	}
+	require.NoError(t, json.Unmarshal(f, out))
+}
+
+func shopeeProduct(t *testing.T) product.Product {
+	var item shopee.GetProductResponse
+	var models shopee.GetModelListResponse
+	loadMockData(t, "shopee/get_product_variation_resp.json", &item)
+	loadMockData(t, "shopee/get_model_list_variation_resp.json", &models)
+	return product.FromShopee(item.Response.ItemList[0], &models.Response)
+}
+
+func lazadaProduct(t *testing.T) product.Product {
+	var resp lazada.GetProductsResponse
+	loadMockData(t, "lazada/get_products_resp.json", &resp)
+	return product.FromLazada(resp.Data.Products[0], "IDR")
+}
+
+func tiktokProduct(t *testing.T) product.Product {
+	var resp tiktok.GetProductInfoResponse
+	loadMockData(t, "tiktok/get_product_resp.json", &resp)
+	return product.FromTiktok(*resp.Data)
+}
+
+func Test_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(t *testing.T) product.Product
+
+		id           string
+		status       product.Status
+		rawStatus    string
+		categoryID   string
+		weight       float64
+		options      []product.Option
+		variants     int
+		firstVariant product.Variant
+		lastStock    product.Stock
+	}{
+		{
+			name:       "shopee",
+			convert:    shopeeProduct,
+			id:         "3400133011",
+			status:     product.StatusActive,
+			rawStatus:  "NORMAL",
+			categoryID: "100017",
+			weight:     0.25,
+			options: []product.Option{
+				{Name: "Warna", Values: []string{"Biru", "Hitam"}},
+				{Name: "Ukuran", Values: []string{"M", "L"}},
+			},
+			variants: 4,
+			firstVariant: product.Variant{
+				ID: "10000", SKU: "KP-01-BL-M",
+				Options:   map[string]string{"Warna": "Biru", "Ukuran": "M"},
+				Price:     money.MustParse("85000", "IDR"),
+				SalePrice: money.MustParse("75000", "IDR"),
+				Stock:     product.Stock{Available: 10, Reserved: 1},
+				Images:    []product.Image{{ID: "id-11134207-biru", URL: "https://cf.shopee.co.id/file/id-11134207-biru"}},
+			},
+			lastStock: product.Stock{},
+		},
+		{
+			name:       "lazada",
+			convert:    lazadaProduct,
+			id:         "7101234567",
+			status:     product.StatusActive,
+			rawStatus:  "Active",
+			categoryID: "10001234",
+			weight:     0.25,
+			options: []product.Option{
+				{Name: "color_family", Values: []string{"Biru", "Hitam"}},
+				{Name: "size", Values: []string{"M", "L"}},
+			},
+			variants: 2,
+			firstVariant: product.Variant{
+				ID: "9101234567", SKU: "KP-01-BL-M",
+				Options:   map[string]string{"color_family": "Biru", "size": "M"},
+				Price:     money.MustParse("85000", "IDR"),
+				SalePrice: money.MustParse("75000", "IDR"),
+				Stock:     product.Stock{Available: 10, Reserved: 2},
+				Images:    []product.Image{{URL: "https://id-live.slatic.net/p/kaos-polos-biru.jpg"}},
+			},
+			lastStock: product.Stock{Available: 4},
+		},
+		{
+			name:       "tiktok",
+			convert:    tiktokProduct,
+			id:         "1729592969712207000",
+			status:     product.StatusActive,
+			rawStatus:  "ACTIVATE",
+			categoryID: "601226",
+			weight:     0.25,
+			options: []product.Option{
+				{Name: "Warna", Values: []string{"Biru", "Hitam"}},
+				{Name: "Ukuran", Values: []string{"M", "L"}},
+			},
+			variants: 2,
+			firstVariant: product.Variant{
+				ID: "1729592969712207012", SKU: "KP-01-BL-M",
+				Options:   map[string]string{"Warna": "Biru", "Ukuran": "M"},
+				Price:     money.MustParse("75000", "IDR"),
+				SalePrice: money.MustParse("75000", "IDR"),
+				Stock:     product.Stock{Available: 10},
+				Images: []product.Image{{
+					ID:  "tos-maliva-i-o3syd03w52-us/biru",
+					URL: "https://p16-oec-va.ibyteimg.com/tos-maliva-i-o3syd03w52-us/biru",
+				}},
+			},
+			lastStock: product.Stock{Available: 3},
+		},
+		{
+			name: "tokopedia",
+			convert: func(t *testing.T) product.Product {
+				var resp tokopedia.ProductInfoResponse
+				loadMockData(t, "tokopedia/get_product_info_resp.json", &resp)
+				return product.FromTokopedia(resp.Data[0], resp.Data[1:]...)
+			},
+			id:         "15000000",
+			status:     product.StatusActive,
+			rawStatus:  "1",
+			categoryID: "1807",
+			weight:     0.25,
+			variants:   2,
+			firstVariant: product.Variant{
+				ID: "15000001", SKU: "KP-01-BL-M",
+				Price:     money.MustParse("75000", "IDR"),
+				SalePrice: money.MustParse("75000", "IDR"),
+				Stock:     product.Stock{Available: 10, Reserved: 1},
+				Images:    []product.Image{{ID: "9002", URL: "https://images.tokopedia.net/img/kaos-polos-biru.jpg"}},
+			},
+			lastStock: product.Stock{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.convert(t)
+
+			assert.Equal(t, tt.id, p.ID)
+			assert.Equal(t, product.Marketplace(tt.name), p.Marketplace)
+			assert.Equal(t, "Kaos Polos", p.Name)
+			assert.Equal(t, tt.status, p.Status)
+			assert.Equal(t, tt.rawStatus, p.RawStatus)
+			assert.Equal(t, tt.categoryID, p.CategoryID)
+			assert.InDelta(t, tt.weight, p.Weight, 0.0001)
+			assert.Equal(t, tt.options, p.Options)
+			assert.NotEmpty(t, p.Images)
+			assert.NotNil(t, p.Raw)
+
+			require.Len(t, p.Variants, tt.variants)
+			assert.Equal(t, tt.firstVariant, p.Variants[0])
+			assert.Equal(t, tt.lastStock, p.Variants[len(p.Variants)-1].Stock)
+		})
+	}
+}
+
+func Test_FromShopeeWithoutModels(t *testing.T) {
+	var item shopee.GetProductResponse
+	loadMockData(t, "shopee/get_product_resp.json", &item)
+
+	p := product.FromShopee(item.Response.ItemList[0], nil)
+
+	assert.Empty(t, p.Options)
+	require.Len(t, p.Variants, 1)
+	assert.Equal(t, "0", p.Variants[0].ID)
+	assert.Equal(t, money.MustParse("100", "SGD"), p.Variants[0].Price)
+	assert.Equal(t, money.MustParse("50", "SGD"), p.Variants[0].SalePrice)
+	assert.Equal(t, int64(223), p.Variants[0].Stock.Available)
+}
+
+func Test_ToShopeeTierVariation(t *testing.T) {
+	var models shopee.GetModelListResponse
+	loadMockData(t, "shopee/get_model_list_variation_resp.json", &models)
+	p := shopeeProduct(t)
+
+	req, err := product.ToShopeeTierVariation(p, 3400133011)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3400133011), req.ItemID)
+	require.Len(t, req.TierVariation, 2)
+	assert.Equal(t, "Warna", req.TierVariation[0].Name)
+	assert.Equal(t, "Hitam", req.TierVariation[0].OptionList[1].Option)
+	require.Len(t, req.Model, len(models.Response.Model))
+	for i, m := range models.Response.Model {
+		assert.Equal(t, m.TierIndex, req.Model[i].TierIndex, m.ModelSKU)
+		assert.Equal(t, m.ModelSKU, req.Model[i].ModelSKU)
+	}
+	assert.Equal(t, float64(90000), req.Model[2].OriginalPrice)
+
+	// options of another marketplace map to tier indexes the same way
+	req, err = product.ToShopeeTierVariation(lazadaProduct(t), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 0}, req.Model[0].TierIndex)
+	assert.Equal(t, []int{1, 1}, req.Model[1].TierIndex)
+
+	p.Variants[1].Options = map[string]string{"Warna": "Merah", "Ukuran": "L"}
+	_, err = product.ToShopeeTierVariation(p, 1)
+	assert.ErrorContains(t, err, `"Merah" is not a value of option "Warna"`)
+
+	p.Variants[1].Options = map[string]string{"Warna": "Biru"}
+	_, err = product.ToShopeeTierVariation(p, 1)
+	assert.ErrorContains(t, err, `has no value for option "Ukuran"`)
+
+	p.Options = nil
+	_, err = product.ToShopeeTierVariation(p, 1)
+	assert.Error(t, err)
+}
+
+func Test_ToShopeeAddItem(t *testing.T) {
+	req, err := product.ToShopeeAddItem(shopeeProduct(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(100017), req.CategoryID)
+	assert.Equal(t, "Kaos Polos", req.ItemName)
+	assert.Equal(t, float64(85000), req.OriginalPrice)
+	assert.Equal(t, 0.25, req.Weight)
+	assert.Equal(t, []string{"id-11134207-7r98o"}, req.Image.ImageIDList)
+	// the stock of an item with variations is set per model
+	assert.Empty(t, req.SellerStock)
+
+	_, err = product.ToShopeeAddItem(tiktokProduct(t))
+	require.NoError(t, err)
+
+	_, err = product.ToShopeeAddItem(product.Product{Name: "no category"})
+	assert.Error(t, err)
+}
+
+func Test_ToLazada(t *testing.T) {
+	t.Run("update", func(t *testing.T) {
+		req, err := product.ToLazada(lazadaProduct(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, 7101234567, req.Product.ItemID)
+		assert.Equal(t, "10001234", req.Product.PrimaryCategory)
+		require.Len(t, req.Product.Skus, 2)
+
+		sku := req.Product.Skus[0]
+		assert.Equal(t, 9101234567, sku.SkuID)
+		assert.Equal(t, "KP-01-BL-M", sku.SellerSku)
+		// the stock of p may be stale by now, it is left alone
+		assert.Nil(t, sku.Quantity)
+		assert.Equal(t, float64(85000), sku.Price)
+		assert.Equal(t, float64(75000), sku.SpecialPrice)
+		assert.Equal(t, lazada.StringMap{"color_family": "Biru", "size": "M"}, sku.SaleProp)
+		assert.Zero(t, req.Product.Skus[1].SpecialPrice)
+
+		body, err := xml.Marshal(req)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "<saleProp><color_family>Biru</color_family><size>M</size></saleProp>")
+	})
+
+	t.Run("create from another marketplace", func(t *testing.T) {
+		req, err := product.ToLazada(tiktokProduct(t))
+		require.NoError(t, err)
+
+		assert.Zero(t, req.Product.ItemID)
+		assert.Zero(t, req.Product.Skus[0].SkuID)
+		assert.Equal(t, lazada.StringMap{"Warna": "Biru", "Ukuran": "M"}, req.Product.Skus[0].SaleProp)
+		assert.Equal(t, "0.25", req.Product.Skus[0].PackageWeight)
+
+		// the reserved stock is held by orders on the other marketplace
+		p := shopeeProduct(t)
+		require.NotZero(t, p.Variants[0].Stock.Reserved)
+		req, err = product.ToLazada(p)
+		require.NoError(t, err)
+		assert.Equal(t, int(p.Variants[0].Stock.Available), *req.Product.Skus[0].Quantity)
+	})
+}
+
+func Test_ToTiktok(t *testing.T) {
+	t.Run("edit", func(t *testing.T) {
+		req := product.ToTiktok(tiktokProduct(t))
+
+		assert.Equal(t, "Kaos Polos", req.Title)
+		assert.Equal(t, "601226", req.CategoryID)
+		assert.Equal(t, []tiktok.MainImage{{URI: "tos-maliva-i-o3syd03w52-us/c668cdf70b7f483c94dbe"}}, req.MainImages)
+		assert.Equal(t, &tiktok.PackageWeight{Unit: "KILOGRAM", Value: "0.25"}, req.PackageWeight)
+		require.Len(t, req.Skus, 2)
+
+		sku := req.Skus[0]
+		assert.Equal(t, "1729592969712207012", sku.ID)
+		assert.Equal(t, &tiktok.Price{Amount: "75000", Currency: "IDR"}, sku.Price)
+		// the stock of p may be stale by now, it is left alone
+		assert.Empty(t, sku.Inventory)
+		assert.Equal(t, []tiktok.SalesAttribute{
+			{Name: "Warna", ValueName: "Biru", SkuImg: &tiktok.MainImage{URI: "tos-maliva-i-o3syd03w52-us/biru"}},
+			{Name: "Ukuran", ValueName: "M"},
+		}, sku.SalesAttributes)
+	})
+
+	t.Run("create from another marketplace", func(t *testing.T) {
+		p := shopeeProduct(t)
+		require.NotEqual(t, p.Variants[0].Price, p.Variants[0].SalePrice)
+		req := product.ToTiktok(p)
+
+		require.Len(t, req.Skus, 4)
+		// the discount is not the price, as for Lazada and Shopee
+		assert.Equal(t, &tiktok.Price{Amount: "85000", Currency: "IDR"}, req.Skus[0].Price)
+		sku := req.Skus[1]
+		assert.Empty(t, sku.ID)
+		assert.Equal(t, "KP-01-BL-L", sku.SellerSku)
+		assert.Equal(t, &tiktok.Price{Amount: p.Variants[1].Price.Amount.String(), Currency: "IDR"}, sku.Price)
+		assert.Equal(t, "Biru", sku.SalesAttributes[0].ValueName)
+		assert.Equal(t, "L", sku.SalesAttributes[1].ValueName)
+		assert.Equal(t, []tiktok.Inventory{{Quantity: p.Variants[1].Stock.Available}}, sku.Inventory)
+	})
+}
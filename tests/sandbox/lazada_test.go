@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lazadaAppKey = "100200"
+	lazadaSecret = "lazada-secret"
+	lazadaToken  = "lazada-token"
+)
+
+func newLazada(t *testing.T) *sandbox.Lazada {
+	s := sandbox.NewLazada(lazadaAppKey, lazadaSecret)
+	t.Cleanup(s.Close)
+	s.AddShop(lazadaToken)
+	return s
+}
+
+func Test_LazadaSandboxSendMessage(t *testing.T) {
+	s := newLazada(t)
+	s.AddSession(lazadaToken, lazada.SessionListData{SessionID: "s1", BuyerID: 9001, LastMessageTime: 1000})
+	s.AddSession(lazadaToken, lazada.SessionListData{SessionID: "s2", BuyerID: 9002, LastMessageTime: 2000})
+	s.AddMessage(lazadaToken, lazada.MessagesListData{SessionID: "s1", MessageID: "m1", SendTime: 1000})
+	client := s.Client()
+	ctx := context.Background()
+
+	sent, err := client.Chat.SendMessage(ctx, lazadaToken, &lazada.SendMessageParams{
+		SessionID:  "s1",
+		TemplateID: 1,
+		Txt:        "hello",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, sent.Data.MessageID)
+
+	var ids []string
+	for m, err := range client.Chat.Messages(ctx, lazadaToken, lazada.MessageQueryParams{SessionID: "s1", PageSize: 1}) {
+		require.NoError(t, err)
+		ids = append(ids, m.MessageID)
+	}
+	assert.Equal(t, []string{"m1", sent.Data.MessageID}, ids)
+
+	messages := s.Messages(lazadaToken, "s1")
+	require.Len(t, messages, 2)
+	var content struct {
+		Txt string `json:"txt"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(messages[1].Content), &content))
+	assert.Equal(t, "hello", content.Txt)
+
+	// the sent message moves its session last
+	var sessions []string
+	for session, err := range client.Chat.Sessions(ctx, lazadaToken, &lazada.SessionListQuery{PageSize: 1}) {
+		require.NoError(t, err)
+		sessions = append(sessions, session.SessionID)
+	}
+	assert.Equal(t, []string{"s2", "s1"}, sessions)
+}
+
+func Test_LazadaSandboxRejectsBadSignature(t *testing.T) {
+	s := newLazada(t)
+
+	bad := lazada.NewClient(lazadaAppKey, "wrong", lazada.Indonesia)
+	bad.BaseURL = s.Client().BaseURL
+	_, err := bad.Product.GetProducts(context.Background(), lazadaToken, &lazada.GetProductsParams{Offset: "0", Limit: "10"})
+	var respErr lazada.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "IncompleteSignature", respErr.Code)
+
+	_, err = s.Client().Product.GetProducts(context.Background(), "stolen", &lazada.GetProductsParams{Offset: "0", Limit: "10"})
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "IllegalAccessToken", respErr.Code)
+}
+
+func Test_LazadaSandboxOrders(t *testing.T) {
+	s := newLazada(t)
+	s.AddOrder(lazadaToken, lazada.Orders{
+		OrderID:   501,
+		CreatedAt: "2024-01-02 10:00:00 +0700",
+		Price:     money.MustParse("125000.00", ""),
+		Statuses:  []string{"pending"},
+	}, lazada.OrderItems{OrderID: 501, Name: "kaos", Currency: "IDR", ItemPrice: money.MustParse("125000", "IDR")})
+	s.AddOrder(lazadaToken, lazada.Orders{OrderID: 502, CreatedAt: "2023-12-01 10:00:00 +0700"})
+	client := s.Client()
+	ctx := context.Background()
+
+	orders, err := client.Order.GetOrders(ctx, lazadaToken, &lazada.GetOrdersParam{
+		Offset:       "0",
+		Limit:        "10",
+		CreatedAfter: "2024-01-01T00:00:00+07:00",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, orders.Data.CountTotal)
+	require.Len(t, orders.Data.Orders, 1)
+	assert.Equal(t, int64(501), orders.Data.Orders[0].OrderID)
+	assert.Equal(t, "125000", orders.Data.Orders[0].Price.Amount.String())
+
+	items, err := client.Order.GetOrderItems(ctx, lazadaToken, &lazada.GetOrderParam{OrderID: 501})
+	require.NoError(t, err)
+	require.Len(t, items.Data, 1)
+	assert.Equal(t, "kaos", items.Data[0].Name)
+	assert.Equal(t, "IDR", items.Data[0].ItemPrice.Currency)
+}
+
+func Test_LazadaSandboxFaults(t *testing.T) {
+	s := newLazada(t)
+	s.AddProduct(lazadaToken, lazada.Products{ItemID: 1, Status: "Active"})
+	s.AddProduct(lazadaToken, lazada.Products{ItemID: 2, Status: "InActive"})
+
+	// the call limit in a 200 body and a 503 are retried by the middleware
+	s.Fail("/rest/products/get", s.ErrorFault("ApiCallLimit", "too many calls"), sandbox.Unavailable())
+	client := s.Client(lazada.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+	products, err := client.Product.GetProducts(context.Background(), lazadaToken, &lazada.GetProductsParams{
+		Filter: "Active",
+		Offset: "0",
+		Limit:  "10",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, products.Data.TotalProducts)
+	assert.Equal(t, 1, products.Data.Products[0].ItemID)
+	assert.Len(t, s.Requests(), 3)
+
+	// without it the error fails the call
+	s.Fail("/rest/products/get", s.ErrorFault("ApiCallLimit", "too many calls"))
+	_, err = s.Client().Product.GetProducts(context.Background(), lazadaToken, &lazada.GetProductsParams{Offset: "0", Limit: "10"})
+	var respErr lazada.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "ApiCallLimit", respErr.Code)
+}
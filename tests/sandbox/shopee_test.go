@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	shopeePartnerID  = 2001234
+	shopeePartnerKey = "partner-key"
+	shopeeShopID     = uint64(77001)
+	shopeeToken      = "shopee-token"
+)
+
+func newShopee(t *testing.T) *sandbox.Shopee {
+	s := sandbox.NewShopee(shopeePartnerID, shopeePartnerKey)
+	t.Cleanup(s.Close)
+	s.AddShop(shopeeShopID, shopeeToken, shopee.GetShopInfoResponse{ShopName: "sandbox shop", Region: "ID"})
+	return s
+}
+
+func Test_ShopeeSandboxShopInfo(t *testing.T) {
+	s := newShopee(t)
+
+	info, err := s.Client().Shop.GetShopInfo(shopeeShopID, shopeeToken)
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox shop", info.ShopName)
+	assert.NotEmpty(t, info.RequestID)
+
+	// a wrong partner key fails the signature, a wrong token the shop
+	bad := shopee.NewClient(shopee.AppConfig{PartnerID: shopeePartnerID, PartnerKey: "wrong", APIURL: s.URL()})
+	_, err = bad.Shop.GetShopInfo(shopeeShopID, shopeeToken)
+	var respErr shopee.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusForbidden, respErr.Status)
+	assert.Contains(t, respErr.Message, "error_sign")
+
+	_, err = s.Client().Shop.GetShopInfo(shopeeShopID, "stolen")
+	require.ErrorAs(t, err, &respErr)
+	assert.Contains(t, respErr.Message, "error_auth")
+}
+
+func Test_ShopeeSandboxSendMessage(t *testing.T) {
+	s := newShopee(t)
+	client := s.Client()
+
+	sent, err := client.Chat.SendMessage(shopeeShopID, shopeeToken, shopee.SendMessageRequest{
+		ToID:        "5001",
+		MessageType: "text",
+		Content:     shopee.ContentSendMessage{Text: "hello"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", sent.Response.Content.Text)
+
+	messages, err := client.Chat.GetMessage(shopeeShopID, shopeeToken, shopee.GetMessageParamsRequest{
+		ConversationID: sent.Response.ConversationID,
+		PageSize:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages.Response.MessagesList, 1)
+	assert.Equal(t, sent.Response.MessageID, messages.Response.MessagesList[0].MessageID)
+	assert.Equal(t, "hello", messages.Response.MessagesList[0].Content.Text)
+
+	conversations, err := client.Chat.GetConversationList(shopeeShopID, shopeeToken, shopee.GetConversationParamsRequest{
+		Direction: "older",
+		Type:      "all",
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, conversations.Response.ConversationsList, 1)
+	assert.Equal(t, 5001, conversations.Response.ConversationsList[0].ToID)
+	assert.Equal(t, "hello", conversations.Response.ConversationsList[0].LatestMessageContent.Text)
+}
+
+func Test_ShopeeSandboxOrders(t *testing.T) {
+	s := newShopee(t)
+	for i, sn := range []string{"SN1", "SN2", "SN3"} {
+		s.AddOrder(shopeeShopID, shopee.OrderList{
+			OrderSn:     sn,
+			OrderStatus: "READY_TO_SHIP",
+			CreateTime:  1700000000 + i,
+			Currency:    "IDR",
+			TotalAmount: money.MustParse("150000.50", "IDR"),
+		})
+	}
+	client := s.Client()
+
+	var sns []string
+	for o, err := range client.Order.All(t.Context(), shopeeShopID, shopeeToken, shopee.GetListOrderParamsRequest{
+		TimeRangeField: "create_time",
+		TimeFrom:       1700000000,
+		TimeTo:         1700000100,
+		PageSize:       2,
+	}) {
+		require.NoError(t, err)
+		sns = append(sns, o.OrderSn)
+	}
+	assert.Equal(t, []string{"SN1", "SN2", "SN3"}, sns)
+
+	detail, err := client.Order.GetOrderDetailByOrderSN(shopeeShopID, shopeeToken, shopee.GetOrderDetailParamsRequest{
+		OrderSNList: "SN2",
+	})
+	require.NoError(t, err)
+	require.Len(t, detail.OrderListResponse.OrderList, 1)
+	assert.Equal(t, "150000.50", detail.OrderListResponse.OrderList[0].TotalAmount.Amount.StringFixed(2))
+	assert.Equal(t, "IDR", detail.OrderListResponse.OrderList[0].TotalAmount.Currency)
+}
+
+func Test_ShopeeSandboxFaults(t *testing.T) {
+	s := newShopee(t)
+	path := "/api/v2/shop/get_shop_info"
+
+	// a 429 is retried after its Retry-After
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	start := time.Now()
+	_, err := s.Client(shopee.WithRetry(2)).Shop.GetShopInfo(shopeeShopID, shopeeToken)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// throttling in a 200 body and a 503 are retried by the middleware
+	s.Fail(path, s.ErrorFault("error_rate_limit", "too many requests"), sandbox.Unavailable())
+	client := s.Client(shopee.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+	_, err = client.Shop.GetShopInfo(shopeeShopID, shopeeToken)
+	require.NoError(t, err)
+
+	// other errors in a 200 body fail the call
+	s.Fail(path, s.ErrorFault("error_server", "something broke"))
+	_, err = s.Client().Shop.GetShopInfo(shopeeShopID, shopeeToken)
+	var respErr shopee.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Contains(t, respErr.Message, "error_server")
+
+	assert.Len(t, s.Requests(), 6)
+}
+
+func Test_ShopeeSubSecondRetryAfter(t *testing.T) {
+	s := newShopee(t)
+	path := "/api/v2/shop/get_shop_info"
+	s.Fail(path, sandbox.RateLimited(200*time.Millisecond))
+
+	resp, err := http.Get(s.URL() + path)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	// rounded up, 0 would mean no wait
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+}
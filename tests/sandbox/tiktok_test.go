@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tiktokAppKey = "tiktok-app"
+	tiktokSecret = "tiktok-secret"
+	tiktokToken  = "tiktok-token"
+	tiktokCipher = "cipher-1"
+)
+
+func newTikTok(t *testing.T) *sandbox.TikTok {
+	s := sandbox.NewTikTok(tiktokAppKey, tiktokSecret)
+	t.Cleanup(s.Close)
+	s.AddShop(tiktokToken, tiktok.Shops{Cipher: tiktokCipher, ID: "7001", Name: "sandbox shop", Region: "ID"})
+	return s
+}
+
+var tiktokShop = tiktok.CommonParamRequest{AccessToken: tiktokToken, ShopCipher: tiktokCipher}
+
+func Test_TikTokSandboxShops(t *testing.T) {
+	s := newTikTok(t)
+
+	shops, err := s.Client().Auth.GetAuthorizationShop(tiktokToken, "")
+	require.NoError(t, err)
+	require.Len(t, shops.Data.Shops, 1)
+	assert.Equal(t, tiktokCipher, shops.Data.Shops[0].Cipher)
+
+	bad := tiktok.NewClient(tiktok.AppConfig{AppKey: tiktokAppKey, AppSecret: "wrong", APIURL: s.URL(), Version: sandbox.TikTokVersion})
+	_, err = bad.Auth.GetAuthorizationShop(tiktokToken, "")
+	var respErr tiktok.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusUnauthorized, respErr.Status)
+	assert.Equal(t, "Invalid sign", respErr.Message)
+
+	// the token must be the one of the shop
+	client := s.Client()
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "stolen", ShopCipher: tiktokCipher})
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"1"}})
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "Invalid access_token", respErr.Message)
+}
+
+func Test_TikTokSandboxSendMessage(t *testing.T) {
+	s := newTikTok(t)
+	s.AddConversation(tiktokCipher, tiktok.Conversations{ID: "c1", CanSendMessage: true, CreateTime: 100})
+	s.AddConversation(tiktokCipher, tiktok.Conversations{ID: "c2", CanSendMessage: true, CreateTime: 200})
+	s.AddMessage(tiktokCipher, "c1", tiktok.MessagesConversation{ID: "m1", Type: "TEXT", Content: `{"content":"hi"}`, CreateTime: 100})
+	client := s.Client()
+
+	client.WithCommonParamRequest(tiktokShop)
+	sent, err := client.Chat.SendMessageToConversationID("c1", tiktok.SendMessageToConversationIDReq{
+		TypeMessage: tiktok.TypeMessageText,
+		Content:     `{"content":"hello"}`,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, sent.Data.MessageID)
+
+	var ids []string
+	for m, err := range client.Chat.Messages(t.Context(), tiktokShop, "c1", tiktok.GetConversationMessagesParam{PageSize: 1, SortOrder: "ASC"}) {
+		require.NoError(t, err)
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"m1", sent.Data.MessageID}, ids)
+	assert.Equal(t, "SHOP", s.Messages(tiktokCipher, "c1")[1].Sender.Role)
+
+	// the conversation with the latest message comes first
+	client.WithCommonParamRequest(tiktokShop)
+	conversations, err := client.Chat.GetConversations(tiktok.GetConversationsParam{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, conversations.Data.Conversations, 2)
+	assert.Equal(t, "c1", conversations.Data.Conversations[0].ID)
+	assert.Equal(t, `{"content":"hello"}`, conversations.Data.Conversations[0].LatestMessage.Content)
+}
+
+func Test_TikTokSandboxSearch(t *testing.T) {
+	s := newTikTok(t)
+	s.AddOrder(tiktokCipher, tiktok.Order{ID: "o1", Status: "AWAITING_SHIPMENT", CreateTime: 100})
+	s.AddOrder(tiktokCipher, tiktok.Order{ID: "o2", Status: "COMPLETED", CreateTime: 200})
+	s.AddOrder(tiktokCipher, tiktok.Order{ID: "o3", Status: "AWAITING_SHIPMENT", CreateTime: 300})
+	s.AddProduct(tiktokCipher, tiktok.ProductData{ID: "p1", Status: "ACTIVATE", Skus: []tiktok.Skus{{
+		SellerSku: "kaos-m",
+		Price:     &tiktok.Price{Currency: "IDR", SalePrice: money.MustParse("99000", "IDR")},
+	}}})
+	s.AddProduct(tiktokCipher, tiktok.ProductData{ID: "p2", Status: "DRAFT"})
+	client := s.Client()
+
+	client.WithCommonParamRequest(tiktokShop)
+	orders, err := client.Order.SearchOrders(tiktok.SearchOrdersParams{PageSize: 1}, tiktok.SearchOrdersBody{
+		OrderStatus: "AWAITING_SHIPMENT",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, orders.Data.TotalCount)
+	require.Len(t, orders.Data.Orders, 1)
+	assert.Equal(t, "o3", orders.Data.Orders[0].ID)
+
+	client.WithCommonParamRequest(tiktokShop)
+	orders, err = client.Order.SearchOrders(tiktok.SearchOrdersParams{PageSize: 1, PageToken: orders.Data.NextPageToken}, tiktok.SearchOrdersBody{
+		OrderStatus: "AWAITING_SHIPMENT",
+	})
+	require.NoError(t, err)
+	require.Len(t, orders.Data.Orders, 1)
+	assert.Equal(t, "o1", orders.Data.Orders[0].ID)
+	assert.Empty(t, orders.Data.NextPageToken)
+
+	client.WithCommonParamRequest(tiktokShop)
+	products, err := client.Product.SearchProducts(tiktok.SearchProductsParams{PageSize: 10}, tiktok.SearchProductsBody{
+		SellerSkus: []string{"kaos-m"},
+	})
+	require.NoError(t, err)
+	require.Len(t, products.Data.Products, 1)
+	price := products.Data.Products[0].Skus[0].Price
+	assert.Equal(t, "99000", price.SalePrice.Amount.String())
+	assert.Equal(t, "IDR", price.SalePrice.Currency)
+}
+
+func Test_TikTokSandboxFaults(t *testing.T) {
+	s := newTikTok(t)
+	s.AddOrder(tiktokCipher, tiktok.Order{ID: "o1"})
+	path := "/order/" + sandbox.TikTokVersion + "/orders"
+
+	// a 429 is retried after its Retry-After
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	client := s.Client(tiktok.WithRetry(2))
+	client.WithCommonParamRequest(tiktokShop)
+	orders, err := client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"o1"}})
+	require.NoError(t, err)
+	assert.Len(t, orders.Data.Orders, 1)
+
+	// throttling in a 200 body and a 503 are retried by the middleware
+	s.Fail(path, s.ErrorFault(36009004, "too many requests"), sandbox.Unavailable())
+	client = s.Client(tiktok.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+	client.WithCommonParamRequest(tiktokShop)
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"o1"}})
+	require.NoError(t, err)
+
+	// other errors fail the call
+	s.Fail(path, s.ErrorFault(36009003, "invalid order id"))
+	client = s.Client()
+	client.WithCommonParamRequest(tiktokShop)
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"o1"}})
+	var respErr tiktok.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, 36009003, respErr.Code)
+}
+
+// bodyRecorder keeps the last response body read by a client.
+type bodyRecorder struct {
+	body []byte
+}
+
+func (b *bodyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	b.body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b.body))
+	return resp, err
+}
+
+func Test_TikTokSandboxAmounts(t *testing.T) {
+	s := newTikTok(t)
+	s.AddProduct(tiktokCipher, tiktok.ProductData{ID: "p1", Status: "ACTIVATE", Skus: []tiktok.Skus{{
+		SellerSku: "kaos-m",
+		Price:     &tiktok.Price{Currency: "IDR", SalePrice: money.MustParse("99000", "IDR")},
+	}, {
+		SellerSku: "kaos-l",
+		// an object with just an amount and a currency, as coupon amounts
+		Price: &tiktok.Price{Amount: "120000", Currency: "IDR"},
+	}}})
+	rec := &bodyRecorder{}
+	client := s.Client()
+	client.Client.Transport = rec
+
+	client.WithCommonParamRequest(tiktokShop)
+	product, err := client.Product.GetProductInfo("p1")
+	require.NoError(t, err)
+
+	require.Len(t, product.Data.Skus, 2)
+	assert.Equal(t, "99000", product.Data.Skus[0].Price.SalePrice.Amount.String())
+	assert.Equal(t, "120000", product.Data.Skus[1].Price.Amount)
+	assert.Contains(t, string(rec.body), `"sale_price":"99000"`)
+	assert.Contains(t, string(rec.body), `"amount":"120000","currency":"IDR"`)
+}
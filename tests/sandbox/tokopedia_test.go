@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokopediaFsID   = 15001
+	tokopediaShopID = 480001
+	tokopediaToken  = "tokopedia-token"
+)
+
+func newTokopedia(t *testing.T) *sandbox.Tokopedia {
+	s := sandbox.NewTokopedia(tokopediaFsID)
+	t.Cleanup(s.Close)
+	s.AddShop(tokopediaToken, tokopedia.ShopData{ShopID: tokopediaShopID, UserID: 11, ShopName: "sandbox shop"})
+	return s
+}
+
+func Test_TokopediaSandboxShopInfo(t *testing.T) {
+	s := newTokopedia(t)
+
+	shops, err := s.Client().Shop.GetShopInfo(tokopediaToken, tokopedia.ShopParams{ShopID: tokopediaShopID})
+	require.NoError(t, err)
+	require.Len(t, shops.Data, 1)
+	assert.Equal(t, "sandbox shop", shops.Data[0].ShopName)
+
+	// a shop of another token is forbidden, an unknown token unauthorized
+	_, err = s.Client().Shop.GetShopInfo(tokopediaToken, tokopedia.ShopParams{ShopID: 1})
+	var respErr tokopedia.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "shop 1 is not authorized", respErr.Header.Reason)
+
+	_, err = s.Client().Shop.GetShopInfo("stolen", tokopedia.ShopParams{ShopID: tokopediaShopID})
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "invalid access token", respErr.Header.Reason)
+
+	// so is another fs
+	other := tokopedia.NewClient(tokopedia.AppConfig{FsID: 1, APIURL: s.URL()})
+	_, err = other.Shop.GetShopInfo(tokopediaToken, tokopedia.ShopParams{ShopID: tokopediaShopID})
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "invalid fs_id 1", respErr.Header.Reason)
+}
+
+func Test_TokopediaSandboxSendMessage(t *testing.T) {
+	s := newTokopedia(t)
+	chat := tokopedia.MessageData{MsgID: 301}
+	chat.Attributes.LastReplyTime = 1000
+	s.AddMessage(tokopediaShopID, chat)
+	s.AddMessage(tokopediaShopID, tokopedia.MessageData{MsgID: 302})
+	s.AddReply(tokopediaShopID, 301, tokopedia.ReplyData{MsgID: 301, ReplyID: 1, Msg: "halo", ReplyTime: 1000})
+	client := s.Client()
+
+	sent, err := client.Chat.SendMessage(tokopediaToken, 301, tokopedia.SendMessageBody{
+		Message: "hello",
+		MsgID:   301,
+		ShopID:  tokopediaShopID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", sent.Data.Msg)
+
+	var replies []string
+	for r, err := range client.Chat.Replies(t.Context(), tokopediaToken, tokopedia.GetReplyListParams{
+		ShopID:  tokopediaShopID,
+		MsgID:   301,
+		PerPage: 1,
+	}) {
+		require.NoError(t, err)
+		replies = append(replies, r.Msg)
+	}
+	assert.Equal(t, []string{"halo", "hello"}, replies)
+	assert.Len(t, s.Replies(tokopediaShopID, 301), 2)
+
+	messages, err := client.Chat.GetMessagesList(tokopediaToken, tokopedia.GetMessagesParams{
+		Page:    1,
+		PerPage: 10,
+		FsID:    tokopediaFsID,
+		ShopID:  tokopediaShopID,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages.Data, 2)
+	assert.Equal(t, 301, messages.Data[0].MsgID)
+	assert.Equal(t, "hello", messages.Data[0].Attributes.LastReplyMsg)
+}
+
+func Test_TokopediaSandboxOrders(t *testing.T) {
+	s := newTokopedia(t)
+	order := tokopedia.Order{OrderID: 9001, InvoiceRefNum: "INV/1", ShopID: tokopediaShopID, OrderStatus: tokopedia.OrderStatusPaymentVerified, CreateTime: 1700000000}
+	order.Amt.TtlAmount = money.MustParse("250000", "IDR")
+	s.AddOrder(tokopediaShopID, order, tokopedia.OrderDetail{OpenAmt: money.MustParse("250000", "IDR")})
+	s.AddOrder(tokopediaShopID, tokopedia.Order{OrderID: 9002, CreateTime: 1600000000}, tokopedia.OrderDetail{})
+	client := s.Client()
+
+	orders, err := client.Order.GetOrders(tokopediaToken, tokopedia.GetOrdersParams{
+		FromDate: 1700000000 - 3600,
+		ToDate:   1700000000 + 3600,
+		Page:     1,
+		PerPage:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders.Data, 1)
+	assert.Equal(t, int64(9001), orders.Data[0].OrderID)
+	assert.Equal(t, "250000", orders.Data[0].Amt.TtlAmount.Amount.String())
+	assert.Equal(t, "IDR", orders.Data[0].Amt.TtlAmount.Currency)
+
+	detail, err := client.Order.GetOrder(tokopediaToken, tokopedia.GetOrderParams{InvoiceNum: "INV/1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9001), detail.Data.OrderID)
+	assert.Equal(t, "250000", detail.Data.OpenAmt.Amount.String())
+
+	// the range is at most 3 days
+	_, err = client.Order.GetOrders(tokopediaToken, tokopedia.GetOrdersParams{FromDate: 0, ToDate: 1700000000, Page: 1, PerPage: 10})
+	var respErr tokopedia.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "invalid to_date", respErr.Header.Reason)
+}
+
+func Test_TokopediaSandboxFaults(t *testing.T) {
+	s := newTokopedia(t)
+	product := tokopedia.ProductData{}
+	product.Basic.ProductID = 123
+	product.Basic.Name = "kaos"
+	product.Price.Value = money.MustParse("75000", "IDR")
+	s.AddProduct(tokopediaShopID, product)
+	path := "/inventory/v1/fs/15001/product/info"
+
+	// a 429 is retried after its X-Ratelimit-Full-Reset-After
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	res, err := s.Client(tokopedia.WithRetry(2)).Product.GetProductInfo(tokopediaToken, 123)
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, "kaos", res.Data[0].Basic.Name)
+	assert.Equal(t, "75000", res.Data[0].Price.Value.Amount.String())
+
+	// and by the middleware, as is a 503
+	s.Fail(path, sandbox.RateLimited(0), sandbox.Unavailable())
+	client := s.Client(tokopedia.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+	_, err = client.Product.GetProductInfo(tokopediaToken, 123)
+	require.NoError(t, err)
+
+	// an error in a 200 body fails the call
+	s.Fail(path, s.ErrorFault("PRODUCT_LOCKED", "product is locked"))
+	_, err = s.Client().Product.GetProductInfo(tokopediaToken, 123)
+	var respErr tokopedia.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, "product is locked", respErr.Header.Reason)
+
+	// without retries the 429 tells the wait
+	s.Fail(path, sandbox.RateLimited(time.Second))
+	_, err = s.Client().Product.GetProductInfo(tokopediaToken, 123)
+	var rateErr tokopedia.RateLimitError
+	require.ErrorAs(t, err, &rateErr)
+	assert.Equal(t, 1, rateErr.RetryAfter)
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// signedShopResponder checks the shop credentials and sign of every request and
+// echoes the shop id back in the shop name.
+func signedShopResponder(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	shopID, token := q.Get("shop_id"), q.Get("access_token")
+	if token != "token-"+shopID {
+		return httpmock.NewStringResponse(403, `{"error":"error_auth","message":"token does not belong to shop"}`), nil
+	}
+
+	baseStr := fmt.Sprintf("%d%s%s%s%s", app.PartnerID, req.URL.Path, q.Get("timestamp"), token, shopID)
+	h := hmac.New(sha256.New, []byte(app.PartnerKey))
+	h.Write([]byte(baseStr))
+	if q.Get("sign") != hex.EncodeToString(h.Sum(nil)) {
+		return httpmock.NewStringResponse(403, `{"error":"error_sign","message":"wrong sign"}`), nil
+	}
+
+	return httpmock.NewStringResponse(200, fmt.Sprintf(`{"shop_name":"shop-%s"}`, shopID)), nil
+}
+
+func Test_ConcurrentShops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL), signedShopResponder)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/auth/token/get", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Has("shop_id") || req.URL.Query().Has("access_token") {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"public api got shop credentials"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"access_token":"token"}`), nil
+		})
+
+	const shops = 300
+	var wg sync.WaitGroup
+	errs := make(chan error, shops*2)
+
+	for i := 1; i <= shops; i++ {
+		wg.Add(2)
+		go func(sid uint64) {
+			defer wg.Done()
+			res, err := client.Shop.GetShopInfo(sid, fmt.Sprintf("token-%d", sid))
+			if err != nil {
+				errs <- fmt.Errorf("shop %d: %w", sid, err)
+				return
+			}
+			if expected := fmt.Sprintf("shop-%d", sid); res.ShopName != expected {
+				errs <- fmt.Errorf("ShopName returned %s, expected %s", res.ShopName, expected)
+			}
+		}(uint64(i))
+		go func() {
+			defer wg.Done()
+			if _, err := client.Auth.GetAccessToken(0, 0, "code"); err != nil {
+				errs <- fmt.Errorf("Auth.GetAccessToken: %w", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_GetShopInfoWithContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		httpmock.NewStringResponder(200, `{"shop_name":"shop"}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Shop.GetShopInfoWithContext(ctx, shopID, accessToken)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Shop.GetShopInfoWithContext error returned %v, expected %v", err, context.Canceled)
+	}
+}
+
+func Test_RateLimitRetryHonoursDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(429, `{"error":"error_rate_limit","message":"too many requests"}`)
+			resp.Header.Set("Retry-After", "30")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Shop.GetShopInfoWithContext(ctx, shopID, accessToken)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shop.GetShopInfoWithContext error returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry sleep ignored the deadline, took %s", elapsed)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 1 {
+		t.Errorf("request count returned %d, expected 1", n)
+	}
+}
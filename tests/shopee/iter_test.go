@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/jarcoal/httpmock"
+)
+
+func conversationPagesResponder(req *http.Request) (*http.Response, error) {
+	switch req.URL.Query().Get("next_timestamp_nano") {
+	case "":
+		return httpmock.NewStringResponse(200, `{"response":{"page_result":{"page_size":2,"next_cursor":{"next_message_time_nano":"1612792485343461649"},"more":true},
+			"conversations":[{"conversation_id":"1"},{"conversation_id":"2"}]}}`), nil
+	case "1612792485343461649":
+		return httpmock.NewStringResponse(200, `{"response":{"page_result":{"page_size":2,"next_cursor":{"next_message_time_nano":"0"},"more":false},
+			"conversations":[{"conversation_id":"3"}]}}`), nil
+	}
+	return httpmock.NewStringResponse(400, `{"error":"error_param","message":"unexpected cursor"}`), nil
+}
+
+func Test_ConversationsIteratesPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/sellerchat/get_conversation_list", app.APIURL),
+		conversationPagesResponder)
+
+	var ids []string
+	for c, err := range client.Chat.Conversations(context.Background(), shopID, accessToken, shopee.GetConversationParamsRequest{
+		Direction: "older",
+		Type:      "all",
+		PageSize:  2,
+	}) {
+		if err != nil {
+			t.Fatalf("Chat.Conversations error: %s", err)
+		}
+		ids = append(ids, c.ConversationID)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Chat.Conversations returned %v, expected [1 2 3]", ids)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("request count returned %d, expected 2", n)
+	}
+}
+
+func Test_ConversationsRangesAgain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/sellerchat/get_conversation_list", app.APIURL),
+		conversationPagesResponder)
+
+	conversations := client.Chat.Conversations(context.Background(), shopID, accessToken, shopee.GetConversationParamsRequest{PageSize: 2})
+	for range 2 {
+		var ids []string
+		for c, err := range conversations {
+			if err != nil {
+				t.Fatalf("Chat.Conversations error: %s", err)
+			}
+			ids = append(ids, c.ConversationID)
+		}
+		if fmt.Sprint(ids) != "[1 2 3]" {
+			t.Errorf("Chat.Conversations returned %v, expected [1 2 3]", ids)
+		}
+	}
+}
+
+func Test_ConversationsStopsEarly(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/sellerchat/get_conversation_list", app.APIURL),
+		conversationPagesResponder)
+
+	var n int
+	for _, err := range client.Chat.Conversations(context.Background(), shopID, accessToken, shopee.GetConversationParamsRequest{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Chat.Conversations error: %s", err)
+		}
+		n++
+		break
+	}
+
+	if n != 1 {
+		t.Errorf("Chat.Conversations yielded %d conversations, expected 1", n)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("request count returned %d, expected 1", calls)
+	}
+}
+
+func Test_OrderAllIteratesPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Query().Get("cursor") {
+			case "":
+				return httpmock.NewStringResponse(200, `{"response":{"more":true,"next_cursor":"2","order_list":[{"order_sn":"A"},{"order_sn":"B"}]}}`), nil
+			case "2":
+				return httpmock.NewStringResponse(200, `{"response":{"more":false,"next_cursor":"","order_list":[{"order_sn":"C"}]}}`), nil
+			}
+			return httpmock.NewStringResponse(400, `{"error":"error_param","message":"unexpected cursor"}`), nil
+		})
+
+	var orders []string
+	for o, err := range client.Order.All(context.Background(), shopID, accessToken, shopee.GetListOrderParamsRequest{
+		TimeRangeField: "create_time",
+		TimeFrom:       1700000000,
+		TimeTo:         1700086400,
+		PageSize:       2,
+	}) {
+		if err != nil {
+			t.Fatalf("Order.All error: %s", err)
+		}
+		orders = append(orders, o.OrderSn)
+	}
+
+	if fmt.Sprint(orders) != "[A B C]" {
+		t.Errorf("Order.All returned %v, expected [A B C]", orders)
+	}
+}
+
+func Test_ProductAllYieldsPageError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("offset") == "0" {
+				return httpmock.NewStringResponse(200, `{"response":{"item":[{"item_id":1},{"item_id":2}],"total_count":4,"has_next_page":true,"next_offset":2}}`), nil
+			}
+			return httpmock.NewStringResponse(400, `{"error":"error_param","message":"invalid offset"}`), nil
+		})
+
+	var (
+		items []int64
+		errs  int
+	)
+	for item, err := range client.Product.All(context.Background(), shopID, accessToken, shopee.GetProductListParamRequest{
+		PageSize:   2,
+		ItemStatus: "NORMAL",
+	}) {
+		if err != nil {
+			errs++
+			continue
+		}
+		items = append(items, item.ItemID)
+	}
+
+	if fmt.Sprint(items) != "[1 2]" {
+		t.Errorf("Product.All returned %v, expected [1 2]", items)
+	}
+	if errs != 1 {
+		t.Errorf("Product.All yielded %d errors, expected 1", errs)
+	}
+}
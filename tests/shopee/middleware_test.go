@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_MiddlewareRetriesRateLimitError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the middleware wraps the default transport, mock that one
+	httpmock.Activate()
+	c := shopee.NewClient(app, shopee.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		httpmock.ResponderFromMultipleResponses([]*http.Response{
+			httpmock.NewStringResponse(200, `{"error":"error_rate_limit","message":"too many requests"}`),
+			httpmock.NewStringResponse(200, `{"error":"","shop_name":"shop"}`),
+		}))
+
+	res, err := c.Shop.GetShopInfo(shopID, accessToken)
+	if err != nil {
+		t.Fatalf("Shop.GetShopInfo error: %s", err)
+	}
+	if res.ShopName != "shop" {
+		t.Errorf("Shop.GetShopInfo returned shop %q, expected %q", res.ShopName, "shop")
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("request count returned %d, expected 2", n)
+	}
+}
+
+func Test_MiddlewareTurnsRetryOff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.Activate()
+	c := shopee.NewClient(app, shopee.WithRetry(3), shopee.WithMiddleware(
+		middleware.WithMaxRetries(1),
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		httpmock.NewStringResponder(200, `{"error":"error_rate_limit","message":"too many requests"}`))
+
+	if _, err := c.Shop.GetShopInfo(shopID, accessToken); err == nil {
+		t.Fatal("Shop.GetShopInfo returned no error, expected the rate limit error")
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("request count returned %d, expected 2", n)
+	}
+}
@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bodyResponder records the JSON body of a request into v and answers body.
+func bodyResponder(v any, body string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+			return nil, err
+		}
+		return httpmock.NewStringResponse(200, body), nil
+	}
+}
+
+func Test_GetShipmentList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_shipment_list", app.APIURL),
+		httpmock.NewStringResponder(200, `{"error":"","response":{"more":true,"next_cursor":"20","order_list":[{"order_sn":"231115ABCD1234","package_number":"OFG1234"}]}}`))
+
+	res, err := client.Order.GetShipmentList(shopID, accessToken, shopee.GetShipmentListParamsRequest{PageSize: 20})
+	require.NoError(t, err)
+
+	assert.True(t, res.Response.More)
+	assert.Equal(t, "20", res.Response.NextCursor)
+	assert.Equal(t, []shopee.ShipmentList{{OrderSn: "231115ABCD1234", PackageNumber: "OFG1234"}}, res.Response.OrderList)
+}
+
+func Test_CancelOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/cancel_order", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"update_time":1700110000}}`))
+
+	res, err := client.Order.CancelOrder(shopID, accessToken, shopee.CancelOrderRequest{
+		OrderSN:      "231115ABCD1234",
+		CancelReason: "OUT_OF_STOCK",
+		ItemList:     []shopee.CancelOrderItem{{ItemID: 3400133011, ModelID: 10001}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1700110000), res.Response.UpdateTime)
+	assert.Equal(t, "OUT_OF_STOCK", sent["cancel_reason"])
+	assert.Equal(t, []any{map[string]any{"item_id": float64(3400133011), "model_id": float64(10001)}}, sent["item_list"])
+}
+
+func Test_HandleBuyerCancellation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/handle_buyer_cancellation", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"update_time":1700110000}}`))
+
+	_, err := client.Order.HandleBuyerCancellation(shopID, accessToken, shopee.HandleBuyerCancellationRequest{
+		OrderSN:   "231115ABCD1234",
+		Operation: "ACCEPT",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"order_sn": "231115ABCD1234", "operation": "ACCEPT", "partner_id": float64(app.PartnerID)}, sent)
+}
+
+func Test_GetShippingParameter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/logistics/get_shipping_parameter", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_shipping_parameter_resp.json")))
+
+	res, err := client.Logistic.GetShippingParameter(shopID, accessToken, shopee.GetShippingParameterParamsRequest{OrderSN: "231115ABCD1234"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"address_id", "pickup_time_id"}, res.Response.InfoNeeded.Pickup)
+	assert.Nil(t, res.Response.Dropoff)
+	require.NotNil(t, res.Response.Pickup)
+	require.Len(t, res.Response.Pickup.AddressList, 1)
+	assert.Equal(t, int64(234), res.Response.Pickup.AddressList[0].AddressID)
+	assert.Equal(t, "1700200800", res.Response.Pickup.AddressList[0].TimeSlotList[0].PickupTimeID)
+}
+
+func Test_ShipOrderWithPickup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/logistics/ship_order", app.APIURL),
+		bodyResponder(&sent, `{"error":"","message":"","request_id":"abc"}`))
+
+	_, err := client.Logistic.ShipOrder(shopID, accessToken, shopee.ShipOrderRequest{
+		OrderSN: "231115ABCD1234",
+		Pickup:  &shopee.ShipOrderPickup{AddressID: 234, PickupTimeID: "1700200800"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"order_sn":   "231115ABCD1234",
+		"partner_id": float64(app.PartnerID),
+		"pickup":     map[string]any{"address_id": float64(234), "pickup_time_id": "1700200800"},
+	}, sent)
+}
+
+func Test_GetTrackingInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/logistics/get_tracking_info", app.APIURL),
+		httpmock.NewStringResponder(200, `{"error":"","response":{"order_sn":"231115ABCD1234","package_number":"OFG1234","logistics_status":"LOGISTICS_PICKUP_DONE",
+			"tracking_info":[{"update_time":1700210000,"description":"Parcel picked up","logistics_status":"LOGISTICS_PICKUP_DONE"}]}}`))
+
+	res, err := client.Logistic.GetTrackingInfo(shopID, accessToken, shopee.GetTrackingInfoParamsRequest{OrderSN: "231115ABCD1234"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "LOGISTICS_PICKUP_DONE", res.GetTrackingInfoData.LogisticsStatus)
+	require.Len(t, res.GetTrackingInfoData.TrackingInfo, 1)
+	assert.Equal(t, "Parcel picked up", res.GetTrackingInfoData.TrackingInfo[0].Description)
+}
+
+func Test_GetReturnDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/returns/get_return_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_return_detail_resp.json")))
+
+	res, err := client.Return.GetReturnDetail(shopID, accessToken, shopee.GetReturnDetailParamsRequest{ReturnSN: "2311160000001"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "REQUESTED", res.Response.Status)
+	assert.Equal(t, money.MustParse("100000", res.Response.Currency), res.Response.RefundAmount)
+	require.NotEmpty(t, res.Response.Currency)
+	require.Len(t, res.Response.Item, 1)
+	assert.Equal(t, res.Response.Currency, res.Response.Item[0].ItemPrice.Currency)
+	assert.Equal(t, "KP-01-BL-L", res.Response.Item[0].VariationSku)
+	require.NotNil(t, res.Response.Negotiation)
+	assert.Equal(t, "RETURN_REFUND", res.Response.Negotiation.LatestSolution)
+}
+
+func Test_DisputeReturn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/dispute", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"return_sn":"2311160000001"}}`))
+
+	res, err := client.Return.Dispute(shopID, accessToken, shopee.DisputeReturnRequest{
+		ReturnSN:      "2311160000001",
+		Email:         "seller@example.com",
+		DisputeReason: 1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "2311160000001", res.Response.ReturnSN)
+	assert.Equal(t, map[string]any{
+		"return_sn":      "2311160000001",
+		"email":          "seller@example.com",
+		"dispute_reason": float64(1),
+		"partner_id":     float64(app.PartnerID),
+	}, sent)
+}
+
+func Test_GetEscrowDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_escrow_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_escrow_detail_resp.json")))
+
+	res, err := client.Payment.GetEscrowDetail(shopID, accessToken, shopee.GetEscrowDetailParamsRequest{OrderSN: "231115ABCD1234"})
+	require.NoError(t, err)
+
+	income := res.Response.OrderIncome
+	assert.Equal(t, money.FromInt(93000, ""), income.EscrowAmount)
+	assert.Equal(t, money.FromInt(4000, ""), income.CommissionFee)
+	require.Len(t, income.Items, 1)
+	assert.Equal(t, 1, income.Items[0].QuantityPurchased)
+}
@@ -2,10 +2,15 @@ package tests
 
 import (
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
 	"testing"
 
 	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_GetProduct(t *testing.T) {
@@ -52,3 +57,144 @@ func Test_GetModelListt(t *testing.T) {
 		t.Errorf("ModelID returned %+v, expected %+v", res.Response.Model[0].ModelID, expected)
 	}
 }
+
+func Test_UpdateItemRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_base_info", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_product_resp.json")))
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/update_item", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"item_id":3400133011,"item_name":"seller discount ended"}}`))
+
+	product, err := client.Product.GetProductById(shopID, accessToken, shopee.GetProductParamRequest{ItemIDList: []int{3400133011}})
+	require.NoError(t, err)
+
+	req := product.Response.ItemList[0].UpdateItemRequest()
+	req.ItemName = "seller discount ended"
+
+	res, err := client.Product.UpdateItem(shopID, accessToken, req)
+	require.NoError(t, err)
+	assert.Equal(t, "seller discount ended", res.Response.ItemName)
+
+	assert.Equal(t, float64(3400133011), sent["item_id"])
+	assert.Equal(t, "seller discount ended", sent["item_name"])
+	assert.Equal(t, float64(1), sent["weight"])
+	assert.Equal(t, map[string]any{"image_id_list": []any{"1e076dff0699d8e778c06dd6c02df1fe", "c07ac95ba7bb624d731e37fe2f0349de"}}, sent["image"])
+	assert.NotContains(t, sent, "dimension")
+	assert.Len(t, sent["logistic_info"], len(product.Response.ItemList[0].LogisticInfo))
+}
+
+func Test_UpdateStock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/update_stock", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"failure_list":[{"model_id":2000458803,"failed_reason":"model not found"}],
+			"success_list":[{"model_id":2000458802,"seller_stock":[{"location_id":"","stock":50}]}]}}`))
+
+	res, err := client.Product.UpdateStock(shopID, accessToken, shopee.UpdateStockRequest{
+		ItemID: 3400133011,
+		StockList: []shopee.ModelStock{
+			{ModelID: 2000458802, SellerStock: []shopee.SellerStock{{Stock: 50}}},
+			{ModelID: 2000458803, SellerStock: []shopee.SellerStock{{Stock: 10}}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []shopee.ModelFailure{{ModelID: 2000458803, FailedReason: "model not found"}}, res.Response.FailureList)
+	require.Len(t, res.Response.SuccessList, 1)
+	assert.Equal(t, int64(50), res.Response.SuccessList[0].SellerStock[0].Stock)
+	assert.Len(t, sent["stock_list"], 2)
+}
+
+func Test_UpdatePrice(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/update_price", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"success_list":[{"model_id":0,"original_price":120.5}]}}`))
+
+	res, err := client.Product.UpdatePrice(shopID, accessToken, shopee.UpdatePriceRequest{
+		ItemID:    3400133011,
+		PriceList: []shopee.ModelPrice{{OriginalPrice: 120.5}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []shopee.ModelPrice{{OriginalPrice: 120.5}}, res.Response.SuccessList)
+	assert.Equal(t, []any{map[string]any{"model_id": float64(0), "original_price": 120.5}}, sent["price_list"])
+}
+
+func Test_DeleteItem(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/delete_item", app.APIURL),
+		bodyResponder(&sent, `{"error":"","message":""}`))
+
+	_, err := client.Product.DeleteItem(shopID, accessToken, 3400133011)
+	require.NoError(t, err)
+	assert.Equal(t, float64(3400133011), sent["item_id"])
+}
+
+func Test_InitTierVariation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/init_tier_variation", app.APIURL),
+		bodyResponder(&sent, `{"error":"","response":{"item_id":3400133011,"tier_variation":[{"name":"Color","option_list":[{"option":"Red"},{"option":"Blue"}]}],
+			"model":[{"tier_index":[0],"model_id":2000458802,"model_sku":"RED"},{"tier_index":[1],"model_id":2000458803,"model_sku":"BLUE"}]}}`))
+
+	res, err := client.Product.InitTierVariation(shopID, accessToken, shopee.InitTierVariationRequest{
+		ItemID:        3400133011,
+		TierVariation: []shopee.TierVariation{{Name: "Color", OptionList: []shopee.TierVariationOption{{Option: "Red"}, {Option: "Blue"}}}},
+		Model: []shopee.InitTierModel{
+			{TierIndex: []int{0}, OriginalPrice: 100, ModelSKU: "RED"},
+			{TierIndex: []int{1}, OriginalPrice: 100, ModelSKU: "BLUE"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Response.Model, 2)
+	assert.Equal(t, uint64(2000458803), res.Response.Model[1].ModelID)
+	assert.Len(t, sent["model"], 2)
+}
+
+func Test_UploadProductImage(t *testing.T) {
+	setup()
+	defer teardown()
+	httpmock.Activate() // the image is downloaded with the default client
+
+	imageURL := "https://example.com/images/shirt.jpg"
+	httpmock.RegisterResponder("GET", imageURL, httpmock.NewStringResponder(200, "jpeg bytes"))
+
+	var field string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/media_space/upload_image", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if err != nil {
+				return nil, err
+			}
+			form, err := multipart.NewReader(req.Body, params["boundary"]).ReadForm(1 << 20)
+			if err != nil {
+				return nil, err
+			}
+			for name := range form.File {
+				field = name
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("upload_media_image_resp.json")), nil
+		})
+
+	res, err := client.Product.UploadImage(shopID, accessToken, imageURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "image", field)
+	assert.Equal(t, "sg-11134201-7rbk0-lp3xy9d0xzm2f1", res.Response.ImageInfo.ImageID)
+	assert.Equal(t, "SG", res.Response.ImageInfo.ImageURLList[0].ImageURLRegion)
+}
@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pushCallbackURL = "https://example.com/shopee/push"
+
+var pushApp = shopee.AppConfig{PartnerID: 12345678, PartnerKey: "hush"}
+
+func signPush(body []byte) string {
+	h := hmac.New(sha256.New, []byte(pushApp.PartnerKey))
+	h.Write([]byte(pushCallbackURL + "|"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sendPush(h http.Handler, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", pushCallbackURL, bytes.NewReader(body))
+	req.Header.Set("Authorization", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_PushOrderStatus(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+
+	var got *shopee.OrderStatusPush
+	h.OnOrderStatus(func(ctx context.Context, e *shopee.OrderStatusPush) error {
+		got = e
+		return nil
+	})
+
+	body := loadFixture("push_order_status.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, uint64(1238762), got.ShopID)
+	assert.Equal(t, "2305150ABCDEFG", got.OrderSN)
+	assert.Equal(t, "READY_TO_SHIP", got.Status)
+	assert.Equal(t, int64(1684131230), got.UpdateTime)
+}
+
+func Test_PushWebchat(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+
+	var got *shopee.WebchatPush
+	h.OnWebchat(func(ctx context.Context, e *shopee.WebchatPush) error {
+		got = e
+		return nil
+	})
+
+	body := loadFixture("push_webchat.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	require.NotNil(t, got.Message)
+	assert.Equal(t, "112233445566778899", got.Message.ConversationID)
+	assert.Equal(t, "Halo kak, barang ready?", got.Message.Content.Text)
+	assert.Equal(t, int64(500123456), got.Message.FromID)
+}
+
+func Test_PushShopAuthorization(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+
+	var got *shopee.ShopAuthorizationPush
+	h.OnShopAuthorization(func(ctx context.Context, e *shopee.ShopAuthorizationPush) error {
+		got = e
+		return nil
+	})
+
+	body := loadFixture("push_shop_authorization.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.True(t, got.Success)
+	assert.Equal(t, "tenant-42", got.Extra)
+}
+
+func Test_PushFallback(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+
+	var got *shopee.PushEvent
+	h.OnPush(func(ctx context.Context, e *shopee.PushEvent) error {
+		got = e
+		return nil
+	})
+
+	body := loadFixture("push_video_upload.json")
+	rec := sendPush(h, body, signPush(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, shopee.PushVideoUpload, got.Code)
+	assert.JSONEq(t, `{"video_upload_id":"sg_4a3b2c1d_1684131400","status":"SUCCEEDED"}`, string(got.Data))
+}
+
+func Test_PushInvalidSignature(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+
+	called := false
+	h.OnOrderStatus(func(ctx context.Context, e *shopee.OrderStatusPush) error {
+		called = true
+		return nil
+	})
+
+	body := loadFixture("push_order_status.json")
+	signature := signPush(body)
+
+	rec := sendPush(h, append(body, ' '), signature)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = sendPush(h, body, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func Test_PushUppercaseSignature(t *testing.T) {
+	body := loadFixture("push_order_status.json")
+	assert.True(t, shopee.VerifyPushSignature(pushApp.PartnerKey, pushCallbackURL, body, strings.ToUpper(signPush(body))))
+
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+	called := false
+	h.OnOrderStatus(func(ctx context.Context, e *shopee.OrderStatusPush) error {
+		called = true
+		return nil
+	})
+	rec := sendPush(h, body, strings.ToUpper(signPush(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+
+	assert.False(t, shopee.VerifyPushSignature(pushApp.PartnerKey, pushCallbackURL, body, "not hex"))
+}
+
+func Test_PushURLFromRequest(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, "")
+
+	body := loadFixture("push_order_status.json")
+	req := httptest.NewRequest("POST", "http://internal:8080/shopee/push", bytes.NewReader(body))
+	req.Host = "example.com"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("Authorization", signPush(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_PushCallbackError(t *testing.T) {
+	h := shopee.NewPushHandler(pushApp, pushCallbackURL)
+	h.OnOrderStatus(func(ctx context.Context, e *shopee.OrderStatusPush) error {
+		return errors.New("database is down")
+	})
+
+	body := loadFixture("push_order_status.json")
+	rec := sendPush(h, body, signPush(body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	bad := []byte(`{"data":{"ordersn":123},"shop_id":1238762,"code":3,"timestamp":1684131231}`)
+	rec = sendPush(h, bad, signPush(bad))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
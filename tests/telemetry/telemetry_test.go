@@ -0,0 +1,293 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/lazada"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/sandbox"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/shopee"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// exporters collects the spans and metrics of the clients in memory.
+type exporters struct {
+	spans  *tracetest.InMemoryExporter
+	reader *sdkmetric.ManualReader
+	opts   []telemetry.Option
+}
+
+func newExporters(t *testing.T) *exporters {
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() {
+		tp.Shutdown(context.Background())
+		mp.Shutdown(context.Background())
+	})
+	return &exporters{
+		spans:  spans,
+		reader: reader,
+		opts:   []telemetry.Option{telemetry.WithTracerProvider(tp), telemetry.WithMeterProvider(mp)},
+	}
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// requests returns the requests counted by status.
+func (e *exporters) requests(t *testing.T) map[int64]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, e.reader.Collect(context.Background(), &rm))
+	counts := map[int64]int64{}
+	var durations uint64
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, telemetry.ScopeName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				assert.Equal(t, telemetry.RequestsMetric, m.Name)
+				for _, dp := range data.DataPoints {
+					status, _ := dp.Attributes.Value(telemetry.StatusKey)
+					counts[status.AsInt64()] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				assert.Equal(t, telemetry.DurationMetric, m.Name)
+				for _, dp := range data.DataPoints {
+					durations += dp.Count
+				}
+			}
+		}
+	}
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+	assert.Equal(t, uint64(total), durations, "every request has a duration")
+	return counts
+}
+
+// shops returns the shops the requests were counted by.
+func (e *exporters) shops(t *testing.T) []string {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, e.reader.Collect(context.Background(), &rm))
+	var shops []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range data.DataPoints {
+					if shop, ok := dp.Attributes.Value(telemetry.ShopIDKey); ok {
+						shops = append(shops, shop.AsString())
+					}
+				}
+			}
+		}
+	}
+	return shops
+}
+
+func Test_ShopeeTelemetry(t *testing.T) {
+	e := newExporters(t)
+	s := sandbox.NewShopee(2001234, "partner-key")
+	defer s.Close()
+	s.AddShop(77001, "shopee-token", shopee.GetShopInfoResponse{ShopName: "shop"})
+	s.Fail("/api/v2/shop/get_shop_info", sandbox.Unavailable())
+
+	client := s.Client(shopee.WithTelemetry(e.opts...), shopee.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: time.Millisecond}),
+	))
+	_, err := client.Shop.GetShopInfo(77001, "shopee-token")
+	require.NoError(t, err)
+
+	// each attempt is a span
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 2)
+	for i, span := range spans {
+		assert.Equal(t, "GET /api/v2/shop/get_shop_info", span.Name)
+		attrs := attributes(span)
+		assert.Equal(t, "shopee", attrs[telemetry.MarketplaceKey].AsString())
+		assert.Equal(t, "/api/v2/shop/get_shop_info", attrs[telemetry.PathKey].AsString())
+		assert.Equal(t, "77001", attrs[telemetry.ShopIDKey].AsString())
+		assert.Equal(t, int64(i), attrs[telemetry.RetryAttemptKey].AsInt64())
+		assert.NotEmpty(t, attrs[telemetry.RequestIDKey].AsString())
+	}
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, int64(503), attributes(spans[0])[telemetry.StatusKey].AsInt64())
+	assert.Equal(t, "error_server", attributes(spans[0])[telemetry.ErrorCodeKey].AsString())
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	assert.Equal(t, int64(200), attributes(spans[1])[telemetry.StatusKey].AsInt64())
+
+	assert.Equal(t, map[int64]int64{200: 1, 503: 1}, e.requests(t))
+	// the shop is on the spans only
+	assert.Empty(t, e.shops(t))
+
+	// the attempts of WithRetry are numbered too
+	e.spans.Reset()
+	s.Fail("/api/v2/shop/get_shop_info", sandbox.RateLimited(0))
+	_, err = s.Client(shopee.WithTelemetry(e.opts...), shopee.WithRetry(2)).Shop.GetShopInfo(77001, "shopee-token")
+	require.NoError(t, err)
+	spans = e.spans.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, int64(1), attributes(spans[1])[telemetry.RetryAttemptKey].AsInt64())
+}
+
+func Test_TelemetryShopMetrics(t *testing.T) {
+	e := newExporters(t)
+	s := sandbox.NewShopee(2001234, "partner-key")
+	defer s.Close()
+	s.AddShop(77001, "shopee-token", shopee.GetShopInfoResponse{ShopName: "shop"})
+
+	opts := append(e.opts, telemetry.WithShopMetrics())
+	_, err := s.Client(shopee.WithTelemetry(opts...)).Shop.GetShopInfo(77001, "shopee-token")
+	require.NoError(t, err)
+
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "77001", attributes(spans[0])[telemetry.ShopIDKey].AsString())
+	assert.Equal(t, []string{"77001"}, e.shops(t))
+}
+
+func Test_LazadaTelemetry(t *testing.T) {
+	e := newExporters(t)
+	s := sandbox.NewLazada("100200", "lazada-secret")
+	defer s.Close()
+	s.AddShop("lazada-token")
+
+	ctx := lazada.WithSeller(context.Background(), "seller-1")
+	_, err := s.Client(lazada.WithTelemetry(e.opts...)).Product.GetProducts(ctx, "stolen", &lazada.GetProductsParams{Offset: "0", Limit: "10"})
+	require.Error(t, err)
+
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 1)
+	attrs := attributes(spans[0])
+	assert.Equal(t, "lazada", attrs[telemetry.MarketplaceKey].AsString())
+	assert.Equal(t, "/rest/products/get", attrs[telemetry.RouteKey].AsString())
+	assert.Equal(t, "seller-1", attrs[telemetry.ShopIDKey].AsString())
+	assert.Equal(t, "IllegalAccessToken", attrs[telemetry.ErrorCodeKey].AsString())
+	assert.NotEmpty(t, attrs[telemetry.RequestIDKey].AsString())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	for _, kv := range spans[0].Attributes {
+		assert.NotContains(t, kv.Value.Emit(), "stolen", "the access token is not recorded")
+	}
+
+	assert.Equal(t, map[int64]int64{200: 1}, e.requests(t))
+}
+
+func Test_TikTokTelemetry(t *testing.T) {
+	e := newExporters(t)
+	s := sandbox.NewTikTok("tiktok-app", "tiktok-secret")
+	defer s.Close()
+	s.AddShop("tiktok-token", tiktok.Shops{Cipher: "cipher-1"})
+	s.AddProduct("cipher-1", tiktok.ProductData{ID: "1729"})
+
+	client := s.Client(tiktok.WithTelemetry(e.opts...))
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "tiktok-token", ShopCipher: "cipher-1"})
+	_, err := client.Product.GetProductInfo("1729")
+	require.NoError(t, err)
+
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 1)
+	attrs := attributes(spans[0])
+	route := "/product/" + sandbox.TikTokVersion + "/products/{id}"
+	assert.Equal(t, "GET "+route, spans[0].Name)
+	assert.Equal(t, route, attrs[telemetry.RouteKey].AsString())
+	assert.Equal(t, "/product/"+sandbox.TikTokVersion+"/products/1729", attrs[telemetry.PathKey].AsString())
+	assert.Equal(t, "cipher-1", attrs[telemetry.ShopIDKey].AsString())
+	assert.NotContains(t, attrs, telemetry.ErrorCodeKey)
+
+	e.spans.Reset()
+	s.Fail("/order/"+sandbox.TikTokVersion+"/orders", s.ErrorFault(36009004, "too many requests"))
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: "tiktok-token", ShopCipher: "cipher-1"})
+	_, err = client.Order.GetOrder(tiktok.GetOrderParams{OrderIDs: []string{"o1"}})
+	require.Error(t, err)
+	spans = e.spans.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "36009004", attributes(spans[0])[telemetry.ErrorCodeKey].AsString())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func Test_TokopediaTelemetry(t *testing.T) {
+	e := newExporters(t)
+	s := sandbox.NewTokopedia(15001)
+	defer s.Close()
+	s.AddShop("tokopedia-token", tokopedia.ShopData{ShopID: 480001})
+	s.Fail("/inventory/v1/fs/15001/product/info", s.ErrorFault("PRODUCT_LOCKED", "product is locked"))
+
+	client := s.Client(tokopedia.WithTelemetry(e.opts...))
+	_, err := client.Product.GetProductInfo("tokopedia-token", 123)
+	require.Error(t, err)
+	_, err = client.Shop.GetShopInfo("tokopedia-token", tokopedia.ShopParams{ShopID: 480001})
+	require.NoError(t, err)
+
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 2)
+	attrs := attributes(spans[0])
+	assert.Equal(t, "tokopedia", attrs[telemetry.MarketplaceKey].AsString())
+	assert.Equal(t, "/inventory/v1/fs/{id}/product/info", attrs[telemetry.RouteKey].AsString())
+	assert.Equal(t, "PRODUCT_LOCKED", attrs[telemetry.ErrorCodeKey].AsString())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	attrs = attributes(spans[1])
+	assert.Equal(t, "/v1/shop/fs/{id}/shop-info", attrs[telemetry.RouteKey].AsString())
+	assert.Equal(t, "480001", attrs[telemetry.ShopIDKey].AsString())
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+
+	assert.Equal(t, map[int64]int64{200: 2}, e.requests(t))
+}
+
+func Test_TokopediaNativeTelemetry(t *testing.T) {
+	e := newExporters(t)
+	path := filepath.Join(t.TempDir(), "tokopedia-native.json")
+	recorded := &cassette.Cassette{Interactions: []cassette.Interaction{{
+		Request: cassette.Request{
+			Method: http.MethodGet,
+			URL:    "https://fs.tokopedia.test/v1/chat/fs/15001/messages/7001/replies?page=1&per_page=10&shop_id=480001",
+		},
+		Response: cassette.Response{
+			Status: http.StatusOK,
+			Header: http.Header{"Content-Type": {"application/json"}},
+			Body:   `{"data":[]}`,
+		},
+	}}}
+	require.NoError(t, recorded.Save(path))
+	rec, err := cassette.New(path, cassette.ModeReplay)
+	require.NoError(t, err)
+
+	// the replaying recorder stands in for the socks proxy
+	client := tokopedia.NewClient(tokopedia.AppConfig{FsID: 15001, APIURL: "https://fs.tokopedia.test"},
+		tokopedia.WithRecorder(rec), tokopedia.WithTelemetry(e.opts...))
+	handler, err := tokopedia.NewTokopediaHTTPHandler(client.WithShopID("480001"), "127.0.0.1:1")
+	require.NoError(t, err)
+	_, err = handler.GetReplyTokopedia(tokopedia.GetReplyListParams{MsgID: 7001, Page: 1, PerPage: 10})
+	require.NoError(t, err)
+
+	spans := e.spans.GetSpans()
+	require.Len(t, spans, 1)
+	attrs := attributes(spans[0])
+	assert.Equal(t, "tokopedia", attrs[telemetry.MarketplaceKey].AsString())
+	assert.Equal(t, "/v1/chat/fs/{id}/messages/{id}/replies", attrs[telemetry.RouteKey].AsString())
+	assert.Equal(t, "480001", attrs[telemetry.ShopIDKey].AsString())
+
+	assert.Equal(t, map[int64]int64{200: 1}, e.requests(t))
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_RateLimitRetryHonoursDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/order/%s/orders", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(429, `{"code":36009004,"message":"too many requests"}`)
+			resp.Header.Set("Content-Type", "application/json")
+			resp.Header.Set("Retry-After", "30")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Order.GetOrderWithContext(ctx, tiktok.GetOrderParams{OrderIDs: []string{"576461413038785752"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Order.GetOrderWithContext error returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry sleep ignored the deadline, took %s", elapsed)
+	}
+}
+
+func Test_GetOrderWithContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/order/%s/orders", app.APIURL, app.Version),
+		httpmock.NewStringResponder(200, `{"code":0,"data":{}}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Order.GetOrderWithContext(ctx, tiktok.GetOrderParams{OrderIDs: []string{"576461413038785752"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Order.GetOrderWithContext error returned %v, expected %v", err, context.Canceled)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("request count returned %d, expected 0", n)
+	}
+}
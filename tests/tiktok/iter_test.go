@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+)
+
+func jsonResponse(body string) *http.Response {
+	resp := httpmock.NewStringResponse(200, body)
+	resp.Header.Set("Content-Type", "application/json")
+	return resp
+}
+
+func Test_ConversationsIteratesPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var missingShop int
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/customer_service/%s/conversations", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			// every page must be sent for the shop given to the iterator
+			if req.Header.Get("x-tts-access-token") != accessToken || req.URL.Query().Get("shop_cipher") != "cipher" {
+				missingShop++
+			}
+
+			switch req.URL.Query().Get("page_token") {
+			case "":
+				return jsonResponse(`{"code":0,"message":"Success","data":{"conversations":[{"id":"c1"},{"id":"c2"}],"next_page_token":"p2"}}`), nil
+			case "p2":
+				return jsonResponse(`{"code":0,"message":"Success","data":{"conversations":[{"id":"c3"}],"next_page_token":""}}`), nil
+			}
+			return jsonResponse(`{"code":36009003,"message":"invalid page token"}`), nil
+		})
+
+	shop := tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"}
+
+	var ids []string
+	for c, err := range client.Chat.Conversations(context.Background(), shop, tiktok.GetConversationsParam{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Chat.Conversations error: %s", err)
+		}
+		ids = append(ids, c.ID)
+	}
+
+	if fmt.Sprint(ids) != "[c1 c2 c3]" {
+		t.Errorf("Chat.Conversations returned %v, expected [c1 c2 c3]", ids)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("request count returned %d, expected 2", n)
+	}
+	if missingShop != 0 {
+		t.Errorf("%d requests were sent without the shop credentials", missingShop)
+	}
+}
+
+func Test_MessagesStopsEarly(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/customer_service/%s/conversations/c1/messages", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"code":0,"message":"Success","data":{"messages":[{"id":"m1"},{"id":"m2"}],"next_page_token":"p2"}}`), nil
+		})
+
+	shop := tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"}
+
+	var ids []string
+	for m, err := range client.Chat.Messages(context.Background(), shop, "c1", tiktok.GetConversationMessagesParam{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Chat.Messages error: %s", err)
+		}
+		ids = append(ids, m.ID)
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	if fmt.Sprint(ids) != "[m1 m2]" {
+		t.Errorf("Chat.Messages returned %v, expected [m1 m2]", ids)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 1 {
+		t.Errorf("request count returned %d, expected 1", n)
+	}
+}
+
+func Test_ConversationsOfSeveralShops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var mixedShop int
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/customer_service/%s/conversations", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			cipher := req.URL.Query().Get("shop_cipher")
+			if req.Header.Get("x-tts-access-token") != "token-"+cipher {
+				mu.Lock()
+				mixedShop++
+				mu.Unlock()
+			}
+			if req.URL.Query().Get("page_token") == "" {
+				return jsonResponse(`{"code":0,"message":"Success","data":{"conversations":[{"id":"` + cipher + `-1"}],"next_page_token":"p2"}}`), nil
+			}
+			return jsonResponse(`{"code":0,"message":"Success","data":{"conversations":[{"id":"` + cipher + `-2"}],"next_page_token":""}}`), nil
+		})
+
+	// ranging for several shops at once never writes to the shared client
+	var wg sync.WaitGroup
+	ids := make([][]string, 4)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cipher := fmt.Sprintf("cipher%d", i)
+			shop := tiktok.CommonParamRequest{AccessToken: "token-" + cipher, ShopCipher: cipher}
+			for c, err := range client.Chat.Conversations(context.Background(), shop, tiktok.GetConversationsParam{PageSize: 1}) {
+				if err != nil {
+					t.Errorf("Chat.Conversations error: %s", err)
+					return
+				}
+				ids[i] = append(ids[i], c.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, got := range ids {
+		expected := fmt.Sprintf("[cipher%d-1 cipher%d-2]", i, i)
+		if fmt.Sprint(got) != expected {
+			t.Errorf("Chat.Conversations returned %v, expected %s", got, expected)
+		}
+	}
+	if mixedShop != 0 {
+		t.Errorf("%d requests were sent with the token of another shop", mixedShop)
+	}
+	if client.ShopCipher != "" || client.AccessToken != "" {
+		t.Errorf("Chat.Conversations set the shop %q on the client", client.ShopCipher)
+	}
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_MiddlewareRetriesTooManyRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the middleware wraps the default transport, mock that one
+	httpmock.Activate()
+	c := tiktok.NewClient(app, tiktok.WithMiddleware(
+		middleware.WithBackoff(middleware.Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}),
+	))
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/customer_service/%s/conversations", app.APIURL, app.Version),
+		httpmock.ResponderFromMultipleResponses([]*http.Response{
+			jsonResponse(`{"code":36009004,"message":"too many requests"}`),
+			jsonResponse(`{"code":0,"message":"Success","data":{"conversations":[{"id":"c1"}]}}`),
+		}))
+
+	c.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+	res, err := c.Chat.GetConversations(tiktok.GetConversationsParam{PageSize: 1})
+	if err != nil {
+		t.Fatalf("Chat.GetConversations error: %s", err)
+	}
+	if len(res.Data.Conversations) != 1 {
+		t.Errorf("Chat.GetConversations returned %d conversations, expected 1", len(res.Data.Conversations))
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("request count returned %d, expected 2", n)
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordJSON records the query and JSON body of a request and answers body.
+func recordJSON(query *url.Values, sent *map[string]any, body string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		*query = req.URL.Query()
+		if err := json.NewDecoder(req.Body).Decode(sent); err != nil {
+			return nil, err
+		}
+		return jsonResponse(body), nil
+	}
+}
+
+func Test_SearchOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/order/%s/orders/search", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"message":"Success","data":{"next_page_token":"p2","total_count":3,
+			"orders":[{"id":"576461413038785752","status":"AWAITING_SHIPMENT","line_items":[{"id":"l1","seller_sku":"KP-01"}],"packages":[{"id":"pkg1"}]}]}}`))
+
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+	res, err := client.Order.SearchOrders(
+		tiktok.SearchOrdersParams{PageSize: 20, PageToken: "p1", SortField: "create_time"},
+		tiktok.SearchOrdersBody{OrderStatus: "AWAITING_SHIPMENT", CreateTimeGe: 1700000000},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "20", query.Get("page_size"))
+	assert.Equal(t, "p1", query.Get("page_token"))
+	assert.Equal(t, "cipher", query.Get("shop_cipher"))
+	assert.NotEmpty(t, query.Get("sign"))
+	assert.Equal(t, map[string]any{"order_status": "AWAITING_SHIPMENT", "create_time_ge": float64(1700000000)}, sent)
+
+	assert.Equal(t, "p2", res.Data.NextPageToken)
+	require.Len(t, res.Data.Orders, 1)
+	assert.Equal(t, "KP-01", res.Data.Orders[0].LineItems[0].SellerSku)
+	assert.Equal(t, "pkg1", res.Data.Orders[0].Packages[0].ID)
+}
+
+func Test_GetPackageDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/fulfillment/%s/packages/pkg1", app.APIURL, app.Version),
+		httpmock.ResponderFromResponse(jsonResponse(`{"code":0,"data":{"id":"pkg1","package_status":"PROCESSING","tracking_number":"JX123",
+			"order_line_item_ids":["l1","l2"],"orders":[{"id":"576461413038785752","skus":[{"id":"sku1","name":"Blue L","quantity":2}]}]}}`)))
+
+	res, err := client.Fulfillment.GetPackageDetail("pkg1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "PROCESSING", res.Data.PackageStatus)
+	assert.Equal(t, []string{"l1", "l2"}, res.Data.OrderLineItemIDs)
+	assert.Equal(t, 2, res.Data.Orders[0].Skus[0].Quantity)
+}
+
+func Test_ShipPackageSelfShipment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/fulfillment/%s/packages/pkg1/ship", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"message":"Success"}`))
+
+	_, err := client.Fulfillment.ShipPackage("pkg1", tiktok.ShipPackageRequest{
+		SelfShipment: &tiktok.SelfShipment{TrackingNumber: "JX123", ShippingProviderID: "6617675021119438849"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"self_shipment": map[string]any{"tracking_number": "JX123", "shipping_provider_id": "6617675021119438849"},
+	}, sent)
+}
+
+func Test_GetShippingDocuments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/fulfillment/%s/packages/pkg1/shipping_documents", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("document_type") != "SHIPPING_LABEL" {
+				return jsonResponse(`{"code":36009003,"message":"invalid document_type"}`), nil
+			}
+			return jsonResponse(`{"code":0,"data":{"doc_url":"https://open-fs.tiktokshop.com/label.pdf","tracking_number":"JX123"}}`), nil
+		})
+
+	res, err := client.Fulfillment.GetShippingDocuments("pkg1", tiktok.GetShippingDocumentsParams{DocumentType: "SHIPPING_LABEL", DocumentSize: "A6"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://open-fs.tiktokshop.com/label.pdf", res.Data.DocURL)
+}
+
+func Test_CombinePackages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/fulfillment/%s/packages/combine", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"data":{"packages":[{"id":"pkg1","order_ids":["o1","o2"]}],"errors":[]}}`))
+
+	res, err := client.Fulfillment.CombinePackages(tiktok.CombinePackagesRequest{
+		CombinablePackages: []tiktok.CombinablePackage{{ID: "pkg1", OrderIDs: []string{"o1", "o2"}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []tiktok.CombinablePackage{{ID: "pkg1", OrderIDs: []string{"o1", "o2"}}}, res.Data.Packages)
+	assert.Len(t, sent["combinable_packages"], 1)
+}
+
+func Test_SearchReturns(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/return_refund/%s/returns/search", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"data":{"total_count":1,"return_orders":[{"return_id":"r1","order_id":"o1","return_status":"RETURN_OR_REFUND_REQUEST_PENDING",
+			"return_line_items":[{"order_line_item_id":"l1","seller_sku":"KP-01"}],"refund_amount":{"currency":"IDR","refund_total":"35000"}}]}}`))
+
+	res, err := client.ReturnRefund.SearchReturns(tiktok.SearchReturnRefundParams{PageSize: 10}, tiktok.SearchReturnsBody{OrderIDs: []string{"o1"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", query.Get("page_size"))
+	assert.Equal(t, map[string]any{"order_ids": []any{"o1"}}, sent)
+	require.Len(t, res.Data.ReturnOrders, 1)
+	assert.Equal(t, "l1", res.Data.ReturnOrders[0].LineItems[0].OrderLineItemID)
+	assert.Equal(t, money.MustParse("35000", "IDR"), res.Data.ReturnOrders[0].RefundAmount.RefundTotal)
+}
+
+func Test_RejectCancellation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/return_refund/%s/cancellations/c1/reject", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"message":"Success"}`))
+
+	_, err := client.ReturnRefund.RejectCancellation("c1", tiktok.RejectRequest{RejectReason: "seller_reject_apply_product_has_been_packed"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"reject_reason": "seller_reject_apply_product_has_been_packed"}, sent)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateInventorySignsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sign, expected string
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/product/%s/products/p1/inventory/update", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return nil, err
+			}
+
+			// sign again what was sent, without the sign itself
+			query := req.URL.Query()
+			sign = query.Get("sign")
+			query.Del("sign")
+			u := *req.URL
+			u.RawQuery = query.Encode()
+			resign, _ := http.NewRequest(req.Method, u.String(), bytes.NewReader(body))
+			resign.Header.Set("Content-Type", req.Header.Get("Content-Type"))
+			expected = client.CalSignAndGenerateSignature(resign, app.AppSecret)
+
+			return jsonResponse(`{"code":0,"message":"Success"}`), nil
+		})
+
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+	_, err := client.Product.UpdateInventory("p1", tiktok.UpdateInventoryRequest{
+		Skus: []tiktok.SkuInventory{{ID: "sku1", Inventory: []tiktok.Inventory{{Quantity: 7, WarehouseID: "w1"}}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, sign)
+	assert.Equal(t, map[string]any{
+		"skus": []any{map[string]any{"id": "sku1", "inventory": []any{map[string]any{"quantity": float64(7), "warehouse_id": "w1"}}}},
+	}, sent)
+}
+
+func Test_UpdatePrice(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []byte
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/product/%s/products/p1/prices/update", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			var err error
+			sent, err = io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			return jsonResponse(`{"code":0,"message":"Success"}`), nil
+		})
+
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+	_, err := client.Product.UpdatePrice("p1", tiktok.UpdatePriceRequest{
+		Skus: []tiktok.SkuPrice{{ID: "sku1", Price: tiktok.PriceAmount{Amount: "150000", Currency: "IDR"}}},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"skus":[{"id":"sku1","price":{"amount":"150000","currency":"IDR"}}]}`, string(sent))
+}
+
+func Test_SearchProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/product/%s/products/search", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"data":{"next_page_token":"p2","total_count":1,
+			"products":[{"id":"p1","title":"Kaos Polos","status":"ACTIVATE","skus":[{"id":"sku1","seller_sku":"KP-01","price":{"currency":"IDR","sale_price":"150000"}}]}]}}`))
+
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+	res, err := client.Product.SearchProducts(tiktok.SearchProductsParams{PageSize: 50}, tiktok.SearchProductsBody{Status: "ACTIVATE"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "50", query.Get("page_size"))
+	assert.Equal(t, map[string]any{"status": "ACTIVATE"}, sent)
+	assert.Equal(t, "p2", res.Data.NextPageToken)
+	require.Len(t, res.Data.Products, 1)
+	assert.Equal(t, money.MustParse("150000", "IDR"), res.Data.Products[0].Skus[0].Price.SalePrice)
+}
+
+func Test_ProductRequestFromProductData(t *testing.T) {
+	var p tiktok.ProductData
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"p1","title":"Kaos Polos","description":"<p>Katun</p>",
+		"category_chains":[{"id":"600001","is_leaf":false},{"id":"601226","is_leaf":true}],
+		"main_images":[{"uri":"tos-img-1","urls":["https://example.com/1.jpg"],"width":800,"height":800}],
+		"skus":[{"id":"sku1","seller_sku":"KP-01","price":{"currency":"IDR","sale_price":"150000","tax_exclusive_price":"135000"}}]}`), &p))
+
+	req := p.ProductRequest()
+
+	assert.Equal(t, "601226", req.CategoryID)
+	assert.Equal(t, []tiktok.MainImage{{URI: "tos-img-1"}}, req.MainImages)
+	require.Len(t, req.Skus, 1)
+	assert.Equal(t, &tiktok.Price{Amount: "150000", Currency: "IDR"}, req.Skus[0].Price)
+}
+
+func Test_GetAttributes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/product/%s/categories/601226/attributes", app.APIURL, app.Version),
+		httpmock.ResponderFromResponse(jsonResponse(`{"code":0,"data":{"attributes":[{"id":"100000","name":"Color","type":"SALES_PROPERTY",
+			"is_customizable":true,"values":[{"id":"1001","name":"Blue"}]}]}}`)))
+
+	res, err := client.Product.GetAttributes("601226")
+	require.NoError(t, err)
+
+	require.Len(t, res.Data.Attributes, 1)
+	assert.Equal(t, "SALES_PROPERTY", res.Data.Attributes[0].Type)
+	assert.True(t, res.Data.Attributes[0].IsCustomizable)
+	assert.Equal(t, "Blue", res.Data.Attributes[0].Values[0].Name)
+}
+
+func Test_DeactivateProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/product/%s/products/deactivate", app.APIURL, app.Version),
+		recordJSON(&query, &sent, `{"code":0,"data":{"errors":[{"code":12052048,"message":"product not found","detail":{"product_id":"p2"}}]}}`))
+
+	res, err := client.Product.DeactivateProducts([]string{"p1", "p2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"product_ids": []any{"p1", "p2"}}, sent)
+	require.Len(t, res.Data.Errors, 1)
+	assert.Equal(t, "p2", res.Data.Errors[0].Detail.ProductID)
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenSource returns the tokens of shops by cipher.
+type tokenSource map[string]string
+
+func (s tokenSource) AccessToken(ctx context.Context, cipher string) (string, error) {
+	tok, ok := s[cipher]
+	if !ok {
+		return "", errors.New("unknown shop")
+	}
+	return tok, nil
+}
+
+func Test_TokenSourceByCipher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c := tiktok.NewClient(app, tiktok.WithTokenSource(tokenSource{"cipher": accessToken}))
+	httpmock.ActivateNonDefault(c.Client)
+
+	var token string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/order/%s/orders", app.APIURL, app.Version),
+		func(req *http.Request) (*http.Response, error) {
+			token = req.Header.Get("x-tts-access-token")
+			resp := httpmock.NewStringResponse(200, `{"code":0,"data":{"orders":[]}}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+	params := tiktok.GetOrderParams{OrderIDs: []string{"576461413038785752"}}
+	c.WithCommonParamRequest(tiktok.CommonParamRequest{ShopCipher: "cipher"})
+	_, err := c.Order.GetOrderWithContext(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, accessToken, token)
+
+	// the token source is keyed by cipher, a shop id alone is not enough
+	c.WithCommonParamRequest(tiktok.CommonParamRequest{ShopID: "7001"})
+	_, err = c.Order.GetOrderWithContext(context.Background(), params)
+	assert.Error(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
@@ -0,0 +1,277 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	uploadURL = "https://upload.tiktokglobalshop.com/open/202512/file/upload"
+	mb        = 1024 * 1024
+)
+
+// mp4Video returns size bytes starting with an mp4 ftyp box.
+func mp4Video(size int) []byte {
+	video := bytes.Repeat([]byte{0x42}, size)
+	copy(video, "\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	return video
+}
+
+type chunkUpload struct {
+	token       string
+	cipher      string
+	contentType string
+	size        int
+}
+
+// chunkServer answers the chunk uploads, failing each chunk the number of
+// times set in failures.
+type chunkServer struct {
+	mu       sync.Mutex
+	count    int
+	failures map[int]int
+	chunks   map[int]chunkUpload
+	order    []int
+	init     map[string]interface{}
+	// initShop is the shop cipher and token FileInit was sent for
+	initShop chunkUpload
+}
+
+func registerChunkServer(count int, failures map[int]int) *chunkServer {
+	s := &chunkServer{count: count, failures: failures, chunks: map[int]chunkUpload{}}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("=~^%s/open/202512/file/init", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			json.NewDecoder(req.Body).Decode(&s.init)
+			s.initShop = chunkUpload{token: req.Header.Get("x-tts-access-token"), cipher: req.URL.Query().Get("shop_cipher")}
+			return jsonResponse(fmt.Sprintf(`{"code":0,"message":"Success","data":{"upload_token":"tok-1","upload_url":%q}}`, uploadURL)), nil
+		})
+
+	httpmock.RegisterResponder("PUT", "=~^"+uploadURL,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			n, _ := strconv.Atoi(q.Get("chunk_num"))
+			data, _ := io.ReadAll(req.Body)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.failures[n] > 0 {
+				s.failures[n]--
+				return jsonResponse(`{"code":36009005,"message":"upload failed"}`), nil
+			}
+			s.chunks[n] = chunkUpload{
+				token:       req.Header.Get("x-tts-access-token"),
+				cipher:      q.Get("shop_cipher"),
+				contentType: req.Header.Get("Content-Type"),
+				size:        len(data),
+			}
+			s.order = append(s.order, n)
+
+			if n < s.count {
+				return jsonResponse(`{"code":0,"message":"Success","data":{}}`), nil
+			}
+			return jsonResponse(`{"code":0,"message":"Success","data":{"file_id":"file-1","url":"https://p16.tiktokcdn.com/file-1.mp4"}}`), nil
+		})
+
+	return s
+}
+
+func Test_VideoUploaderUploadsChunks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerChunkServer(3, map[int]int{2: 1})
+
+	var progress []tiktok.VideoUploadProgress
+	uploader := tiktok.NewVideoUploader(client,
+		tiktok.WithChunkRetries(1, 0),
+		tiktok.WithUploadStore(tiktok.NewMemoryVideoUploadStore()),
+		tiktok.WithSpoolDir(t.TempDir()),
+		tiktok.WithUploadProgress(func(p tiktok.VideoUploadProgress) {
+			progress = append(progress, p)
+		}),
+	)
+
+	client.WithCommonParamRequest(tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"})
+
+	// a reader of unknown length
+	video := io.MultiReader(bytes.NewReader(mp4Video(25 * mb)))
+	res, err := uploader.Upload(context.Background(), video, &tiktok.VideoUploadRequest{
+		Key:      "video.mp4",
+		FileName: "video.mp4",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &tiktok.UploadedVideo{
+		FileID:      "file-1",
+		URL:         "https://p16.tiktokcdn.com/file-1.mp4",
+		UploadToken: "tok-1",
+		ContentType: "video/mp4",
+		Size:        25 * mb,
+	}, res)
+
+	assert.Equal(t, "mp4", server.init["file_type"])
+	assert.Equal(t, float64(25*mb), server.init["file_size"])
+	assert.Equal(t, float64(3), server.init["total_chunk_count"])
+	assert.Equal(t, chunkUpload{token: accessToken, cipher: "cipher"}, server.initShop)
+	assert.Empty(t, client.ShopCipher, "the shop set on the client is used for one upload")
+
+	// every chunk is sent for the shop set before uploading, the last one once
+	// the others are uploaded
+	want := chunkUpload{token: accessToken, cipher: "cipher", contentType: "video/mp4", size: 10 * mb}
+	assert.Equal(t, want, server.chunks[1])
+	assert.Equal(t, want, server.chunks[2])
+	want.size = 5 * mb
+	assert.Equal(t, want, server.chunks[3])
+	assert.Equal(t, 3, server.order[2])
+
+	require.Len(t, progress, 3)
+	assert.Equal(t, tiktok.VideoUploadProgress{
+		UploadToken:    "tok-1",
+		UploadedBytes:  25 * mb,
+		TotalBytes:     25 * mb,
+		UploadedChunks: 3,
+		ChunkCount:     3,
+	}, progress[2])
+}
+
+func Test_VideoUploaderResumesFromUploadToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerChunkServer(3, map[int]int{3: 10})
+	store := tiktok.NewMemoryVideoUploadStore()
+	uploader := tiktok.NewVideoUploader(client,
+		tiktok.WithUploadConcurrency(1),
+		tiktok.WithChunkRetries(0, 0),
+		tiktok.WithUploadStore(store),
+	)
+
+	video := mp4Video(25 * mb)
+	req := &tiktok.VideoUploadRequest{
+		Shop:     tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"},
+		Key:      "video.mp4",
+		FileName: "video.mp4",
+	}
+	_, err := uploader.Upload(context.Background(), bytes.NewReader(video), req)
+
+	var respErr tiktok.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, 36009005, respErr.Code)
+
+	state, err := store.Load(context.Background(), "video.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, &tiktok.VideoUploadState{
+		UploadToken: "tok-1",
+		UploadURL:   uploadURL,
+		Size:        25 * mb,
+		ChunkCount:  3,
+		ContentType: "video/mp4",
+		Chunks:      []int{1, 2},
+	}, state)
+
+	// the upload resumes with the saved token, only the last chunk is sent
+	server.failures[3] = 0
+	server.order = nil
+	httpmock.ZeroCallCounters()
+
+	var progress []tiktok.VideoUploadProgress
+	uploader = tiktok.NewVideoUploader(client,
+		tiktok.WithUploadStore(store),
+		tiktok.WithUploadProgress(func(p tiktok.VideoUploadProgress) {
+			progress = append(progress, p)
+		}),
+	)
+	res, err := uploader.Upload(context.Background(), bytes.NewReader(video), req)
+	require.NoError(t, err)
+	assert.Equal(t, "file-1", res.FileID)
+	assert.Equal(t, []int{3}, server.order)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 0, calls[fmt.Sprintf("POST =~^%s/open/202512/file/init", app.APIURL)])
+
+	require.Len(t, progress, 2)
+	assert.Equal(t, int64(20*mb), progress[0].UploadedBytes)
+	assert.Equal(t, int64(25*mb), progress[1].UploadedBytes)
+
+	state, err = store.Load(context.Background(), "video.mp4")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func Test_VideoUploaderContentType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := registerChunkServer(1, nil)
+	uploader := tiktok.NewVideoUploader(client)
+
+	// the bytes of a QuickTime video are not sniffed, the extension tells it
+	video := bytes.Repeat([]byte{0x42}, 3*mb)
+	res, err := uploader.Upload(context.Background(), bytes.NewReader(video), &tiktok.VideoUploadRequest{
+		Shop:     tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"},
+		FileName: "video.mov",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "video/quicktime", res.ContentType)
+	assert.Equal(t, "quicktime", server.init["file_type"])
+	assert.Equal(t, "video/quicktime", server.chunks[1].contentType)
+}
+
+func Test_VideoUploaderSeveralShops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerChunkServer(1, nil)
+	uploader := tiktok.NewVideoUploader(client)
+
+	// uploads for several shops at once never write to the shared client
+	var wg sync.WaitGroup
+	for _, cipher := range []string{"cipher-a", "cipher-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := uploader.Upload(context.Background(), bytes.NewReader(mp4Video(mb)), &tiktok.VideoUploadRequest{
+				Shop:     tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: cipher},
+				FileName: "video.mp4",
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Empty(t, client.ShopCipher)
+}
+
+func Test_VideoUploaderStopsOnInvalidParam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerChunkServer(1, nil)
+	httpmock.RegisterResponder("PUT", "=~^"+uploadURL,
+		httpmock.NewStringResponder(200, `{"code":36009003,"message":"invalid upload token"}`))
+	uploader := tiktok.NewVideoUploader(client, tiktok.WithChunkRetries(3, 0))
+
+	_, err := uploader.Upload(context.Background(), bytes.NewReader(mp4Video(mb)), &tiktok.VideoUploadRequest{
+		Shop:     tiktok.CommonParamRequest{AccessToken: accessToken, ShopCipher: "cipher"},
+		FileName: "video.mp4",
+	})
+	assert.ErrorIs(t, err, apierror.ErrInvalidParam)
+
+	// an error retrying cannot fix is not retried
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, calls["PUT =~^"+uploadURL])
+}
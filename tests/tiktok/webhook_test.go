@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tiktok"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookApp = tiktok.AppConfig{AppKey: "6abcdefghijkl", AppSecret: "webhooksecret"}
+
+func signWebhook(body []byte) string {
+	h := hmac.New(sha256.New, []byte(webhookApp.AppSecret))
+	h.Write([]byte(webhookApp.AppKey))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// webhookBody loads a fixture and moves its timestamp to ts.
+func webhookBody(t *testing.T, filename string, ts time.Time) []byte {
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(loadFixture(filename), &payload))
+	payload["timestamp"] = ts.Unix()
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return body
+}
+
+func sendWebhook(h http.Handler, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "https://example.com/tiktok/webhook", bytes.NewReader(body))
+	req.Header.Set("Authorization", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_WebhookOrderStatusChange(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	var got *tiktok.OrderStatusChangeEvent
+	h.OnOrderStatusChange(func(ctx context.Context, e *tiktok.OrderStatusChangeEvent) error {
+		got = e
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_order_status_change.json", time.Now())
+	rec := sendWebhook(h, body, signWebhook(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "7494049642642441621", got.ShopID)
+	assert.Equal(t, "576469784328342200", got.Order.ID)
+	assert.Equal(t, "AWAITING_SHIPMENT", got.Order.Status)
+	assert.Equal(t, int64(1644412880), got.Order.UpdateTime)
+}
+
+func Test_WebhookNewMessage(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	var got *tiktok.NewMessageEvent
+	h.OnNewMessage(func(ctx context.Context, e *tiktok.NewMessageEvent) error {
+		got = e
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_new_message.json", time.Now())
+	rec := sendWebhook(h, body, signWebhook(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "7327112393057310001", got.ConversationID)
+	assert.Equal(t, "7327112393057371915", got.Message.ID)
+	assert.Equal(t, tiktok.TypeMessageText, got.Message.Type)
+	assert.JSONEq(t, `{"content":"Halo, kapan dikirim?"}`, got.Message.Content)
+	require.NotNil(t, got.Message.Sender)
+	assert.Equal(t, "BUYER", got.Message.Sender.Role)
+}
+
+func Test_WebhookProductStatusChange(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	var got *tiktok.ProductStatusChangeEvent
+	h.OnProductStatusChange(func(ctx context.Context, e *tiktok.ProductStatusChangeEvent) error {
+		got = e
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_product_status_change.json", time.Now())
+	rec := sendWebhook(h, body, signWebhook(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "1729592969712207000", got.ProductID)
+	assert.Equal(t, "FAILED", got.Status)
+	assert.Equal(t, "Image does not match the product", got.SuspendedReason)
+}
+
+func Test_WebhookFallback(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	var got *tiktok.WebhookEvent
+	h.OnEvent(func(ctx context.Context, e *tiktok.WebhookEvent) error {
+		got = e
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_seller_deauthorization.json", time.Now())
+	rec := sendWebhook(h, body, signWebhook(body))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, tiktok.WebhookSellerDeauthorization, got.Type)
+}
+
+func Test_WebhookInvalidSignature(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	called := false
+	h.OnEvent(func(ctx context.Context, e *tiktok.WebhookEvent) error {
+		called = true
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_order_status_change.json", time.Now())
+	other := tiktok.AppConfig{AppKey: webhookApp.AppKey, AppSecret: "othersecret"}
+	rec := sendWebhook(tiktok.NewWebhookHandler(other), body, signWebhook(body))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = sendWebhook(h, body, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func Test_WebhookUppercaseSignature(t *testing.T) {
+	body := webhookBody(t, "webhook_order_status_change.json", time.Now())
+	assert.True(t, tiktok.VerifyWebhookSignature(webhookApp.AppKey, webhookApp.AppSecret, body, strings.ToUpper(signWebhook(body))))
+
+	h := tiktok.NewWebhookHandler(webhookApp)
+	called := false
+	h.OnOrderStatusChange(func(ctx context.Context, e *tiktok.OrderStatusChangeEvent) error {
+		called = true
+		return nil
+	})
+	rec := sendWebhook(h, body, strings.ToUpper(signWebhook(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+
+	assert.False(t, tiktok.VerifyWebhookSignature(webhookApp.AppKey, webhookApp.AppSecret, body, "not hex"))
+}
+
+func Test_WebhookStaleTimestamp(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp, tiktok.WithWebhookTolerance(time.Minute))
+
+	called := false
+	h.OnEvent(func(ctx context.Context, e *tiktok.WebhookEvent) error {
+		called = true
+		return nil
+	})
+
+	for _, ts := range []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(2 * time.Minute)} {
+		body := webhookBody(t, "webhook_seller_deauthorization.json", ts)
+		rec := sendWebhook(h, body, signWebhook(body))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+	assert.False(t, called)
+
+	_, err := h.Parse(webhookBody(t, "webhook_seller_deauthorization.json", time.Unix(1644412885, 0)), "")
+	assert.ErrorIs(t, err, tiktok.ErrWebhookSignature)
+}
+
+func Test_WebhookReplay(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	calls := 0
+	fail := true
+	h.OnOrderStatusChange(func(ctx context.Context, e *tiktok.OrderStatusChangeEvent) error {
+		calls++
+		if fail {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_order_status_change.json", time.Now())
+	signature := signWebhook(body)
+
+	// a failed delivery is retried by TikTok and must go through again
+	rec := sendWebhook(h, body, signature)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	fail = false
+	rec = sendWebhook(h, body, signature)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = sendWebhook(h, body, signature)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func Test_WebhookConcurrentReplay(t *testing.T) {
+	h := tiktok.NewWebhookHandler(webhookApp)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	h.OnOrderStatusChange(func(ctx context.Context, e *tiktok.OrderStatusChangeEvent) error {
+		calls.Add(1)
+		<-release
+		return nil
+	})
+
+	body := webhookBody(t, "webhook_order_status_change.json", time.Now())
+	signature := signWebhook(body)
+
+	// TikTok resends while the first delivery is still in flight
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = sendWebhook(h, body, signature).Code
+		}()
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+}
@@ -0,0 +1,30 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_GetOrdersWithContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/v2/order/list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_orders_resp.json")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Order.GetOrdersWithContext(ctx, accessToken, tokopedia.GetOrdersParams{Page: 1, PerPage: 50})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Order.GetOrdersWithContext error returned %v, expected %v", err, context.Canceled)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("request count returned %d, expected 0", n)
+	}
+}
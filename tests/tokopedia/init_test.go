@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+)
+
+const (
+	maxRetries  = 3
+	fsID        = 13004
+	accessToken = "accesstoken"
+)
+
+var (
+	client *tokopedia.TokopediaClient
+	app    tokopedia.AppConfig
+)
+
+func setup() {
+	app = tokopedia.AppConfig{
+		ClientID:     "clientid",
+		ClientSecret: "clientsecret",
+		FsID:         fsID,
+		APIURL:       tokopedia.APIURL,
+	}
+	client = tokopedia.NewClient(app,
+		tokopedia.WithRetry(maxRetries))
+	httpmock.ActivateNonDefault(client.Client)
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+func loadFixture(filename string) []byte {
+	f, err := os.ReadFile("../../mockdata/tokopedia/" + filename)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot load fixture %v", filename))
+	}
+	return f
+}
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/tokopedia"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query map[string][]string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/v2/order/list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			if req.Header.Get("Authorization") != "Bearer "+accessToken {
+				return httpmock.NewStringResponse(401, `{"header":{"reason":"unauthorized"}}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_orders_resp.json")), nil
+		})
+
+	res, err := client.Order.GetOrders(accessToken, tokopedia.GetOrdersParams{
+		FromDate: 1700000000,
+		ToDate:   1700086400,
+		Page:     1,
+		PerPage:  50,
+		Status:   tokopedia.OrderStatusPaymentVerified,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"13004"}, query["fs_id"])
+	assert.Equal(t, []string{"220"}, query["status"])
+	assert.Nil(t, query["shop_id"])
+
+	require.Len(t, res.Data, 1)
+	order := res.Data[0]
+	assert.Equal(t, int64(48297285), order.OrderID)
+	assert.Equal(t, "KP-01-BL-L", order.Products[0].Sku)
+	assert.Equal(t, money.MustParse("159000", "IDR"), order.Amt.TtlAmount)
+	assert.Equal(t, "JNE", order.Logistics.ShippingAgency)
+}
+
+func Test_GetOrderRequiresID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Order.GetOrder(accessToken, tokopedia.GetOrderParams{})
+	assert.Error(t, err)
+}
+
+func Test_RejectOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/v1/order/48297285/fs/%d/nack", app.APIURL, fsID),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"header":{"messages":"Your request has been processed successfully"},"data":"success"}`), nil
+		})
+
+	res, err := client.Order.RejectOrder(accessToken, 48297285, tokopedia.RejectOrderBody{
+		ReasonCode:    tokopedia.RejectReasonOutOfStock,
+		Reason:        "stok habis",
+		EmptyProducts: []tokopedia.EmptyProduct{{ProductID: 15236812}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "success", res.Data)
+	assert.Equal(t, map[string]any{
+		"reason_code":    float64(1),
+		"reason":         "stok habis",
+		"empty_products": []any{map[string]any{"product_id": float64(15236812)}},
+	}, sent)
+}
+
+func Test_ConfirmShipping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]any
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/v1/order/48297285/fs/%d/status", app.APIURL, fsID),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"header":{},"data":"success"}`), nil
+		})
+
+	_, err := client.Order.ConfirmShipping(accessToken, 48297285, "JX1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"order_status": float64(500), "shipping_ref_num": "JX1234567890"}, sent)
+}
+
+func Test_AcceptOrderError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/v1/order/48297285/fs/%d/ack", app.APIURL, fsID),
+		httpmock.NewStringResponder(400, `{"header":{"messages":"Your request failed","reason":"order status is not valid"},"data":null}`))
+
+	_, err := client.Order.AcceptOrder(accessToken, 48297285)
+
+	var respErr tokopedia.ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, "order status is not valid", respErr.GetErrors())
+}
+
+func Test_GetShippingLabel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	label := `<html><body>KP-01-BL-L</body></html>`
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/v1/order/48297285/fs/%d/shipping-label", app.APIURL, fsID),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "1", req.URL.Query().Get("printed"))
+			resp := httpmock.NewStringResponse(200, label)
+			resp.Header.Set("Content-Type", "text/html; charset=utf-8")
+			return resp, nil
+		})
+
+	res, err := client.Logistic.GetShippingLabel(accessToken, 48297285, true)
+	require.NoError(t, err)
+	assert.Equal(t, label, string(res))
+}
+
+func Test_RequestPickup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/inventory/v1/fs/%d/pick-up", app.APIURL, fsID),
+		httpmock.NewStringResponder(200, `{"header":{},"data":{"order_id":48297285,"shop_id":479573,"request_time":"2023-11-15 10:00:00","result":"success"}}`))
+
+	res, err := client.Logistic.RequestPickup(accessToken, tokopedia.RequestPickupBody{OrderID: 48297285, ShopID: 479573})
+	require.NoError(t, err)
+	assert.Equal(t, "success", res.Data.Result)
+}
+
+func Test_WebhookOrderStatus(t *testing.T) {
+	h := tokopedia.NewWebhookHandler()
+
+	var got *tokopedia.OrderStatusEvent
+	h.OnOrderStatus(func(ctx context.Context, e *tokopedia.OrderStatusEvent) error {
+		got = e
+		return nil
+	})
+
+	body := `{"order_status":450,"fs_id":"13004","shop_id":479573,"order_id":48297285,
+		"product_details":[{"id":15236812,"Name":"Kaos Polos Biru L","quantity":2,"sku":"KP-01-BL-L"}]}`
+	rec := httptest.NewRecorder()
+	h.OrderStatus().ServeHTTP(rec, httptest.NewRequest("POST", "https://example.com/tokopedia/order-status", bytes.NewBufferString(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, tokopedia.OrderStatusWaitingPickup, got.OrderStatus)
+	assert.Equal(t, "13004", got.FsID.String())
+	assert.Equal(t, "KP-01-BL-L", got.ProductDetails[0].Sku)
+}
+
+func Test_WebhookOrderNotificationSubscriberError(t *testing.T) {
+	h := tokopedia.NewWebhookHandler()
+	h.OnOrderNotification(func(ctx context.Context, o *tokopedia.Order) error {
+		return errors.New("queue is down")
+	})
+
+	var order bytes.Buffer
+	var list tokopedia.GetOrdersResponse
+	require.NoError(t, json.Unmarshal(loadFixture("get_orders_resp.json"), &list))
+	require.NoError(t, json.NewEncoder(&order).Encode(list.Data[0]))
+
+	rec := httptest.NewRecorder()
+	h.OrderNotification().ServeHTTP(rec, httptest.NewRequest("POST", "https://example.com/tokopedia/order", &order))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/upload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type state struct {
+	ID    string         `json:"id"`
+	Parts map[int]string `json:"parts"`
+}
+
+func Test_StoresKeepACopy(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range map[string]upload.Store[state]{
+		"memory": upload.NewMemoryStore[state](),
+		"file":   upload.NewFileStore[state](t.TempDir()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			missing, err := store.Load(ctx, "video")
+			require.NoError(t, err)
+			assert.Nil(t, missing)
+
+			s := &state{ID: "u1", Parts: map[int]string{0: "etag-0"}}
+			require.NoError(t, store.Save(ctx, "video", s))
+			// the uploader goes on with its state
+			s.Parts[1] = "etag-1"
+
+			loaded, err := store.Load(ctx, "video")
+			require.NoError(t, err)
+			assert.Equal(t, &state{ID: "u1", Parts: map[int]string{0: "etag-0"}}, loaded)
+
+			require.NoError(t, store.Delete(ctx, "video"))
+			require.NoError(t, store.Delete(ctx, "video"))
+			loaded, err = store.Load(ctx, "video")
+			require.NoError(t, err)
+			assert.Nil(t, loaded)
+		})
+	}
+}
+
+func Test_ParallelStopsOnError(t *testing.T) {
+	failed := errors.New("part failed")
+	var calls, running, peak atomic.Int32
+	err := upload.Parallel(context.Background(), 2, []int{1, 2, 3, 4, 5, 6}, func(ctx context.Context, n int) error {
+		calls.Add(1)
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if now <= p || peak.CompareAndSwap(p, now) {
+				break
+			}
+		}
+		if n == 2 {
+			return failed
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(10 * time.Millisecond):
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, failed)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Less(t, calls.Load(), int32(6), "the parts left are not started")
+}
+
+func Test_RetryBacksOff(t *testing.T) {
+	var calls int
+	start := time.Now()
+	err := upload.Retry(context.Background(), 2, 5*time.Millisecond, func() error {
+		calls++
+		return errors.New("try again")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	calls = 0
+	require.NoError(t, upload.Retry(context.Background(), 2, time.Millisecond, func() error {
+		calls++
+		if calls < 2 {
+			return errors.New("try again")
+		}
+		return nil
+	}))
+	assert.Equal(t, 2, calls)
+}
@@ -1,6 +1,7 @@
 package tiktok
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -8,8 +9,11 @@ type AuthService interface {
 	GetAuthURL(serviceID string) (string, error)
 	GetLegacyAuthURL(appKey, state string) (string, error)
 	GetAccessToken(params GetAccessTokenParams) (*GetAccessTokenResponse, error)
+	GetAccessTokenWithContext(ctx context.Context, params GetAccessTokenParams) (*GetAccessTokenResponse, error)
 	GetAuthorizationShop(accessToken string, shopID string) (*GetShopsResponse, error)
+	GetAuthorizationShopWithContext(ctx context.Context, accessToken string, shopID string) (*GetShopsResponse, error)
 	GetRefreshToken(params GetRefreshTokenParams) (*GetRefreshTokenResponse, error)
+	GetRefreshTokenWithContext(ctx context.Context, params GetRefreshTokenParams) (*GetRefreshTokenResponse, error)
 }
 
 // const (
@@ -55,9 +59,13 @@ func (s *AuthServiceOp) GetLegacyAuthURL(appKey, state string) (string, error) {
 }
 
 func (s *AuthServiceOp) GetAccessToken(params GetAccessTokenParams) (*GetAccessTokenResponse, error) {
+	return s.GetAccessTokenWithContext(context.Background(), params)
+}
+
+func (s *AuthServiceOp) GetAccessTokenWithContext(ctx context.Context, params GetAccessTokenParams) (*GetAccessTokenResponse, error) {
 	path := fmt.Sprintf("%s/api/v2/token/get", LegacyAuthURL)
 	resp := new(GetAccessTokenResponse)
-	err := s.client.Get(path, resp, params)
+	err := s.client.GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
 
@@ -78,10 +86,14 @@ type DataShops struct {
 }
 
 func (s *AuthServiceOp) GetAuthorizationShop(accessToken string, shopID string) (*GetShopsResponse, error) {
+	return s.GetAuthorizationShopWithContext(context.Background(), accessToken, shopID)
+}
+
+func (s *AuthServiceOp) GetAuthorizationShopWithContext(ctx context.Context, accessToken string, shopID string) (*GetShopsResponse, error) {
 	// host https://open-api.tiktokglobalshop.com, automatically add app_key, sign, and timestamp in query param. Check func makeSignature
 	path := fmt.Sprintf("/authorization/%s/shops", s.client.appConfig.Version)
 	resp := new(GetShopsResponse)
-	err := s.client.WithShopID(shopID).WithAccessToken(accessToken).Get(path, resp, nil)
+	err := s.client.WithShopID(shopID).WithAccessToken(accessToken).GetWithContext(ctx, path, resp, nil)
 	return resp, err
 }
 
@@ -98,8 +110,12 @@ type GetRefreshTokenResponse struct {
 }
 
 func (s *AuthServiceOp) GetRefreshToken(params GetRefreshTokenParams) (*GetRefreshTokenResponse, error) {
+	return s.GetRefreshTokenWithContext(context.Background(), params)
+}
+
+func (s *AuthServiceOp) GetRefreshTokenWithContext(ctx context.Context, params GetRefreshTokenParams) (*GetRefreshTokenResponse, error) {
 	path := fmt.Sprintf("%s/api/v2/token/refresh", LegacyAuthURL)
 	resp := new(GetRefreshTokenResponse)
-	err := s.client.Get(path, resp, params)
+	err := s.client.GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
@@ -1,18 +1,30 @@
 package tiktok
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 type ChatService interface {
 	GetConversationMessages(conversationID string, param GetConversationMessagesParam) (*GetConversationMessagesResponse, error)
+	GetConversationMessagesWithContext(ctx context.Context, conversationID string, params GetConversationMessagesParam) (*GetConversationMessagesResponse, error)
 	GetConversations(params GetConversationsParam) (*GetConversationsResponse, error)
+	GetConversationsWithContext(ctx context.Context, params GetConversationsParam) (*GetConversationsResponse, error)
+	Conversations(ctx context.Context, shop CommonParamRequest, params GetConversationsParam) iter.Seq2[Conversations, error]
+	Messages(ctx context.Context, shop CommonParamRequest, conversationID string, params GetConversationMessagesParam) iter.Seq2[MessagesConversation, error]
 	SendMessageToConversationID(conversationID string, body SendMessageToConversationIDReq) (*SendMessageToConversationIDResp, error)
+	SendMessageToConversationIDWithContext(ctx context.Context, conversationID string, body SendMessageToConversationIDReq) (*SendMessageToConversationIDResp, error)
 	ReadMessageConversationID(conversationID string) (*ReadMessageConversationIDResp, error)
+	ReadMessageConversationIDWithContext(ctx context.Context, conversationID string) (*ReadMessageConversationIDResp, error)
 	CreateConversation(body CreateConversationReq) (*CreateConversationResp, error)
+	CreateConversationWithContext(ctx context.Context, body CreateConversationReq) (*CreateConversationResp, error)
 	UploadBuyerMessagesImages(filename string) (*UploadMessagesImagesResp, error)
+	UploadBuyerMessagesImagesWithContext(ctx context.Context, filename string) (*UploadMessagesImagesResp, error)
 	FileInit(body FileInitRequest) (*FileInitResp, error)
+	FileInitWithContext(ctx context.Context, body FileInitRequest) (*FileInitResp, error)
 	UploadVideo(body UploadVideoRequest) (string, error)
+	UploadVideoWithContext(ctx context.Context, body UploadVideoRequest) (string, error)
 }
 
 type ChatServiceOp struct {
@@ -52,19 +64,92 @@ type DataConversationMessages struct {
 }
 
 func (s *ChatServiceOp) GetConversationMessages(conversationID string, params GetConversationMessagesParam) (*GetConversationMessagesResponse, error) {
+	return s.GetConversationMessagesWithContext(context.Background(), conversationID, params)
+}
+
+func (s *ChatServiceOp) GetConversationMessagesWithContext(ctx context.Context, conversationID string, params GetConversationMessagesParam) (*GetConversationMessagesResponse, error) {
 	path := fmt.Sprintf("/customer_service/%s/conversations/%s/messages", s.client.appConfig.Version, conversationID)
 	resp := new(GetConversationMessagesResponse)
-	err := s.client.Get(path, resp, params)
+	err := s.client.GetWithContext(ctx, path, resp, params)
 
 	return resp, err
 }
 
+// Messages iterates over the messages of a conversation of shop, following
+// next_page_token from page to page. Every page is fetched for shop as with
+// WithShop, the shop set on the client is not used. A failed page is yielded
+// as an error and ends the iteration.
+func (s *ChatServiceOp) Messages(ctx context.Context, shop CommonParamRequest, conversationID string, params GetConversationMessagesParam) iter.Seq2[MessagesConversation, error] {
+	ctx = WithShop(ctx, shop)
+
+	return func(yield func(MessagesConversation, error) bool) {
+		// ranging again starts from params, not from the last page
+		params := params
+		for {
+			resp, err := s.GetConversationMessagesWithContext(ctx, conversationID, params)
+			if err != nil {
+				yield(MessagesConversation{}, err)
+				return
+			}
+			if resp.Data == nil {
+				return
+			}
+
+			for _, m := range resp.Data.Messages {
+				if !yield(m, nil) {
+					return
+				}
+			}
+
+			next := resp.Data.NextPageToken
+			if len(resp.Data.Messages) == 0 || next == "" || next == params.PageToken {
+				return
+			}
+			params.PageToken = next
+		}
+	}
+}
+
 type GetConversationsParam struct {
 	PageToken string `url:"page_token,omitempty"`
 	PageSize  int    `url:"page_size"`
 	Locale    string `url:"locale,omitempty"`
 }
 
+// Conversations iterates over the conversations of shop, following
+// next_page_token from page to page. Every page is fetched for shop as with
+// WithShop, the shop set on the client is not used. A failed page is yielded
+// as an error and ends the iteration.
+func (s *ChatServiceOp) Conversations(ctx context.Context, shop CommonParamRequest, params GetConversationsParam) iter.Seq2[Conversations, error] {
+	ctx = WithShop(ctx, shop)
+
+	return func(yield func(Conversations, error) bool) {
+		params := params
+		for {
+			resp, err := s.GetConversationsWithContext(ctx, params)
+			if err != nil {
+				yield(Conversations{}, err)
+				return
+			}
+			if resp.Data == nil {
+				return
+			}
+
+			for _, c := range resp.Data.Conversations {
+				if !yield(c, nil) {
+					return
+				}
+			}
+
+			next := resp.Data.NextPageToken
+			if len(resp.Data.Conversations) == 0 || next == "" || next == params.PageToken {
+				return
+			}
+			params.PageToken = next
+		}
+	}
+}
+
 type GetConversationsResponse struct {
 	BaseResponse
 	Data *DataGetConversations `json:"data"`
@@ -101,10 +186,14 @@ type DataGetConversations struct {
 }
 
 func (s *ChatServiceOp) GetConversations(params GetConversationsParam) (*GetConversationsResponse, error) {
+	return s.GetConversationsWithContext(context.Background(), params)
+}
+
+func (s *ChatServiceOp) GetConversationsWithContext(ctx context.Context, params GetConversationsParam) (*GetConversationsResponse, error) {
 	path := fmt.Sprintf("/customer_service/%s/conversations", s.client.appConfig.Version)
 	resp := new(GetConversationsResponse)
 	err := s.client.
-		Get(path, resp, params)
+		GetWithContext(ctx, path, resp, params)
 
 	return resp, err
 }
@@ -141,10 +230,14 @@ type DataSendMsgResponse struct {
 }
 
 func (s *ChatServiceOp) SendMessageToConversationID(conversationID string, body SendMessageToConversationIDReq) (*SendMessageToConversationIDResp, error) {
+	return s.SendMessageToConversationIDWithContext(context.Background(), conversationID, body)
+}
+
+func (s *ChatServiceOp) SendMessageToConversationIDWithContext(ctx context.Context, conversationID string, body SendMessageToConversationIDReq) (*SendMessageToConversationIDResp, error) {
 	path := fmt.Sprintf("/customer_service/%s/conversations/%s/messages", s.client.appConfig.Version, conversationID)
 
 	resp := new(SendMessageToConversationIDResp)
-	err := s.client.Post(path, body, resp)
+	err := s.client.PostWithContext(ctx, path, body, resp)
 	return resp, err
 }
 
@@ -154,9 +247,13 @@ type ReadMessageConversationIDResp struct {
 }
 
 func (s *ChatServiceOp) ReadMessageConversationID(conversationID string) (*ReadMessageConversationIDResp, error) {
+	return s.ReadMessageConversationIDWithContext(context.Background(), conversationID)
+}
+
+func (s *ChatServiceOp) ReadMessageConversationIDWithContext(ctx context.Context, conversationID string) (*ReadMessageConversationIDResp, error) {
 	path := fmt.Sprintf("/customer_service/%s/conversations/%s/messages/read", s.client.appConfig.Version, conversationID)
 	resp := new(ReadMessageConversationIDResp)
-	err := s.client.Post(path, nil, resp)
+	err := s.client.PostWithContext(ctx, path, nil, resp)
 	return resp, err
 }
 
@@ -172,9 +269,13 @@ type DataUploadMessagesImages struct {
 }
 
 func (s *ChatServiceOp) UploadBuyerMessagesImages(filename string) (*UploadMessagesImagesResp, error) {
+	return s.UploadBuyerMessagesImagesWithContext(context.Background(), filename)
+}
+
+func (s *ChatServiceOp) UploadBuyerMessagesImagesWithContext(ctx context.Context, filename string) (*UploadMessagesImagesResp, error) {
 	path := fmt.Sprintf("/customer_service/%s/images/upload", s.client.appConfig.Version)
 	resp := new(UploadMessagesImagesResp)
-	err := s.client.Upload(path, "data", filename, resp)
+	err := s.client.UploadWithContext(ctx, path, "data", filename, resp)
 	return resp, err
 }
 
@@ -192,9 +293,13 @@ type DataCreateConversationResp struct {
 }
 
 func (s *ChatServiceOp) CreateConversation(body CreateConversationReq) (*CreateConversationResp, error) {
+	return s.CreateConversationWithContext(context.Background(), body)
+}
+
+func (s *ChatServiceOp) CreateConversationWithContext(ctx context.Context, body CreateConversationReq) (*CreateConversationResp, error) {
 	path := fmt.Sprintf("/customer_service/%s/conversations", s.client.appConfig.Version)
 	resp := new(CreateConversationResp)
-	err := s.client.Post(path, body, resp)
+	err := s.client.PostWithContext(ctx, path, body, resp)
 	return resp, err
 }
 
@@ -207,11 +312,15 @@ type FileInitRequest struct {
 }
 
 func (s *ChatServiceOp) FileInit(body FileInitRequest) (*FileInitResp, error) {
+	return s.FileInitWithContext(context.Background(), body)
+}
+
+func (s *ChatServiceOp) FileInitWithContext(ctx context.Context, body FileInitRequest) (*FileInitResp, error) {
 	path := "/open/202512/file/init"
 	resp := new(FileInitResp)
 	countChunk := CalcChunkCount(int64(body.FileSize))
 	body.TotalChunkCount = countChunk
-	err := s.client.Post(path, body, resp)
+	err := s.client.PostWithContext(ctx, path, body, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -233,13 +342,22 @@ type UploadVideoRequest struct {
 	UploadURL   string `json:"upload_url"`
 	ChunkNum    int    `json:"chunk_num"`
 	FileBytes   []byte `json:"file_bytes"`
+	// ContentType of the video, see RequestUploadFile.
+	ContentType string `json:"content_type,omitempty"`
 }
 
+// UploadVideo uploads one chunk of a video initialized with FileInit, see
+// VideoUploader to upload a whole video.
 func (s *ChatServiceOp) UploadVideo(body UploadVideoRequest) (string, error) {
-	resp, err := s.client.UploadFile(body.UploadURL, RequestUploadFile{
+	return s.UploadVideoWithContext(context.Background(), body)
+}
+
+func (s *ChatServiceOp) UploadVideoWithContext(ctx context.Context, body UploadVideoRequest) (string, error) {
+	resp, err := s.client.UploadFileWithContext(ctx, body.UploadURL, RequestUploadFile{
 		UploadToken: body.UploadToken,
 		ChunkNum:    body.ChunkNum,
 		FileBytes:   body.FileBytes,
+		ContentType: body.ContentType,
 	})
 	if err != nil {
 		return "", err
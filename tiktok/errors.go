@@ -0,0 +1,48 @@
+package tiktok
+
+import (
+	"strconv"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+)
+
+// Codes categorizes the error codes of TikTok Shop, see apierror.
+var Codes = apierror.Codes{
+	"105001":   apierror.ErrTokenExpired, // invalid access token
+	"105002":   apierror.ErrTokenExpired, // expired access token
+	"106001":   apierror.ErrUnauthorized, // invalid sign
+	"106002":   apierror.ErrUnauthorized, // invalid app key
+	"36009003": apierror.ErrInvalidParam,
+	"36009004": apierror.ErrRateLimited,
+	"36009005": apierror.ErrUnavailable,
+}
+
+var _ apierror.Error = ResponseError{}
+
+// GetMarketplace returns apierror.Tiktok
+func (e ResponseError) GetMarketplace() apierror.Marketplace {
+	return apierror.Tiktok
+}
+
+// GetCode returns the error code, empty for none.
+func (e ResponseError) GetCode() string {
+	if e.Code == 0 {
+		return ""
+	}
+	return strconv.Itoa(e.Code)
+}
+
+// GetRequestID returns the request id of the call
+func (e ResponseError) GetRequestID() string {
+	return e.RequestID
+}
+
+// Retryable tells whether the call may succeed later
+func (e ResponseError) Retryable() bool {
+	return Codes.Retryable(e.Status, e.GetCode())
+}
+
+// Is matches the apierror category of e.
+func (e ResponseError) Is(target error) bool {
+	return Codes.Match(e.Status, e.GetCode(), target)
+}
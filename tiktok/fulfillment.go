@@ -1,9 +1,21 @@
 package tiktok
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type FulfillmentService interface {
 	GetTracking(orderID string) (*GetTrackingResponse, error)
+	GetTrackingWithContext(ctx context.Context, orderID string) (*GetTrackingResponse, error)
+	GetPackageDetail(packageID string) (*GetPackageDetailResponse, error)
+	GetPackageDetailWithContext(ctx context.Context, packageID string) (*GetPackageDetailResponse, error)
+	ShipPackage(packageID string, body ShipPackageRequest) (*ShipPackageResponse, error)
+	ShipPackageWithContext(ctx context.Context, packageID string, body ShipPackageRequest) (*ShipPackageResponse, error)
+	GetShippingDocuments(packageID string, params GetShippingDocumentsParams) (*GetShippingDocumentsResponse, error)
+	GetShippingDocumentsWithContext(ctx context.Context, packageID string, params GetShippingDocumentsParams) (*GetShippingDocumentsResponse, error)
+	CombinePackages(body CombinePackagesRequest) (*CombinePackagesResponse, error)
+	CombinePackagesWithContext(ctx context.Context, body CombinePackagesRequest) (*CombinePackagesResponse, error)
 }
 
 type FulfillmentServiceOp struct {
@@ -29,10 +41,140 @@ type TrackingData struct {
 
 // /fulfillment/202309/orders/{order_id}/tracking
 func (p *FulfillmentServiceOp) GetTracking(orderID string) (*GetTrackingResponse, error) {
+	return p.GetTrackingWithContext(context.Background(), orderID)
+}
+
+func (p *FulfillmentServiceOp) GetTrackingWithContext(ctx context.Context, orderID string) (*GetTrackingResponse, error) {
 	path := fmt.Sprintf("/fulfillment/%s/orders/%s/tracking", p.client.appConfig.Version, orderID)
 
 	resp := new(GetTrackingResponse)
-	err := p.client.Get(path, resp, nil)
+	err := p.client.GetWithContext(ctx, path, resp, nil)
+
+	return resp, err
+}
+
+type GetPackageDetailResponse struct {
+	BaseResponse
+	Data Package `json:"data"`
+}
+
+// /fulfillment/202309/packages/{package_id}
+func (p *FulfillmentServiceOp) GetPackageDetail(packageID string) (*GetPackageDetailResponse, error) {
+	return p.GetPackageDetailWithContext(context.Background(), packageID)
+}
+
+func (p *FulfillmentServiceOp) GetPackageDetailWithContext(ctx context.Context, packageID string) (*GetPackageDetailResponse, error) {
+	path := fmt.Sprintf("/fulfillment/%s/packages/%s", p.client.appConfig.Version, packageID)
+
+	resp := new(GetPackageDetailResponse)
+	err := p.client.GetWithContext(ctx, path, resp, nil)
+
+	return resp, err
+}
+
+// ShipPackageRequest ships a package with the TikTok shipping provider, set
+// HandoverMethod and PickupSlot, or with the seller's own provider, set
+// SelfShipment.
+type ShipPackageRequest struct {
+	HandoverMethod string        `json:"handover_method,omitempty"` // PICKUP or DROP_OFF
+	PickupSlot     *PickupSlot   `json:"pickup_slot,omitempty"`
+	SelfShipment   *SelfShipment `json:"self_shipment,omitempty"`
+}
+
+type PickupSlot struct {
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+type SelfShipment struct {
+	TrackingNumber     string `json:"tracking_number"`
+	ShippingProviderID string `json:"shipping_provider_id"`
+}
+
+type ShipPackageResponse struct {
+	BaseResponse
+}
+
+// /fulfillment/202309/packages/{package_id}/ship
+func (p *FulfillmentServiceOp) ShipPackage(packageID string, body ShipPackageRequest) (*ShipPackageResponse, error) {
+	return p.ShipPackageWithContext(context.Background(), packageID, body)
+}
+
+func (p *FulfillmentServiceOp) ShipPackageWithContext(ctx context.Context, packageID string, body ShipPackageRequest) (*ShipPackageResponse, error) {
+	path := fmt.Sprintf("/fulfillment/%s/packages/%s/ship", p.client.appConfig.Version, packageID)
+
+	resp := new(ShipPackageResponse)
+	err := p.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+type GetShippingDocumentsParams struct {
+	DocumentType   string `url:"document_type"`             // SHIPPING_LABEL, PACKING_SLIP or SHIPPING_LABEL_AND_PACKING_SLIP
+	DocumentSize   string `url:"document_size,omitempty"`   // A6 or A5
+	DocumentFormat string `url:"document_format,omitempty"` // PDF or ZPL
+}
+
+type GetShippingDocumentsResponse struct {
+	BaseResponse
+	Data ShippingDocument `json:"data"`
+}
+
+type ShippingDocument struct {
+	DocURL         string `json:"doc_url"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+// /fulfillment/202309/packages/{package_id}/shipping_documents, the label is
+// downloaded from DocURL.
+func (p *FulfillmentServiceOp) GetShippingDocuments(packageID string, params GetShippingDocumentsParams) (*GetShippingDocumentsResponse, error) {
+	return p.GetShippingDocumentsWithContext(context.Background(), packageID, params)
+}
+
+func (p *FulfillmentServiceOp) GetShippingDocumentsWithContext(ctx context.Context, packageID string, params GetShippingDocumentsParams) (*GetShippingDocumentsResponse, error) {
+	path := fmt.Sprintf("/fulfillment/%s/packages/%s/shipping_documents", p.client.appConfig.Version, packageID)
+
+	resp := new(GetShippingDocumentsResponse)
+	err := p.client.GetWithContext(ctx, path, resp, params)
+
+	return resp, err
+}
+
+type CombinePackagesRequest struct {
+	CombinablePackages []CombinablePackage `json:"combinable_packages"`
+}
+
+type CombinablePackage struct {
+	ID       string   `json:"id"`
+	OrderIDs []string `json:"order_ids"`
+}
+
+type CombinePackagesResponse struct {
+	BaseResponse
+	Data struct {
+		Packages []CombinablePackage `json:"packages"`
+		Errors   []CombineError      `json:"errors"`
+	} `json:"data"`
+}
+
+type CombineError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Detail  struct {
+		PackageID string `json:"package_id"`
+	} `json:"detail"`
+}
+
+// /fulfillment/202309/packages/combine
+func (p *FulfillmentServiceOp) CombinePackages(body CombinePackagesRequest) (*CombinePackagesResponse, error) {
+	return p.CombinePackagesWithContext(context.Background(), body)
+}
+
+func (p *FulfillmentServiceOp) CombinePackagesWithContext(ctx context.Context, body CombinePackagesRequest) (*CombinePackagesResponse, error) {
+	path := fmt.Sprintf("/fulfillment/%s/packages/combine", p.client.appConfig.Version)
+
+	resp := new(CombinePackagesResponse)
+	err := p.client.PostWithContext(ctx, path, body, resp)
 
 	return resp, err
 }
@@ -1,14 +1,108 @@
 package tiktok
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 )
 
+// codeTooManyRequests is the error code of throttled TikTok Shop calls.
+const codeTooManyRequests = 36009004
+
 // Option is used to configure client with options
 type Option func(c *TiktokClient)
 
+// TokenSource returns the access token of a shop, shopID is the shop cipher
+// as in auth.Token.
+type TokenSource interface {
+	AccessToken(ctx context.Context, shopID string) (string, error)
+}
+
+// WithTokenSource makes calls with a shop but no access token fetch it from
+// src, so callers only need to set the shop cipher. A call with a shop id but
+// no cipher fails.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *TiktokClient) {
+		c.tokenSource = src
+	}
+}
+
+type shopKeyType struct{}
+
+// WithShop returns a copy of ctx carrying the shop a call is made for. Calls
+// with such a context take the shop cipher, shop id and access token from it
+// instead of from the client, and leave the client alone, so one client can
+// serve several shops at once.
+func WithShop(ctx context.Context, shop CommonParamRequest) context.Context {
+	return context.WithValue(ctx, shopKeyType{}, shop)
+}
+
+// ShopFromContext returns the shop set with WithShop.
+func ShopFromContext(ctx context.Context) (CommonParamRequest, bool) {
+	shop, ok := ctx.Value(shopKeyType{}).(CommonParamRequest)
+	return shop, ok
+}
+
+// WithMiddleware routes requests through a middleware.Transport, retrying
+// TikTok throttling errors and rate limiting by app and shop. It wraps the
+// transport set by the other options whatever their order, and turns
+// WithRetry off.
+func WithMiddleware(opts ...middleware.Option) Option {
+	return func(c *TiktokClient) {
+		c.middleware = append([]middleware.Option{
+			middleware.WithRetryable(Retryable),
+			middleware.WithShopKey(shopKey),
+		}, opts...)
+	}
+}
+
+// WithRecorder records the calls of the client to r or replays them, see cassette.
+func WithRecorder(r *cassette.Recorder) Option {
+	return func(c *TiktokClient) {
+		c.recorder = r
+	}
+}
+
+// WithTelemetry traces and measures every call with OpenTelemetry, see telemetry.
+func WithTelemetry(opts ...telemetry.Option) Option {
+	return func(c *TiktokClient) {
+		c.telemetry = append([]telemetry.Option{}, opts...)
+	}
+}
+
+// Retryable is the middleware.Retryable of TikTok Shop, it also retries the
+// throttling error code whatever the status it comes with.
+func Retryable(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err == nil {
+		var body struct {
+			Code int `json:"code"`
+		}
+		if json.Unmarshal(middleware.PeekBody(resp), &body) == nil && body.Code == codeTooManyRequests {
+			return true, middleware.RetryAfter(resp.Header, "Retry-After")
+		}
+	}
+	return middleware.DefaultRetryable(req, resp, err)
+}
+
+// shopKey picks the rate limit bucket of a request by its shop cipher, or
+// its shop id for APIs called without one.
+func shopKey(req *http.Request) string {
+	q := req.URL.Query()
+	if cipher := q.Get("shop_cipher"); cipher != "" {
+		return cipher
+	}
+	return q.Get("shop_id")
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *TiktokClient) {
 		c.log = logger
@@ -36,3 +130,31 @@ func WithTimeout(timeout int) Option {
 		c.Client.Timeout = time.Duration(timeout) * time.Second
 	}
 }
+
+// telemetryMarketplace describes the calls of TikTok Shop to the telemetry
+// transport.
+var telemetryMarketplace = telemetry.Marketplace{
+	Name:    string(apierror.Tiktok),
+	ShopKey: shopKey,
+	Route:   route,
+	Describe: func(resp *http.Response) (string, string) {
+		var body BaseResponse
+		json.Unmarshal(middleware.PeekBody(resp), &body)
+		if body.Code == 0 {
+			return "", body.RequestID
+		}
+		return strconv.Itoa(body.Code), body.RequestID
+	},
+}
+
+// route replaces the ids in a path, e.g. /product/202309/products/{id}. The
+// segments after the version that are not lowercase words are ids.
+func route(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 3; i < len(segments); i++ {
+		if strings.Trim(segments[i], "abcdefghijklmnopqrstuvwxyz_") != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
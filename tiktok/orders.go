@@ -1,11 +1,18 @@
 package tiktok
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
 )
 
 type OrderService interface {
 	GetOrder(params GetOrderParams) (*GetOrderResponse, error)
+	GetOrderWithContext(ctx context.Context, params GetOrderParams) (*GetOrderResponse, error)
+	SearchOrders(params SearchOrdersParams, body SearchOrdersBody) (*SearchOrdersResponse, error)
+	SearchOrdersWithContext(ctx context.Context, params SearchOrdersParams, body SearchOrdersBody) (*SearchOrdersResponse, error)
 }
 
 type OrderServiceOp struct {
@@ -84,16 +91,16 @@ type LineItem struct {
 	ID                   string                `json:"id"`
 	IsGift               bool                  `json:"is_gift"`
 	ItemTax              []ItemTax             `json:"item_tax"`
-	OriginalPrice        string                `json:"original_price"`
+	OriginalPrice        money.Money           `json:"original_price"`
 	PackageID            string                `json:"package_id"`
 	PackageStatus        string                `json:"package_status"`
-	PlatformDiscount     string                `json:"platform_discount"`
+	PlatformDiscount     money.Money           `json:"platform_discount"`
 	ProductID            string                `json:"product_id"`
 	ProductName          string                `json:"product_name"`
-	RetailDeliveryFee    string                `json:"retail_delivery_fee"`
+	RetailDeliveryFee    money.Money           `json:"retail_delivery_fee"`
 	RTSTime              int64                 `json:"rts_time"`
-	SalePrice            string                `json:"sale_price"`
-	SellerDiscount       string                `json:"seller_discount"`
+	SalePrice            money.Money           `json:"sale_price"`
+	SellerDiscount       money.Money           `json:"seller_discount"`
 	SellerSku            string                `json:"seller_sku"`
 	ShippingProviderID   string                `json:"shipping_provider_id"`
 	ShippingProviderName string                `json:"shipping_provider_name"`
@@ -101,10 +108,24 @@ type LineItem struct {
 	SkuImage             string                `json:"sku_image"`
 	SkuName              string                `json:"sku_name"`
 	SkuType              string                `json:"sku_type"`
-	SmallOrderFee        string                `json:"small_order_fee"`
+	SmallOrderFee        money.Money           `json:"small_order_fee"`
 	TrackingNumber       string                `json:"tracking_number"`
 }
 
+func (l *LineItem) UnmarshalJSON(b []byte) error {
+	type lineItem LineItem
+	if err := json.Unmarshal(b, (*lineItem)(l)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(l.Currency, &l.OriginalPrice, &l.PlatformDiscount, &l.RetailDeliveryFee,
+		&l.SalePrice, &l.SellerDiscount, &l.SmallOrderFee)
+	for i := range l.ItemTax {
+		money.FillCurrency(l.Currency, &l.ItemTax[i].TaxAmount)
+	}
+	return nil
+}
+
 type CombinedListingSkus struct {
 	ProductID string `json:"product_id"`
 	SkuCount  int64  `json:"sku_count"`
@@ -112,31 +133,68 @@ type CombinedListingSkus struct {
 }
 
 type ItemTax struct {
-	TaxAmount string `json:"tax_amount"`
-	TaxRate   string `json:"tax_rate"`
-	TaxType   string `json:"tax_type"`
+	TaxAmount money.Money `json:"tax_amount"`
+	TaxRate   string      `json:"tax_rate"`
+	TaxType   string      `json:"tax_type"`
 }
 
+// Package is a package of an order, the order only carries its ID, the other
+// fields are returned by GetPackageDetail.
 type Package struct {
-	ID string `json:"id"`
+	ID                   string         `json:"id"`
+	PackageStatus        string         `json:"package_status"`
+	ShippingType         string         `json:"shipping_type"`
+	ShippingProviderID   string         `json:"shipping_provider_id"`
+	ShippingProviderName string         `json:"shipping_provider_name"`
+	TrackingNumber       string         `json:"tracking_number"`
+	HandoverMethod       string         `json:"handover_method"`
+	OrderLineItemIDs     []string       `json:"order_line_item_ids"`
+	Orders               []PackageOrder `json:"orders"`
+	CreateTime           int64          `json:"create_time"`
+	UpdateTime           int64          `json:"update_time"`
+}
+
+type PackageOrder struct {
+	ID   string       `json:"id"`
+	Skus []PackageSku `json:"skus"`
+}
+
+type PackageSku struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ImageURL string `json:"image_url"`
+	Quantity int    `json:"quantity"`
 }
 
 type Payment struct {
-	Currency                    string `json:"currency"`
-	OriginalShippingFee         string `json:"original_shipping_fee"`
-	OriginalTotalProductPrice   string `json:"original_total_product_price"`
-	PlatformDiscount            string `json:"platform_discount"`
-	ProductTax                  string `json:"product_tax"`
-	RetailDeliveryFee           string `json:"retail_delivery_fee"`
-	SellerDiscount              string `json:"seller_discount"`
-	ShippingFee                 string `json:"shipping_fee"`
-	ShippingFeePlatformDiscount string `json:"shipping_fee_platform_discount"`
-	ShippingFeeSellerDiscount   string `json:"shipping_fee_seller_discount"`
-	ShippingFeeTax              string `json:"shipping_fee_tax"`
-	SmallOrderFee               string `json:"small_order_fee"`
-	SubTotal                    string `json:"sub_total"`
-	Tax                         string `json:"tax"`
-	TotalAmount                 string `json:"total_amount"`
+	Currency                    string      `json:"currency"`
+	OriginalShippingFee         money.Money `json:"original_shipping_fee"`
+	OriginalTotalProductPrice   money.Money `json:"original_total_product_price"`
+	PlatformDiscount            money.Money `json:"platform_discount"`
+	ProductTax                  money.Money `json:"product_tax"`
+	RetailDeliveryFee           money.Money `json:"retail_delivery_fee"`
+	SellerDiscount              money.Money `json:"seller_discount"`
+	ShippingFee                 money.Money `json:"shipping_fee"`
+	ShippingFeePlatformDiscount money.Money `json:"shipping_fee_platform_discount"`
+	ShippingFeeSellerDiscount   money.Money `json:"shipping_fee_seller_discount"`
+	ShippingFeeTax              money.Money `json:"shipping_fee_tax"`
+	SmallOrderFee               money.Money `json:"small_order_fee"`
+	SubTotal                    money.Money `json:"sub_total"`
+	Tax                         money.Money `json:"tax"`
+	TotalAmount                 money.Money `json:"total_amount"`
+}
+
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	type payment Payment
+	if err := json.Unmarshal(b, (*payment)(p)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(p.Currency, &p.OriginalShippingFee, &p.OriginalTotalProductPrice,
+		&p.PlatformDiscount, &p.ProductTax, &p.RetailDeliveryFee, &p.SellerDiscount,
+		&p.ShippingFee, &p.ShippingFeePlatformDiscount, &p.ShippingFeeSellerDiscount,
+		&p.ShippingFeeTax, &p.SmallOrderFee, &p.SubTotal, &p.Tax, &p.TotalAmount)
+	return nil
 }
 
 type RecipientAddress struct {
@@ -165,10 +223,58 @@ type DistrictInfo struct {
 }
 
 func (s *OrderServiceOp) GetOrder(params GetOrderParams) (*GetOrderResponse, error) {
+	return s.GetOrderWithContext(context.Background(), params)
+}
+
+func (s *OrderServiceOp) GetOrderWithContext(ctx context.Context, params GetOrderParams) (*GetOrderResponse, error) {
 	path := fmt.Sprintf("/order/%s/orders", s.client.appConfig.Version)
 
 	resp := new(GetOrderResponse)
-	err := s.client.Get(path, resp, params)
+	err := s.client.GetWithContext(ctx, path, resp, params)
+
+	return resp, err
+}
+
+type SearchOrdersParams struct {
+	PageSize  int    `url:"page_size"`
+	PageToken string `url:"page_token,omitempty"`
+	SortField string `url:"sort_field,omitempty"` // create_time or update_time
+	SortOrder string `url:"sort_order,omitempty"` // ASC or DESC
+}
+
+// SearchOrdersBody filters the orders, times are unix seconds.
+type SearchOrdersBody struct {
+	OrderStatus          string   `json:"order_status,omitempty"`
+	CreateTimeGe         int64    `json:"create_time_ge,omitempty"`
+	CreateTimeLt         int64    `json:"create_time_lt,omitempty"`
+	UpdateTimeGe         int64    `json:"update_time_ge,omitempty"`
+	UpdateTimeLt         int64    `json:"update_time_lt,omitempty"`
+	ShippingType         string   `json:"shipping_type,omitempty"`
+	BuyerUserID          string   `json:"buyer_user_id,omitempty"`
+	IsBuyerRequestCancel *bool    `json:"is_buyer_request_cancel,omitempty"`
+	WarehouseIDs         []string `json:"warehouse_ids,omitempty"`
+}
+
+type SearchOrdersResponse struct {
+	BaseResponse
+	Data SearchOrdersData `json:"data"`
+}
+
+type SearchOrdersData struct {
+	NextPageToken string  `json:"next_page_token"`
+	TotalCount    int     `json:"total_count"`
+	Orders        []Order `json:"orders"`
+}
+
+func (s *OrderServiceOp) SearchOrders(params SearchOrdersParams, body SearchOrdersBody) (*SearchOrdersResponse, error) {
+	return s.SearchOrdersWithContext(context.Background(), params, body)
+}
+
+func (s *OrderServiceOp) SearchOrdersWithContext(ctx context.Context, params SearchOrdersParams, body SearchOrdersBody) (*SearchOrdersResponse, error) {
+	path := fmt.Sprintf("/order/%s/orders/search", s.client.appConfig.Version)
+
+	resp := new(SearchOrdersResponse)
+	err := s.client.CreateAndDoWithContext(ctx, "POST", path, body, params, nil, resp)
 
 	return resp, err
 }
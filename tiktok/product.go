@@ -1,9 +1,36 @@
 package tiktok
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
 
 type ProductService interface {
 	GetProductInfo(productID string) (*GetProductInfoResponse, error)
+	GetProductInfoWithContext(ctx context.Context, productID string) (*GetProductInfoResponse, error)
+	SearchProducts(params SearchProductsParams, body SearchProductsBody) (*SearchProductsResponse, error)
+	SearchProductsWithContext(ctx context.Context, params SearchProductsParams, body SearchProductsBody) (*SearchProductsResponse, error)
+	CreateProduct(body ProductRequest) (*ProductWriteResponse, error)
+	CreateProductWithContext(ctx context.Context, body ProductRequest) (*ProductWriteResponse, error)
+	EditProduct(productID string, body ProductRequest) (*ProductWriteResponse, error)
+	EditProductWithContext(ctx context.Context, productID string, body ProductRequest) (*ProductWriteResponse, error)
+	UpdateInventory(productID string, body UpdateInventoryRequest) (*UpdateSkusResponse, error)
+	UpdateInventoryWithContext(ctx context.Context, productID string, body UpdateInventoryRequest) (*UpdateSkusResponse, error)
+	UpdatePrice(productID string, body UpdatePriceRequest) (*UpdateSkusResponse, error)
+	UpdatePriceWithContext(ctx context.Context, productID string, body UpdatePriceRequest) (*UpdateSkusResponse, error)
+	ActivateProducts(productIDs []string) (*ProductStatusResponse, error)
+	ActivateProductsWithContext(ctx context.Context, productIDs []string) (*ProductStatusResponse, error)
+	DeactivateProducts(productIDs []string) (*ProductStatusResponse, error)
+	DeactivateProductsWithContext(ctx context.Context, productIDs []string) (*ProductStatusResponse, error)
+	GetCategories(params GetCategoriesParams) (*GetCategoriesResponse, error)
+	GetCategoriesWithContext(ctx context.Context, params GetCategoriesParams) (*GetCategoriesResponse, error)
+	GetAttributes(categoryID string) (*GetAttributesResponse, error)
+	GetAttributesWithContext(ctx context.Context, categoryID string) (*GetAttributesResponse, error)
+	UploadImage(filename string) (*UploadProductImageResponse, error)
+	UploadImageWithContext(ctx context.Context, filename string) (*UploadProductImageResponse, error)
 }
 
 type ProductServiceOp struct {
@@ -48,12 +75,14 @@ type CategoryChain struct {
 	ParentID  string `json:"parent_id"`
 }
 
+// MainImage is an image of a product, writes only send its URI, see
+// UploadImage.
 type MainImage struct {
-	Height    int64    `json:"height"`
-	ThumbUrls []string `json:"thumb_urls"`
+	Height    int64    `json:"height,omitempty"`
+	ThumbUrls []string `json:"thumb_urls,omitempty"`
 	URI       string   `json:"uri"`
-	Urls      []string `json:"urls"`
-	Width     int64    `json:"width"`
+	Urls      []string `json:"urls,omitempty"`
+	Width     int64    `json:"width,omitempty"`
 }
 
 type PackageDimensions struct {
@@ -75,34 +104,386 @@ type ProductAttribute struct {
 }
 
 type Skus struct {
-	ID              string         `json:"id"`
-	IdentifierCode  IdentifierCode `json:"identifier_code"`
-	Inventory       []Inventory    `json:"inventory"`
-	Price           *Price         `json:"price"`
-	SalesAttributes []interface{}  `json:"sales_attributes"`
-	SellerSku       string         `json:"seller_sku"`
+	ID              string           `json:"id,omitempty"`
+	IdentifierCode  *IdentifierCode  `json:"identifier_code,omitempty"`
+	Inventory       []Inventory      `json:"inventory,omitempty"`
+	Price           *Price           `json:"price,omitempty"`
+	SalesAttributes []SalesAttribute `json:"sales_attributes,omitempty"`
+	SellerSku       string           `json:"seller_sku,omitempty"`
 }
 
 type IdentifierCode struct {
+	Code string `json:"code,omitempty"`
 	Type string `json:"type"`
 }
 
+// SalesAttribute is a variation of a sku, e.g. a color. Writes identify it by
+// ID and ValueID, or name a new one by Name and ValueName.
+type SalesAttribute struct {
+	ID        string     `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	ValueID   string     `json:"value_id,omitempty"`
+	ValueName string     `json:"value_name,omitempty"`
+	SkuImg    *MainImage `json:"sku_img,omitempty"`
+}
+
 type Inventory struct {
 	Quantity    int64  `json:"quantity"`
 	WarehouseID string `json:"warehouse_id"`
 }
 
+// Price is read as SalePrice and TaxExclusivePrice and written as Amount.
 type Price struct {
-	Currency          string `json:"currency"`
-	SalePrice         string `json:"sale_price"`
-	TaxExclusivePrice string `json:"tax_exclusive_price"`
+	Amount            string      `json:"amount,omitempty"`
+	Currency          string      `json:"currency"`
+	SalePrice         money.Money `json:"sale_price,omitzero"`
+	TaxExclusivePrice money.Money `json:"tax_exclusive_price,omitzero"`
+}
+
+func (p *Price) UnmarshalJSON(b []byte) error {
+	type price Price
+	if err := json.Unmarshal(b, (*price)(p)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(p.Currency, &p.SalePrice, &p.TaxExclusivePrice)
+	return nil
 }
 
 func (p *ProductServiceOp) GetProductInfo(productID string) (*GetProductInfoResponse, error) {
+	return p.GetProductInfoWithContext(context.Background(), productID)
+}
+
+func (p *ProductServiceOp) GetProductInfoWithContext(ctx context.Context, productID string) (*GetProductInfoResponse, error) {
 	path := fmt.Sprintf("/product/%s/products/%s", p.client.appConfig.Version, productID)
 
 	resp := new(GetProductInfoResponse)
-	err := p.client.Get(path, resp, nil)
+	err := p.client.GetWithContext(ctx, path, resp, nil)
+
+	return resp, err
+}
+
+type SearchProductsParams struct {
+	PageSize  int    `url:"page_size"`
+	PageToken string `url:"page_token,omitempty"`
+}
+
+type SearchProductsBody struct {
+	Status       string   `json:"status,omitempty"` // e.g. ACTIVATE, DRAFT, SELLER_DEACTIVATED
+	SellerSkus   []string `json:"seller_skus,omitempty"`
+	CreateTimeGe int64    `json:"create_time_ge,omitempty"`
+	CreateTimeLe int64    `json:"create_time_le,omitempty"`
+	UpdateTimeGe int64    `json:"update_time_ge,omitempty"`
+	UpdateTimeLe int64    `json:"update_time_le,omitempty"`
+}
+
+type SearchProductsResponse struct {
+	BaseResponse
+	Data struct {
+		NextPageToken string        `json:"next_page_token"`
+		TotalCount    int           `json:"total_count"`
+		Products      []ProductData `json:"products"`
+	} `json:"data"`
+}
+
+// /product/202309/products/search
+func (p *ProductServiceOp) SearchProducts(params SearchProductsParams, body SearchProductsBody) (*SearchProductsResponse, error) {
+	return p.SearchProductsWithContext(context.Background(), params, body)
+}
+
+func (p *ProductServiceOp) SearchProductsWithContext(ctx context.Context, params SearchProductsParams, body SearchProductsBody) (*SearchProductsResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/search", p.client.appConfig.Version)
+
+	resp := new(SearchProductsResponse)
+	err := p.client.CreateAndDoWithContext(ctx, "POST", path, body, params, nil, resp)
+
+	return resp, err
+}
+
+// ProductRequest is the body of CreateProduct and EditProduct, an edit
+// replaces the whole product.
+type ProductRequest struct {
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	CategoryID        string             `json:"category_id"`
+	BrandID           string             `json:"brand_id,omitempty"`
+	MainImages        []MainImage        `json:"main_images"`
+	Skus              []Skus             `json:"skus"`
+	PackageWeight     *PackageWeight     `json:"package_weight,omitempty"`
+	PackageDimensions *PackageDimensions `json:"package_dimensions,omitempty"`
+	ProductAttributes []ProductAttribute `json:"product_attributes,omitempty"`
+	IsCodAllowed      bool               `json:"is_cod_allowed,omitempty"`
+	SaveMode          string             `json:"save_mode,omitempty"` // AS_DRAFT or LISTING
+}
+
+// ProductRequest returns the request that writes p back, for a read, modify,
+// write round trip from GetProductInfo. Sku prices are sent as their sale
+// price.
+func (p ProductData) ProductRequest() ProductRequest {
+	req := ProductRequest{
+		Title:             p.Title,
+		Description:       p.Description,
+		BrandID:           p.Brand.ID,
+		PackageWeight:     p.PackageWeight,
+		PackageDimensions: p.PackageDimensions,
+		ProductAttributes: p.ProductAttributes,
+		IsCodAllowed:      p.IsCodAllowed,
+	}
+
+	// the leaf of the chain is the category of the product
+	for _, c := range p.CategoryChains {
+		if c.IsLeaf {
+			req.CategoryID = c.ID
+		}
+	}
+	for _, img := range p.MainImages {
+		req.MainImages = append(req.MainImages, MainImage{URI: img.URI})
+	}
+	for _, sku := range p.Skus {
+		if sku.Price != nil {
+			sku.Price = &Price{Amount: sku.Price.SalePrice.Amount.String(), Currency: sku.Price.Currency}
+		}
+		req.Skus = append(req.Skus, sku)
+	}
+
+	return req
+}
+
+type ProductWriteResponse struct {
+	BaseResponse
+	Data struct {
+		ProductID string `json:"product_id"`
+		Skus      []Skus `json:"skus"`
+	} `json:"data"`
+}
+
+// /product/202309/products
+func (p *ProductServiceOp) CreateProduct(body ProductRequest) (*ProductWriteResponse, error) {
+	return p.CreateProductWithContext(context.Background(), body)
+}
+
+func (p *ProductServiceOp) CreateProductWithContext(ctx context.Context, body ProductRequest) (*ProductWriteResponse, error) {
+	path := fmt.Sprintf("/product/%s/products", p.client.appConfig.Version)
+
+	resp := new(ProductWriteResponse)
+	err := p.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// /product/202309/products/{product_id}
+func (p *ProductServiceOp) EditProduct(productID string, body ProductRequest) (*ProductWriteResponse, error) {
+	return p.EditProductWithContext(context.Background(), productID, body)
+}
+
+func (p *ProductServiceOp) EditProductWithContext(ctx context.Context, productID string, body ProductRequest) (*ProductWriteResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/%s", p.client.appConfig.Version, productID)
+
+	resp := new(ProductWriteResponse)
+	err := p.client.PutWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// UpdateInventoryRequest sets the stock of skus by ID.
+type UpdateInventoryRequest struct {
+	Skus []SkuInventory `json:"skus"`
+}
+
+type SkuInventory struct {
+	ID        string      `json:"id"`
+	Inventory []Inventory `json:"inventory"`
+}
+
+// UpdatePriceRequest sets the price of skus by ID.
+type UpdatePriceRequest struct {
+	Skus []SkuPrice `json:"skus"`
+}
+
+type SkuPrice struct {
+	ID    string      `json:"id"`
+	Price PriceAmount `json:"price"`
+}
+
+// PriceAmount is a price as written, Amount is a decimal string.
+type PriceAmount struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type UpdateSkusResponse struct {
+	BaseResponse
+}
+
+// /product/202309/products/{product_id}/inventory/update
+func (p *ProductServiceOp) UpdateInventory(productID string, body UpdateInventoryRequest) (*UpdateSkusResponse, error) {
+	return p.UpdateInventoryWithContext(context.Background(), productID, body)
+}
+
+func (p *ProductServiceOp) UpdateInventoryWithContext(ctx context.Context, productID string, body UpdateInventoryRequest) (*UpdateSkusResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/%s/inventory/update", p.client.appConfig.Version, productID)
+
+	resp := new(UpdateSkusResponse)
+	err := p.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// /product/202309/products/{product_id}/prices/update
+func (p *ProductServiceOp) UpdatePrice(productID string, body UpdatePriceRequest) (*UpdateSkusResponse, error) {
+	return p.UpdatePriceWithContext(context.Background(), productID, body)
+}
+
+func (p *ProductServiceOp) UpdatePriceWithContext(ctx context.Context, productID string, body UpdatePriceRequest) (*UpdateSkusResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/%s/prices/update", p.client.appConfig.Version, productID)
+
+	resp := new(UpdateSkusResponse)
+	err := p.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+type productIDsRequest struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+// ProductStatusResponse lists the products that could not be activated or
+// deactivated.
+type ProductStatusResponse struct {
+	BaseResponse
+	Data struct {
+		Errors []ProductError `json:"errors"`
+	} `json:"data"`
+}
+
+type ProductError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Detail  struct {
+		ProductID string `json:"product_id"`
+	} `json:"detail"`
+}
+
+// /product/202309/products/activate
+func (p *ProductServiceOp) ActivateProducts(productIDs []string) (*ProductStatusResponse, error) {
+	return p.ActivateProductsWithContext(context.Background(), productIDs)
+}
+
+func (p *ProductServiceOp) ActivateProductsWithContext(ctx context.Context, productIDs []string) (*ProductStatusResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/activate", p.client.appConfig.Version)
+
+	resp := new(ProductStatusResponse)
+	err := p.client.PostWithContext(ctx, path, productIDsRequest{ProductIDs: productIDs}, resp)
+
+	return resp, err
+}
+
+// /product/202309/products/deactivate
+func (p *ProductServiceOp) DeactivateProducts(productIDs []string) (*ProductStatusResponse, error) {
+	return p.DeactivateProductsWithContext(context.Background(), productIDs)
+}
+
+func (p *ProductServiceOp) DeactivateProductsWithContext(ctx context.Context, productIDs []string) (*ProductStatusResponse, error) {
+	path := fmt.Sprintf("/product/%s/products/deactivate", p.client.appConfig.Version)
+
+	resp := new(ProductStatusResponse)
+	err := p.client.PostWithContext(ctx, path, productIDsRequest{ProductIDs: productIDs}, resp)
+
+	return resp, err
+}
+
+type GetCategoriesParams struct {
+	Keyword         string `url:"keyword,omitempty"`
+	Locale          string `url:"locale,omitempty"`
+	CategoryVersion string `url:"category_version,omitempty"`
+}
+
+type GetCategoriesResponse struct {
+	BaseResponse
+	Data struct {
+		Categories []Category `json:"categories"`
+	} `json:"data"`
+}
+
+type Category struct {
+	ID                 string   `json:"id"`
+	ParentID           string   `json:"parent_id"`
+	LocalName          string   `json:"local_name"`
+	IsLeaf             bool     `json:"is_leaf"`
+	PermissionStatuses []string `json:"permission_statuses"`
+}
+
+// /product/202309/categories
+func (p *ProductServiceOp) GetCategories(params GetCategoriesParams) (*GetCategoriesResponse, error) {
+	return p.GetCategoriesWithContext(context.Background(), params)
+}
+
+func (p *ProductServiceOp) GetCategoriesWithContext(ctx context.Context, params GetCategoriesParams) (*GetCategoriesResponse, error) {
+	path := fmt.Sprintf("/product/%s/categories", p.client.appConfig.Version)
+
+	resp := new(GetCategoriesResponse)
+	err := p.client.GetWithContext(ctx, path, resp, params)
+
+	return resp, err
+}
+
+type GetAttributesResponse struct {
+	BaseResponse
+	Data struct {
+		Attributes []CategoryAttribute `json:"attributes"`
+	} `json:"data"`
+}
+
+type CategoryAttribute struct {
+	ID                  string           `json:"id"`
+	Name                string           `json:"name"`
+	Type                string           `json:"type"` // SALES_PROPERTY or PRODUCT_PROPERTY
+	IsMultipleSelection bool             `json:"is_multiple_selection"`
+	IsCustomizable      bool             `json:"is_customizable"`
+	Values              []AttributeValue `json:"values"`
+}
+
+type AttributeValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// /product/202309/categories/{category_id}/attributes
+func (p *ProductServiceOp) GetAttributes(categoryID string) (*GetAttributesResponse, error) {
+	return p.GetAttributesWithContext(context.Background(), categoryID)
+}
+
+func (p *ProductServiceOp) GetAttributesWithContext(ctx context.Context, categoryID string) (*GetAttributesResponse, error) {
+	path := fmt.Sprintf("/product/%s/categories/%s/attributes", p.client.appConfig.Version, categoryID)
+
+	resp := new(GetAttributesResponse)
+	err := p.client.GetWithContext(ctx, path, resp, nil)
+
+	return resp, err
+}
+
+type UploadProductImageResponse struct {
+	BaseResponse
+	Data struct {
+		URI     string `json:"uri"`
+		URL     string `json:"url"`
+		Width   int64  `json:"width"`
+		Height  int64  `json:"height"`
+		UseCase string `json:"use_case"`
+	} `json:"data"`
+}
+
+// /product/202309/images/upload, filename is the URL of the image. The URI of
+// the response goes into MainImages.
+func (p *ProductServiceOp) UploadImage(filename string) (*UploadProductImageResponse, error) {
+	return p.UploadImageWithContext(context.Background(), filename)
+}
+
+func (p *ProductServiceOp) UploadImageWithContext(ctx context.Context, filename string) (*UploadProductImageResponse, error) {
+	path := fmt.Sprintf("/product/%s/images/upload", p.client.appConfig.Version)
+
+	resp := new(UploadProductImageResponse)
+	err := p.client.UploadWithContext(ctx, path, "data", filename, resp)
 
 	return resp, err
 }
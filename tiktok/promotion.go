@@ -1,10 +1,17 @@
 package tiktok
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
 
 type PromotionService interface {
 	SearchCoupons(pageSize int, pageToken string, body SearchCouponsBody) (*SearchCouponsResponse, error)
+	SearchCouponsWithContext(ctx context.Context, pageSize int, pageToken string, body SearchCouponsBody) (*SearchCouponsResponse, error)
 	GetCoupon(id string) (*GetCouponResponse, error)
+	GetCouponWithContext(ctx context.Context, id string) (*GetCouponResponse, error)
 }
 
 type PromotionServiceOp struct {
@@ -40,15 +47,11 @@ type UsageLimits struct {
 	RedemptionLimit       int `json:"redemption_limit"`
 }
 
-type ReductionAmount struct {
-	Amount   string `json:"amount"`
-	Currency string `json:"currency"`
-}
+// Coupon amounts are sent as an amount and a currency, which is how
+// money.Money reads and writes them.
+type ReductionAmount = money.Money
 
-type MaxDiscount struct {
-	Amount   string `json:"amount"`
-	Currency string `json:"currency"`
-}
+type MaxDiscount = money.Money
 
 type Discount struct {
 	Type            string          `json:"type"`
@@ -57,10 +60,7 @@ type Discount struct {
 	MaxDiscount     MaxDiscount     `json:"max_discount"`
 }
 
-type MinSpend struct {
-	Amount   string `json:"amount"`
-	Currency string `json:"currency"`
-}
+type MinSpend = money.Money
 
 type Threshold struct {
 	Type     string   `json:"type"`
@@ -91,12 +91,16 @@ type DataCoupon struct {
 }
 
 func (s *PromotionServiceOp) SearchCoupons(pageSize int, pageToken string, body SearchCouponsBody) (*SearchCouponsResponse, error) {
+	return s.SearchCouponsWithContext(context.Background(), pageSize, pageToken, body)
+}
+
+func (s *PromotionServiceOp) SearchCouponsWithContext(ctx context.Context, pageSize int, pageToken string, body SearchCouponsBody) (*SearchCouponsResponse, error) {
 	path := fmt.Sprintf("/promotion/202406/coupons/search?page_size=%d", pageSize)
 	if pageToken != "" {
 		path += fmt.Sprintf("&page_token=%s", pageToken)
 	}
 	resp := new(SearchCouponsResponse)
-	err := s.client.Post(path, body, resp)
+	err := s.client.PostWithContext(ctx, path, body, resp)
 	return resp, err
 }
 
@@ -135,8 +139,12 @@ type DataDetailCoupon struct {
 }
 
 func (s *PromotionServiceOp) GetCoupon(id string) (*GetCouponResponse, error) {
+	return s.GetCouponWithContext(context.Background(), id)
+}
+
+func (s *PromotionServiceOp) GetCouponWithContext(ctx context.Context, id string) (*GetCouponResponse, error) {
 	path := fmt.Sprintf("/promotion/202406/coupons/%s", id)
 	resp := new(GetCouponResponse)
-	err := s.client.Get(path, resp, nil)
+	err := s.client.GetWithContext(ctx, path, resp, nil)
 	return resp, err
 }
@@ -21,11 +21,11 @@ type BaseResponse struct {
 
 // A general response error
 type ResponseError struct {
-	Message  string   `json:"message"`
-	Status   int      `json:"status"`
-	RequstID string   `json:"request_id"`
-	Code     int      `json:"code"`
-	Errors   []string `json:"errors,omitempty"`
+	Message   string   `json:"message"`
+	Status    int      `json:"status"`
+	RequestID string   `json:"request_id"`
+	Code      int      `json:"code"`
+	Errors    []string `json:"errors,omitempty"`
 }
 
 // GetStatus returns http  response status
@@ -103,29 +103,27 @@ func CheckResponseError(r *http.Response) error {
 		if http.StatusOK <= r.StatusCode && r.StatusCode < http.StatusMultipleChoices {
 			return nil
 		}
-		return fmt.Errorf("empty response body, status: %d", r.StatusCode)
+		return wrapSpecificError(r, ResponseError{
+			Status:  r.StatusCode,
+			Message: "empty response body",
+		})
 	}
 
 	contentType := r.Header.Get("Content-Type")
-	isJSON := strings.Contains(contentType, "application/json")
-	if isJSON {
-		err := json.Unmarshal(bodyBytes, &tiktokError)
-		if err != nil {
-			log.Printf("[CheckResponseError] Failed to decode JSON. Body:\n%s\n", string(bodyBytes))
-			return ResponseDecodingError{
-				Body:    bodyBytes,
-				Message: err.Error(),
-				Status:  r.StatusCode,
-			}
-		}
-	} else {
-		// Non-JSON: maybe HTML error, log it
-		if bodyBytes[0] == '<' {
-			log.Printf("[CheckResponseError] Non-JSON (possible HTML) body received with status %d:\n%s\n", r.StatusCode, string(bodyBytes))
-			return fmt.Errorf("unexpected non-JSON response received (status %d)", r.StatusCode)
-		} else {
-			log.Printf("[CheckResponseError] Unexpected non-JSON body received: %s", string(bodyBytes))
-			return fmt.Errorf("unexpected non-JSON response body (status %d)", r.StatusCode)
+	if !strings.Contains(contentType, "application/json") {
+		// Non-JSON, such as the HTML error page of a gateway: only the
+		// status tells what went wrong
+		return wrapSpecificError(r, ResponseError{
+			Status:  r.StatusCode,
+			Message: fmt.Sprintf("unexpected non-JSON response body (content type %q)", contentType),
+		})
+	}
+
+	if err := json.Unmarshal(bodyBytes, &tiktokError); err != nil {
+		return ResponseDecodingError{
+			Body:    bodyBytes,
+			Message: err.Error(),
+			Status:  r.StatusCode,
 		}
 	}
 
@@ -136,10 +134,10 @@ func CheckResponseError(r *http.Response) error {
 
 	// Consolidated ResponseError
 	responseError := ResponseError{
-		Status:   r.StatusCode,
-		Message:  tiktokError.Message,
-		RequstID: tiktokError.RequstID,
-		Code:     tiktokError.Code,
+		Status:    r.StatusCode,
+		Message:   tiktokError.Message,
+		RequestID: tiktokError.RequestID,
+		Code:      tiktokError.Code,
 	}
 	return wrapSpecificError(r, responseError)
 }
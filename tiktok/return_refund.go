@@ -0,0 +1,266 @@
+package tiktok
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+type ReturnRefundService interface {
+	CancelOrder(body CancelOrderRequest) (*CancelOrderResponse, error)
+	CancelOrderWithContext(ctx context.Context, body CancelOrderRequest) (*CancelOrderResponse, error)
+	SearchCancellations(params SearchReturnRefundParams, body SearchCancellationsBody) (*SearchCancellationsResponse, error)
+	SearchCancellationsWithContext(ctx context.Context, params SearchReturnRefundParams, body SearchCancellationsBody) (*SearchCancellationsResponse, error)
+	ApproveCancellation(cancelID string) (*ReturnRefundDecisionResponse, error)
+	ApproveCancellationWithContext(ctx context.Context, cancelID string) (*ReturnRefundDecisionResponse, error)
+	RejectCancellation(cancelID string, body RejectRequest) (*ReturnRefundDecisionResponse, error)
+	RejectCancellationWithContext(ctx context.Context, cancelID string, body RejectRequest) (*ReturnRefundDecisionResponse, error)
+	SearchReturns(params SearchReturnRefundParams, body SearchReturnsBody) (*SearchReturnsResponse, error)
+	SearchReturnsWithContext(ctx context.Context, params SearchReturnRefundParams, body SearchReturnsBody) (*SearchReturnsResponse, error)
+	ApproveReturn(returnID string, body ApproveReturnRequest) (*ReturnRefundDecisionResponse, error)
+	ApproveReturnWithContext(ctx context.Context, returnID string, body ApproveReturnRequest) (*ReturnRefundDecisionResponse, error)
+	RejectReturn(returnID string, body RejectRequest) (*ReturnRefundDecisionResponse, error)
+	RejectReturnWithContext(ctx context.Context, returnID string, body RejectRequest) (*ReturnRefundDecisionResponse, error)
+}
+
+type ReturnRefundServiceOp struct {
+	client *TiktokClient
+}
+
+// CancelOrderRequest cancels an order by the seller, the whole order when
+// OrderLineItemIDs is empty.
+type CancelOrderRequest struct {
+	OrderID          string   `json:"order_id"`
+	OrderLineItemIDs []string `json:"order_line_item_ids,omitempty"`
+	CancelReason     string   `json:"cancel_reason"`
+}
+
+type CancelOrderResponse struct {
+	BaseResponse
+	Data struct {
+		CancelID     string `json:"cancel_id"`
+		CancelStatus string `json:"cancel_status"`
+	} `json:"data"`
+}
+
+type SearchReturnRefundParams struct {
+	PageSize  int    `url:"page_size"`
+	PageToken string `url:"page_token,omitempty"`
+	SortField string `url:"sort_field,omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+type SearchCancellationsBody struct {
+	CancelIDs    []string `json:"cancel_ids,omitempty"`
+	OrderIDs     []string `json:"order_ids,omitempty"`
+	CancelTypes  []string `json:"cancel_types,omitempty"`
+	CancelStatus []string `json:"cancel_status,omitempty"`
+	CreateTimeGe int64    `json:"create_time_ge,omitempty"`
+	CreateTimeLt int64    `json:"create_time_lt,omitempty"`
+	UpdateTimeGe int64    `json:"update_time_ge,omitempty"`
+	UpdateTimeLt int64    `json:"update_time_lt,omitempty"`
+}
+
+type SearchCancellationsResponse struct {
+	BaseResponse
+	Data struct {
+		NextPageToken string         `json:"next_page_token"`
+		TotalCount    int            `json:"total_count"`
+		Cancellations []Cancellation `json:"cancellations"`
+	} `json:"data"`
+}
+
+type Cancellation struct {
+	CancelID     string           `json:"cancel_id"`
+	OrderID      string           `json:"order_id"`
+	CancelType   string           `json:"cancel_type"`
+	CancelStatus string           `json:"cancel_status"`
+	CancelReason string           `json:"cancel_reason"`
+	Role         string           `json:"role"`
+	CreateTime   int64            `json:"create_time"`
+	UpdateTime   int64            `json:"update_time"`
+	LineItems    []ReturnLineItem `json:"cancel_line_items"`
+	RefundAmount *RefundAmount    `json:"refund_amount"`
+}
+
+type SearchReturnsBody struct {
+	ReturnIDs    []string `json:"return_ids,omitempty"`
+	OrderIDs     []string `json:"order_ids,omitempty"`
+	ReturnTypes  []string `json:"return_types,omitempty"`
+	ReturnStatus []string `json:"return_status,omitempty"`
+	CreateTimeGe int64    `json:"create_time_ge,omitempty"`
+	CreateTimeLt int64    `json:"create_time_lt,omitempty"`
+	UpdateTimeGe int64    `json:"update_time_ge,omitempty"`
+	UpdateTimeLt int64    `json:"update_time_lt,omitempty"`
+}
+
+type SearchReturnsResponse struct {
+	BaseResponse
+	Data struct {
+		NextPageToken string   `json:"next_page_token"`
+		TotalCount    int      `json:"total_count"`
+		ReturnOrders  []Return `json:"return_orders"`
+	} `json:"data"`
+}
+
+type Return struct {
+	ReturnID             string           `json:"return_id"`
+	OrderID              string           `json:"order_id"`
+	ReturnType           string           `json:"return_type"`
+	ReturnStatus         string           `json:"return_status"`
+	ReturnReason         string           `json:"return_reason"`
+	ReturnReasonText     string           `json:"return_reason_text"`
+	Role                 string           `json:"role"`
+	ReturnTrackingNumber string           `json:"return_tracking_number"`
+	ReturnProviderName   string           `json:"return_provider_name"`
+	CreateTime           int64            `json:"create_time"`
+	UpdateTime           int64            `json:"update_time"`
+	LineItems            []ReturnLineItem `json:"return_line_items"`
+	RefundAmount         *RefundAmount    `json:"refund_amount"`
+}
+
+// ReturnLineItem is a LineItem of the order as cancellations and returns
+// carry it.
+type ReturnLineItem struct {
+	OrderLineItemID string `json:"order_line_item_id"`
+	ProductName     string `json:"product_name"`
+	SellerSku       string `json:"seller_sku"`
+	SkuID           string `json:"sku_id"`
+	SkuName         string `json:"sku_name"`
+}
+
+type RefundAmount struct {
+	Currency          string      `json:"currency"`
+	RefundTotal       money.Money `json:"refund_total"`
+	RefundSubtotal    money.Money `json:"refund_subtotal"`
+	RefundShippingFee money.Money `json:"refund_shipping_fee"`
+}
+
+func (r *RefundAmount) UnmarshalJSON(b []byte) error {
+	type refundAmount RefundAmount
+	if err := json.Unmarshal(b, (*refundAmount)(r)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(r.Currency, &r.RefundTotal, &r.RefundSubtotal, &r.RefundShippingFee)
+	return nil
+}
+
+// RejectRequest rejects a cancellation or return, RejectReason is one of the
+// reject reasons of the platform.
+type RejectRequest struct {
+	Decision     string   `json:"decision,omitempty"` // returns only, e.g. REJECT_REFUND
+	RejectReason string   `json:"reject_reason"`
+	Comment      string   `json:"comment,omitempty"`
+	Images       []string `json:"images,omitempty"`
+}
+
+// ApproveReturnRequest approves a return, Decision is e.g. APPROVE_REFUND,
+// APPROVE_RETURN or APPROVE_RECEIVED_PACKAGE.
+type ApproveReturnRequest struct {
+	Decision string `json:"decision"`
+}
+
+type ReturnRefundDecisionResponse struct {
+	BaseResponse
+}
+
+// /return_refund/202309/cancellations
+func (s *ReturnRefundServiceOp) CancelOrder(body CancelOrderRequest) (*CancelOrderResponse, error) {
+	return s.CancelOrderWithContext(context.Background(), body)
+}
+
+func (s *ReturnRefundServiceOp) CancelOrderWithContext(ctx context.Context, body CancelOrderRequest) (*CancelOrderResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/cancellations", s.client.appConfig.Version)
+
+	resp := new(CancelOrderResponse)
+	err := s.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/cancellations/search
+func (s *ReturnRefundServiceOp) SearchCancellations(params SearchReturnRefundParams, body SearchCancellationsBody) (*SearchCancellationsResponse, error) {
+	return s.SearchCancellationsWithContext(context.Background(), params, body)
+}
+
+func (s *ReturnRefundServiceOp) SearchCancellationsWithContext(ctx context.Context, params SearchReturnRefundParams, body SearchCancellationsBody) (*SearchCancellationsResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/cancellations/search", s.client.appConfig.Version)
+
+	resp := new(SearchCancellationsResponse)
+	err := s.client.CreateAndDoWithContext(ctx, "POST", path, body, params, nil, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/cancellations/{cancel_id}/approve
+func (s *ReturnRefundServiceOp) ApproveCancellation(cancelID string) (*ReturnRefundDecisionResponse, error) {
+	return s.ApproveCancellationWithContext(context.Background(), cancelID)
+}
+
+func (s *ReturnRefundServiceOp) ApproveCancellationWithContext(ctx context.Context, cancelID string) (*ReturnRefundDecisionResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/cancellations/%s/approve", s.client.appConfig.Version, cancelID)
+
+	resp := new(ReturnRefundDecisionResponse)
+	err := s.client.PostWithContext(ctx, path, nil, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/cancellations/{cancel_id}/reject
+func (s *ReturnRefundServiceOp) RejectCancellation(cancelID string, body RejectRequest) (*ReturnRefundDecisionResponse, error) {
+	return s.RejectCancellationWithContext(context.Background(), cancelID, body)
+}
+
+func (s *ReturnRefundServiceOp) RejectCancellationWithContext(ctx context.Context, cancelID string, body RejectRequest) (*ReturnRefundDecisionResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/cancellations/%s/reject", s.client.appConfig.Version, cancelID)
+
+	resp := new(ReturnRefundDecisionResponse)
+	err := s.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/returns/search
+func (s *ReturnRefundServiceOp) SearchReturns(params SearchReturnRefundParams, body SearchReturnsBody) (*SearchReturnsResponse, error) {
+	return s.SearchReturnsWithContext(context.Background(), params, body)
+}
+
+func (s *ReturnRefundServiceOp) SearchReturnsWithContext(ctx context.Context, params SearchReturnRefundParams, body SearchReturnsBody) (*SearchReturnsResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/returns/search", s.client.appConfig.Version)
+
+	resp := new(SearchReturnsResponse)
+	err := s.client.CreateAndDoWithContext(ctx, "POST", path, body, params, nil, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/returns/{return_id}/approve
+func (s *ReturnRefundServiceOp) ApproveReturn(returnID string, body ApproveReturnRequest) (*ReturnRefundDecisionResponse, error) {
+	return s.ApproveReturnWithContext(context.Background(), returnID, body)
+}
+
+func (s *ReturnRefundServiceOp) ApproveReturnWithContext(ctx context.Context, returnID string, body ApproveReturnRequest) (*ReturnRefundDecisionResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/returns/%s/approve", s.client.appConfig.Version, returnID)
+
+	resp := new(ReturnRefundDecisionResponse)
+	err := s.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
+
+// /return_refund/202309/returns/{return_id}/reject
+func (s *ReturnRefundServiceOp) RejectReturn(returnID string, body RejectRequest) (*ReturnRefundDecisionResponse, error) {
+	return s.RejectReturnWithContext(context.Background(), returnID, body)
+}
+
+func (s *ReturnRefundServiceOp) RejectReturnWithContext(ctx context.Context, returnID string, body RejectRequest) (*ReturnRefundDecisionResponse, error) {
+	path := fmt.Sprintf("/return_refund/%s/returns/%s/reject", s.client.appConfig.Version, returnID)
+
+	resp := new(ReturnRefundDecisionResponse)
+	err := s.client.PostWithContext(ctx, path, body, resp)
+
+	return resp, err
+}
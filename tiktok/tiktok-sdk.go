@@ -2,17 +2,23 @@ package tiktok
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
-	"log"
 	"mime"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 )
 
 const (
@@ -38,20 +44,32 @@ type TiktokClient struct {
 	baseURL   *url.URL
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
+
+	// looks up shop tokens when a call has none, see WithTokenSource
+	tokenSource TokenSource
+
+	// wraps the transport once options are applied, see WithMiddleware
+	middleware []middleware.Option
+
+	// records or replays the transport, see WithRecorder
+	recorder *cassette.Recorder
+
+	// traces and measures calls, see WithTelemetry
+	telemetry []telemetry.Option
 
 	ShopCipher  string
 	AccessToken string
 	ShopID      string
 
-	Auth        AuthService
-	Util        UtilService
-	Chat        ChatService
-	Order       OrderService
-	Product     ProductService
-	Fulfillment FulfillmentService
-	Promotion   PromotionService
+	Auth         AuthService
+	Util         UtilService
+	Chat         ChatService
+	Order        OrderService
+	Product      ProductService
+	Fulfillment  FulfillmentService
+	Promotion    PromotionService
+	ReturnRefund ReturnRefundService
 }
 
 type CommonParamRequest struct {
@@ -81,11 +99,22 @@ func NewClient(app AppConfig, opts ...Option) *TiktokClient {
 	c.Product = &ProductServiceOp{client: c}
 	c.Fulfillment = &FulfillmentServiceOp{client: c}
 	c.Promotion = &PromotionServiceOp{client: c}
+	c.ReturnRefund = &ReturnRefundServiceOp{client: c}
 
 	// apply any options
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.recorder != nil {
+		c.Client.Transport = c.recorder.Wrap(c.Client.Transport)
+	}
+	if c.telemetry != nil {
+		c.Client.Transport = telemetry.New(c.Client.Transport, telemetryMarketplace, c.telemetry...)
+	}
+	if c.middleware != nil {
+		c.Client.Transport = middleware.New(c.Client.Transport, c.middleware...)
+		c.retries = 0 // the middleware retries instead
+	}
 
 	return c
 }
@@ -104,6 +133,27 @@ func (c *TiktokClient) WithCommonParamRequest(param CommonParamRequest) *TiktokC
 	return c
 }
 
+// commonParams returns the shop set on the client with WithCommonParamRequest.
+func (c *TiktokClient) commonParams() CommonParamRequest {
+	return CommonParamRequest{
+		AccessToken: c.AccessToken,
+		ShopID:      c.ShopID,
+		ShopCipher:  c.ShopCipher,
+	}
+}
+
+// takeCommonParams returns the shop set on the client and clears it for the
+// next call, as a call does. Nothing is written when no shop was set.
+func (c *TiktokClient) takeCommonParams() CommonParamRequest {
+	shop := c.commonParams()
+	if shop != (CommonParamRequest{}) {
+		c.ShopCipher = ""
+		c.ShopID = ""
+		c.AccessToken = ""
+	}
+	return shop
+}
+
 func (c *TiktokClient) WithShopCipher(cipher string) *TiktokClient {
 	c.ShopCipher = cipher
 	return c
@@ -127,22 +177,22 @@ func (c *TiktokClient) WithShopID(shopID string) *TiktokClient {
 	return c
 }
 
-func (c *TiktokClient) makeSignature(req *http.Request) string {
+func (c *TiktokClient) makeSignature(req *http.Request, shop CommonParamRequest) string {
 	ts := time.Now().Unix()
 	u := req.URL
 
 	query := u.Query()
-	if c.ShopCipher != "" {
-		query.Add("shop_cipher", fmt.Sprintf("%v", c.ShopCipher))
+	if shop.ShopCipher != "" {
+		query.Add("shop_cipher", fmt.Sprintf("%v", shop.ShopCipher))
 	}
 
-	if c.ShopID != "" {
-		query.Add("shop_id", fmt.Sprintf("%v", c.ShopID))
+	if shop.ShopID != "" {
+		query.Add("shop_id", fmt.Sprintf("%v", shop.ShopID))
 	}
 
-	if c.AccessToken != "" {
-		// query.Add("access_token", fmt.Sprintf("%v", c.AccessToken))
-		req.Header.Add("x-tts-access-token", c.AccessToken)
+	if shop.AccessToken != "" {
+		// query.Add("access_token", fmt.Sprintf("%v", shop.AccessToken))
+		req.Header.Add("x-tts-access-token", shop.AccessToken)
 	}
 
 	query.Add("app_key", c.appConfig.AppKey)
@@ -222,33 +272,68 @@ type RequestUploadFile struct {
 	UploadToken string `json:"upload_token"`
 	ChunkNum    int    `json:"chunk_num"`
 	FileBytes   []byte `json:"file_bytes"`
+	// ContentType of the file, detected from FileBytes when empty or else
+	// video/mp4.
+	ContentType string `json:"content_type,omitempty"`
 }
 
 func (c *TiktokClient) UploadFile(uploadURL string, body RequestUploadFile) (string, error) {
+	return c.UploadFileWithContext(context.Background(), uploadURL, body)
+}
+
+// UploadFileWithContext is UploadFile with a context.
+func (c *TiktokClient) UploadFileWithContext(ctx context.Context, uploadURL string, body RequestUploadFile) (string, error) {
+	return c.uploadFile(ctx, uploadURL, c.shopOf(ctx), body)
+}
+
+// uploadFile PUTs a chunk of a file to uploadURL for shop, it does not use
+// the shop set on the client so that chunks can be uploaded concurrently.
+func (c *TiktokClient) uploadFile(ctx context.Context, uploadURL string, shop CommonParamRequest, body RequestUploadFile) (string, error) {
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing upload url: %w", err)
+	}
+	q := u.Query()
+	q.Set("app_key", c.appConfig.AppKey)
+	q.Set("shop_cipher", shop.ShopCipher)
+	q.Set("upload_token", body.UploadToken)
+	q.Set("chunk_num", strconv.Itoa(body.ChunkNum))
+	u.RawQuery = q.Encode()
+
+	token, err := c.accessTokenOf(ctx, shop)
+	if err != nil {
+		return "", err
+	}
 
-	url := fmt.Sprintf("%s?app_key=%s&shop_cipher=%s&upload_token=%s&chunk_num=%d", uploadURL, c.appConfig.AppKey, c.ShopCipher, body.UploadToken, body.ChunkNum)
+	contentType := body.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(body.FileBytes)
+	}
+	if !strings.HasPrefix(contentType, "video/") {
+		// only the first chunk of a video can be told from its bytes
+		contentType = "video/mp4"
+	}
 
-	req, err := http.NewRequest("PUT", url, bytes.NewReader(body.FileBytes))
+	req, err := http.NewRequestWithContext(ctx, "PUT", u.String(), bytes.NewReader(body.FileBytes))
 	if err != nil {
 		return "", fmt.Errorf("error creating request: %w", err)
 	}
-	req.Header.Set("x-tts-access-token", c.AccessToken)
-	req.Header.Set("Content-Type", "video/mp4")
+	req.Header.Set("x-tts-access-token", token)
+	req.Header.Set("Content-Type", contentType)
 	req.ContentLength = int64(len(body.FileBytes))
+	c.logRequest(req, true)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.Client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("error performing upload request: %w", err)
 	}
-	log.Println("upload URL check: ", resp.Request.URL)
 	defer resp.Body.Close()
+	c.logResponse(resp)
 
 	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return "", fmt.Errorf("upload failed. Status: %d. Body: %s", resp.StatusCode, string(respBody))
 	}
 
-	fmt.Println("Upload success. Response:", string(respBody))
 	return string(respBody), nil
 }
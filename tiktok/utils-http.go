@@ -2,7 +2,9 @@ package tiktok
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
@@ -12,6 +14,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
 	"github.com/google/go-querystring/query"
 )
 
@@ -22,11 +26,20 @@ import (
 // If the data argument is non-nil, it will be used as the body of the request
 // for POST and PUT requests.
 func (c *TiktokClient) CreateAndDo(method, relPath string, data, options, headers, resource interface{}) error {
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, headers, resource)
+}
+
+// CreateAndDoWithContext is CreateAndDo with a context for cancellation and
+// deadlines.
+func (c *TiktokClient) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) error {
+	_, perCall := ShopFromContext(ctx)
 	defer func() {
-		// clear for next call
-		c.ShopCipher = ""
-		c.ShopID = ""
-		c.AccessToken = ""
+		// clear for next call, a shop of ctx was never set on the client
+		if !perCall {
+			c.ShopCipher = ""
+			c.ShopID = ""
+			c.AccessToken = ""
+		}
 
 		legacyAuthURL, _ := url.Parse(LegacyAuthURL)
 		authURL, _ := url.Parse(AuthBaseURL)
@@ -39,21 +52,68 @@ func (c *TiktokClient) CreateAndDo(method, relPath string, data, options, header
 
 	}()
 
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, headers, resource)
+	ctx, err := c.resolveShop(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.createAndDoGetHeaders(ctx, method, relPath, data, options, headers, resource)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// shopOf returns the shop of a call, the one of ctx set with WithShop or else
+// the one set on the client.
+func (c *TiktokClient) shopOf(ctx context.Context) CommonParamRequest {
+	if shop, ok := ShopFromContext(ctx); ok {
+		return shop
+	}
+	return c.commonParams()
+}
+
+// resolveShop returns a copy of ctx carrying the shop of the call with its
+// access token, fetched from the token source when the shop has none.
+func (c *TiktokClient) resolveShop(ctx context.Context) (context.Context, error) {
+	shop := c.shopOf(ctx)
+	tok, err := c.accessTokenOf(ctx, shop)
+	if err != nil {
+		return nil, err
+	}
+	shop.AccessToken = tok
+	return WithShop(ctx, shop), nil
+}
+
+// accessTokenOf returns the access token of shop, from the token source by
+// its cipher when shop has none.
+func (c *TiktokClient) accessTokenOf(ctx context.Context, shop CommonParamRequest) (string, error) {
+	if shop.AccessToken != "" || c.tokenSource == nil {
+		return shop.AccessToken, nil
+	}
+
+	if shop.ShopCipher == "" {
+		if shop.ShopID != "" {
+			return "", fmt.Errorf("tiktok: access token of shop %s: the token source needs its cipher", shop.ShopID)
+		}
+		return "", nil
+	}
+
+	tok, err := c.tokenSource.AccessToken(ctx, shop.ShopCipher)
+	if err != nil {
+		return "", fmt.Errorf("tiktok: access token of %s: %w", shop.ShopCipher, err)
+	}
+	return tok, nil
+}
+
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *TiktokClient) createAndDoGetHeaders(method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
+func (c *TiktokClient) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
-	req, err := c.NewRequest(method, relPath, data, options, headers)
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, options, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -68,28 +128,54 @@ func (c *TiktokClient) Get(path string, resource, options interface{}) error {
 	return c.CreateAndDo("GET", path, nil, options, nil, resource)
 }
 
+// GetWithContext is Get with a context.
+func (c *TiktokClient) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, nil, resource)
+}
+
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *TiktokClient) Post(path string, data, resource interface{}) error {
 	return c.CreateAndDo("POST", path, data, nil, nil, resource)
 }
 
+// PostWithContext is Post with a context.
+func (c *TiktokClient) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, nil, resource)
+}
+
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *TiktokClient) Put(path string, data, resource interface{}) error {
 	return c.CreateAndDo("PUT", path, data, nil, nil, resource)
 }
 
+// PutWithContext is Put with a context.
+func (c *TiktokClient) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, nil, resource)
+}
+
 // Delete performs a DELETE request for the given path
 func (c *TiktokClient) Delete(path string) error {
 	return c.CreateAndDo("DELETE", path, nil, nil, nil, nil)
 }
 
+// DeleteWithContext is Delete with a context.
+func (c *TiktokClient) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
 // Creates an API request. A relative URL can be provided in urlStr, which will
 // be resolved to the BaseURL of the Client. Relative URLS should always be
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *TiktokClient) NewRequest(method, relPath string, body, options, headers interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options, headers)
+}
+
+// NewRequestWithContext is NewRequest with a context, the context controls the
+// whole lifetime of the request, including retries.
+func (c *TiktokClient) NewRequestWithContext(ctx context.Context, method, relPath string, body, options, headers interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -123,7 +209,7 @@ func (c *TiktokClient) NewRequest(method, relPath string, body, options, headers
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		log.Printf("[NewRequest] error in create new request:%+v\n", err)
 		return nil, err
@@ -132,11 +218,12 @@ func (c *TiktokClient) NewRequest(method, relPath string, body, options, headers
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 
-	if c.AccessToken != "" {
-		req.Header.Add("x-tts-access-token", c.AccessToken)
+	shop := c.shopOf(ctx)
+	if shop.AccessToken != "" {
+		req.Header.Add("x-tts-access-token", shop.AccessToken)
 	}
 
-	c.makeSignature(req)
+	c.makeSignature(req, shop)
 
 	return req, nil
 }
@@ -144,7 +231,18 @@ func (c *TiktokClient) NewRequest(method, relPath string, body, options, headers
 // Upload performs a Upload request for the given path and saves the result in the
 // given resource.
 func (c *TiktokClient) Upload(relPath, fieldname, filename string, resource interface{}) error {
-	req, err := c.NewfileUploadRequest(relPath, fieldname, filename)
+	return c.UploadWithContext(context.Background(), relPath, fieldname, filename, resource)
+}
+
+// UploadWithContext is Upload with a context, the context also covers the
+// download of filename.
+func (c *TiktokClient) UploadWithContext(ctx context.Context, relPath, fieldname, filename string, resource interface{}) error {
+	ctx, err := c.resolveShop(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newFileUploadRequest(ctx, relPath, fieldname, filename)
 	if err != nil {
 		return err
 	}
@@ -158,6 +256,10 @@ func (c *TiktokClient) Upload(relPath, fieldname, filename string, resource inte
 
 // Creates a new file upload http request with optional extra params
 func (c *TiktokClient) NewfileUploadRequest(relPath, paramName, filename string) (*http.Request, error) {
+	return c.newFileUploadRequest(context.Background(), relPath, paramName, filename)
+}
+
+func (c *TiktokClient) newFileUploadRequest(ctx context.Context, relPath, paramName, filename string) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -173,7 +275,11 @@ func (c *TiktokClient) NewfileUploadRequest(relPath, paramName, filename string)
 	uri := u.String()
 
 	// Replace os.Open with http.Get to fetch data from the URL
-	resp, err := http.Get(filename)
+	fileReq, err := http.NewRequestWithContext(ctx, http.MethodGet, filename, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(fileReq)
 	if err != nil {
 		return nil, err
 	}
@@ -196,20 +302,21 @@ func (c *TiktokClient) NewfileUploadRequest(relPath, paramName, filename string)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", uri, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, body)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.AccessToken != "" {
-		req.Header.Add("x-tts-access-token", c.AccessToken)
-		req.URL.Query().Add("access_token", c.AccessToken)
+	shop := c.shopOf(ctx)
+	if shop.AccessToken != "" {
+		req.Header.Add("x-tts-access-token", shop.AccessToken)
+		req.URL.Query().Add("access_token", shop.AccessToken)
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", UserAgent)
 
-	c.makeSignature(req)
+	c.makeSignature(req, shop)
 
 	return req, nil
 }
@@ -220,13 +327,18 @@ func (c *TiktokClient) doGetHeaders(req *http.Request, v interface{}, skipBody b
 	var err error
 
 	retries := c.retries
-	c.attempts = 0
+	attempts := 0
 	c.logRequest(req, skipBody)
 
 	for {
-		c.attempts++
+		attempts++
+
+		// not every transport checks the context before sending
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
 
-		resp, err = c.Client.Do(req)
+		resp, err = c.Client.Do(req.WithContext(middleware.WithAttempt(req.Context(), attempts-1)))
 		c.logResponse(resp)
 		if err != nil {
 			return nil, err //http client errors, not api responses
@@ -248,8 +360,10 @@ func (c *TiktokClient) doGetHeaders(req *http.Request, v interface{}, skipBody b
 			// back off and retry
 
 			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
+			c.log.Debugf("rate limited on attempt %d, waiting %s", attempts, wait.String())
+			if err := clock.Sleep(req.Context(), wait); err != nil {
+				return nil, err
+			}
 			retries--
 			continue
 		}
@@ -257,7 +371,7 @@ func (c *TiktokClient) doGetHeaders(req *http.Request, v interface{}, skipBody b
 		var doRetry bool
 		switch resp.StatusCode {
 		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
+			c.log.Debugf("service unavailable on attempt %d, retrying", attempts)
 			doRetry = true
 			retries--
 		}
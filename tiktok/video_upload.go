@@ -0,0 +1,481 @@
+package tiktok
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/upload"
+)
+
+const (
+	// DefaultUploadConcurrency is the number of chunks a VideoUploader
+	// uploads at once.
+	DefaultUploadConcurrency = 3
+
+	// DefaultChunkRetries is the number of times a VideoUploader retries a
+	// failed chunk.
+	DefaultChunkRetries = 3
+
+	// DefaultChunkBackoff is the wait before the first retry of a chunk, it
+	// doubles on each retry.
+	DefaultChunkBackoff = time.Second
+)
+
+// VideoUploadState is where a chat video upload stands: the upload token of
+// FileInit and the chunks uploaded with it. A VideoUploader saves it to its
+// store after each chunk, so that an interrupted upload resumes with the
+// missing chunks.
+type VideoUploadState struct {
+	UploadToken string `json:"upload_token"`
+	UploadURL   string `json:"upload_url"`
+	Size        int64  `json:"size"`
+	ChunkCount  int    `json:"chunk_count"`
+	ContentType string `json:"content_type"`
+	// Numbers of the uploaded chunks, from 1.
+	Chunks []int `json:"chunks"`
+}
+
+func (s *VideoUploadState) uploaded(n int) bool {
+	for _, c := range s.Chunks {
+		if c == n {
+			return true
+		}
+	}
+	return false
+}
+
+// chunkRange returns the bytes of chunk n of the upload. Chunks are
+// chunkSize bytes, the last one takes the rest as CalcChunkCount counts.
+func (s *VideoUploadState) chunkRange(n int) (int64, int64) {
+	start := int64(n-1) * chunkSize
+	if n == s.ChunkCount {
+		return start, s.Size
+	}
+	return start, start + chunkSize
+}
+
+func (s *VideoUploadState) uploadedBytes() int64 {
+	var total int64
+	for _, n := range s.Chunks {
+		start, end := s.chunkRange(n)
+		total += end - start
+	}
+	return total
+}
+
+// VideoUploadStore keeps the state of video uploads by key. Load returns nil
+// and no error when there is no upload for key.
+type VideoUploadStore = upload.Store[VideoUploadState]
+
+// MemoryVideoUploadStore keeps upload states in memory, it resumes uploads
+// within the process only.
+type MemoryVideoUploadStore = upload.MemoryStore[VideoUploadState]
+
+func NewMemoryVideoUploadStore() *MemoryVideoUploadStore {
+	return upload.NewMemoryStore[VideoUploadState]()
+}
+
+// FileVideoUploadStore keeps each upload state in a JSON file of dir, named
+// after the key, so uploads resume after a restart.
+type FileVideoUploadStore = upload.FileStore[VideoUploadState]
+
+func NewFileVideoUploadStore(dir string) *FileVideoUploadStore {
+	return upload.NewFileStore[VideoUploadState](dir)
+}
+
+// VideoUploadProgress reports the bytes of a video uploaded so far.
+type VideoUploadProgress struct {
+	UploadToken   string
+	UploadedBytes int64
+	TotalBytes    int64
+	// Chunks uploaded so far, including those of an earlier attempt.
+	UploadedChunks int
+	ChunkCount     int
+}
+
+// VideoUploaderOption configures a VideoUploader.
+type VideoUploaderOption func(u *VideoUploader)
+
+// WithUploadConcurrency sets the number of chunks uploaded at once.
+func WithUploadConcurrency(n int) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		if n > 0 {
+			u.concurrency = n
+		}
+	}
+}
+
+// WithChunkRetries sets how many times a failed chunk is retried and the
+// wait before the first retry, which doubles on each retry.
+func WithChunkRetries(retries int, backoff time.Duration) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		if retries >= 0 {
+			u.retries = retries
+		}
+		if backoff >= 0 {
+			u.backoff = backoff
+		}
+	}
+}
+
+// WithUploadStore saves the state of uploads with a key to store, see
+// VideoUploadRequest.Key.
+func WithUploadStore(store VideoUploadStore) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		u.store = store
+	}
+}
+
+// WithUploadProgress calls fn after each uploaded chunk, and once before the
+// first with the chunks of a resumed upload. Calls do not overlap.
+func WithUploadProgress(fn func(VideoUploadProgress)) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		u.progress = fn
+	}
+}
+
+// WithSpoolDir sets the directory of the temporary file a video of unknown
+// length is copied to, os.TempDir by default.
+func WithSpoolDir(dir string) VideoUploaderOption {
+	return func(u *VideoUploader) {
+		u.spoolDir = dir
+	}
+}
+
+// VideoUploader uploads chat videos in chunks of FileInit, several chunks at
+// a time, retrying the chunks that fail. FileInit is called with the shop set
+// on the client as other calls are, chunks are uploaded without it.
+type VideoUploader struct {
+	client      *TiktokClient
+	concurrency int
+	retries     int
+	backoff     time.Duration
+	store       VideoUploadStore
+	progress    func(VideoUploadProgress)
+	spoolDir    string
+}
+
+func NewVideoUploader(client *TiktokClient, opts ...VideoUploaderOption) *VideoUploader {
+	u := &VideoUploader{
+		client:      client,
+		concurrency: DefaultUploadConcurrency,
+		retries:     DefaultChunkRetries,
+		backoff:     DefaultChunkBackoff,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+type VideoUploadRequest struct {
+	// Shop uploads the video, the shop set on the client when empty.
+	Shop CommonParamRequest
+
+	// Key names the upload in the store of the uploader, an upload with a
+	// saved state resumes from its upload token. Uploads without key are
+	// not saved.
+	Key string
+
+	FileName string
+	// FileType is the file_type of FileInit, the extension of the content
+	// type when empty, e.g. mp4.
+	FileType   string
+	TargetPath string
+	// ContentType of the video, detected from its first bytes or else from
+	// the extension of FileName when empty.
+	ContentType string
+}
+
+type DataUploadChunk struct {
+	FileID string `json:"file_id"`
+	URL    string `json:"url"`
+}
+
+// UploadChunkResp is the response of a chunk upload, TikTok completes the
+// file on its last chunk and returns its id.
+type UploadChunkResp struct {
+	BaseResponse
+	Data *DataUploadChunk `json:"data"`
+}
+
+// UploadedVideo is a video uploaded by a VideoUploader, send FileID in a
+// TypeMessageVideo message.
+type UploadedVideo struct {
+	FileID      string
+	URL         string
+	UploadToken string
+	ContentType string
+	Size        int64
+}
+
+// sizedReaderAt is a reader whose chunks can be read without copying it, as
+// bytes.Reader, strings.Reader and io.SectionReader.
+type sizedReaderAt interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// Upload uploads the video of r and returns its file id. A reader of unknown
+// length is copied to a temporary file first, to count its chunks with
+// CalcChunkCount. The state is deleted from the store once the last chunk is
+// uploaded. On error it stays, and calling Upload again with the same key and
+// content uploads the missing chunks with the saved upload token.
+func (u *VideoUploader) Upload(ctx context.Context, r io.Reader, req *VideoUploadRequest) (*UploadedVideo, error) {
+	shop := req.Shop
+	if shop == (CommonParamRequest{}) {
+		shop = u.client.takeCommonParams()
+	}
+
+	var ra io.ReaderAt
+	var size int64
+	if sr, ok := r.(sizedReaderAt); ok {
+		ra, size = sr, sr.Size()
+	} else {
+		f, err := os.CreateTemp(u.spoolDir, "tiktok-video-*")
+		if err != nil {
+			return nil, fmt.Errorf("tiktok: spool video: %w", err)
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		if size, err = io.Copy(f, r); err != nil {
+			return nil, fmt.Errorf("tiktok: spool video: %w", err)
+		}
+		ra = f
+	}
+	if size <= 0 {
+		return nil, errors.New("tiktok: video is empty")
+	}
+
+	state, err := u.start(ctx, shop, ra, size, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.uploadChunks(ctx, shop, ra, req, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.store != nil && req.Key != "" {
+		if err := u.store.Delete(ctx, req.Key); err != nil {
+			return nil, fmt.Errorf("tiktok: delete upload state: %w", err)
+		}
+	}
+
+	return &UploadedVideo{
+		FileID:      res.FileID,
+		URL:         res.URL,
+		UploadToken: state.UploadToken,
+		ContentType: state.ContentType,
+		Size:        size,
+	}, nil
+}
+
+// start returns the saved state of the upload, or a new one from FileInit. A
+// saved state of another size is discarded with its upload token.
+func (u *VideoUploader) start(ctx context.Context, shop CommonParamRequest, r io.ReaderAt, size int64, req *VideoUploadRequest) (*VideoUploadState, error) {
+	if u.store != nil && req.Key != "" {
+		state, err := u.store.Load(ctx, req.Key)
+		if err != nil {
+			return nil, fmt.Errorf("tiktok: load upload state: %w", err)
+		}
+		if state != nil && state.UploadToken != "" && state.Size == size && state.ChunkCount == CalcChunkCount(size) {
+			return state, nil
+		}
+	}
+
+	contentType, err := u.contentType(r, size, req)
+	if err != nil {
+		return nil, err
+	}
+	fileType := req.FileType
+	if fileType == "" {
+		_, fileType, _ = strings.Cut(contentType, "/")
+	}
+
+	init, err := u.client.Chat.FileInitWithContext(WithShop(ctx, shop), FileInitRequest{
+		FileName:   req.FileName,
+		FileType:   fileType,
+		FileSize:   int(size),
+		TargetPath: req.TargetPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("file init: %w", err)
+	}
+	if init.Data == nil || init.Data.UploadToken == "" || init.Data.UploadURL == "" {
+		return nil, fmt.Errorf("file init returned empty upload_token")
+	}
+
+	state := &VideoUploadState{
+		UploadToken: init.Data.UploadToken,
+		UploadURL:   init.Data.UploadURL,
+		Size:        size,
+		ChunkCount:  CalcChunkCount(size),
+		ContentType: contentType,
+	}
+	if err := u.save(ctx, req, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// videoTypes are the content types of the video extensions, which the mime
+// package only knows from the tables of the system.
+var videoTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".m4v":  "video/x-m4v",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".avi":  "video/x-msvideo",
+}
+
+func (u *VideoUploader) contentType(r io.ReaderAt, size int64, req *VideoUploadRequest) (string, error) {
+	if req.ContentType != "" {
+		return req.ContentType, nil
+	}
+
+	head := make([]byte, min(size, 512))
+	if n, err := r.ReadAt(head, 0); n < len(head) {
+		return "", fmt.Errorf("tiktok: read video: %w", err)
+	}
+	if ct := http.DetectContentType(head); strings.HasPrefix(ct, "video/") {
+		return ct, nil
+	}
+	ext := strings.ToLower(filepath.Ext(req.FileName))
+	if ct, ok := videoTypes[ext]; ok {
+		return ct, nil
+	}
+	if ct := mime.TypeByExtension(ext); ct != "" {
+		return ct, nil
+	}
+	return "video/mp4", nil
+}
+
+func (u *VideoUploader) save(ctx context.Context, req *VideoUploadRequest, state *VideoUploadState) error {
+	if u.store == nil || req.Key == "" {
+		return nil
+	}
+	if err := u.store.Save(ctx, req.Key, state); err != nil {
+		return fmt.Errorf("tiktok: save upload state: %w", err)
+	}
+	return nil
+}
+
+// uploadChunks uploads the chunks missing from state and returns the file of
+// the last chunk, which is uploaded once all others are. The first chunk to
+// fail all its retries stops the others.
+func (u *VideoUploader) uploadChunks(ctx context.Context, shop CommonParamRequest, r io.ReaderAt, req *VideoUploadRequest, state *VideoUploadState) (*DataUploadChunk, error) {
+	var pending []int
+	for n := 1; n < state.ChunkCount; n++ {
+		if !state.uploaded(n) {
+			pending = append(pending, n)
+		}
+	}
+
+	var mu sync.Mutex
+	report := func() {
+		if u.progress != nil {
+			u.progress(VideoUploadProgress{
+				UploadToken:    state.UploadToken,
+				UploadedBytes:  state.uploadedBytes(),
+				TotalBytes:     state.Size,
+				UploadedChunks: len(state.Chunks),
+				ChunkCount:     state.ChunkCount,
+			})
+		}
+	}
+	done := func(n int) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		state.Chunks = append(state.Chunks, n)
+		sort.Ints(state.Chunks)
+		if err := u.save(ctx, req, state); err != nil {
+			return err
+		}
+		report()
+		return nil
+	}
+	if len(state.Chunks) > 0 {
+		report()
+	}
+
+	if err := upload.Parallel(ctx, u.concurrency, pending, func(ctx context.Context, n int) error {
+		if _, err := u.uploadChunk(ctx, shop, r, state, n); err != nil {
+			return fmt.Errorf("upload chunk %d/%d: %w", n, state.ChunkCount, err)
+		}
+		return done(n)
+	}); err != nil {
+		return nil, err
+	}
+
+	last := state.ChunkCount
+	res, err := u.uploadChunk(ctx, shop, r, state, last)
+	if err != nil {
+		return nil, fmt.Errorf("upload chunk %d/%d: %w", last, state.ChunkCount, err)
+	}
+	if res.Data == nil || res.Data.FileID == "" {
+		return nil, fmt.Errorf("upload chunk %d/%d returned empty file_id", last, state.ChunkCount)
+	}
+	if err := done(last); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// uploadChunk reads chunk n and uploads it, retrying on failure.
+func (u *VideoUploader) uploadChunk(ctx context.Context, shop CommonParamRequest, r io.ReaderAt, state *VideoUploadState, n int) (*UploadChunkResp, error) {
+	start, end := state.chunkRange(n)
+	data := make([]byte, end-start)
+	if read, err := r.ReadAt(data, start); read < len(data) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	var res *UploadChunkResp
+	err := upload.Retry(ctx, u.retries, u.backoff, func() error {
+		var err error
+		res, err = u.sendChunk(ctx, shop, state, n, data)
+		return err
+	})
+	return res, err
+}
+
+func (u *VideoUploader) sendChunk(ctx context.Context, shop CommonParamRequest, state *VideoUploadState, n int, data []byte) (*UploadChunkResp, error) {
+	body, err := u.client.uploadFile(ctx, state.UploadURL, shop, RequestUploadFile{
+		UploadToken: state.UploadToken,
+		ChunkNum:    n,
+		FileBytes:   data,
+		ContentType: state.ContentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(UploadChunkResp)
+	if strings.TrimSpace(body) == "" {
+		return res, nil
+	}
+	if err := json.Unmarshal([]byte(body), res); err != nil {
+		return nil, ResponseDecodingError{Body: []byte(body), Message: err.Error()}
+	}
+	if res.Code != 0 {
+		return nil, ResponseError{Message: res.Message, RequestID: res.RequestID, Code: res.Code}
+	}
+	return res, nil
+}
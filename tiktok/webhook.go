@@ -0,0 +1,380 @@
+package tiktok
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// https://partner.tiktokshop.com/docv2/page/64f1997e93f5dc028e357341
+type WebhookType int
+
+const (
+	WebhookOrderStatusChange               WebhookType = 1
+	WebhookReverseStatusUpdate             WebhookType = 2
+	WebhookRecipientAddressUpdate          WebhookType = 3
+	WebhookPackageUpdate                   WebhookType = 4
+	WebhookProductStatusChange             WebhookType = 5
+	WebhookSellerDeauthorization           WebhookType = 6
+	WebhookUpcomingAuthorizationExpiration WebhookType = 7
+	WebhookCancellationStatusChange        WebhookType = 11
+	WebhookReturnStatusChange              WebhookType = 12
+	WebhookNewConversation                 WebhookType = 13
+	WebhookNewMessage                      WebhookType = 14
+)
+
+const (
+	// DefaultWebhookTolerance is how far a webhook timestamp may be from now.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	// maxWebhookBody is the largest webhook body the handler reads.
+	maxWebhookBody = 1 << 20
+)
+
+var (
+	ErrWebhookSignature = errors.New("tiktok: invalid webhook signature")
+	ErrWebhookStale     = errors.New("tiktok: webhook timestamp outside tolerance")
+)
+
+// WebhookEvent is the envelope of every webhook, Data holds the type specific
+// part.
+type WebhookEvent struct {
+	Type           WebhookType     `json:"type"`
+	NotificationID string          `json:"tts_notification_id"`
+	ShopID         string          `json:"shop_id"`
+	Timestamp      int64           `json:"timestamp"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// OrderStatusChangeEvent carries the order fields sent with the webhook: ID,
+// Status, IsOnHoldOrder and UpdateTime. Use Order.GetOrder for the rest.
+type OrderStatusChangeEvent struct {
+	ShopID    string
+	Timestamp int64
+	Order     Order
+}
+
+// ProductStatusChangeEvent is sent when a product changes status, including
+// audit results.
+type ProductStatusChangeEvent struct {
+	ShopID          string `json:"-"`
+	Timestamp       int64  `json:"-"`
+	ProductID       string `json:"product_id"`
+	Status          string `json:"status"`
+	SuspendedReason string `json:"suspended_reason"`
+	UpdateTime      int64  `json:"update_time"`
+}
+
+type NewConversationEvent struct {
+	ShopID         string `json:"-"`
+	Timestamp      int64  `json:"-"`
+	ConversationID string `json:"conversation_id"`
+	CreateTime     int64  `json:"create_time"`
+}
+
+type NewMessageEvent struct {
+	ShopID         string
+	Timestamp      int64
+	ConversationID string
+	Message        MessagesConversation
+}
+
+// VerifyWebhookSignature checks the Authorization header of a webhook, which
+// is HMAC-SHA256(appSecret, appKey + body) in hex of either case.
+func VerifyWebhookSignature(appKey, appSecret string, body []byte, signature string) bool {
+	sum, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	h := hmac.New(sha256.New, []byte(appSecret))
+	h.Write([]byte(appKey))
+	h.Write(body)
+	return hmac.Equal(h.Sum(nil), sum)
+}
+
+// WebhookOption is used to configure a WebhookHandler with options
+type WebhookOption func(h *WebhookHandler)
+
+// WithWebhookTolerance sets how far a webhook timestamp may be from now,
+// webhooks outside are rejected as stale.
+func WithWebhookTolerance(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = d
+	}
+}
+
+// WebhookHandler is an http.Handler receiving TikTok Shop webhooks. It
+// verifies the signature, rejects stale timestamps and notification ids it
+// already handled, decodes the event and calls the subscriber of its type,
+// or the OnEvent subscriber when there is none.
+//
+// A subscriber error answers 500 so TikTok sends the webhook again. A replay
+// of a handled notification is answered 200 without calling subscribers, so
+// TikTok stops resending it, as is one arriving while the same notification is
+// being handled. Notification ids are remembered in memory for
+// the tolerance window, which does not cover replays across instances.
+//
+// Subscribe before serving, subscribers are not guarded for concurrent
+// registration.
+type WebhookHandler struct {
+	appKey    string
+	appSecret string
+	tolerance time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+
+	onEvent               func(ctx context.Context, e *WebhookEvent) error
+	onOrderStatusChange   func(ctx context.Context, e *OrderStatusChangeEvent) error
+	onProductStatusChange func(ctx context.Context, e *ProductStatusChangeEvent) error
+	onNewConversation     func(ctx context.Context, e *NewConversationEvent) error
+	onNewMessage          func(ctx context.Context, e *NewMessageEvent) error
+}
+
+func NewWebhookHandler(app AppConfig, opts ...WebhookOption) *WebhookHandler {
+	h := &WebhookHandler{
+		appKey:    app.AppKey,
+		appSecret: app.AppSecret,
+		tolerance: DefaultWebhookTolerance,
+		seen:      make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// OnEvent sets the subscriber for events without a typed subscriber.
+func (h *WebhookHandler) OnEvent(fn func(ctx context.Context, e *WebhookEvent) error) {
+	h.onEvent = fn
+}
+
+func (h *WebhookHandler) OnOrderStatusChange(fn func(ctx context.Context, e *OrderStatusChangeEvent) error) {
+	h.onOrderStatusChange = fn
+}
+
+func (h *WebhookHandler) OnProductStatusChange(fn func(ctx context.Context, e *ProductStatusChangeEvent) error) {
+	h.onProductStatusChange = fn
+}
+
+func (h *WebhookHandler) OnNewConversation(fn func(ctx context.Context, e *NewConversationEvent) error) {
+	h.onNewConversation = fn
+}
+
+func (h *WebhookHandler) OnNewMessage(fn func(ctx context.Context, e *NewMessageEvent) error) {
+	h.onNewMessage = fn
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.Parse(body, r.Header.Get("Authorization"))
+	switch {
+	case errors.Is(err, ErrWebhookSignature):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if e.NotificationID != "" && !h.claim(e.NotificationID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), e); err != nil {
+		if e.NotificationID != "" {
+			// accept the retry of TikTok
+			h.release(e.NotificationID)
+		}
+		status := http.StatusInternalServerError
+		var dataErr *WebhookDataError
+		if errors.As(err, &dataErr) {
+			// a retry would not decode any better
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Parse verifies and decodes a webhook body, returning ErrWebhookSignature or
+// ErrWebhookStale when it must not be trusted.
+func (h *WebhookHandler) Parse(body []byte, signature string) (*WebhookEvent, error) {
+	if !VerifyWebhookSignature(h.appKey, h.appSecret, body, signature) {
+		return nil, ErrWebhookSignature
+	}
+
+	e := new(WebhookEvent)
+	if err := json.Unmarshal(body, e); err != nil {
+		return nil, fmt.Errorf("tiktok: decode webhook: %w", err)
+	}
+
+	age := time.Since(time.Unix(e.Timestamp, 0))
+	if age > h.tolerance || age < -h.tolerance {
+		return nil, ErrWebhookStale
+	}
+	return e, nil
+}
+
+// claim marks id as handled and tells whether it was not already, a
+// concurrent delivery of the same id is only claimed once.
+func (h *WebhookHandler) claim(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.seen[id]; ok {
+		return false
+	}
+
+	// forget ids older than the tolerance, their webhooks are stale anyway
+	now := time.Now()
+	for seenID, at := range h.seen {
+		if now.Sub(at) > 2*h.tolerance {
+			delete(h.seen, seenID)
+		}
+	}
+	h.seen[id] = now
+	return true
+}
+
+// release forgets id after its dispatch failed.
+func (h *WebhookHandler) release(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.seen, id)
+}
+
+// webhookMessage is the data of a NEW_MESSAGE webhook.
+type webhookMessage struct {
+	MessageID      string  `json:"message_id"`
+	ConversationID string  `json:"conversation_id"`
+	Type           string  `json:"type"`
+	Content        string  `json:"content"`
+	CreateTime     int     `json:"create_time"`
+	IsVisible      bool    `json:"is_visible"`
+	Sender         *Sender `json:"sender"`
+}
+
+// webhookOrder is the data of an ORDER_STATUS_CHANGE webhook.
+type webhookOrder struct {
+	OrderID       string `json:"order_id"`
+	OrderStatus   string `json:"order_status"`
+	IsOnHoldOrder bool   `json:"is_on_hold_order"`
+	UpdateTime    int64  `json:"update_time"`
+}
+
+// Dispatch decodes a verified webhook and calls its subscriber, it is used by
+// ServeHTTP and is handy for webhooks received through a queue.
+func (h *WebhookHandler) Dispatch(ctx context.Context, e *WebhookEvent) error {
+	switch e.Type {
+	case WebhookOrderStatusChange:
+		if h.onOrderStatusChange == nil {
+			break
+		}
+		var data webhookOrder
+		if err := decodeWebhookData(e, &data); err != nil {
+			return err
+		}
+		return h.onOrderStatusChange(ctx, &OrderStatusChangeEvent{
+			ShopID:    e.ShopID,
+			Timestamp: e.Timestamp,
+			Order: Order{
+				ID:            data.OrderID,
+				Status:        data.OrderStatus,
+				IsOnHoldOrder: data.IsOnHoldOrder,
+				UpdateTime:    data.UpdateTime,
+			},
+		})
+
+	case WebhookProductStatusChange:
+		if h.onProductStatusChange == nil {
+			break
+		}
+		data := &ProductStatusChangeEvent{ShopID: e.ShopID, Timestamp: e.Timestamp}
+		if err := decodeWebhookData(e, data); err != nil {
+			return err
+		}
+		return h.onProductStatusChange(ctx, data)
+
+	case WebhookNewConversation:
+		if h.onNewConversation == nil {
+			break
+		}
+		data := &NewConversationEvent{ShopID: e.ShopID, Timestamp: e.Timestamp}
+		if err := decodeWebhookData(e, data); err != nil {
+			return err
+		}
+		return h.onNewConversation(ctx, data)
+
+	case WebhookNewMessage:
+		if h.onNewMessage == nil {
+			break
+		}
+		var data webhookMessage
+		if err := decodeWebhookData(e, &data); err != nil {
+			return err
+		}
+		return h.onNewMessage(ctx, &NewMessageEvent{
+			ShopID:         e.ShopID,
+			Timestamp:      e.Timestamp,
+			ConversationID: data.ConversationID,
+			Message: MessagesConversation{
+				ID:         data.MessageID,
+				Type:       data.Type,
+				Content:    data.Content,
+				CreateTime: data.CreateTime,
+				IsVisible:  data.IsVisible,
+				Sender:     data.Sender,
+			},
+		})
+	}
+
+	if h.onEvent != nil {
+		return h.onEvent(ctx, e)
+	}
+	return nil
+}
+
+// WebhookDataError is returned by Dispatch when the data of a webhook does
+// not match its type.
+type WebhookDataError struct {
+	Type WebhookType
+	Err  error
+}
+
+func (e *WebhookDataError) Error() string {
+	return fmt.Sprintf("tiktok: decode webhook type %d: %s", e.Type, e.Err)
+}
+
+func (e *WebhookDataError) Unwrap() error {
+	return e.Err
+}
+
+func decodeWebhookData(e *WebhookEvent, v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return &WebhookDataError{Type: e.Type, Err: err}
+	}
+	return nil
+}
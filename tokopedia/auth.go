@@ -1,6 +1,7 @@
 package tokopedia
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -15,6 +16,7 @@ type TokopediaAuthResponse struct {
 
 type AuthService interface {
 	GetToken(clientID string, secret string) (res *TokopediaAuthResponse, err error)
+	GetTokenWithContext(ctx context.Context, clientID, secret string) (*TokopediaAuthResponse, error)
 }
 
 type AuthServiceOp struct {
@@ -25,6 +27,10 @@ type AuthServiceOp struct {
 // It accepts two parameters: a context (for managing the lifecycle of the request), and data (which contains authentication credentials).
 // The function returns a pointer to a TokopediaAuthResponse and an error.
 func (s *AuthServiceOp) GetToken(clientID, secret string) (*TokopediaAuthResponse, error) {
+	return s.GetTokenWithContext(context.Background(), clientID, secret)
+}
+
+func (s *AuthServiceOp) GetTokenWithContext(ctx context.Context, clientID, secret string) (*TokopediaAuthResponse, error) {
 
 	// Encode client ID and secret in base64
 	var token string
@@ -43,7 +49,7 @@ func (s *AuthServiceOp) GetToken(clientID, secret string) (*TokopediaAuthRespons
 	}
 
 	s.client.baseURL = authURL
-	err = s.client.WithBasicAuth(token).Post(path, nil, resp)
+	err = s.client.WithBasicAuth(token).PostWithContext(ctx, path, nil, resp)
 
 	if err != nil {
 		return nil, err
@@ -1,7 +1,9 @@
 package tokopedia
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 type MessageResponse struct {
@@ -107,8 +109,13 @@ type SendMessageResponseData struct {
 
 type ChatService interface {
 	GetMessagesList(token string, params GetMessagesParams) (res *MessageResponse, err error)
+	GetMessagesListWithContext(ctx context.Context, token string, params GetMessagesParams) (res *MessageResponse, err error)
 	GetReplyList(token string, params GetReplyListParams) (res *ReplyListResponse, err error)
+	GetReplyListWithContext(ctx context.Context, token string, params GetReplyListParams) (res *ReplyListResponse, err error)
+	Messages(ctx context.Context, token string, params GetMessagesParams) iter.Seq2[MessageData, error]
+	Replies(ctx context.Context, token string, params GetReplyListParams) iter.Seq2[ReplyData, error]
 	SendMessage(token string, msgID int, body SendMessageBody) (res *SendMessageResponse, err error)
+	SendMessageWithContext(ctx context.Context, token string, msgID int, body SendMessageBody) (res *SendMessageResponse, err error)
 }
 
 type ChatServiceOp struct {
@@ -116,6 +123,10 @@ type ChatServiceOp struct {
 }
 
 func (s *ChatServiceOp) GetMessagesList(token string, params GetMessagesParams) (res *MessageResponse, err error) {
+	return s.GetMessagesListWithContext(context.Background(), token, params)
+}
+
+func (s *ChatServiceOp) GetMessagesListWithContext(ctx context.Context, token string, params GetMessagesParams) (res *MessageResponse, err error) {
 
 	path := fmt.Sprintf("/v1/chat/fs/%d/messages", s.client.appConfig.FsID)
 
@@ -124,7 +135,7 @@ func (s *ChatServiceOp) GetMessagesList(token string, params GetMessagesParams)
 	}
 
 	resp := new(MessageResponse)
-	err = s.client.WithAccessToken(token).Get(path, resp, params)
+	err = s.client.WithAccessToken(token).GetWithContext(ctx, path, resp, params)
 
 	if err != nil {
 		return nil, err
@@ -133,19 +144,95 @@ func (s *ChatServiceOp) GetMessagesList(token string, params GetMessagesParams)
 	return resp, err
 }
 
+// Messages iterates over the chats of a shop page by page, starting at
+// params.Page, until a page is shorter than params.PerPage. A failed page is
+// yielded as an error and ends the iteration.
+func (s *ChatServiceOp) Messages(ctx context.Context, token string, params GetMessagesParams) iter.Seq2[MessageData, error] {
+	return func(yield func(MessageData, error) bool) {
+		// the pages are counted on a copy, each range starts at the first
+		params := params
+		params.Page, params.PerPage = firstPage(params.Page, params.PerPage)
+		for {
+			resp, err := s.GetMessagesListWithContext(ctx, token, params)
+			if err != nil {
+				yield(MessageData{}, err)
+				return
+			}
+
+			for _, m := range resp.Data {
+				if !yield(m, nil) {
+					return
+				}
+			}
+
+			if len(resp.Data) < params.PerPage {
+				return
+			}
+			params.Page++
+		}
+	}
+}
+
+// Replies iterates over the replies of a chat page by page, starting at
+// params.Page, until a page is shorter than params.PerPage. A failed page is
+// yielded as an error and ends the iteration.
+func (s *ChatServiceOp) Replies(ctx context.Context, token string, params GetReplyListParams) iter.Seq2[ReplyData, error] {
+	return func(yield func(ReplyData, error) bool) {
+		params := params
+		params.Page, params.PerPage = firstPage(params.Page, params.PerPage)
+		for {
+			resp, err := s.GetReplyListWithContext(ctx, token, params)
+			if err != nil {
+				yield(ReplyData{}, err)
+				return
+			}
+
+			for _, r := range resp.Data {
+				if !yield(r, nil) {
+					return
+				}
+			}
+
+			if len(resp.Data) < params.PerPage {
+				return
+			}
+			params.Page++
+		}
+	}
+}
+
+// firstPage defaults the page to 1 and the page size to 20.
+func firstPage(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 20
+	}
+	return page, perPage
+}
+
 func (s *ChatServiceOp) GetReplyList(token string, params GetReplyListParams) (res *ReplyListResponse, err error) {
+	return s.GetReplyListWithContext(context.Background(), token, params)
+}
+
+func (s *ChatServiceOp) GetReplyListWithContext(ctx context.Context, token string, params GetReplyListParams) (res *ReplyListResponse, err error) {
 
 	path := fmt.Sprintf("/v1/chat/fs/%d/messages/%d/replies", s.client.appConfig.FsID, params.MsgID)
 	resp := new(ReplyListResponse)
-	err = s.client.WithAccessToken(token).Get(path, resp, params)
+	err = s.client.WithAccessToken(token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 
 }
 
 func (s *ChatServiceOp) SendMessage(token string, msgID int, body SendMessageBody) (res *SendMessageResponse, err error) {
+	return s.SendMessageWithContext(context.Background(), token, msgID, body)
+}
+
+func (s *ChatServiceOp) SendMessageWithContext(ctx context.Context, token string, msgID int, body SendMessageBody) (res *SendMessageResponse, err error) {
 	path := fmt.Sprintf("/v1/chat/fs/%d/messages/%d/reply", s.client.appConfig.FsID, msgID)
 	resp := new(SendMessageResponse)
-	err = s.client.WithAccessToken(token).Post(path, body, resp)
+	err = s.client.WithAccessToken(token).PostWithContext(ctx, path, body, resp)
 	return resp, err
 
 }
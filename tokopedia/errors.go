@@ -0,0 +1,44 @@
+package tokopedia
+
+import "github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+
+// Codes categorizes the error_code of Tokopedia headers, see apierror.
+//
+// It is empty on purpose. Tokopedia answers an invalid token with 401, a
+// shop the app may not access with 403, an unknown resource with 404, an
+// invalid parameter with 400 and throttling with 429, which Category maps
+// without a code. Its error_code values are free text set per endpoint, none
+// of which tells a category apart from its status.
+var Codes = apierror.Codes{}
+
+var _ apierror.Error = ResponseError{}
+
+// GetMarketplace returns apierror.Tokopedia
+func (e ResponseError) GetMarketplace() apierror.Marketplace {
+	return apierror.Tokopedia
+}
+
+// GetStatus returns http response status
+func (e ResponseError) GetStatus() int {
+	return e.Status
+}
+
+// GetCode returns the error_code of the header
+func (e ResponseError) GetCode() string {
+	return string(e.Header.ErrorCode)
+}
+
+// GetRequestID returns the request id of the call
+func (e ResponseError) GetRequestID() string {
+	return e.ReqID
+}
+
+// Retryable tells whether the call may succeed later
+func (e ResponseError) Retryable() bool {
+	return Codes.Retryable(e.Status, e.GetCode())
+}
+
+// Is matches the apierror category of e.
+func (e ResponseError) Is(target error) bool {
+	return Codes.Match(e.Status, e.GetCode(), target)
+}
@@ -0,0 +1,65 @@
+package tokopedia
+
+import (
+	"context"
+	"fmt"
+)
+
+type LogisticService interface {
+	RequestPickup(token string, body RequestPickupBody) (res *RequestPickupResponse, err error)
+	RequestPickupWithContext(ctx context.Context, token string, body RequestPickupBody) (res *RequestPickupResponse, err error)
+	GetShippingLabel(token string, orderID int64, printed bool) (label []byte, err error)
+	GetShippingLabelWithContext(ctx context.Context, token string, orderID int64, printed bool) (label []byte, err error)
+}
+
+type LogisticServiceOp struct {
+	client *TokopediaClient
+}
+
+type RequestPickupBody struct {
+	OrderID int64 `json:"order_id"`
+	ShopID  int64 `json:"shop_id"`
+}
+
+type RequestPickupResponse struct {
+	BaseResponse
+	Data struct {
+		OrderID     int64  `json:"order_id"`
+		ShopID      int64  `json:"shop_id"`
+		RequestTime string `json:"request_time"`
+		Result      string `json:"result"`
+	} `json:"data"`
+}
+
+// RequestPickup asks the courier of an accepted order to pick it up.
+func (l *LogisticServiceOp) RequestPickup(token string, body RequestPickupBody) (res *RequestPickupResponse, err error) {
+	return l.RequestPickupWithContext(context.Background(), token, body)
+}
+
+func (l *LogisticServiceOp) RequestPickupWithContext(ctx context.Context, token string, body RequestPickupBody) (res *RequestPickupResponse, err error) {
+	path := fmt.Sprintf("/inventory/v1/fs/%d/pick-up", l.client.appConfig.FsID)
+	resp := new(RequestPickupResponse)
+	err = l.client.WithAccessToken(token).PostWithContext(ctx, path, body, resp)
+	return resp, err
+}
+
+type shippingLabelParams struct {
+	Printed int `url:"printed"`
+}
+
+// GetShippingLabel returns the shipping label of an order as an HTML page,
+// printed marks the label as printed on Tokopedia.
+func (l *LogisticServiceOp) GetShippingLabel(token string, orderID int64, printed bool) (label []byte, err error) {
+	return l.GetShippingLabelWithContext(context.Background(), token, orderID, printed)
+}
+
+func (l *LogisticServiceOp) GetShippingLabelWithContext(ctx context.Context, token string, orderID int64, printed bool) (label []byte, err error) {
+	path := fmt.Sprintf("/v1/order/%d/fs/%d/shipping-label", orderID, l.client.appConfig.FsID)
+	params := shippingLabelParams{}
+	if printed {
+		params.Printed = 1
+	}
+
+	err = l.client.WithAccessToken(token).GetWithContext(ctx, path, &label, params)
+	return label, err
+}
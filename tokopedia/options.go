@@ -1,17 +1,71 @@
 package tokopedia
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/apierror"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 	"golang.org/x/net/proxy"
 )
 
 // Option is used to configure client with options
 type Option func(c *TokopediaClient)
 
+// WithMiddleware routes requests through a middleware.Transport, retrying
+// throttled calls after X-Ratelimit-Full-Reset-After and rate limiting by app
+// and shop. It also applies to the handlers of NewTokopediaHTTPHandler. It
+// wraps the transport set by the other options whatever their order, and
+// turns WithRetry off.
+func WithMiddleware(opts ...middleware.Option) Option {
+	return func(c *TokopediaClient) {
+		c.middleware = append([]middleware.Option{
+			middleware.WithRetryable(Retryable),
+			middleware.WithShopKey(shopKey),
+		}, opts...)
+	}
+}
+
+// WithRecorder records the calls of the client and of its
+// NewTokopediaHTTPHandler handlers to r or replays them, see cassette.
+func WithRecorder(r *cassette.Recorder) Option {
+	return func(c *TokopediaClient) {
+		c.recorder = r
+	}
+}
+
+// WithTelemetry traces and measures every call of the client and of its
+// NewTokopediaHTTPHandler handlers with OpenTelemetry, see telemetry.
+func WithTelemetry(opts ...telemetry.Option) Option {
+	return func(c *TokopediaClient) {
+		c.telemetry = append([]telemetry.Option{}, opts...)
+	}
+}
+
+// Retryable is the middleware.Retryable of Tokopedia, throttled calls are
+// retried once the full rate limit window resets.
+func Retryable(req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		wait := middleware.RetryAfter(resp.Header, "X-Ratelimit-Full-Reset-After")
+		if wait == 0 {
+			wait = middleware.RetryAfter(resp.Header, "Retry-After")
+		}
+		return true, wait
+	}
+	return middleware.DefaultRetryable(req, resp, err)
+}
+
+// shopKey picks the rate limit bucket of a request by its shop.
+func shopKey(req *http.Request) string {
+	return req.URL.Query().Get("shop_id")
+}
+
 func WithLogger(logger LeveledLoggerInterface) Option {
 	return func(c *TokopediaClient) {
 		c.log = logger
@@ -55,3 +109,30 @@ func WithSocks5(socksAddress string) Option {
 		c.Client.Transport = &http.Transport{Dial: dialer.Dial}
 	}
 }
+
+// telemetryMarketplace describes the calls of Tokopedia to the telemetry
+// transport.
+var telemetryMarketplace = telemetry.Marketplace{
+	Name:    string(apierror.Tokopedia),
+	ShopKey: shopKey,
+	Route:   route,
+	Describe: func(resp *http.Response) (string, string) {
+		if !isJSON(resp) {
+			return "", ""
+		}
+		var body ResponseError
+		json.Unmarshal(middleware.PeekBody(resp), &body)
+		return string(body.Header.ErrorCode), body.ReqID
+	},
+}
+
+// route replaces the ids in a path, e.g. /v1/order/{id}/fs/{id}/ack.
+func route(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if s != "" && strings.Trim(s, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
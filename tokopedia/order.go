@@ -0,0 +1,410 @@
+package tokopedia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
+)
+
+// currencyIDR is the currency of Tokopedia amounts, which are sent without
+// one.
+const currencyIDR = "IDR"
+
+// Order statuses, see https://developer.tokopedia.com/openapi/guide/#/order/orderstatus
+const (
+	OrderStatusSellerCancel       = 0
+	OrderStatusSellerRejected     = 10
+	OrderStatusPaymentVerified    = 220
+	OrderStatusSellerAccepted     = 400
+	OrderStatusWaitingPickup      = 450
+	OrderStatusShipped            = 500
+	OrderStatusInvalidShipmentRef = 520
+	OrderStatusDelivered          = 600
+	OrderStatusBuyerOpenCase      = 601
+	OrderStatusFinished           = 700
+)
+
+// Reasons to reject an order
+const (
+	RejectReasonOutOfStock         = 1
+	RejectReasonVariantUnavailable = 2
+	RejectReasonWrongPriceOrWeight = 3
+	RejectReasonShopClosed         = 4
+	RejectReasonOthers             = 5
+	RejectReasonCourierProblem     = 7
+	RejectReasonBuyerRequest       = 8
+)
+
+type OrderService interface {
+	GetOrders(token string, params GetOrdersParams) (res *GetOrdersResponse, err error)
+	GetOrdersWithContext(ctx context.Context, token string, params GetOrdersParams) (res *GetOrdersResponse, err error)
+	GetOrder(token string, params GetOrderParams) (res *GetOrderResponse, err error)
+	GetOrderWithContext(ctx context.Context, token string, params GetOrderParams) (res *GetOrderResponse, err error)
+	AcceptOrder(token string, orderID int64) (res *OrderActionResponse, err error)
+	AcceptOrderWithContext(ctx context.Context, token string, orderID int64) (res *OrderActionResponse, err error)
+	RejectOrder(token string, orderID int64, body RejectOrderBody) (res *OrderActionResponse, err error)
+	RejectOrderWithContext(ctx context.Context, token string, orderID int64, body RejectOrderBody) (res *OrderActionResponse, err error)
+	ConfirmShipping(token string, orderID int64, awb string) (res *OrderActionResponse, err error)
+	ConfirmShippingWithContext(ctx context.Context, token string, orderID int64, awb string) (res *OrderActionResponse, err error)
+}
+
+type OrderServiceOp struct {
+	client *TokopediaClient
+}
+
+type GetOrdersParams struct {
+	FromDate int64 `url:"from_date"` // epoch based, at most 3 days before ToDate
+	ToDate   int64 `url:"to_date"`
+	Page     int   `url:"page"`
+	PerPage  int   `url:"per_page"`
+	ShopID   int64 `url:"shop_id,omitempty"`
+	Status   int   `url:"status,omitempty"`
+}
+
+type GetOrdersResponse struct {
+	BaseResponse
+	Data []Order `json:"data"`
+}
+
+// Order is an order of the order list, it is also the payload of the order
+// notification webhook.
+type Order struct {
+	FsID              int64               `json:"fs_id"`
+	OrderID           int64               `json:"order_id"`
+	InvoiceRefNum     string              `json:"invoice_ref_num"`
+	AcceptPartial     bool                `json:"accept_partial"`
+	IsCodMitra        bool                `json:"is_cod_mitra"`
+	Products          []OrderProduct      `json:"products"`
+	ProductsFulfilled []ProductsFulfilled `json:"products_fulfilled"`
+	DeviceType        string              `json:"device_type"`
+	Buyer             OrderBuyer          `json:"buyer"`
+	ShopID            int64               `json:"shop_id"`
+	PaymentID         int64               `json:"payment_id"`
+	Recipient         OrderRecipient      `json:"recipient"`
+	Logistics         OrderLogistics      `json:"logistics"`
+	Amt               OrderAmount         `json:"amt"`
+	VoucherInfo       struct {
+		VoucherCode string `json:"voucher_code"`
+		VoucherType int    `json:"voucher_type"`
+	} `json:"voucher_info"`
+	OrderStatus  int   `json:"order_status"`
+	WarehouseID  int64 `json:"warehouse_id"`
+	FulfillBy    int   `json:"fulfill_by"`
+	CreateTime   int64 `json:"create_time"`
+	CustomFields struct {
+		Awb string `json:"awb"`
+	} `json:"custom_fields"`
+	// Encryption holds the key of encrypted buyer data, when the app has
+	// encryption enabled.
+	Encryption *OrderEncryption `json:"encryption,omitempty"`
+}
+
+// UnmarshalJSON sets the currency of the amounts and product prices of the
+// order, Tokopedia sells in IDR.
+func (o *Order) UnmarshalJSON(b []byte) error {
+	type order Order
+	if err := json.Unmarshal(b, (*order)(o)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(currencyIDR, &o.Amt.TtlProductPrice, &o.Amt.ShippingCost, &o.Amt.InsuranceCost,
+		&o.Amt.TtlAmount, &o.Amt.VoucherAmount, &o.Amt.ToppointsAmount)
+	for i := range o.Products {
+		// the currency of a product is sent as "Rp"
+		money.FillCurrency(currencyIDR, &o.Products[i].Price, &o.Products[i].TotalPrice)
+	}
+	return nil
+}
+
+type OrderProduct struct {
+	ID          int64       `json:"id"`
+	Name        string      `json:"Name"`
+	Quantity    int         `json:"quantity"`
+	Notes       string      `json:"notes"`
+	Weight      float64     `json:"weight"`
+	TotalWeight float64     `json:"total_weight"`
+	Price       money.Money `json:"price"`
+	TotalPrice  money.Money `json:"total_price"`
+	Currency    string      `json:"currency"`
+	Sku         string      `json:"sku"`
+	IsWholesale bool        `json:"is_wholesale"`
+}
+
+type ProductsFulfilled struct {
+	ProductID       int64 `json:"product_id"`
+	QuantityDeliver int   `json:"quantity_deliver"`
+	QuantityReject  int   `json:"quantity_reject"`
+}
+
+type OrderBuyer struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"Name"`
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+}
+
+type OrderRecipient struct {
+	Name    string `json:"Name"`
+	Phone   string `json:"phone"`
+	Address struct {
+		AddressFull string `json:"address_full"`
+		District    string `json:"district"`
+		City        string `json:"city"`
+		Province    string `json:"province"`
+		Country     string `json:"country"`
+		PostalCode  string `json:"postal_code"`
+		DistrictID  int64  `json:"district_id"`
+		CityID      int64  `json:"city_id"`
+		ProvinceID  int64  `json:"province_id"`
+		Geo         string `json:"geo"`
+	} `json:"address"`
+}
+
+type OrderLogistics struct {
+	ShippingID     int64  `json:"shipping_id"`
+	DistrictID     int64  `json:"district_id"`
+	CityID         int64  `json:"city_id"`
+	ProvinceID     int64  `json:"province_id"`
+	Geo            string `json:"geo"`
+	ShippingAgency string `json:"shipping_agency"`
+	ServiceType    string `json:"service_type"`
+}
+
+type OrderAmount struct {
+	TtlProductPrice money.Money `json:"ttl_product_price"`
+	ShippingCost    money.Money `json:"shipping_cost"`
+	InsuranceCost   money.Money `json:"insurance_cost"`
+	TtlAmount       money.Money `json:"ttl_amount"`
+	VoucherAmount   money.Money `json:"voucher_amount"`
+	ToppointsAmount money.Money `json:"toppoints_amount"`
+}
+
+type OrderEncryption struct {
+	Secret  string `json:"secret"`
+	Content string `json:"content"`
+}
+
+func (o *OrderServiceOp) GetOrders(token string, params GetOrdersParams) (res *GetOrdersResponse, err error) {
+	return o.GetOrdersWithContext(context.Background(), token, params)
+}
+
+func (o *OrderServiceOp) GetOrdersWithContext(ctx context.Context, token string, params GetOrdersParams) (res *GetOrdersResponse, err error) {
+	if o.client.appConfig.FsID == 0 {
+		return nil, fmt.Errorf("fs_id is required")
+	}
+
+	path := "/v2/order/list"
+	resp := new(GetOrdersResponse)
+	err = o.client.WithAccessToken(token).GetWithContext(ctx, path, resp, struct {
+		FsID int `url:"fs_id"`
+		GetOrdersParams
+	}{o.client.appConfig.FsID, params})
+	return resp, err
+}
+
+// GetOrderParams finds an order by OrderID or by InvoiceNum.
+type GetOrderParams struct {
+	OrderID    int64  `url:"order_id,omitempty"`
+	InvoiceNum string `url:"invoice_num,omitempty"`
+}
+
+type GetOrderResponse struct {
+	BaseResponse
+	Data OrderDetail `json:"data"`
+}
+
+type OrderDetail struct {
+	OrderID       int64       `json:"order_id"`
+	BuyerID       int64       `json:"buyer_id"`
+	SellerID      int64       `json:"seller_id"`
+	PaymentID     int64       `json:"payment_id"`
+	OrderStatus   int         `json:"order_status"`
+	InvoiceNumber string      `json:"invoice_number"`
+	InvoicePdf    string      `json:"invoice_pdf"`
+	InvoiceURL    string      `json:"invoice_url"`
+	OpenAmt       money.Money `json:"open_amt"`
+	ItemPrice     money.Money `json:"item_price"`
+	Comment       string      `json:"comment"`
+	BuyerInfo     struct {
+		BuyerID       int64  `json:"buyer_id"`
+		BuyerFullname string `json:"buyer_fullname"`
+		BuyerEmail    string `json:"buyer_email"`
+		BuyerPhone    string `json:"buyer_phone"`
+	} `json:"buyer_info"`
+	ShopInfo struct {
+		ShopID     int64  `json:"shop_id"`
+		ShopName   string `json:"shop_name"`
+		ShopDomain string `json:"shop_domain"`
+	} `json:"shop_info"`
+	OrderInfo struct {
+		OrderDetail    []OrderDetailItem `json:"order_detail"`
+		OrderHistory   []OrderHistory    `json:"order_history"`
+		ShippingInfo   OrderShippingInfo `json:"shipping_info"`
+		Destination    OrderDestination  `json:"destination"`
+		PartialProcess int               `json:"partial_process"`
+		IsReplacement  bool              `json:"is_replacement"`
+	} `json:"order_info"`
+	PaymentInfo struct {
+		PaymentID       int64       `json:"payment_id"`
+		PaymentRefNum   string      `json:"payment_ref_num"`
+		PaymentDate     string      `json:"payment_date"`
+		PaymentMethod   int         `json:"payment_method"`
+		PaymentStatus   string      `json:"payment_status"`
+		PaymentStatusID int         `json:"payment_status_id"`
+		GatewayName     string      `json:"gateway_name"`
+		DiscountAmount  money.Money `json:"discount_amount"`
+		VoucherCode     string      `json:"voucher_code"`
+	} `json:"payment_info"`
+	InvoiceDate string `json:"invoice_date"`
+}
+
+func (o *OrderDetail) UnmarshalJSON(b []byte) error {
+	type orderDetail OrderDetail
+	if err := json.Unmarshal(b, (*orderDetail)(o)); err != nil {
+		return err
+	}
+
+	shipping := &o.OrderInfo.ShippingInfo
+	money.FillCurrency(currencyIDR, &o.OpenAmt, &o.ItemPrice, &o.PaymentInfo.DiscountAmount,
+		&shipping.ShippingPrice, &shipping.InsurancePrice)
+	for i := range o.OrderInfo.OrderDetail {
+		item := &o.OrderInfo.OrderDetail[i]
+		money.FillCurrency(currencyIDR, &item.ProductPrice, &item.SubtotalPrice, &item.InsurancePrice, &item.NormalPrice)
+	}
+	return nil
+}
+
+type OrderDetailItem struct {
+	OrderDetailID   int64       `json:"order_detail_id"`
+	ProductID       int64       `json:"product_id"`
+	ProductName     string      `json:"product_name"`
+	ProductPrice    money.Money `json:"product_price"`
+	SubtotalPrice   money.Money `json:"subtotal_price"`
+	Weight          float64     `json:"weight"`
+	TotalWeight     float64     `json:"total_weight"`
+	Quantity        int         `json:"quantity"`
+	QuantityDeliver int         `json:"quantity_deliver"`
+	QuantityReject  int         `json:"quantity_reject"`
+	InsurancePrice  money.Money `json:"insurance_price"`
+	NormalPrice     money.Money `json:"normal_price"`
+	CurrencyID      int         `json:"currency_id"`
+	ProductPicture  string      `json:"product_picture"`
+	SnapshotURL     string      `json:"snapshot_url"`
+	Sku             string      `json:"sku"`
+}
+
+type OrderHistory struct {
+	ActionBy       string `json:"action_by"`
+	HistStatusCode int    `json:"hist_status_code"`
+	Message        string `json:"message"`
+	Timestamp      string `json:"timestamp"`
+	Comment        string `json:"comment"`
+}
+
+type OrderShippingInfo struct {
+	SpID            int64       `json:"sp_id"`
+	ShippingID      int64       `json:"shipping_id"`
+	LogisticName    string      `json:"logistic_name"`
+	LogisticService string      `json:"logistic_service"`
+	ShippingPrice   money.Money `json:"shipping_price"`
+	InsurancePrice  money.Money `json:"insurance_price"`
+	Awb             string      `json:"awb"`
+	AutoresiAwb     string      `json:"autoresi_awb"`
+	IsCashless      bool        `json:"isCashless"`
+}
+
+type OrderDestination struct {
+	ReceiverName    string `json:"receiver_name"`
+	ReceiverPhone   string `json:"receiver_phone"`
+	AddressStreet   string `json:"address_street"`
+	AddressDistrict string `json:"address_district"`
+	AddressCity     string `json:"address_city"`
+	AddressProvince string `json:"address_province"`
+	AddressPostal   string `json:"address_postal"`
+}
+
+func (o *OrderServiceOp) GetOrder(token string, params GetOrderParams) (res *GetOrderResponse, err error) {
+	return o.GetOrderWithContext(context.Background(), token, params)
+}
+
+func (o *OrderServiceOp) GetOrderWithContext(ctx context.Context, token string, params GetOrderParams) (res *GetOrderResponse, err error) {
+	if params.OrderID == 0 && params.InvoiceNum == "" {
+		return nil, fmt.Errorf("order_id or invoice_num is required")
+	}
+
+	path := fmt.Sprintf("/v2/fs/%d/order", o.client.appConfig.FsID)
+	resp := new(GetOrderResponse)
+	err = o.client.WithAccessToken(token).GetWithContext(ctx, path, resp, params)
+	return resp, err
+}
+
+// OrderActionResponse answers accepting, rejecting and shipping an order,
+// Data is "success" when it went through.
+type OrderActionResponse struct {
+	BaseResponse
+	Data string `json:"data"`
+}
+
+func (o *OrderServiceOp) AcceptOrder(token string, orderID int64) (res *OrderActionResponse, err error) {
+	return o.AcceptOrderWithContext(context.Background(), token, orderID)
+}
+
+func (o *OrderServiceOp) AcceptOrderWithContext(ctx context.Context, token string, orderID int64) (res *OrderActionResponse, err error) {
+	path := fmt.Sprintf("/v1/order/%d/fs/%d/ack", orderID, o.client.appConfig.FsID)
+	resp := new(OrderActionResponse)
+	err = o.client.WithAccessToken(token).PostWithContext(ctx, path, nil, resp)
+	return resp, err
+}
+
+// RejectOrderBody tells why an order is rejected, ReasonCode is one of the
+// RejectReason constants. A shop closed rejection needs ShopCloseEndDate
+// (dd/mm/yyyy) and ShopCloseNote, an out of stock one the EmptyProducts.
+type RejectOrderBody struct {
+	ReasonCode       int            `json:"reason_code"`
+	Reason           string         `json:"reason,omitempty"`
+	ShopCloseEndDate string         `json:"shop_close_end_date,omitempty"`
+	ShopCloseNote    string         `json:"shop_close_note,omitempty"`
+	EmptyProducts    []EmptyProduct `json:"empty_products,omitempty"`
+}
+
+type EmptyProduct struct {
+	ProductID int64 `json:"product_id"`
+}
+
+func (o *OrderServiceOp) RejectOrder(token string, orderID int64, body RejectOrderBody) (res *OrderActionResponse, err error) {
+	return o.RejectOrderWithContext(context.Background(), token, orderID, body)
+}
+
+func (o *OrderServiceOp) RejectOrderWithContext(ctx context.Context, token string, orderID int64, body RejectOrderBody) (res *OrderActionResponse, err error) {
+	path := fmt.Sprintf("/v1/order/%d/fs/%d/nack", orderID, o.client.appConfig.FsID)
+	resp := new(OrderActionResponse)
+	err = o.client.WithAccessToken(token).PostWithContext(ctx, path, body, resp)
+	return resp, err
+}
+
+type confirmShippingBody struct {
+	OrderStatus    int    `json:"order_status"`
+	ShippingRefNum string `json:"shipping_ref_num"`
+}
+
+// ConfirmShipping marks an order shipped with the airway bill number of a
+// non pickup courier.
+func (o *OrderServiceOp) ConfirmShipping(token string, orderID int64, awb string) (res *OrderActionResponse, err error) {
+	return o.ConfirmShippingWithContext(context.Background(), token, orderID, awb)
+}
+
+func (o *OrderServiceOp) ConfirmShippingWithContext(ctx context.Context, token string, orderID int64, awb string) (res *OrderActionResponse, err error) {
+	if awb == "" {
+		return nil, fmt.Errorf("shipping_ref_num is required")
+	}
+
+	path := fmt.Sprintf("/v1/order/%d/fs/%d/status", orderID, o.client.appConfig.FsID)
+	resp := new(OrderActionResponse)
+	err = o.client.WithAccessToken(token).PostWithContext(ctx, path, confirmShippingBody{
+		OrderStatus:    OrderStatusShipped,
+		ShippingRefNum: awb,
+	}, resp)
+	return resp, err
+}
@@ -1,7 +1,11 @@
 package tokopedia
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/money"
 )
 
 type ProductInfoResponse struct {
@@ -24,10 +28,10 @@ type ProductData struct {
 		ShortDesc       string `json:"shortDesc"`
 	} `json:"basic"`
 	Price struct {
-		Value          int `json:"value"`
-		Currency       int `json:"currency"`
-		LastUpdateUnix int `json:"LastUpdateUnix"`
-		Idr            int `json:"idr"`
+		Value          money.Money `json:"value"`
+		Currency       int         `json:"currency"`
+		LastUpdateUnix int         `json:"LastUpdateUnix"`
+		Idr            money.Money `json:"idr"`
 	} `json:"price"`
 	Weight struct {
 		Value int `json:"value"`
@@ -85,8 +89,20 @@ type ProductData struct {
 	}
 }
 
+// UnmarshalJSON sets the currency of the price, Tokopedia sells in IDR.
+func (p *ProductData) UnmarshalJSON(b []byte) error {
+	type productData ProductData
+	if err := json.Unmarshal(b, (*productData)(p)); err != nil {
+		return err
+	}
+
+	money.FillCurrency(currencyIDR, &p.Price.Value, &p.Price.Idr)
+	return nil
+}
+
 type ProductService interface {
 	GetProductInfo(token string, productID int) (res *ProductInfoResponse, err error)
+	GetProductInfoWithContext(ctx context.Context, token string, productID int) (res *ProductInfoResponse, err error)
 }
 
 type ProductServiceOp struct {
@@ -94,6 +110,10 @@ type ProductServiceOp struct {
 }
 
 func (p *ProductServiceOp) GetProductInfo(token string, productID int) (res *ProductInfoResponse, err error) {
+	return p.GetProductInfoWithContext(context.Background(), token, productID)
+}
+
+func (p *ProductServiceOp) GetProductInfoWithContext(ctx context.Context, token string, productID int) (res *ProductInfoResponse, err error) {
 	path := fmt.Sprintf("/inventory/v1/fs/%d/product/info", p.client.appConfig.FsID)
 
 	resp := new(ProductInfoResponse)
@@ -106,7 +126,7 @@ func (p *ProductServiceOp) GetProductInfo(token string, productID int) (res *Pro
 		ProductID: productID,
 	}
 
-	err = p.client.WithAccessToken(token).Get(path, resp, params)
+	err = p.client.WithAccessToken(token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type BaseResponse struct {
@@ -13,20 +14,50 @@ type BaseResponse struct {
 }
 
 type HeaderResponse struct {
-	ProcessTime int               `json:"process_time"`
+	ProcessTime float64           `json:"process_time"`
 	Messages    string            `json:"messages"`
 	Message     string            `json:"message"`
 	Reason      string            `json:"reason"`
+	ErrorCode   ErrorCode         `json:"error_code"`
 	StatusCode  int               `json:"http_code"`
 	HTTPHeader  map[string]string `json:"http_header"`
 }
 
+// ErrorCode is the error_code of a header. Some endpoints send it as a
+// string, others as a number with 0 for no error.
+type ErrorCode string
+
+func (c *ErrorCode) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*c = ErrorCode(s)
+		return nil
+	}
+
+	var n *json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	if n == nil || *n == "0" {
+		*c = ""
+		return nil
+	}
+	*c = ErrorCode(*n)
+	return nil
+}
+
 // A general response error
 type ResponseError struct {
 	Header  HeaderResponse `json:"header"`
 	Data    interface{}    `json:"data"`
 	Message string         `json:"message"`
 	ReqID   string         `json:"req_id"`
+
+	// Status is the HTTP status of the response
+	Status int `json:"-"`
 }
 
 // GetMessage returns response error message
@@ -81,6 +112,12 @@ func CheckResponseError(r *http.Response) error {
 		r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}()
 
+	// a successful non JSON body, e.g. a shipping label, is not an error
+	success := http.StatusOK <= r.StatusCode && r.StatusCode < http.StatusMultipleChoices
+	if success && !isJSON(r) {
+		return nil
+	}
+
 	if len(bodyBytes) > 0 {
 		err := json.Unmarshal(bodyBytes, &tokopediaError)
 		if err != nil {
@@ -92,7 +129,7 @@ func CheckResponseError(r *http.Response) error {
 		}
 	}
 
-	if tokopediaError.Header.Reason == "" && http.StatusOK <= r.StatusCode && r.StatusCode < http.StatusMultipleChoices {
+	if tokopediaError.Header.Reason == "" && success {
 		return nil
 	}
 
@@ -108,12 +145,21 @@ func CheckResponseError(r *http.Response) error {
 		Header:  tokopediaError.Header,
 		Data:    tokopediaError.Data,
 		Message: tokopediaError.Message,
+		ReqID:   tokopediaError.ReqID,
+		Status:  r.StatusCode,
 	}
 	// log.Println(responseError.Header)
 
 	return wrapSpecificError(r, responseError)
 }
 
+// isJSON tells whether a response is JSON, a missing Content-Type is taken as
+// JSON.
+func isJSON(r *http.Response) bool {
+	ct := r.Header.Get("Content-Type")
+	return ct == "" || strings.Contains(ct, "json")
+}
+
 func wrapSpecificError(r *http.Response, err ResponseError) error {
 	// TODO: check rate-limit error for tokopedia
 	if r.StatusCode == http.StatusTooManyRequests {
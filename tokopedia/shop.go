@@ -1,6 +1,9 @@
 package tokopedia
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type ShopResponse struct {
 	BaseResponse
@@ -61,6 +64,7 @@ type ShopParams struct {
 
 type ShopService interface {
 	GetShopInfo(token string, params ShopParams) (res *ShopResponse, err error)
+	GetShopInfoWithContext(ctx context.Context, token string, params ShopParams) (res *ShopResponse, err error)
 }
 
 type ShopServiceOp struct {
@@ -68,9 +72,13 @@ type ShopServiceOp struct {
 }
 
 func (s *ShopServiceOp) GetShopInfo(token string, params ShopParams) (res *ShopResponse, err error) {
+	return s.GetShopInfoWithContext(context.Background(), token, params)
+}
+
+func (s *ShopServiceOp) GetShopInfoWithContext(ctx context.Context, token string, params ShopParams) (res *ShopResponse, err error) {
 	path := fmt.Sprintf("/v1/shop/fs/%d/shop-info", s.client.appConfig.FsID)
 	resp := new(ShopResponse)
 
-	err = s.client.WithAccessToken(token).Get(path, resp, params)
+	err = s.client.WithAccessToken(token).GetWithContext(ctx, path, resp, params)
 	return resp, err
 }
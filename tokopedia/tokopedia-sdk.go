@@ -3,6 +3,10 @@ package tokopedia
 import (
 	"net/http"
 	"net/url"
+
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 )
 
 const (
@@ -27,17 +31,27 @@ type TokopediaClient struct {
 	baseURL   *url.URL
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
+
+	// wraps the transport once options are applied, see WithMiddleware
+	middleware []middleware.Option
+
+	// records or replays the transport, see WithRecorder
+	recorder *cassette.Recorder
+
+	// traces and measures calls, see WithTelemetry
+	telemetry []telemetry.Option
 
 	AccessToken string
 	AuthToken   string
 	ShopID      string
 
-	Auth    AuthService
-	Chat    ChatService
-	Product ProductService
-	Shop    ShopService
+	Auth     AuthService
+	Chat     ChatService
+	Logistic LogisticService
+	Order    OrderService
+	Product  ProductService
+	Shop     ShopService
 }
 
 type CommonParamRequest struct {
@@ -62,6 +76,8 @@ func NewClient(app AppConfig, opts ...Option) *TokopediaClient {
 
 	c.Auth = &AuthServiceOp{client: c}
 	c.Chat = &ChatServiceOp{client: c}
+	c.Logistic = &LogisticServiceOp{client: c}
+	c.Order = &OrderServiceOp{client: c}
 	c.Product = &ProductServiceOp{client: c}
 	c.Shop = &ShopServiceOp{client: c}
 
@@ -69,6 +85,16 @@ func NewClient(app AppConfig, opts ...Option) *TokopediaClient {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.recorder != nil {
+		c.Client.Transport = c.recorder.Wrap(c.Client.Transport)
+	}
+	if c.telemetry != nil {
+		c.Client.Transport = telemetry.New(c.Client.Transport, telemetryMarketplace, c.telemetry...)
+	}
+	if c.middleware != nil {
+		c.Client.Transport = middleware.New(c.Client.Transport, c.middleware...)
+		c.retries = 0 // the middleware retries instead
+	}
 
 	return c
 }
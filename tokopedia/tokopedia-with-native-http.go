@@ -1,7 +1,7 @@
 package tokopedia
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +12,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/cassette"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/telemetry"
 	"golang.org/x/net/proxy"
 )
 
@@ -21,6 +25,10 @@ type TokopediaHTTPOpts struct {
 	ShopID            int64
 	SocksProxyAddress string
 	APIURL            string
+
+	recorder   *cassette.Recorder
+	telemetry  []telemetry.Option
+	middleware []middleware.Option
 }
 
 func NewTokopediaHTTPHandler(client *TokopediaClient, sockAddress string) (*TokopediaHTTPOpts, error) {
@@ -45,259 +53,153 @@ func NewTokopediaHTTPHandler(client *TokopediaClient, sockAddress string) (*Toko
 		ShopID:            int64(intShopID),
 		SocksProxyAddress: fmt.Sprintf("socks5://%s", sockAddress),
 		APIURL:            client.appConfig.APIURL,
+		recorder:          client.recorder,
+		telemetry:         client.telemetry,
+		middleware:        client.middleware,
 	}, nil
 }
 
-func (opts *TokopediaHTTPOpts) GetListMessages(params GetMessagesParams) (*MessageResponse, error) {
-	var client = &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-		Timeout: 80 * time.Second,
+// transport returns the socks transport of a call, wrapped by the recorder,
+// telemetry and middleware of the client in the order NewClient uses.
+func (opts *TokopediaHTTPOpts) transport(dialer proxy.Dialer) http.RoundTripper {
+	var rt http.RoundTripper = &http.Transport{Dial: dialer.Dial}
+	if opts.recorder != nil {
+		rt = opts.recorder.Wrap(rt)
 	}
-	proxyURL, err := url.Parse(opts.SocksProxyAddress)
-	if err != nil {
-		log.Println("error while parse socks address")
-		return nil, err
+	if opts.telemetry != nil {
+		rt = telemetry.New(rt, telemetryMarketplace, opts.telemetry...)
 	}
-
-	dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
-	if err != nil {
-		log.Println("error while make transport dialer")
-		return nil, err
+	if opts.middleware != nil {
+		rt = middleware.New(rt, opts.middleware...)
 	}
-	client.Transport = &http.Transport{Dial: dialer.Dial}
+	return rt
+}
 
+func (opts *TokopediaHTTPOpts) GetListMessages(params GetMessagesParams) (*MessageResponse, error) {
+	return opts.GetListMessagesWithContext(context.Background(), params)
+}
+
+func (opts *TokopediaHTTPOpts) GetListMessagesWithContext(ctx context.Context, params GetMessagesParams) (*MessageResponse, error) {
 	urlParam := fmt.Sprintf("%s/v1/chat/fs/%d/messages?page=%d&per_page=%d&shop_id=%d", opts.APIURL, opts.FsID, params.Page, params.PerPage, opts.ShopID)
-	req, err := http.NewRequest(http.MethodGet, urlParam, nil)
-	if err != nil {
+
+	var response MessageResponse
+	if err := opts.get(ctx, urlParam, &response); err != nil {
 		return nil, err
 	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+opts.Token)
-	req.Header.Add("Accept", "application/json")
+	return &response, nil
+}
+
+func (opts *TokopediaHTTPOpts) GetProductInfo(params ProductParams) (*ProductInfoResponse, error) {
+	return opts.GetProductInfoWithContext(context.Background(), params)
+}
 
-	isDone := false
-	maxRetries := 3
+func (opts *TokopediaHTTPOpts) GetProductInfoWithContext(ctx context.Context, params ProductParams) (*ProductInfoResponse, error) {
+	urlParam := fmt.Sprintf("%s/inventory/v1/fs/%d/product/info?product_id=%d", opts.APIURL, opts.FsID, params.ProductID)
 
-	var response MessageResponse
-	for !isDone {
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+	var response ProductInfoResponse
+	if err := opts.get(ctx, urlParam, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
 
-		if resp.StatusCode != 200 && resp.StatusCode != http.StatusTooManyRequests {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
-			}
-			log.Println("response:", map[string]interface{}{
-				"body": string(respBytes),
-				"code": resp.StatusCode,
-			})
-			return nil, errors.New("response not ok")
-		}
+func (opts *TokopediaHTTPOpts) GetReplyTokopedia(params GetReplyListParams) (*ReplyListResponse, error) {
+	return opts.GetReplyTokopediaWithContext(context.Background(), params)
+}
 
-		retryAfter := 0
-		if resp.StatusCode == http.StatusTooManyRequests {
-			headerReset := resp.Header.Get("X-Ratelimit-Full-Reset-After")
-			f, _ := strconv.ParseFloat(headerReset, 64)
-			retryAfter = int(f)
-			time.Sleep(time.Duration(retryAfter) * time.Second)
-			maxRetries--
-		} else {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
-			}
+func (opts *TokopediaHTTPOpts) GetReplyTokopediaWithContext(ctx context.Context, params GetReplyListParams) (*ReplyListResponse, error) {
+	urlParam := fmt.Sprintf("%s/v1/chat/fs/%d/messages/%d/replies?page=%d&per_page=%d&shop_id=%d", opts.APIURL, opts.FsID, params.MsgID, params.Page, params.PerPage, opts.ShopID)
 
-			if err := json.Unmarshal(respBytes, &response); err != nil {
-				log.Printf("Error unmarshaling response, cause: %+v\n", err)
-				log.Println("Response body:", string(respBytes))
-				return nil, err
-			}
-			isDone = true
-		}
-		if maxRetries == 0 {
-			isDone = true
-		}
+	var response ReplyListResponse
+	if err := opts.get(ctx, urlParam, &response); err != nil {
+		return nil, err
 	}
-
 	return &response, nil
 }
 
-func (opts *TokopediaHTTPOpts) GetProductInfo(params ProductParams) (*ProductInfoResponse, error) {
-	var client = &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-		Timeout: 80 * time.Second,
-	}
+// nativeAttempts is how many times a throttled native call is sent when the
+// handler has no middleware to retry it.
+const nativeAttempts = 3
 
+// get sends a GET of urlParam through the socks proxy and decodes the response
+// into v. A call still throttled after its last attempt returns the
+// RateLimitError of the response.
+func (opts *TokopediaHTTPOpts) get(ctx context.Context, urlParam string, v any) error {
 	proxyURL, err := url.Parse(opts.SocksProxyAddress)
 	if err != nil {
 		log.Println("error while parse socks address")
-		return nil, err
+		return err
 	}
 
 	dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
 	if err != nil {
-		log.Println("error while dialer")
-		return nil, err
+		log.Println("error while make transport dialer")
+		return err
+	}
+	client := &http.Client{
+		Transport: opts.transport(dialer),
+		Timeout:   80 * time.Second,
 	}
-	client.Transport = &http.Transport{Dial: dialer.Dial}
 
-	urlParam := fmt.Sprintf("%s/inventory/v1/fs/%d/product/info?product_id=%d", opts.APIURL, opts.FsID, params.ProductID)
-	req, err := http.NewRequest(http.MethodGet, urlParam, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlParam, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", "Bearer "+opts.Token)
 	req.Header.Add("Accept", "application/json")
 
-	isDone := false
-	maxRetries := 3
+	// the middleware owns retries when there is one
+	attempts := nativeAttempts
+	if opts.middleware != nil {
+		attempts = 1
+	}
 
-	var response ProductInfoResponse
-	for !isDone {
+	for attempt := 1; ; attempt++ {
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != 200 && resp.StatusCode != http.StatusTooManyRequests {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < attempts {
+			resp.Body.Close()
+			wait := middleware.RetryAfter(resp.Header, "X-Ratelimit-Full-Reset-After")
+			if err := clock.Sleep(ctx, wait); err != nil {
+				return err
 			}
-			log.Println("response:", map[string]interface{}{
-				"body": string(respBytes),
-				"code": resp.StatusCode,
-			})
-			return nil, errors.New("response not ok")
+			continue
 		}
 
-		retryAfter := 0
-		if resp.StatusCode == http.StatusTooManyRequests {
-			headerReset := resp.Header.Get("X-Ratelimit-Full-Reset-After")
-			f, _ := strconv.ParseFloat(headerReset, 64)
-			retryAfter = int(f)
-			time.Sleep(time.Duration(retryAfter) * time.Second)
-			maxRetries--
-		} else {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
-			}
-
-			if err := json.Unmarshal(respBytes, &response); err != nil {
-				log.Printf("Error unmarshaling response, cause: %+v\n", err)
-				log.Println("Response body:", string(respBytes))
-				return nil, err
-			}
-			isDone = true
-		}
-		if maxRetries == 0 {
-			isDone = true
-		}
+		return decodeNative(resp, v)
 	}
-
-	return &response, nil
 }
 
-func (opts *TokopediaHTTPOpts) GetReplyTokopedia(params GetReplyListParams) (*ReplyListResponse, error) {
-	var client = &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-		Timeout: 80 * time.Second,
-	}
+// decodeNative decodes the body of a native call into v and closes it.
+func decodeNative(resp *http.Response, v any) error {
+	defer resp.Body.Close()
 
-	proxyURL, err := url.Parse(opts.SocksProxyAddress)
-	if err != nil {
-		log.Println("error while parse socks address")
-		return nil, err
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return CheckResponseError(resp)
 	}
 
-	dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Println("error while parse direct")
-		return nil, err
+		log.Printf("Error reading body, cause: %+v\n", err)
+		return err
 	}
-	client.Transport = &http.Transport{Dial: dialer.Dial}
 
-	urlParam := fmt.Sprintf("%s/v1/chat/fs/%d/messages/%d/replies?page=%d&per_page=%d&shop_id=%d", opts.APIURL, opts.FsID, params.MsgID, params.Page, params.PerPage, opts.ShopID)
-	req, err := http.NewRequest(http.MethodGet, urlParam, nil)
-	if err != nil {
-		return nil, err
+	if resp.StatusCode != http.StatusOK {
+		log.Println("response:", map[string]interface{}{
+			"body": string(respBytes),
+			"code": resp.StatusCode,
+		})
+		return errors.New("response not ok")
 	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+opts.Token)
-	req.Header.Add("Accept", "application/json")
-
-	isDone := false
-	maxRetries := 3
 
-	var response ReplyListResponse
-	for !isDone {
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != 200 && resp.StatusCode != http.StatusTooManyRequests {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
-			}
-			log.Println("response:", map[string]interface{}{
-				"body": string(respBytes),
-				"code": resp.StatusCode,
-			})
-			return nil, errors.New("response not ok")
-		}
-
-		retryAfter := 0
-		if resp.StatusCode == http.StatusTooManyRequests {
-			headerReset := resp.Header.Get("X-Ratelimit-Full-Reset-After")
-			f, _ := strconv.ParseFloat(headerReset, 64)
-			retryAfter = int(f)
-			time.Sleep(time.Duration(retryAfter) * time.Second)
-			maxRetries--
-		} else {
-			respBytes, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Printf("Error reading body, cause: %+v\n", err)
-				return nil, err
-			}
-
-			if err := json.Unmarshal(respBytes, &response); err != nil {
-				log.Printf("Error unmarshaling response, cause: %+v\n", err)
-				log.Println("Response body:", string(respBytes))
-				return nil, err
-			}
-			isDone = true
-		}
-		if maxRetries == 0 {
-			isDone = true
-		}
+	if err := json.Unmarshal(respBytes, v); err != nil {
+		log.Printf("Error unmarshaling response, cause: %+v\n", err)
+		log.Println("Response body:", string(respBytes))
+		return err
 	}
-
-	return &response, nil
+	return nil
 }
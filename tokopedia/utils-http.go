@@ -2,6 +2,7 @@ package tokopedia
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -12,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/internal/clock"
+	"github.com/apsyadira-jubelio/go-marketplace-sdk/middleware"
 	"github.com/google/go-querystring/query"
 )
 
@@ -22,6 +25,12 @@ import (
 // If the data argument is non-nil, it will be used as the body of the request
 // for POST and PUT requests.
 func (c *TokopediaClient) CreateAndDo(method, relPath string, data, options, headers, resource interface{}) error {
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, headers, resource)
+}
+
+// CreateAndDoWithContext is CreateAndDo with a context for cancellation and
+// deadlines.
+func (c *TokopediaClient) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) error {
 	defer func() {
 		// clear for next call
 		c.ShopID = ""
@@ -30,7 +39,7 @@ func (c *TokopediaClient) CreateAndDo(method, relPath string, data, options, hea
 
 	}()
 
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, headers, resource)
+	_, err := c.createAndDoGetHeaders(ctx, method, relPath, data, options, headers, resource)
 	if err != nil {
 		return err
 	}
@@ -39,13 +48,13 @@ func (c *TokopediaClient) CreateAndDo(method, relPath string, data, options, hea
 }
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *TokopediaClient) createAndDoGetHeaders(method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
+func (c *TokopediaClient) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
-	req, err := c.NewRequest(method, relPath, data, options, headers)
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, options, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -60,28 +69,54 @@ func (c *TokopediaClient) Get(path string, resource, options interface{}) error
 	return c.CreateAndDo("GET", path, nil, options, nil, resource)
 }
 
+// GetWithContext is Get with a context.
+func (c *TokopediaClient) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, nil, resource)
+}
+
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *TokopediaClient) Post(path string, data, resource interface{}) error {
 	return c.CreateAndDo("POST", path, data, nil, nil, resource)
 }
 
+// PostWithContext is Post with a context.
+func (c *TokopediaClient) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, nil, resource)
+}
+
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *TokopediaClient) Put(path string, data, resource interface{}) error {
 	return c.CreateAndDo("PUT", path, data, nil, nil, resource)
 }
 
+// PutWithContext is Put with a context.
+func (c *TokopediaClient) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, nil, resource)
+}
+
 // Delete performs a DELETE request for the given path
 func (c *TokopediaClient) Delete(path string) error {
 	return c.CreateAndDo("DELETE", path, nil, nil, nil, nil)
 }
 
+// DeleteWithContext is Delete with a context.
+func (c *TokopediaClient) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
 // Creates an API request. A relative URL can be provided in urlStr, which will
 // be resolved to the BaseURL of the Client. Relative URLS should always be
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *TokopediaClient) NewRequest(method, relPath string, body, options, headers interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options, headers)
+}
+
+// NewRequestWithContext is NewRequest with a context, the context controls the
+// whole lifetime of the request, including retries.
+func (c *TokopediaClient) NewRequestWithContext(ctx context.Context, method, relPath string, body, options, headers interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -115,7 +150,7 @@ func (c *TokopediaClient) NewRequest(method, relPath string, body, options, head
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		c.log.Errorf("[NewRequest] error in create new request:%+v\n", err)
 		return nil, err
@@ -138,7 +173,13 @@ func (c *TokopediaClient) NewRequest(method, relPath string, body, options, head
 // Upload performs a Upload request for the given path and saves the result in the
 // given resource.
 func (c *TokopediaClient) Upload(relPath, fieldname, filename string, resource interface{}) error {
-	req, err := c.NewfileUploadRequest(relPath, fieldname, filename)
+	return c.UploadWithContext(context.Background(), relPath, fieldname, filename, resource)
+}
+
+// UploadWithContext is Upload with a context, the context also covers the
+// download of filename.
+func (c *TokopediaClient) UploadWithContext(ctx context.Context, relPath, fieldname, filename string, resource interface{}) error {
+	req, err := c.newFileUploadRequest(ctx, relPath, fieldname, filename)
 	if err != nil {
 		return err
 	}
@@ -152,6 +193,10 @@ func (c *TokopediaClient) Upload(relPath, fieldname, filename string, resource i
 
 // Creates a new file upload http request with optional extra params
 func (c *TokopediaClient) NewfileUploadRequest(relPath, paramName, filename string) (*http.Request, error) {
+	return c.newFileUploadRequest(context.Background(), relPath, paramName, filename)
+}
+
+func (c *TokopediaClient) newFileUploadRequest(ctx context.Context, relPath, paramName, filename string) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -167,7 +212,11 @@ func (c *TokopediaClient) NewfileUploadRequest(relPath, paramName, filename stri
 	uri := u.String()
 
 	// Replace os.Open with http.Get to fetch data from the URL
-	resp, err := http.Get(filename)
+	fileReq, err := http.NewRequestWithContext(ctx, http.MethodGet, filename, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(fileReq)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +239,7 @@ func (c *TokopediaClient) NewfileUploadRequest(relPath, paramName, filename stri
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", uri, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, body)
 	if err != nil {
 		return nil, err
 	}
@@ -211,13 +260,18 @@ func (c *TokopediaClient) doGetHeaders(req *http.Request, v interface{}, skipBod
 	var err error
 
 	retries := c.retries
-	c.attempts = 0
+	attempts := 0
 	c.logRequest(req, skipBody)
 
 	for {
-		c.attempts++
+		attempts++
 
-		resp, err = c.Client.Do(req)
+		// not every transport checks the context before sending
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		resp, err = c.Client.Do(req.WithContext(middleware.WithAttempt(req.Context(), attempts-1)))
 		c.logResponse(resp)
 		if err != nil {
 			return nil, err //http client errors, not api responses
@@ -239,7 +293,10 @@ func (c *TokopediaClient) doGetHeaders(req *http.Request, v interface{}, skipBod
 			rateLimitErr := respErr.(RateLimitError)
 			// back off and retry
 			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			time.Sleep(wait)
+			c.log.Debugf("rate limited on attempt %d, waiting %s", attempts, wait.String())
+			if err := clock.Sleep(req.Context(), wait); err != nil {
+				return nil, err
+			}
 			retries--
 			continue
 		}
@@ -247,7 +304,7 @@ func (c *TokopediaClient) doGetHeaders(req *http.Request, v interface{}, skipBod
 		var doRetry bool
 		switch resp.StatusCode {
 		case http.StatusServiceUnavailable:
-			c.log.Errorf("service unavailable, retrying")
+			c.log.Errorf("service unavailable on attempt %d, retrying", attempts)
 			doRetry = true
 			retries--
 		}
@@ -262,7 +319,12 @@ func (c *TokopediaClient) doGetHeaders(req *http.Request, v interface{}, skipBod
 
 	defer resp.Body.Close()
 
-	if v != nil {
+	if raw, ok := v.(*[]byte); ok {
+		*raw, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+	} else if v != nil {
 		decoder := json.NewDecoder(resp.Body)
 		err := decoder.Decode(&v)
 		if err != nil {
@@ -0,0 +1,101 @@
+package tokopedia
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxWebhookBody is the largest webhook body the handlers read.
+const maxWebhookBody = 1 << 20
+
+// OrderStatusEvent is the payload of the order status webhook.
+type OrderStatusEvent struct {
+	OrderStatus    int            `json:"order_status"`
+	FsID           json.Number    `json:"fs_id"`
+	ShopID         int64          `json:"shop_id"`
+	OrderID        int64          `json:"order_id"`
+	ProductDetails []OrderProduct `json:"product_details"`
+}
+
+// WebhookHandler receives the order webhooks of Tokopedia. Tokopedia
+// registers one URL per webhook, so each webhook has its own http.Handler:
+// mount OrderNotification at the order notification URL and OrderStatus at
+// the order status one.
+//
+// Tokopedia does not sign webhooks, restrict the URLs to its IPs or keep them
+// secret. A subscriber error answers 500 so Tokopedia sends the webhook
+// again.
+//
+// Subscribe before serving, subscribers are not guarded for concurrent
+// registration.
+type WebhookHandler struct {
+	onOrderNotification func(ctx context.Context, o *Order) error
+	onOrderStatus       func(ctx context.Context, e *OrderStatusEvent) error
+}
+
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{}
+}
+
+// OnOrderNotification sets the subscriber for new orders.
+func (h *WebhookHandler) OnOrderNotification(fn func(ctx context.Context, o *Order) error) {
+	h.onOrderNotification = fn
+}
+
+func (h *WebhookHandler) OnOrderStatus(fn func(ctx context.Context, e *OrderStatusEvent) error) {
+	h.onOrderStatus = fn
+}
+
+func (h *WebhookHandler) OrderNotification() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveWebhook(w, r, func(ctx context.Context, body []byte) (int, error) {
+			if h.onOrderNotification == nil {
+				return http.StatusOK, nil
+			}
+			o := new(Order)
+			if err := json.Unmarshal(body, o); err != nil {
+				return http.StatusBadRequest, err
+			}
+			return http.StatusInternalServerError, h.onOrderNotification(ctx, o)
+		})
+	})
+}
+
+func (h *WebhookHandler) OrderStatus() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveWebhook(w, r, func(ctx context.Context, body []byte) (int, error) {
+			if h.onOrderStatus == nil {
+				return http.StatusOK, nil
+			}
+			e := new(OrderStatusEvent)
+			if err := json.Unmarshal(body, e); err != nil {
+				return http.StatusBadRequest, err
+			}
+			return http.StatusInternalServerError, h.onOrderStatus(ctx, e)
+		})
+	})
+}
+
+// serveWebhook reads the body of a webhook and hands it to dispatch, which
+// returns the status to answer when it fails.
+func serveWebhook(w http.ResponseWriter, r *http.Request, dispatch func(ctx context.Context, body []byte) (int, error)) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if status, err := dispatch(r.Context(), body); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}